@@ -0,0 +1,335 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultNATSPort    = "4222"
+	defaultNATSTimeout = 10 * time.Second
+)
+
+// NATSPublisher publishes events to a NATS server over its text protocol,
+// each to the subject <prefix>.<resource type>.<event type>. It keeps one
+// connection, so the server receives events in the order they are
+// published, and reconnects after a failure.
+//
+// Without JetStream an event counts as accepted once the server has
+// answered the PING following it. With JetStream it waits for the
+// acknowledgement of the stream storing the subject, and sends the event ID
+// as Nats-Msg-Id so the stream drops events published twice within its
+// duplicate window.
+type NATSPublisher struct {
+	addr      string
+	user      *url.Userinfo
+	prefix    string
+	jetStream bool
+
+	mu       sync.Mutex
+	conn     net.Conn
+	reader   *bufio.Reader
+	inbox    string
+	requests int
+}
+
+// natsPubAck is the acknowledgement of a JetStream publish.
+type natsPubAck struct {
+	Stream    string `json:"stream"`
+	Sequence  uint64 `json:"seq"`
+	Duplicate bool   `json:"duplicate"`
+	Error     *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+func NewNATSPublisher(rawURL, prefix string, jetStream bool) (*NATSPublisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid NATS URL %q: expected nats://host[:port]", rawURL)
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = defaultNATSPort
+	}
+
+	return &NATSPublisher{
+		addr:      net.JoinHostPort(u.Hostname(), port),
+		user:      u.User,
+		prefix:    strings.TrimSuffix(prefix, "."),
+		jetStream: jetStream,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return fmt.Errorf("connecting to NATS: %w", err)
+		}
+	}
+
+	_ = p.conn.SetDeadline(deadline(ctx))
+	subject := p.prefix + "." + event.ResourceType + "." + string(event.Type)
+	if p.jetStream {
+		err = p.publishJetStream(subject, event.ID, payload)
+	} else {
+		err = p.publishCore(subject, payload)
+	}
+	if err != nil {
+		p.close()
+		return err
+	}
+
+	return nil
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(deadline(ctx))
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	if err := p.handshake(); err != nil {
+		p.close()
+		return err
+	}
+	return nil
+}
+
+// handshake reads the INFO the server greets with, sends CONNECT, subscribes
+// to the reply inbox for JetStream and waits for the server to confirm.
+func (p *NATSPublisher) handshake() error {
+	line, err := p.readLine()
+	if err != nil {
+		return err
+	}
+	infoJSON, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected greeting %q", line)
+	}
+	var info struct {
+		Headers     bool `json:"headers"`
+		TLSRequired bool `json:"tls_required"`
+	}
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("decoding server info: %w", err)
+	}
+	if info.TLSRequired {
+		return errors.New("server requires TLS, which is not supported")
+	}
+	if p.jetStream && !info.Headers {
+		return errors.New("server does not support headers, which JetStream publishing needs")
+	}
+
+	options := map[string]any{
+		"verbose":       false,
+		"pedantic":      false,
+		"lang":          "go",
+		"version":       "1.0.0",
+		"name":          "codex-documents",
+		"protocol":      1,
+		"headers":       info.Headers,
+		"no_responders": info.Headers,
+	}
+	if p.user != nil {
+		if pass, ok := p.user.Password(); ok {
+			options["user"] = p.user.Username()
+			options["pass"] = pass
+		} else {
+			options["auth_token"] = p.user.Username()
+		}
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return fmt.Errorf("encoding connect options: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("CONNECT " + string(connect) + "\r\n")
+	if p.jetStream {
+		p.inbox = "_INBOX." + strings.ReplaceAll(uuid.New().String(), "-", "")
+		b.WriteString("SUB " + p.inbox + ".* 1\r\n")
+	}
+	b.WriteString("PING\r\n")
+	if _, err := io.WriteString(p.conn, b.String()); err != nil {
+		return err
+	}
+
+	return p.waitPong()
+}
+
+// publishCore publishes with PUB and flushes with a PING, whose PONG tells
+// that the server has processed the message.
+func (p *NATSPublisher) publishCore(subject string, payload []byte) error {
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload)
+	if _, err := io.WriteString(p.conn, msg); err != nil {
+		return err
+	}
+	return p.waitPong()
+}
+
+// publishJetStream publishes with a reply subject and waits for the stream's
+// acknowledgement on it.
+func (p *NATSPublisher) publishJetStream(subject, id string, payload []byte) error {
+	p.requests++
+	reply := p.inbox + "." + strconv.Itoa(p.requests)
+	headers := "NATS/1.0\r\nNats-Msg-Id: " + id + "\r\n\r\n"
+	msg := fmt.Sprintf("HPUB %s %s %d %d\r\n%s%s\r\n", subject, reply, len(headers), len(headers)+len(payload), headers, payload)
+	if _, err := io.WriteString(p.conn, msg); err != nil {
+		return err
+	}
+
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "PING":
+			if _, err := io.WriteString(p.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("server error: %s", args)
+		case "MSG", "HMSG":
+			target, status, body, err := p.readMessage(strings.ToUpper(op) == "HMSG", args)
+			if err != nil {
+				return err
+			}
+			if target != reply {
+				// The acknowledgement of an earlier publish that timed out.
+				continue
+			}
+			if status != "" {
+				return fmt.Errorf("publishing to %s: %s", subject, status)
+			}
+			return parsePubAck(subject, body)
+		}
+	}
+}
+
+// readMessage reads the payload of a MSG or HMSG whose arguments are args
+// and returns its subject, the status of its header and its body.
+func (p *NATSPublisher) readMessage(withHeaders bool, args string) (subject, status string, body []byte, err error) {
+	fields := strings.Fields(args)
+	if len(fields) < 3 {
+		return "", "", nil, fmt.Errorf("malformed message %q", args)
+	}
+	subject = fields[0]
+
+	total, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return "", "", nil, fmt.Errorf("malformed message %q", args)
+	}
+	headerSize := 0
+	if withHeaders {
+		if headerSize, err = strconv.Atoi(fields[len(fields)-2]); err != nil || headerSize > total {
+			return "", "", nil, fmt.Errorf("malformed message %q", args)
+		}
+	}
+
+	buf := make([]byte, total+2)
+	if _, err := io.ReadFull(p.reader, buf); err != nil {
+		return "", "", nil, err
+	}
+
+	if withHeaders {
+		statusLine, _, _ := strings.Cut(string(buf[:headerSize]), "\r\n")
+		status = strings.TrimSpace(strings.TrimPrefix(statusLine, "NATS/1.0"))
+	}
+	return subject, status, buf[headerSize:total], nil
+}
+
+func parsePubAck(subject string, body []byte) error {
+	var ack natsPubAck
+	if err := json.Unmarshal(body, &ack); err != nil {
+		return fmt.Errorf("decoding acknowledgement: %w", err)
+	}
+	if ack.Error != nil {
+		return fmt.Errorf("publishing to %s: %d %s", subject, ack.Error.Code, ack.Error.Description)
+	}
+	if ack.Stream == "" {
+		return fmt.Errorf("publishing to %s: acknowledgement names no stream", subject)
+	}
+	return nil
+}
+
+// waitPong reads until the server answers a PING, replying to its own PINGs
+// meanwhile.
+func (p *NATSPublisher) waitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "PONG":
+			return nil
+		case "PING":
+			if _, err := io.WriteString(p.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("server error: %s", args)
+		case "MSG", "HMSG":
+			if _, _, _, err := p.readMessage(strings.ToUpper(op) == "HMSG", args); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *NATSPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *NATSPublisher) close() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.conn = nil
+	p.reader = nil
+}
+
+func deadline(ctx context.Context) time.Time {
+	if d, ok := ctx.Deadline(); ok {
+		return d
+	}
+	return time.Now().Add(defaultNATSTimeout)
+}
@@ -0,0 +1,363 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	coreConnect = `{"headers":false,"lang":"go","name":"codex-documents","no_responders":false,"pass":"secret","pedantic":false,"protocol":1,"user":"alice","verbose":false,"version":"1.0.0"}`
+	jsConnect   = `{"auth_token":"s3cr3t","headers":true,"lang":"go","name":"codex-documents","no_responders":true,"pedantic":false,"protocol":1,"verbose":false,"version":"1.0.0"}`
+)
+
+// stubNATS accepts connections the test then plays the server side of.
+type stubNATS struct {
+	listener net.Listener
+	accepted chan net.Conn
+}
+
+func newStubNATS(t *testing.T) *stubNATS {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &stubNATS{listener: listener, accepted: make(chan net.Conn, 4)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				close(s.accepted)
+				return
+			}
+			s.accepted <- conn
+		}
+	}()
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return s
+}
+
+func (s *stubNATS) url(userinfo string) string {
+	return "nats://" + userinfo + s.listener.Addr().String()
+}
+
+func (s *stubNATS) accept(t *testing.T) *stubConn {
+	select {
+	case conn, ok := <-s.accepted:
+		require.True(t, ok, "listener closed")
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		t.Cleanup(func() { _ = conn.Close() })
+		return &stubConn{conn: conn, reader: bufio.NewReader(conn)}
+	case <-time.After(5 * time.Second):
+		t.Fatal("publisher did not connect")
+		return nil
+	}
+}
+
+type stubConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func (c *stubConn) send(t *testing.T, data string) {
+	_, err := io.WriteString(c.conn, data)
+	require.NoError(t, err)
+}
+
+// expect reads exactly as many bytes as want has and compares them.
+func (c *stubConn) expect(t *testing.T, want string) {
+	buf := make([]byte, len(want))
+	_, err := io.ReadFull(c.reader, buf)
+	require.NoError(t, err)
+	require.Equal(t, want, string(buf))
+}
+
+// handshake greets with info, checks CONNECT and, when the client subscribes
+// to a reply inbox, returns the inbox.
+func (c *stubConn) handshake(t *testing.T, info, connect string, jetStream bool) string {
+	c.send(t, "INFO "+info+"\r\n")
+	c.expect(t, "CONNECT "+connect+"\r\n")
+
+	inbox := ""
+	if jetStream {
+		line, err := c.reader.ReadString('\n')
+		require.NoError(t, err)
+		fields := strings.Fields(line)
+		require.Len(t, fields, 3)
+		require.Equal(t, "SUB", fields[0])
+		require.Equal(t, "1", fields[2])
+		require.True(t, strings.HasPrefix(fields[1], "_INBOX.") && strings.HasSuffix(fields[1], ".*"), fields[1])
+		inbox = strings.TrimSuffix(fields[1], ".*")
+	}
+
+	c.expect(t, "PING\r\n")
+	c.send(t, "PONG\r\n")
+	return inbox
+}
+
+func testEvent() domain.DomainEvent {
+	return domain.DomainEvent{
+		ID:           "e-1",
+		Type:         domain.EventResourceCreated,
+		ResourceType: "Observation",
+		ResourceID:   "obs-1",
+		PatientID:    "patient-1",
+		Sequence:     1,
+		OccurredAt:   time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func publishAsync(p *NATSPublisher, event domain.DomainEvent) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		result <- p.Publish(ctx, event)
+	}()
+	return result
+}
+
+func TestNewNATSPublisher(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		expectedAddr  string
+		expectedError bool
+	}{
+		{name: "default port", url: "nats://broker", expectedAddr: "broker:4222"},
+		{name: "explicit port", url: "nats://broker:4333", expectedAddr: "broker:4333"},
+		{name: "wrong scheme", url: "tls://broker:4222", expectedError: true},
+		{name: "no host", url: "nats://", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewNATSPublisher(tt.url, "codex.", false)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAddr, p.addr)
+			assert.Equal(t, "codex", p.prefix)
+		})
+	}
+}
+
+func TestNATSPublisher_PublishCore(t *testing.T) {
+	server := newStubNATS(t)
+	p, err := NewNATSPublisher(server.url("alice:secret@"), "codex.", false)
+	require.NoError(t, err)
+
+	event := testEvent()
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	result := publishAsync(p, event)
+	conn := server.accept(t)
+	conn.handshake(t, `{"server_id":"stub","headers":false}`, coreConnect, false)
+
+	conn.expect(t, fmt.Sprintf("PUB codex.Observation.ResourceCreated %d\r\n%s\r\nPING\r\n", len(payload), payload))
+	// A PING of the server is answered before the publish completes.
+	conn.send(t, "PING\r\n")
+	conn.expect(t, "PONG\r\n")
+	conn.send(t, "PONG\r\n")
+	require.NoError(t, <-result)
+
+	// The connection is kept for the next event.
+	result = publishAsync(p, event)
+	conn.expect(t, fmt.Sprintf("PUB codex.Observation.ResourceCreated %d\r\n%s\r\nPING\r\n", len(payload), payload))
+	conn.send(t, "-ERR 'Permissions Violation for Publish to codex.Observation.ResourceCreated'\r\n")
+	err = <-result
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Permissions Violation")
+}
+
+func TestNATSPublisher_PublishJetStream(t *testing.T) {
+	ack := func(subject, body string) string {
+		return fmt.Sprintf("MSG %s 1 %d\r\n%s\r\n", subject, len(body), body)
+	}
+
+	tests := []struct {
+		name          string
+		reply         func(reply string) string
+		expectedError string
+	}{
+		{
+			name: "acknowledged",
+			reply: func(reply string) string {
+				return ack(reply, `{"stream":"EVENTS","seq":7}`)
+			},
+		},
+		{
+			name: "duplicate acknowledged",
+			reply: func(reply string) string {
+				return ack(reply, `{"stream":"EVENTS","seq":7,"duplicate":true}`)
+			},
+		},
+		{
+			name: "stale acknowledgement skipped",
+			reply: func(reply string) string {
+				stale := strings.TrimSuffix(reply, ".1") + ".0"
+				return ack(stale, `{"stream":"EVENTS","seq":6}`) + ack(reply, `{"stream":"EVENTS","seq":7}`)
+			},
+		},
+		{
+			name: "server ping answered while waiting",
+			reply: func(reply string) string {
+				return "PING\r\n" + ack(reply, `{"stream":"EVENTS","seq":7}`)
+			},
+		},
+		{
+			name: "stream error",
+			reply: func(reply string) string {
+				return ack(reply, `{"error":{"code":503,"err_code":10077,"description":"maximum messages exceeded"}}`)
+			},
+			expectedError: "503 maximum messages exceeded",
+		},
+		{
+			name: "acknowledgement without stream",
+			reply: func(reply string) string {
+				return ack(reply, `{"seq":7}`)
+			},
+			expectedError: "names no stream",
+		},
+		{
+			name: "malformed acknowledgement",
+			reply: func(reply string) string {
+				return ack(reply, `not json`)
+			},
+			expectedError: "decoding acknowledgement",
+		},
+		{
+			name: "no responders",
+			reply: func(reply string) string {
+				headers := "NATS/1.0 503\r\n\r\n"
+				return fmt.Sprintf("HMSG %s 1 %d %d\r\n%s\r\n", reply, len(headers), len(headers), headers)
+			},
+			expectedError: "503",
+		},
+		{
+			name: "server error",
+			reply: func(reply string) string {
+				return "-ERR 'Authorization Violation'\r\n"
+			},
+			expectedError: "Authorization Violation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStubNATS(t)
+			p, err := NewNATSPublisher(server.url("s3cr3t@"), "codex", true)
+			require.NoError(t, err)
+
+			event := testEvent()
+			payload, err := json.Marshal(event)
+			require.NoError(t, err)
+
+			result := publishAsync(p, event)
+			conn := server.accept(t)
+			inbox := conn.handshake(t, `{"server_id":"stub","headers":true,"jetstream":true}`, jsConnect, true)
+
+			reply := inbox + ".1"
+			headers := "NATS/1.0\r\nNats-Msg-Id: e-1\r\n\r\n"
+			conn.expect(t, fmt.Sprintf("HPUB codex.Observation.ResourceCreated %s %d %d\r\n%s%s\r\n",
+				reply, len(headers), len(headers)+len(payload), headers, payload))
+
+			response := tt.reply(reply)
+			conn.send(t, response)
+			if strings.HasPrefix(response, "PING") {
+				conn.expect(t, "PONG\r\n")
+			}
+
+			err = <-result
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNATSPublisher_Handshake(t *testing.T) {
+	tests := []struct {
+		name          string
+		jetStream     bool
+		greeting      string
+		expectedError string
+	}{
+		{
+			name:          "TLS required",
+			greeting:      `INFO {"tls_required":true}`,
+			expectedError: "requires TLS",
+		},
+		{
+			name:          "JetStream without headers",
+			jetStream:     true,
+			greeting:      `INFO {"headers":false}`,
+			expectedError: "does not support headers",
+		},
+		{
+			name:          "not a NATS server",
+			greeting:      `HTTP/1.1 400 Bad Request`,
+			expectedError: "unexpected greeting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStubNATS(t)
+			p, err := NewNATSPublisher(server.url(""), "codex", tt.jetStream)
+			require.NoError(t, err)
+
+			result := publishAsync(p, testEvent())
+			conn := server.accept(t)
+			conn.send(t, tt.greeting+"\r\n")
+
+			err = <-result
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.Nil(t, p.conn)
+		})
+	}
+}
+
+func TestNATSPublisher_Reconnect(t *testing.T) {
+	server := newStubNATS(t)
+	p, err := NewNATSPublisher(server.url("alice:secret@"), "codex", false)
+	require.NoError(t, err)
+
+	event := testEvent()
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	pub := fmt.Sprintf("PUB codex.Observation.ResourceCreated %d\r\n%s\r\nPING\r\n", len(payload), payload)
+
+	result := publishAsync(p, event)
+	first := server.accept(t)
+	first.handshake(t, `{"headers":false}`, coreConnect, false)
+	first.expect(t, pub)
+	_ = first.conn.Close()
+	require.Error(t, <-result)
+	assert.Nil(t, p.conn)
+
+	result = publishAsync(p, event)
+	second := server.accept(t)
+	second.handshake(t, `{"headers":false}`, coreConnect, false)
+	second.expect(t, pub)
+	second.send(t, "PONG\r\n")
+	require.NoError(t, <-result)
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gruzdev-dev/codex-documents/configs"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+)
+
+const defaultSubjectPrefix = "codex.documents"
+
+// NewPublisher returns the publisher cfg.Events.Publisher names.
+func NewPublisher(cfg *configs.Config) (ports.EventPublisher, error) {
+	switch cfg.Events.Publisher {
+	case "", "log":
+		return NewLogPublisher(), nil
+	case "memory":
+		return NewMemoryPublisher(), nil
+	case "nats":
+		prefix := cfg.Events.SubjectPrefix
+		if prefix == "" {
+			prefix = defaultSubjectPrefix
+		}
+		return NewNATSPublisher(cfg.Events.NATSURL, prefix, cfg.Events.JetStream)
+	default:
+		return nil, fmt.Errorf("unknown event publisher %q", cfg.Events.Publisher)
+	}
+}
+
+type LogPublisher struct{}
+
+// NewLogPublisher returns a publisher that only logs events, for
+// deployments without a message broker.
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	log.Printf("Domain event %s: %s %s #%d", event.ID, event.Type, event.Key(), event.Sequence)
+	return nil
+}
+
+// MemoryPublisher keeps published events in memory, for tests and local
+// development.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []domain.DomainEvent
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns the events published so far, in the order they were.
+func (p *MemoryPublisher) Events() []domain.DomainEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]domain.DomainEvent(nil), p.events...)
+}
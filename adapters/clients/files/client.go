@@ -2,6 +2,8 @@ package files
 
 import (
 	"context"
+	"time"
+
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 	"github.com/gruzdev-dev/codex-documents/core/ports"
 	"github.com/gruzdev-dev/codex-files/proto"
@@ -45,6 +47,13 @@ func (c *client) GetPresignedUrls(ctx context.Context, data domain.GetPresignedU
 	}, nil
 }
 
+// GetDownloadUrl is not available yet: the FilesService API of codex-files
+// this client is built against has no RPC signing the download URL of a
+// stored file, only of one being created.
+func (c *client) GetDownloadUrl(ctx context.Context, fileId string, ttl time.Duration) (string, error) {
+	return "", domain.ErrDownloadUrlUnsupported
+}
+
 func (c *client) DeleteFile(ctx context.Context, fileId string) error {
 	md := metadata.New(map[string]string{
 		"x-internal-token": c.secret,
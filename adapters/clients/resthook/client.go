@@ -0,0 +1,88 @@
+package resthook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/netguard"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	defaultContentType = "application/fhir+json"
+	defaultTimeout     = 10 * time.Second
+	// maxDrainedBody caps how much of a response is read so the connection
+	// can be reused; an endpoint cannot keep the dispatcher reading.
+	maxDrainedBody = 4 << 10
+)
+
+type client struct {
+	http *http.Client
+}
+
+// NewClient returns a channel that POSTs notification bundles to the
+// endpoint of a rest-hook subscription, with its parameters as HTTP headers.
+// It connects only to addresses the guard lets through, redirects included,
+// and never through a proxy, which would dial for it.
+func NewClient(endpoints *netguard.Guard) ports.NotificationChannel {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = endpoints.DialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+
+	return &client{
+		http: &http.Client{Transport: transport},
+	}
+}
+
+func (c *client) Send(ctx context.Context, sub *models.Subscription, bundle *models.Bundle) error {
+	if sub.Endpoint == nil {
+		return fmt.Errorf("subscription has no endpoint")
+	}
+
+	body, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("encoding notification bundle: %w", err)
+	}
+
+	timeout := defaultTimeout
+	if sub.Timeout != nil {
+		timeout = time.Duration(min(*sub.Timeout, domain.SubscriptionMaxTimeout)) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+
+	contentType := defaultContentType
+	if sub.ContentType != nil {
+		contentType = *sub.ContentType
+	}
+	req.Header.Set("Content-Type", contentType)
+	for _, param := range sub.Parameter {
+		req.Header.Add(param.Name, param.Value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+
+	return nil
+}
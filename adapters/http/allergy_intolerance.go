@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateAllergyIntolerance(w http.ResponseWriter, r *http.Request) {
+	var ai models.AllergyIntolerance
+	if err := json.NewDecoder(r.Body).Decode(&ai); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := ai.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.allergyIntoleranceService.Create(r.Context(), &ai)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetAllergyIntolerance(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	ai, err := h.allergyIntoleranceService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, ai)
+}
+
+func (h *Handler) UpdateAllergyIntolerance(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var ai models.AllergyIntolerance
+	if err := json.NewDecoder(r.Body).Decode(&ai); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := ai.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if ai.Id == nil || *ai.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.allergyIntoleranceService.Update(r.Context(), &ai)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteAllergyIntolerance(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.allergyIntoleranceService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListAllergyIntolerances(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.AllergySearch{
+		PatientID:      query.Get("patient"),
+		ClinicalStatus: query.Get("clinical-status"),
+		Code:           query.Get("code"),
+		Category:       query.Get("category"),
+		Criticality:    query.Get("criticality"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.allergyIntoleranceService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapAllergyIntolerancesInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapAllergyIntolerancesInBundle(allergies []models.AllergyIntolerance, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(allergies)),
+	}
+
+	for i := range allergies {
+		resourceRaw, err := json.Marshal(allergies[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateAppointment(w http.ResponseWriter, r *http.Request) {
+	var appt models.Appointment
+	if err := json.NewDecoder(r.Body).Decode(&appt); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := appt.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.appointmentService.Create(r.Context(), &appt)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetAppointment(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	appt, err := h.appointmentService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, appt)
+}
+
+func (h *Handler) UpdateAppointment(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var appt models.Appointment
+	if err := json.NewDecoder(r.Body).Decode(&appt); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := appt.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if appt.Id == nil || *appt.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.appointmentService.Update(r.Context(), &appt)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteAppointment(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.appointmentService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListAppointments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.AppointmentSearch{
+		PatientID: query.Get("patient"),
+		Status:    query.Get("status"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Date = append(search.Date, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.appointmentService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapAppointmentsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapAppointmentsInBundle(appointments []models.Appointment, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(appointments)),
+	}
+
+	for i := range appointments {
+		resourceRaw, err := json.Marshal(appointments[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
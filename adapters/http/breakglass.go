@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+type BreakGlassRequest struct {
+	Reason          string `json:"reason"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
+
+type BreakGlassReviewRequest struct {
+	Note string `json:"note"`
+}
+
+type BreakGlassResponse struct {
+	ID             string `json:"id"`
+	PatientID      string `json:"patient_id"`
+	PractitionerID string `json:"practitioner_id"`
+	Reason         string `json:"reason"`
+	CreatedAt      int64  `json:"created_at"`
+	ExpiresAt      int64  `json:"expires_at"`
+	ReviewedAt     int64  `json:"reviewed_at,omitempty"`
+	ReviewedBy     string `json:"reviewed_by,omitempty"`
+	ReviewNote     string `json:"review_note,omitempty"`
+}
+
+type BreakGlassListResponse struct {
+	Items []BreakGlassResponse `json:"items"`
+	Total int64                `json:"total"`
+}
+
+func (h *Handler) RequestBreakGlass(w http.ResponseWriter, r *http.Request) {
+	patientID := mux.Vars(r)["id"]
+
+	var req BreakGlassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	grant, err := h.breakGlassService.Request(r.Context(), domain.BreakGlassRequest{
+		PatientID: patientID,
+		Reason:    req.Reason,
+		Duration:  time.Duration(req.DurationMinutes) * time.Minute,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, toBreakGlassResponse(*grant))
+}
+
+func (h *Handler) ListBreakGlassForReview(w http.ResponseWriter, r *http.Request) {
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.breakGlassService.ListForReview(r.Context(), limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	resp := BreakGlassListResponse{
+		Items: make([]BreakGlassResponse, 0, len(res.Items)),
+		Total: res.Total,
+	}
+	for _, grant := range res.Items {
+		resp.Items = append(resp.Items, toBreakGlassResponse(grant))
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) ReviewBreakGlass(w http.ResponseWriter, r *http.Request) {
+	grantID := mux.Vars(r)["id"]
+
+	var req BreakGlassReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	err := h.breakGlassService.Review(r.Context(), domain.BreakGlassReview{
+		GrantID: grantID,
+		Note:    req.Note,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) respondWithJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func toBreakGlassResponse(g domain.BreakGlassGrant) BreakGlassResponse {
+	return BreakGlassResponse{
+		ID:             g.ID,
+		PatientID:      g.PatientID,
+		PractitionerID: g.PractitionerID,
+		Reason:         g.Reason,
+		CreatedAt:      g.CreatedAt,
+		ExpiresAt:      g.ExpiresAt,
+		ReviewedAt:     g.ReviewedAt,
+		ReviewedBy:     g.ReviewedBy,
+		ReviewNote:     g.ReviewNote,
+	}
+}
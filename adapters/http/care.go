@@ -0,0 +1,104 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+type GrantCareRequest struct {
+	PractitionerID string   `json:"practitioner_id"`
+	Access         []string `json:"access"`
+}
+
+type CareRelationshipResponse struct {
+	ID             string   `json:"id"`
+	PatientID      string   `json:"patient_id"`
+	PractitionerID string   `json:"practitioner_id"`
+	Access         []string `json:"access"`
+	CreatedAt      int64    `json:"created_at"`
+}
+
+func (h *Handler) GrantCare(w http.ResponseWriter, r *http.Request) {
+	patientID := mux.Vars(r)["id"]
+
+	var req GrantCareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	access := make([]domain.CareAccess, 0, len(req.Access))
+	for _, a := range req.Access {
+		access = append(access, domain.CareAccess(a))
+	}
+
+	rel, err := h.careService.Grant(r.Context(), domain.GrantCareRequest{
+		PatientID:      patientID,
+		PractitionerID: req.PractitionerID,
+		Access:         access,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(toCareRelationshipResponse(*rel))
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *Handler) ListCare(w http.ResponseWriter, r *http.Request) {
+	patientID := mux.Vars(r)["id"]
+
+	rels, err := h.careService.List(r.Context(), patientID)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	resp := make([]CareRelationshipResponse, 0, len(rels))
+	for _, rel := range rels {
+		resp = append(resp, toCareRelationshipResponse(rel))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *Handler) RevokeCare(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.careService.Revoke(r.Context(), vars["id"], vars["practitionerId"]); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func toCareRelationshipResponse(rel domain.CareRelationship) CareRelationshipResponse {
+	access := make([]string, 0, len(rel.Access))
+	for _, a := range rel.Access {
+		access = append(access, string(a))
+	}
+
+	return CareRelationshipResponse{
+		ID:             rel.ID,
+		PatientID:      rel.PatientID,
+		PractitionerID: rel.PractitionerID,
+		Access:         access,
+		CreatedAt:      rel.CreatedAt,
+	}
+}
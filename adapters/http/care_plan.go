@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateCarePlan(w http.ResponseWriter, r *http.Request) {
+	var plan models.CarePlan
+	if err := json.NewDecoder(r.Body).Decode(&plan); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := plan.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.carePlanService.Create(r.Context(), &plan)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetCarePlan(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	plan, err := h.carePlanService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, plan)
+}
+
+func (h *Handler) UpdateCarePlan(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var plan models.CarePlan
+	if err := json.NewDecoder(r.Body).Decode(&plan); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := plan.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if plan.Id == nil || *plan.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.carePlanService.Update(r.Context(), &plan)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteCarePlan(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.carePlanService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListCarePlans(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.CarePlanSearch{
+		PatientID: query.Get("patient"),
+		Status:    query.Get("status"),
+		Category:  query.Get("category"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Date = append(search.Date, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.carePlanService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapCarePlansInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapCarePlansInBundle(plans []models.CarePlan, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(plans)),
+	}
+
+	for i := range plans {
+		resourceRaw, err := json.Marshal(plans[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateComposition(w http.ResponseWriter, r *http.Request) {
+	var comp models.Composition
+	if err := json.NewDecoder(r.Body).Decode(&comp); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := comp.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.compositionService.Create(r.Context(), &comp)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetComposition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	comp, err := h.compositionService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, comp)
+}
+
+func (h *Handler) UpdateComposition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var comp models.Composition
+	if err := json.NewDecoder(r.Body).Decode(&comp); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := comp.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if comp.Id == nil || *comp.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.compositionService.Update(r.Context(), &comp)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteComposition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.compositionService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListCompositions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.CompositionSearch{
+		PatientID: query.Get("patient"),
+		Status:    query.Get("status"),
+		Type:      query.Get("type"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Date = append(search.Date, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.compositionService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapCompositionsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+// CompositionDocument serves Composition/{id}/$document. With persist=true
+// the generated document is also stored as a DocumentReference.
+func (h *Handler) CompositionDocument(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	persist, err := parsePersist(r)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	res, err := h.compositionService.Document(r.Context(), id, persist)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithGeneratedDocument(w, res)
+}
+
+// parsePersist reads the persist parameter of the document generating
+// operations.
+func parsePersist(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("persist")
+	if value == "" {
+		return false, nil
+	}
+	persist, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: persist must be true or false", domain.ErrInvalidInput)
+	}
+	return persist, nil
+}
+
+// respondWithGeneratedDocument returns a generated document bundle. When the
+// document was persisted, the URL of its DocumentReference is returned in the
+// Content-Location header.
+func (h *Handler) respondWithGeneratedDocument(w http.ResponseWriter, res *domain.GeneratedDocument) {
+	if res.DocumentReference != nil && res.DocumentReference.Id != nil {
+		w.Header().Set("Content-Location", fmt.Sprintf("%s/api/v1/DocumentReference/%s", strings.TrimSuffix(h.cfg.HTTP.PublicURL, "/"), *res.DocumentReference.Id))
+	}
+	h.respondWithResource(w, http.StatusOK, res.Bundle)
+}
+
+func (h *Handler) wrapCompositionsInBundle(comps []models.Composition, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(comps)),
+	}
+
+	for i := range comps {
+		resourceRaw, err := json.Marshal(comps[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
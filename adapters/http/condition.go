@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateCondition(w http.ResponseWriter, r *http.Request) {
+	var cond models.Condition
+	if err := json.NewDecoder(r.Body).Decode(&cond); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := cond.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.conditionService.Create(r.Context(), &cond)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetCondition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	cond, err := h.conditionService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, cond)
+}
+
+func (h *Handler) UpdateCondition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var cond models.Condition
+	if err := json.NewDecoder(r.Body).Decode(&cond); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := cond.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if cond.Id == nil || *cond.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.conditionService.Update(r.Context(), &cond)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteCondition(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.conditionService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListConditions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.ConditionSearch{
+		PatientID:      query.Get("patient"),
+		ClinicalStatus: query.Get("clinical-status"),
+		Code:           query.Get("code"),
+		Category:       query.Get("category"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["onset-date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: onset-date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.OnsetDate = append(search.OnsetDate, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.conditionService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapConditionsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapConditionsInBundle(conditions []models.Condition, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(conditions)),
+	}
+
+	for i := range conditions {
+		resourceRaw, err := json.Marshal(conditions[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateConsent(w http.ResponseWriter, r *http.Request) {
+	var consent models.Consent
+	if err := json.NewDecoder(r.Body).Decode(&consent); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := consent.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	created, err := h.consentService.Create(r.Context(), &consent)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, created)
+}
+
+func (h *Handler) GetConsent(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	consent, err := h.consentService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, consent)
+}
+
+func (h *Handler) UpdateConsent(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var consent models.Consent
+	if err := json.NewDecoder(r.Body).Decode(&consent); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := consent.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if consent.Id == nil || *consent.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	updated, err := h.consentService.Update(r.Context(), &consent)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, updated)
+}
+
+func (h *Handler) DeleteConsent(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.consentService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListConsents(w http.ResponseWriter, r *http.Request) {
+	patientID := r.URL.Query().Get("patient")
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.consentService.List(r.Context(), patientID, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           ptr.To(fmt.Sprintf("bundle-%d", res.Total)),
+		Type:         "searchset",
+		Total:        ptr.To(int(res.Total)),
+		Entry:        make([]models.BundleEntry, 0, len(res.Items)),
+	}
+
+	for i := range res.Items {
+		resourceRaw, err := json.Marshal(res.Items[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+// withheldOutcome reports the resources patient consents kept out of a
+// response. It returns nil when nothing was withheld.
+func withheldOutcome(withheld []domain.ConsentDenial) *models.OperationOutcome {
+	if len(withheld) == 0 {
+		return nil
+	}
+
+	outcome := &models.OperationOutcome{
+		ResourceType: "OperationOutcome",
+		Issue:        make([]models.OperationOutcomeIssue, 0, len(withheld)),
+	}
+	for _, d := range withheld {
+		outcome.Issue = append(outcome.Issue, models.OperationOutcomeIssue{
+			Severity:    string(models.IssueSeverityWarning),
+			Code:        string(models.IssueTypeSuppressed),
+			Diagnostics: ptr.To(fmt.Sprintf("%s withheld: %s", d.Reference, d.Reason)),
+		})
+	}
+	return outcome
+}
+
+// appendWithheldEntry adds the withheld report to a searchset as an entry
+// with search mode "outcome".
+func appendWithheldEntry(bundle *models.Bundle, withheld []domain.ConsentDenial) {
+	outcome := withheldOutcome(withheld)
+	if outcome == nil {
+		return
+	}
+
+	resourceRaw, err := json.Marshal(outcome)
+	if err != nil {
+		return
+	}
+
+	bundle.Entry = append(bundle.Entry, models.BundleEntry{
+		Resource: resourceRaw,
+		Search:   &models.BundleEntrySearch{Mode: ptr.To("outcome")},
+	})
+}
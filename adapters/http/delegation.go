@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+type GrantDelegationRequest struct {
+	UserID          string   `json:"user_id"`
+	RelatedPersonID string   `json:"related_person_id"`
+	Access          []string `json:"access"`
+}
+
+type DelegationResponse struct {
+	ID              string   `json:"id"`
+	PatientID       string   `json:"patient_id"`
+	UserID          string   `json:"user_id"`
+	RelatedPersonID string   `json:"related_person_id"`
+	Access          []string `json:"access"`
+	CreatedAt       int64    `json:"created_at"`
+}
+
+func (h *Handler) GrantDelegation(w http.ResponseWriter, r *http.Request) {
+	patientID := mux.Vars(r)["id"]
+
+	var req GrantDelegationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	access := make([]domain.CareAccess, 0, len(req.Access))
+	for _, a := range req.Access {
+		access = append(access, domain.CareAccess(a))
+	}
+
+	d, err := h.delegationService.Grant(r.Context(), domain.GrantDelegationRequest{
+		PatientID:       patientID,
+		UserID:          req.UserID,
+		RelatedPersonID: req.RelatedPersonID,
+		Access:          access,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(toDelegationResponse(*d))
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *Handler) ListDelegations(w http.ResponseWriter, r *http.Request) {
+	patientID := mux.Vars(r)["id"]
+
+	delegations, err := h.delegationService.List(r.Context(), patientID)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithDelegations(w, delegations)
+}
+
+func (h *Handler) ListMyDelegations(w http.ResponseWriter, r *http.Request) {
+	delegations, err := h.delegationService.ListMine(r.Context())
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithDelegations(w, delegations)
+}
+
+func (h *Handler) RevokeDelegation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := h.delegationService.Revoke(r.Context(), vars["id"], vars["userId"]); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) respondWithDelegations(w http.ResponseWriter, delegations []domain.Delegation) {
+	resp := make([]DelegationResponse, 0, len(delegations))
+	for _, d := range delegations {
+		resp = append(resp, toDelegationResponse(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func toDelegationResponse(d domain.Delegation) DelegationResponse {
+	access := make([]string, 0, len(d.Access))
+	for _, a := range d.Access {
+		access = append(access, string(a))
+	}
+
+	return DelegationResponse{
+		ID:              d.ID,
+		PatientID:       d.PatientID,
+		UserID:          d.UserID,
+		RelatedPersonID: d.RelatedPersonID,
+		Access:          access,
+		CreatedAt:       d.CreatedAt,
+	}
+}
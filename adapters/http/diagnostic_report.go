@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateDiagnosticReport(w http.ResponseWriter, r *http.Request) {
+	var report models.DiagnosticReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := report.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.diagnosticReportService.Create(r.Context(), &report)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetDiagnosticReport(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	report, err := h.diagnosticReportService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, report)
+}
+
+func (h *Handler) UpdateDiagnosticReport(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var report models.DiagnosticReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := report.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if report.Id == nil || *report.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.diagnosticReportService.Update(r.Context(), &report)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteDiagnosticReport(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.diagnosticReportService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListDiagnosticReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.DiagnosticReportSearch{
+		PatientID: query.Get("patient"),
+		Status:    query.Get("status"),
+		Code:      query.Get("code"),
+		Category:  query.Get("category"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Date = append(search.Date, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.diagnosticReportService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapDiagnosticReportsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapDiagnosticReportsInBundle(reports []models.DiagnosticReport, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(reports)),
+	}
+
+	for i := range reports {
+		resourceRaw, err := json.Marshal(reports[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
@@ -64,21 +64,25 @@ func (h *Handler) DeleteDocument(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) ListDocuments(w http.ResponseWriter, r *http.Request) {
-	patientID := r.URL.Query().Get("patient")
-	if patientID == "" {
+	search := domain.DocumentSearch{
+		PatientID: r.URL.Query().Get("patient"),
+		Encounter: r.URL.Query().Get("encounter"),
+	}
+	if search.PatientID == "" {
 		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
 		return
 	}
 
 	limit, offset := h.parsePagination(r)
 
-	res, err := h.documentService.ListDocuments(r.Context(), patientID, limit, offset)
+	res, err := h.documentService.ListDocuments(r.Context(), search, limit, offset)
 	if err != nil {
 		h.respondWithError(w, err)
 		return
 	}
 
 	bundle := h.wrapInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
 	h.respondWithResource(w, http.StatusOK, bundle)
 }
 
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateEncounter(w http.ResponseWriter, r *http.Request) {
+	var enc models.Encounter
+	if err := json.NewDecoder(r.Body).Decode(&enc); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := enc.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.encounterService.Create(r.Context(), &enc)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetEncounter(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	enc, err := h.encounterService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, enc)
+}
+
+func (h *Handler) UpdateEncounter(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var enc models.Encounter
+	if err := json.NewDecoder(r.Body).Decode(&enc); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := enc.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if enc.Id == nil || *enc.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.encounterService.Update(r.Context(), &enc)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteEncounter(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.encounterService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListEncounters(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.EncounterSearch{
+		PatientID:  query.Get("patient"),
+		Status:     query.Get("status"),
+		Class:      query.Get("class"),
+		ReasonCode: query.Get("reason-code"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Date = append(search.Date, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.encounterService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapEncountersInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapEncountersInBundle(encounters []models.Encounter, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(encounters)),
+	}
+
+	for i := range encounters {
+		resourceRaw, err := json.Marshal(encounters[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
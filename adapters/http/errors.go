@@ -63,6 +63,116 @@ func (h *Handler) mapErrorToFhir(err error) (int, models.IssueSeverity, models.I
 	case errors.Is(err, domain.ErrDerivedFromDocNotFound):
 		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
 
+	case errors.Is(err, domain.ErrConditionNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrConditionIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrInvalidEvidenceRef):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeInvalid
+
+	case errors.Is(err, domain.ErrEvidenceNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrMedicationStatementNotFound), errors.Is(err, domain.ErrMedicationRequestNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrMedicationStatementIDRequired), errors.Is(err, domain.ErrMedicationRequestIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrAllergyIntoleranceNotFound), errors.Is(err, domain.ErrImmunizationNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrAllergyIntoleranceIDRequired), errors.Is(err, domain.ErrImmunizationIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrDiagnosticReportNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrDiagnosticReportIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrInvalidResultRef):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeInvalid
+
+	case errors.Is(err, domain.ErrResultNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrEncounterNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrEncounterIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrInvalidEncounterRef):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeInvalid
+
+	case errors.Is(err, domain.ErrEncounterRefNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrCompositionNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrCompositionIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrInvalidSectionRef):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeInvalid
+
+	case errors.Is(err, domain.ErrSectionEntryNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrProcedureNotFound), errors.Is(err, domain.ErrFamilyMemberHistoryNotFound), errors.Is(err, domain.ErrAppointmentNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrProcedureIDRequired), errors.Is(err, domain.ErrFamilyMemberHistoryIDRequired), errors.Is(err, domain.ErrAppointmentIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrGoalNotFound), errors.Is(err, domain.ErrCarePlanNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrGoalIDRequired), errors.Is(err, domain.ErrCarePlanIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrInvalidPlanRef):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeInvalid
+
+	case errors.Is(err, domain.ErrPlanRefNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrQuestionnaireNotFound),
+		errors.Is(err, domain.ErrQuestionnaireResponseNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrQuestionnaireIDRequired),
+		errors.Is(err, domain.ErrQuestionnaireResponseIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrQuestionnaireRefNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrInvalidAnswers):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeInvalid
+
+	case errors.Is(err, domain.ErrSubscriptionTopicNotFound), errors.Is(err, domain.ErrSubscriptionNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrSubscriptionTopicIDRequired), errors.Is(err, domain.ErrSubscriptionIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrTopicRefNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrOrganizationNotFound), errors.Is(err, domain.ErrLocationNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrOrganizationIDRequired), errors.Is(err, domain.ErrLocationIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrDirectoryRefNotFound):
+		return http.StatusUnprocessableEntity, models.IssueSeverityError, models.IssueTypeNotFound
+
 	case errors.Is(err, domain.ErrAccessDenied):
 		return http.StatusForbidden, models.IssueSeverityError, models.IssueTypeForbidden
 
@@ -81,6 +191,45 @@ func (h *Handler) mapErrorToFhir(err error) (int, models.IssueSeverity, models.I
 	case errors.Is(err, domain.ErrNoResourcesToShare):
 		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
 
+	case errors.Is(err, domain.ErrSHLNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrSHLPasscodeInvalid):
+		return http.StatusUnauthorized, models.IssueSeverityError, models.IssueTypeSecurity
+
+	case errors.Is(err, domain.ErrPractitionerNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrPractitionerIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrPractitionerRoleNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrCareRelationshipNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrRelatedPersonNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrDelegationNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrUserIDRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
+	case errors.Is(err, domain.ErrConsentNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrConsentDenied):
+		return http.StatusForbidden, models.IssueSeverityError, models.IssueTypeSuppressed
+
+	case errors.Is(err, domain.ErrBreakGlassNotFound):
+		return http.StatusNotFound, models.IssueSeverityError, models.IssueTypeNotFound
+
+	case errors.Is(err, domain.ErrBreakGlassReasonRequired):
+		return http.StatusBadRequest, models.IssueSeverityError, models.IssueTypeRequired
+
 	default:
 		return http.StatusInternalServerError, models.IssueSeverityFatal, models.IssueTypeException
 	}
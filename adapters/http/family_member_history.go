@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateFamilyMemberHistory(w http.ResponseWriter, r *http.Request) {
+	var fmh models.FamilyMemberHistory
+	if err := json.NewDecoder(r.Body).Decode(&fmh); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := fmh.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.familyMemberHistoryService.Create(r.Context(), &fmh)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetFamilyMemberHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	fmh, err := h.familyMemberHistoryService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, fmh)
+}
+
+func (h *Handler) UpdateFamilyMemberHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var fmh models.FamilyMemberHistory
+	if err := json.NewDecoder(r.Body).Decode(&fmh); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := fmh.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if fmh.Id == nil || *fmh.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.familyMemberHistoryService.Update(r.Context(), &fmh)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteFamilyMemberHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.familyMemberHistoryService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListFamilyMemberHistories(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.FamilyMemberHistorySearch{
+		PatientID:    query.Get("patient"),
+		Status:       query.Get("status"),
+		Relationship: query.Get("relationship"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.familyMemberHistoryService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapFamilyMemberHistoriesInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapFamilyMemberHistoriesInBundle(histories []models.FamilyMemberHistory, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(histories)),
+	}
+
+	for i := range histories {
+		resourceRaw, err := json.Marshal(histories[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
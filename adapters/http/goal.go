@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	var goal models.Goal
+	if err := json.NewDecoder(r.Body).Decode(&goal); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := goal.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.goalService.Create(r.Context(), &goal)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetGoal(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	goal, err := h.goalService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, goal)
+}
+
+func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var goal models.Goal
+	if err := json.NewDecoder(r.Body).Decode(&goal); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := goal.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if goal.Id == nil || *goal.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.goalService.Update(r.Context(), &goal)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.goalService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.GoalSearch{
+		PatientID:       query.Get("patient"),
+		LifecycleStatus: query.Get("lifecycle-status"),
+		Category:        query.Get("category"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.goalService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapGoalsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapGoalsInBundle(goals []models.Goal, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(goals)),
+	}
+
+	for i := range goals {
+		resourceRaw, err := json.Marshal(goals[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
+
+// GoalProgress evaluates a goal against the observations of its subject and
+// returns the achievement status of the goal and each measurable target.
+func (h *Handler) GoalProgress(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	progress, err := h.goalService.Progress(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, goalProgressParameters(progress))
+}
+
+func goalProgressParameters(progress *domain.GoalProgress) *models.Parameters {
+	params := &models.Parameters{
+		ResourceType: "Parameters",
+		Parameter: []models.ParametersParameter{
+			{Name: "goal", ValueReference: &models.Reference{Reference: ptr.To("Goal/" + *progress.Goal.Id)}},
+			{Name: "achievementStatus", ValueCodeableConcept: goalAchievementConcept(progress.AchievementStatus)},
+		},
+	}
+
+	for _, target := range progress.Targets {
+		parts := []models.ParametersParameter{
+			{Name: "measure", ValueCodeableConcept: target.Measure},
+			{Name: "achievementStatus", ValueCodeableConcept: goalAchievementConcept(target.AchievementStatus)},
+			{Name: "observations", ValueInteger: ptr.To(target.Observations)},
+		}
+		if target.Latest != nil && target.Latest.Id != nil {
+			parts = append(parts, models.ParametersParameter{
+				Name:           "latest",
+				ValueReference: &models.Reference{Reference: ptr.To("Observation/" + *target.Latest.Id)},
+			})
+		}
+		params.Parameter = append(params.Parameter, models.ParametersParameter{Name: "target", Part: parts})
+	}
+
+	return params
+}
+
+func goalAchievementConcept(code string) *models.CodeableConcept {
+	return &models.CodeableConcept{Coding: []models.Coding{{
+		System: ptr.To(domain.GoalAchievementSystem),
+		Code:   ptr.To(code),
+	}}}
+}
@@ -12,40 +12,146 @@ import (
 )
 
 type Handler struct {
-	cfg                *configs.Config
-	patientService     ports.PatientService
-	documentService    ports.DocumentService
-	observationService ports.ObservationService
-	shareService       ports.ShareService
+	cfg                     *configs.Config
+	patientService          ports.PatientService
+	documentService         ports.DocumentService
+	observationService      ports.ObservationService
+	shareService            ports.ShareService
+	practitionerService     ports.PractitionerService
+	practitionerRoleService ports.PractitionerRoleService
+	careService             ports.CareRelationshipService
+	relatedPersonService    ports.RelatedPersonService
+	delegationService       ports.DelegationService
+	breakGlassService       ports.BreakGlassService
+	notificationService     ports.NotificationService
+	consentService          ports.ConsentService
+	conditionService        ports.ConditionService
+
+	medicationStatementService   ports.MedicationStatementService
+	medicationRequestService     ports.MedicationRequestService
+	allergyIntoleranceService    ports.AllergyIntoleranceService
+	immunizationService          ports.ImmunizationService
+	diagnosticReportService      ports.DiagnosticReportService
+	procedureService             ports.ProcedureService
+	familyMemberHistoryService   ports.FamilyMemberHistoryService
+	appointmentService           ports.AppointmentService
+	encounterService             ports.EncounterService
+	organizationService          ports.OrganizationService
+	locationService              ports.LocationService
+	compositionService           ports.CompositionService
+	questionnaireService         ports.QuestionnaireService
+	questionnaireResponseService ports.QuestionnaireResponseService
+	subscriptionTopicService     ports.SubscriptionTopicService
+	subscriptionService          ports.SubscriptionService
+	goalService                  ports.GoalService
+	carePlanService              ports.CarePlanService
+	everythingService            ports.EverythingService
+	summaryService               ports.SummaryService
 }
 
-func NewHandler(cfg *configs.Config, ps ports.PatientService, ds ports.DocumentService, os ports.ObservationService, ss ports.ShareService) *Handler {
+func NewHandler(cfg *configs.Config, ps ports.PatientService, ds ports.DocumentService, os ports.ObservationService, ss ports.ShareService, prs ports.PractitionerService, rs ports.PractitionerRoleService, cs ports.CareRelationshipService, rps ports.RelatedPersonService, dls ports.DelegationService, bgs ports.BreakGlassService, ns ports.NotificationService, cns ports.ConsentService, cds ports.ConditionService, mss ports.MedicationStatementService, mrs ports.MedicationRequestService, ais ports.AllergyIntoleranceService, ims ports.ImmunizationService, drs ports.DiagnosticReportService, pcs ports.ProcedureService, fhs ports.FamilyMemberHistoryService, aps ports.AppointmentService, ens ports.EncounterService, ogs ports.OrganizationService, lcs ports.LocationService, cps ports.CompositionService, qs ports.QuestionnaireService, qrs ports.QuestionnaireResponseService, sts ports.SubscriptionTopicService, sbs ports.SubscriptionService, gls ports.GoalService, cpls ports.CarePlanService, evs ports.EverythingService, sms ports.SummaryService) *Handler {
 	return &Handler{
-		cfg:                cfg,
-		patientService:     ps,
-		documentService:    ds,
-		observationService: os,
-		shareService:       ss,
+		cfg:                     cfg,
+		patientService:          ps,
+		documentService:         ds,
+		observationService:      os,
+		shareService:            ss,
+		practitionerService:     prs,
+		practitionerRoleService: rs,
+		careService:             cs,
+		relatedPersonService:    rps,
+		delegationService:       dls,
+		breakGlassService:       bgs,
+		notificationService:     ns,
+		consentService:          cns,
+		conditionService:        cds,
+
+		medicationStatementService:   mss,
+		medicationRequestService:     mrs,
+		allergyIntoleranceService:    ais,
+		immunizationService:          ims,
+		diagnosticReportService:      drs,
+		procedureService:             pcs,
+		familyMemberHistoryService:   fhs,
+		appointmentService:           aps,
+		encounterService:             ens,
+		organizationService:          ogs,
+		locationService:              lcs,
+		compositionService:           cps,
+		questionnaireService:         qs,
+		questionnaireResponseService: qrs,
+		subscriptionTopicService:     sts,
+		subscriptionService:          sbs,
+		goalService:                  gls,
+		carePlanService:              cpls,
+		everythingService:            evs,
+		summaryService:               sms,
 	}
 }
 
 func (h *Handler) RegisterRoutes(router *mux.Router) {
-	authMid := NewAuthMiddleware(h.cfg.Auth.JWTSecret)
+	authMid := NewAuthMiddleware(h.cfg)
 
 	router.HandleFunc("/health", h.HealthCheck).Methods("GET")
 
+	// The SHL manifest is fetched anonymously by the link recipient and is
+	// protected by the passcode instead of a bearer token.
+	router.HandleFunc("/api/v1/shl/{id}", h.GetSHLManifest).Methods("POST")
+
 	api := router.PathPrefix("/api/v1").Subrouter()
 	api.Use(authMid.Handler)
 
 	p := api.PathPrefix("/Patient").Subrouter()
 	p.HandleFunc("/{id}", h.GetPatient).Methods("GET")
 	p.HandleFunc("/{id}", h.UpdatePatient).Methods("PUT")
+	p.HandleFunc("/{id}/$everything", h.PatientEverything).Methods("GET")
+	p.HandleFunc("/{id}/$summary", h.PatientSummary).Methods("GET")
+	p.HandleFunc("/{id}/care-relationships", h.GrantCare).Methods("POST")
+	p.HandleFunc("/{id}/care-relationships", h.ListCare).Methods("GET")
+	p.HandleFunc("/{id}/care-relationships/{practitionerId}", h.RevokeCare).Methods("DELETE")
+	p.HandleFunc("/{id}/delegations", h.GrantDelegation).Methods("POST")
+	p.HandleFunc("/{id}/delegations", h.ListDelegations).Methods("GET")
+	p.HandleFunc("/{id}/delegations/{userId}", h.RevokeDelegation).Methods("DELETE")
+	p.HandleFunc("/{id}/break-glass", h.RequestBreakGlass).Methods("POST")
+
+	rp := api.PathPrefix("/RelatedPerson").Subrouter()
+	rp.HandleFunc("", h.CreateRelatedPerson).Methods("POST")
+	rp.HandleFunc("", h.ListRelatedPersons).Methods("GET")
+	rp.HandleFunc("/{id}", h.GetRelatedPerson).Methods("GET")
+	rp.HandleFunc("/{id}", h.DeleteRelatedPerson).Methods("DELETE")
+
+	cn := api.PathPrefix("/Consent").Subrouter()
+	cn.HandleFunc("", h.CreateConsent).Methods("POST")
+	cn.HandleFunc("", h.ListConsents).Methods("GET")
+	cn.HandleFunc("/{id}", h.GetConsent).Methods("GET")
+	cn.HandleFunc("/{id}", h.UpdateConsent).Methods("PUT")
+	cn.HandleFunc("/{id}", h.DeleteConsent).Methods("DELETE")
+
+	api.HandleFunc("/delegations", h.ListMyDelegations).Methods("GET")
+	api.HandleFunc("/notifications", h.ListNotifications).Methods("GET")
+
+	bg := api.PathPrefix("/break-glass").Subrouter()
+	bg.HandleFunc("/review", h.ListBreakGlassForReview).Methods("GET")
+	bg.HandleFunc("/{id}/review", h.ReviewBreakGlass).Methods("POST")
+
+	pr := api.PathPrefix("/Practitioner").Subrouter()
+	pr.HandleFunc("", h.CreatePractitioner).Methods("POST")
+	pr.HandleFunc("/{id}", h.GetPractitioner).Methods("GET")
+	pr.HandleFunc("/{id}", h.UpdatePractitioner).Methods("PUT")
+
+	role := api.PathPrefix("/PractitionerRole").Subrouter()
+	role.HandleFunc("", h.CreatePractitionerRole).Methods("POST")
+	role.HandleFunc("", h.ListPractitionerRoles).Methods("GET")
+	role.HandleFunc("/{id}", h.GetPractitionerRole).Methods("GET")
+	role.HandleFunc("/{id}", h.DeletePractitionerRole).Methods("DELETE")
 
 	d := api.PathPrefix("/DocumentReference").Subrouter()
 	d.HandleFunc("", h.CreateDocument).Methods("POST")
 	d.HandleFunc("", h.ListDocuments).Methods("GET")
 	d.HandleFunc("/{id}", h.GetDocument).Methods("GET")
 	d.HandleFunc("/{id}", h.DeleteDocument).Methods("DELETE")
+	d.HandleFunc("/{id}/$meta-add", h.AddDocumentMeta).Methods("POST")
+	d.HandleFunc("/{id}/$meta-delete", h.DeleteDocumentMeta).Methods("POST")
 
 	o := api.PathPrefix("/Observation").Subrouter()
 	o.HandleFunc("", h.CreateObservation).Methods("POST")
@@ -53,9 +159,178 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 	o.HandleFunc("/{id}", h.GetObservation).Methods("GET")
 	o.HandleFunc("/{id}", h.UpdateObservation).Methods("PUT")
 	o.HandleFunc("/{id}", h.DeleteObservation).Methods("DELETE")
+	o.HandleFunc("/{id}/$meta-add", h.AddObservationMeta).Methods("POST")
+	o.HandleFunc("/{id}/$meta-delete", h.DeleteObservationMeta).Methods("POST")
+
+	c := api.PathPrefix("/Condition").Subrouter()
+	c.HandleFunc("", h.CreateCondition).Methods("POST")
+	c.HandleFunc("", h.ListConditions).Methods("GET")
+	c.HandleFunc("/{id}", h.GetCondition).Methods("GET")
+	c.HandleFunc("/{id}", h.UpdateCondition).Methods("PUT")
+	c.HandleFunc("/{id}", h.DeleteCondition).Methods("DELETE")
+	c.HandleFunc("/{id}/$meta-add", h.AddConditionMeta).Methods("POST")
+	c.HandleFunc("/{id}/$meta-delete", h.DeleteConditionMeta).Methods("POST")
+
+	ms := api.PathPrefix("/MedicationStatement").Subrouter()
+	ms.HandleFunc("", h.CreateMedicationStatement).Methods("POST")
+	ms.HandleFunc("", h.ListMedicationStatements).Methods("GET")
+	ms.HandleFunc("/{id}", h.GetMedicationStatement).Methods("GET")
+	ms.HandleFunc("/{id}", h.UpdateMedicationStatement).Methods("PUT")
+	ms.HandleFunc("/{id}", h.DeleteMedicationStatement).Methods("DELETE")
+	ms.HandleFunc("/{id}/$meta-add", h.AddMedicationStatementMeta).Methods("POST")
+	ms.HandleFunc("/{id}/$meta-delete", h.DeleteMedicationStatementMeta).Methods("POST")
+
+	mr := api.PathPrefix("/MedicationRequest").Subrouter()
+	mr.HandleFunc("", h.CreateMedicationRequest).Methods("POST")
+	mr.HandleFunc("", h.ListMedicationRequests).Methods("GET")
+	mr.HandleFunc("/{id}", h.GetMedicationRequest).Methods("GET")
+	mr.HandleFunc("/{id}", h.UpdateMedicationRequest).Methods("PUT")
+	mr.HandleFunc("/{id}", h.DeleteMedicationRequest).Methods("DELETE")
+	mr.HandleFunc("/{id}/$meta-add", h.AddMedicationRequestMeta).Methods("POST")
+	mr.HandleFunc("/{id}/$meta-delete", h.DeleteMedicationRequestMeta).Methods("POST")
+
+	ai := api.PathPrefix("/AllergyIntolerance").Subrouter()
+	ai.HandleFunc("", h.CreateAllergyIntolerance).Methods("POST")
+	ai.HandleFunc("", h.ListAllergyIntolerances).Methods("GET")
+	ai.HandleFunc("/{id}", h.GetAllergyIntolerance).Methods("GET")
+	ai.HandleFunc("/{id}", h.UpdateAllergyIntolerance).Methods("PUT")
+	ai.HandleFunc("/{id}", h.DeleteAllergyIntolerance).Methods("DELETE")
+	ai.HandleFunc("/{id}/$meta-add", h.AddAllergyIntoleranceMeta).Methods("POST")
+	ai.HandleFunc("/{id}/$meta-delete", h.DeleteAllergyIntoleranceMeta).Methods("POST")
+
+	imm := api.PathPrefix("/Immunization").Subrouter()
+	imm.HandleFunc("", h.CreateImmunization).Methods("POST")
+	imm.HandleFunc("", h.ListImmunizations).Methods("GET")
+	imm.HandleFunc("/{id}", h.GetImmunization).Methods("GET")
+	imm.HandleFunc("/{id}", h.UpdateImmunization).Methods("PUT")
+	imm.HandleFunc("/{id}", h.DeleteImmunization).Methods("DELETE")
+	imm.HandleFunc("/{id}/$meta-add", h.AddImmunizationMeta).Methods("POST")
+	imm.HandleFunc("/{id}/$meta-delete", h.DeleteImmunizationMeta).Methods("POST")
+
+	dr := api.PathPrefix("/DiagnosticReport").Subrouter()
+	dr.HandleFunc("", h.CreateDiagnosticReport).Methods("POST")
+	dr.HandleFunc("", h.ListDiagnosticReports).Methods("GET")
+	dr.HandleFunc("/{id}", h.GetDiagnosticReport).Methods("GET")
+	dr.HandleFunc("/{id}", h.UpdateDiagnosticReport).Methods("PUT")
+	dr.HandleFunc("/{id}", h.DeleteDiagnosticReport).Methods("DELETE")
+	dr.HandleFunc("/{id}/$meta-add", h.AddDiagnosticReportMeta).Methods("POST")
+	dr.HandleFunc("/{id}/$meta-delete", h.DeleteDiagnosticReportMeta).Methods("POST")
+
+	proc := api.PathPrefix("/Procedure").Subrouter()
+	proc.HandleFunc("", h.CreateProcedure).Methods("POST")
+	proc.HandleFunc("", h.ListProcedures).Methods("GET")
+	proc.HandleFunc("/{id}", h.GetProcedure).Methods("GET")
+	proc.HandleFunc("/{id}", h.UpdateProcedure).Methods("PUT")
+	proc.HandleFunc("/{id}", h.DeleteProcedure).Methods("DELETE")
+	proc.HandleFunc("/{id}/$meta-add", h.AddProcedureMeta).Methods("POST")
+	proc.HandleFunc("/{id}/$meta-delete", h.DeleteProcedureMeta).Methods("POST")
+
+	fh := api.PathPrefix("/FamilyMemberHistory").Subrouter()
+	fh.HandleFunc("", h.CreateFamilyMemberHistory).Methods("POST")
+	fh.HandleFunc("", h.ListFamilyMemberHistories).Methods("GET")
+	fh.HandleFunc("/{id}", h.GetFamilyMemberHistory).Methods("GET")
+	fh.HandleFunc("/{id}", h.UpdateFamilyMemberHistory).Methods("PUT")
+	fh.HandleFunc("/{id}", h.DeleteFamilyMemberHistory).Methods("DELETE")
+	fh.HandleFunc("/{id}/$meta-add", h.AddFamilyMemberHistoryMeta).Methods("POST")
+	fh.HandleFunc("/{id}/$meta-delete", h.DeleteFamilyMemberHistoryMeta).Methods("POST")
+
+	appt := api.PathPrefix("/Appointment").Subrouter()
+	appt.HandleFunc("", h.CreateAppointment).Methods("POST")
+	appt.HandleFunc("", h.ListAppointments).Methods("GET")
+	appt.HandleFunc("/{id}", h.GetAppointment).Methods("GET")
+	appt.HandleFunc("/{id}", h.UpdateAppointment).Methods("PUT")
+	appt.HandleFunc("/{id}", h.DeleteAppointment).Methods("DELETE")
+	appt.HandleFunc("/{id}/$meta-add", h.AddAppointmentMeta).Methods("POST")
+	appt.HandleFunc("/{id}/$meta-delete", h.DeleteAppointmentMeta).Methods("POST")
+
+	enc := api.PathPrefix("/Encounter").Subrouter()
+	enc.HandleFunc("", h.CreateEncounter).Methods("POST")
+	enc.HandleFunc("", h.ListEncounters).Methods("GET")
+	enc.HandleFunc("/{id}", h.GetEncounter).Methods("GET")
+	enc.HandleFunc("/{id}", h.UpdateEncounter).Methods("PUT")
+	enc.HandleFunc("/{id}", h.DeleteEncounter).Methods("DELETE")
+	enc.HandleFunc("/{id}/$meta-add", h.AddEncounterMeta).Methods("POST")
+	enc.HandleFunc("/{id}/$meta-delete", h.DeleteEncounterMeta).Methods("POST")
+
+	org := api.PathPrefix("/Organization").Subrouter()
+	org.HandleFunc("", h.CreateOrganization).Methods("POST")
+	org.HandleFunc("", h.ListOrganizations).Methods("GET")
+	org.HandleFunc("/{id}", h.GetOrganization).Methods("GET")
+	org.HandleFunc("/{id}", h.UpdateOrganization).Methods("PUT")
+	org.HandleFunc("/{id}", h.DeleteOrganization).Methods("DELETE")
+
+	loc := api.PathPrefix("/Location").Subrouter()
+	loc.HandleFunc("", h.CreateLocation).Methods("POST")
+	loc.HandleFunc("", h.ListLocations).Methods("GET")
+	loc.HandleFunc("/{id}", h.GetLocation).Methods("GET")
+	loc.HandleFunc("/{id}", h.UpdateLocation).Methods("PUT")
+	loc.HandleFunc("/{id}", h.DeleteLocation).Methods("DELETE")
+
+	comp := api.PathPrefix("/Composition").Subrouter()
+	comp.HandleFunc("", h.CreateComposition).Methods("POST")
+	comp.HandleFunc("", h.ListCompositions).Methods("GET")
+	comp.HandleFunc("/{id}", h.GetComposition).Methods("GET")
+	comp.HandleFunc("/{id}", h.UpdateComposition).Methods("PUT")
+	comp.HandleFunc("/{id}", h.DeleteComposition).Methods("DELETE")
+	comp.HandleFunc("/{id}/$meta-add", h.AddCompositionMeta).Methods("POST")
+	comp.HandleFunc("/{id}/$meta-delete", h.DeleteCompositionMeta).Methods("POST")
+	comp.HandleFunc("/{id}/$document", h.CompositionDocument).Methods("GET")
+
+	qn := api.PathPrefix("/Questionnaire").Subrouter()
+	qn.HandleFunc("", h.CreateQuestionnaire).Methods("POST")
+	qn.HandleFunc("", h.ListQuestionnaires).Methods("GET")
+	qn.HandleFunc("/{id}", h.GetQuestionnaire).Methods("GET")
+	qn.HandleFunc("/{id}", h.UpdateQuestionnaire).Methods("PUT")
+	qn.HandleFunc("/{id}", h.DeleteQuestionnaire).Methods("DELETE")
+
+	qr := api.PathPrefix("/QuestionnaireResponse").Subrouter()
+	qr.HandleFunc("", h.CreateQuestionnaireResponse).Methods("POST")
+	qr.HandleFunc("", h.ListQuestionnaireResponses).Methods("GET")
+	qr.HandleFunc("/{id}", h.GetQuestionnaireResponse).Methods("GET")
+	qr.HandleFunc("/{id}", h.UpdateQuestionnaireResponse).Methods("PUT")
+	qr.HandleFunc("/{id}", h.DeleteQuestionnaireResponse).Methods("DELETE")
+	qr.HandleFunc("/{id}/$meta-add", h.AddQuestionnaireResponseMeta).Methods("POST")
+	qr.HandleFunc("/{id}/$meta-delete", h.DeleteQuestionnaireResponseMeta).Methods("POST")
+	qr.HandleFunc("/{id}/$extract", h.ExtractQuestionnaireResponse).Methods("POST")
+
+	st := api.PathPrefix("/SubscriptionTopic").Subrouter()
+	st.HandleFunc("", h.CreateSubscriptionTopic).Methods("POST")
+	st.HandleFunc("", h.ListSubscriptionTopics).Methods("GET")
+	st.HandleFunc("/{id}", h.GetSubscriptionTopic).Methods("GET")
+	st.HandleFunc("/{id}", h.UpdateSubscriptionTopic).Methods("PUT")
+	st.HandleFunc("/{id}", h.DeleteSubscriptionTopic).Methods("DELETE")
+
+	sb := api.PathPrefix("/Subscription").Subrouter()
+	sb.HandleFunc("", h.CreateSubscription).Methods("POST")
+	sb.HandleFunc("", h.ListSubscriptions).Methods("GET")
+	sb.HandleFunc("/{id}", h.GetSubscription).Methods("GET")
+	sb.HandleFunc("/{id}", h.UpdateSubscription).Methods("PUT")
+	sb.HandleFunc("/{id}", h.DeleteSubscription).Methods("DELETE")
+	sb.HandleFunc("/{id}/$status", h.GetSubscriptionStatus).Methods("GET")
+
+	gl := api.PathPrefix("/Goal").Subrouter()
+	gl.HandleFunc("", h.CreateGoal).Methods("POST")
+	gl.HandleFunc("", h.ListGoals).Methods("GET")
+	gl.HandleFunc("/{id}", h.GetGoal).Methods("GET")
+	gl.HandleFunc("/{id}", h.UpdateGoal).Methods("PUT")
+	gl.HandleFunc("/{id}", h.DeleteGoal).Methods("DELETE")
+	gl.HandleFunc("/{id}/$meta-add", h.AddGoalMeta).Methods("POST")
+	gl.HandleFunc("/{id}/$meta-delete", h.DeleteGoalMeta).Methods("POST")
+	gl.HandleFunc("/{id}/$progress", h.GoalProgress).Methods("GET")
+
+	cp := api.PathPrefix("/CarePlan").Subrouter()
+	cp.HandleFunc("", h.CreateCarePlan).Methods("POST")
+	cp.HandleFunc("", h.ListCarePlans).Methods("GET")
+	cp.HandleFunc("/{id}", h.GetCarePlan).Methods("GET")
+	cp.HandleFunc("/{id}", h.UpdateCarePlan).Methods("PUT")
+	cp.HandleFunc("/{id}", h.DeleteCarePlan).Methods("DELETE")
+	cp.HandleFunc("/{id}/$meta-add", h.AddCarePlanMeta).Methods("POST")
+	cp.HandleFunc("/{id}/$meta-delete", h.DeleteCarePlanMeta).Methods("POST")
 
 	api.HandleFunc("/share", h.CreateShare).Methods("POST")
+	api.HandleFunc("/share/shl", h.CreateSHL).Methods("POST")
 	api.HandleFunc("/shared", h.GetSharedResources).Methods("GET")
+	api.HandleFunc("/shared/$bundle", h.GetSharedBundle).Methods("GET")
 }
 
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
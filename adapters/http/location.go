@@ -0,0 +1,129 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var loc models.Location
+	if err := json.NewDecoder(r.Body).Decode(&loc); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := loc.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.locationService.Create(r.Context(), &loc)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	loc, err := h.locationService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, loc)
+}
+
+func (h *Handler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var loc models.Location
+	if err := json.NewDecoder(r.Body).Decode(&loc); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := loc.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if loc.Id == nil || *loc.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.locationService.Update(r.Context(), &loc)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.locationService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.DirectorySearch{
+		Name:    query.Get("name"),
+		Address: query.Get("address"),
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.locationService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapLocationsInBundle(res.Items, res.Total)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapLocationsInBundle(locations []models.Location, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(locations)),
+	}
+
+	for i := range locations {
+		resourceRaw, err := json.Marshal(locations[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
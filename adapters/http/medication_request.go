@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateMedicationRequest(w http.ResponseWriter, r *http.Request) {
+	var mr models.MedicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&mr); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := mr.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.medicationRequestService.Create(r.Context(), &mr)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetMedicationRequest(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	mr, err := h.medicationRequestService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, mr)
+}
+
+func (h *Handler) UpdateMedicationRequest(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var mr models.MedicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&mr); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := mr.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if mr.Id == nil || *mr.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.medicationRequestService.Update(r.Context(), &mr)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteMedicationRequest(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.medicationRequestService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListMedicationRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.MedicationSearch{
+		PatientID: query.Get("patient"),
+		Status:    query.Get("status"),
+		Code:      query.Get("code"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["effective"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: effective: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Effective = append(search.Effective, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.medicationRequestService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapMedicationRequestsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapMedicationRequestsInBundle(requests []models.MedicationRequest, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(requests)),
+	}
+
+	for i := range requests {
+		resourceRaw, err := json.Marshal(requests[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
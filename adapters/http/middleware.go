@@ -1,52 +1,108 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/gruzdev-dev/codex-documents/configs"
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/jwks"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
 )
 
+const authRealm = "codex-documents"
+
+// activePatientHeader selects the patient compartment a caregiver acts on.
+// Whether the user may act on it is decided by the authorizer.
+const activePatientHeader = "X-Active-Patient"
+
+var asymmetricMethods = []string{"RS256", "ES256", "EdDSA"}
+
 type AuthMiddleware struct {
 	secret []byte
+	keys   *jwks.KeySet
+	parser *jwt.Parser
 }
 
-func NewAuthMiddleware(secret string) *AuthMiddleware {
-	return &AuthMiddleware{secret: []byte(secret)}
+// NewAuthMiddleware accepts HS256 tokens signed with the shared secret and,
+// when a JWKS source is configured, RS256, ES256 and EdDSA tokens signed by
+// any key in the set.
+func NewAuthMiddleware(cfg *configs.Config) *AuthMiddleware {
+	m := &AuthMiddleware{secret: []byte(cfg.Auth.JWTSecret)}
+
+	var methods []string
+	if cfg.Auth.JWTSecret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	var source jwks.Source
+	switch {
+	case cfg.Auth.JWKSURL != "":
+		source = jwks.NewHTTPSource(cfg.Auth.JWKSURL, nil)
+	case cfg.Auth.JWKSFile != "":
+		source = jwks.NewFileSource(cfg.Auth.JWKSFile)
+	}
+	if source != nil {
+		m.keys = jwks.NewKeySet(source,
+			jwks.WithCacheTTL(cfg.Auth.JWKSCacheTTL),
+			jwks.WithRefreshInterval(cfg.Auth.JWKSRefreshInterval),
+			jwks.WithMaxStale(cfg.Auth.JWKSMaxStale),
+		)
+		methods = append(methods, asymmetricMethods...)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithLeeway(cfg.Auth.Leeway),
+	}
+	if cfg.Auth.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Auth.Issuer))
+	}
+	if len(cfg.Auth.Audience) > 0 {
+		opts = append(opts, jwt.WithAudience(cfg.Auth.Audience...))
+	}
+	if cfg.Auth.RequireExp {
+		opts = append(opts, jwt.WithExpirationRequired())
+	}
+	m.parser = jwt.NewParser(opts...)
+
+	return m
 }
 
 func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			next.ServeHTTP(w, r)
+		tokenString, ok := bearerToken(r)
+		if !ok {
+			writeUnauthorized(w, `Bearer realm="`+authRealm+`"`, models.IssueTypeLogin, "authentication required")
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
 		claims := jwt.MapClaims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return m.secret, nil
+		token, err := m.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return m.verificationKey(r, token)
 		})
 
 		if err != nil || !token.Valid {
-			w.WriteHeader(http.StatusUnauthorized)
+			issue, description := describeTokenError(err)
+			challenge := fmt.Sprintf(`Bearer realm="%s", error="invalid_token", error_description="%s"`, authRealm, description)
+			writeUnauthorized(w, challenge, issue, description)
 			return
 		}
 
 		id := domain.Identity{
-			UserID:    getClaim(claims, "sub"),
-			PatientID: getClaim(claims, "patient_id"),
+			UserID:         getClaim(claims, "sub"),
+			PatientID:      getClaim(claims, "patient_id"),
+			PractitionerID: getClaim(claims, "practitioner_id"),
 		}
 
-		if id.UserID == "" && id.PatientID == "" {
+		if id.IsTmpToken() {
 			scopesStr := getClaim(claims, "scopes")
 			if scopesStr != "" {
 				id.Scopes = strings.Split(scopesStr, ",")
@@ -55,6 +111,9 @@ func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
 			}
 		} else {
 			id.Scopes = parseScopes(claims["scope"])
+			id.ActivePatientID = r.Header.Get(activePatientHeader)
+			id.PurposeOfUse = getClaim(claims, "purpose_of_use")
+			id.SystemClient = isClientCredentials(claims)
 		}
 
 		ctx := identity.WithCtx(r.Context(), id)
@@ -62,6 +121,95 @@ func (m *AuthMiddleware) Handler(next http.Handler) http.Handler {
 	})
 }
 
+// bearerToken extracts the access token from an RFC 6750 Authorization
+// header. Requests with any other scheme count as unauthenticated.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func describeTokenError(err error) (models.IssueType, string) {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return models.IssueTypeExpired, "the access token expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return models.IssueTypeLogin, "the access token is not valid yet"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return models.IssueTypeLogin, "the access token has an unexpected issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return models.IssueTypeLogin, "the access token is not meant for this service"
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return models.IssueTypeLogin, "the access token is missing a required claim"
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return models.IssueTypeLogin, "the access token is malformed"
+	default:
+		return models.IssueTypeLogin, "the access token signature could not be verified"
+	}
+}
+
+// writeUnauthorized answers with 401, a WWW-Authenticate challenge and an
+// OperationOutcome describing why the request was not authenticated.
+func writeUnauthorized(w http.ResponseWriter, challenge string, issue models.IssueType, diagnostics string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/fhir+json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(models.OperationOutcome{
+		ResourceType: "OperationOutcome",
+		Issue: []models.OperationOutcomeIssue{
+			{
+				Severity:    string(models.IssueSeverityError),
+				Code:        string(issue),
+				Diagnostics: ptr.To(diagnostics),
+			},
+		},
+	})
+}
+
+func (m *AuthMiddleware) verificationKey(r *http.Request, token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(m.secret) == 0 {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return m.secret, nil
+	}
+
+	if m.keys == nil {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	keys, err := m.keys.Keys(r.Context(), kid)
+	if err != nil {
+		if !errors.Is(err, jwks.ErrKeyNotFound) {
+			log.Printf("auth: failed to load signing keys: %v", err)
+		}
+		return nil, err
+	}
+
+	set := jwt.VerificationKeySet{}
+	for _, k := range keys {
+		if k.Algorithm != "" && k.Algorithm != token.Method.Alg() {
+			continue
+		}
+		set.Keys = append(set.Keys, k.Public)
+	}
+	if len(set.Keys) == 0 {
+		return nil, jwks.ErrKeyNotFound
+	}
+	return set, nil
+}
+
+// isClientCredentials reports whether the token was issued through the
+// client-credentials grant, which authorization servers record in "gty"
+// (client-credentials) or "grant_type" (client_credentials).
+func isClientCredentials(claims jwt.MapClaims) bool {
+	return getClaim(claims, "gty") == "client-credentials" || getClaim(claims, "grant_type") == "client_credentials"
+}
+
 func getClaim(claims jwt.MapClaims, key string) string {
 	val, _ := claims[key].(string)
 	return val
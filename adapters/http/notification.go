@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+type NotificationResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	Reference string `json:"reference,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Items []NotificationResponse `json:"items"`
+	Total int64                  `json:"total"`
+}
+
+func (h *Handler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.notificationService.List(r.Context(), limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	resp := NotificationListResponse{
+		Items: make([]NotificationResponse, 0, len(res.Items)),
+		Total: res.Total,
+	}
+	for _, n := range res.Items {
+		resp.Items = append(resp.Items, toNotificationResponse(n))
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func toNotificationResponse(n domain.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID,
+		Type:      n.Type,
+		Message:   n.Message,
+		Reference: n.Reference,
+		CreatedAt: n.CreatedAt,
+	}
+}
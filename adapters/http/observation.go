@@ -84,21 +84,37 @@ func (h *Handler) DeleteObservation(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) ListObservations(w http.ResponseWriter, r *http.Request) {
-	patientID := r.URL.Query().Get("patient")
-	if patientID == "" {
+	query := r.URL.Query()
+
+	search := domain.ObservationSearch{
+		PatientID:  query.Get("patient"),
+		Encounter:  query.Get("encounter"),
+		Code:       query.Get("code"),
+		Identifier: query.Get("identifier"),
+	}
+	if search.PatientID == "" {
 		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
 		return
 	}
+	for _, value := range query["date"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: date: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Date = append(search.Date, date)
+	}
 
 	limit, offset := h.parsePagination(r)
 
-	res, err := h.observationService.List(r.Context(), patientID, limit, offset)
+	res, err := h.observationService.List(r.Context(), search, limit, offset)
 	if err != nil {
 		h.respondWithError(w, err)
 		return
 	}
 
 	bundle := h.wrapObservationsInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
 	h.respondWithResource(w, http.StatusOK, bundle)
 }
 
@@ -46,3 +46,58 @@ func (h *Handler) UpdatePatient(w http.ResponseWriter, r *http.Request) {
 
 	h.respondWithResource(w, http.StatusOK, updatedPatient)
 }
+
+func (h *Handler) PatientEverything(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.everythingService.Everything(r.Context(), id, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, h.wrapEverythingInBundle(res))
+}
+
+// PatientSummary serves Patient/{id}/$summary. With persist=true the
+// summary is also stored as a DocumentReference, which the patient can share
+// like any other document.
+func (h *Handler) PatientSummary(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	persist, err := parsePersist(r)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	res, err := h.summaryService.Summary(r.Context(), id, persist)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithGeneratedDocument(w, res)
+}
+
+func (h *Handler) wrapEverythingInBundle(res *domain.PatientEverything) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", res.Total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(res.Total) + 1),
+		Entry:        make([]models.BundleEntry, 0, len(res.Resources)+1),
+	}
+
+	h.appendSearchEntry(bundle, "Patient", res.Patient.Id, res.Patient)
+	for _, resource := range res.Resources {
+		h.appendSearchEntry(bundle, resource.Type, &resource.ID, resource.Resource)
+	}
+
+	appendWithheldEntry(bundle, res.Withheld)
+
+	return bundle
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreatePractitioner(w http.ResponseWriter, r *http.Request) {
+	var practitioner models.Practitioner
+	if err := json.NewDecoder(r.Body).Decode(&practitioner); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := practitioner.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	created, err := h.practitionerService.Create(r.Context(), &practitioner)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, created)
+}
+
+func (h *Handler) GetPractitioner(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	practitioner, err := h.practitionerService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, practitioner)
+}
+
+func (h *Handler) UpdatePractitioner(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var practitioner models.Practitioner
+	if err := json.NewDecoder(r.Body).Decode(&practitioner); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	practitioner.Id = ptr.To(id)
+
+	if err := practitioner.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	updated, err := h.practitionerService.Update(r.Context(), &practitioner)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, updated)
+}
+
+func (h *Handler) CreatePractitionerRole(w http.ResponseWriter, r *http.Request) {
+	var role models.PractitionerRole
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := role.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	created, err := h.practitionerRoleService.Create(r.Context(), &role)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, created)
+}
+
+func (h *Handler) GetPractitionerRole(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	role, err := h.practitionerRoleService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, role)
+}
+
+func (h *Handler) DeletePractitionerRole(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.practitionerRoleService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListPractitionerRoles(w http.ResponseWriter, r *http.Request) {
+	practitionerID := r.URL.Query().Get("practitioner")
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.practitionerRoleService.List(r.Context(), practitionerID, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           ptr.To(fmt.Sprintf("bundle-%d", res.Total)),
+		Type:         "searchset",
+		Total:        ptr.To(int(res.Total)),
+		Entry:        make([]models.BundleEntry, 0, len(res.Items)),
+	}
+
+	for i := range res.Items {
+		resourceRaw, err := json.Marshal(res.Items[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
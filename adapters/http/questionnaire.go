@@ -0,0 +1,123 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// CreateQuestionnaire publishes a questionnaire. The generated model
+// validation requires every value type of an answer option at once, so
+// questionnaires are validated by the service alone.
+func (h *Handler) CreateQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	var q models.Questionnaire
+	if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	result, err := h.questionnaireService.Create(r.Context(), &q)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	q, err := h.questionnaireService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, q)
+}
+
+func (h *Handler) UpdateQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var q models.Questionnaire
+	if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if q.Id == nil || *q.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.questionnaireService.Update(r.Context(), &q)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.questionnaireService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListQuestionnaires(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.QuestionnaireSearch{
+		Status: query.Get("status"),
+		URL:    query.Get("url"),
+		Title:  query.Get("title"),
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.questionnaireService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapQuestionnairesInBundle(res.Items, res.Total)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapQuestionnairesInBundle(questionnaires []models.Questionnaire, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(questionnaires)),
+	}
+
+	for i := range questionnaires {
+		resourceRaw, err := json.Marshal(questionnaires[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
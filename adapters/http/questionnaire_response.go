@@ -0,0 +1,151 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// CreateQuestionnaireResponse stores the answers to a questionnaire. The
+// generated model validation requires every value type of an answer at
+// once, so responses are validated by the service alone.
+func (h *Handler) CreateQuestionnaireResponse(w http.ResponseWriter, r *http.Request) {
+	var resp models.QuestionnaireResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	result, err := h.questionnaireResponseService.Create(r.Context(), &resp)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetQuestionnaireResponse(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	resp, err := h.questionnaireResponseService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, resp)
+}
+
+func (h *Handler) UpdateQuestionnaireResponse(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var resp models.QuestionnaireResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if resp.Id == nil || *resp.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.questionnaireResponseService.Update(r.Context(), &resp)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteQuestionnaireResponse(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.questionnaireResponseService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListQuestionnaireResponses(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.QuestionnaireResponseSearch{
+		PatientID:     query.Get("patient"),
+		Status:        query.Get("status"),
+		Questionnaire: query.Get("questionnaire"),
+	}
+	if search.PatientID == "" {
+		h.respondWithError(w, fmt.Errorf("%w: patient query parameter is required", domain.ErrInvalidInput))
+		return
+	}
+	for _, value := range query["authored"] {
+		date, err := domain.ParseDateFilter(value)
+		if err != nil {
+			h.respondWithError(w, fmt.Errorf("%w: authored: %v", domain.ErrInvalidInput, err))
+			return
+		}
+		search.Authored = append(search.Authored, date)
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.questionnaireResponseService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapQuestionnaireResponsesInBundle(res.Items, res.Total)
+	appendWithheldEntry(bundle, res.Withheld)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+// ExtractQuestionnaireResponse creates observations from the answers of a
+// completed response and returns them.
+func (h *Handler) ExtractQuestionnaireResponse(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	observations, err := h.questionnaireResponseService.Extract(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapObservationsInBundle(observations, int64(len(observations)))
+	h.respondWithResource(w, http.StatusCreated, bundle)
+}
+
+func (h *Handler) wrapQuestionnaireResponsesInBundle(responses []models.QuestionnaireResponse, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(responses)),
+	}
+
+	for i := range responses {
+		resourceRaw, err := json.Marshal(responses[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateRelatedPerson(w http.ResponseWriter, r *http.Request) {
+	var person models.RelatedPerson
+	if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := person.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	created, err := h.relatedPersonService.Create(r.Context(), &person)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, created)
+}
+
+func (h *Handler) GetRelatedPerson(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	person, err := h.relatedPersonService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, person)
+}
+
+func (h *Handler) DeleteRelatedPerson(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.relatedPersonService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListRelatedPersons(w http.ResponseWriter, r *http.Request) {
+	patientID := r.URL.Query().Get("patient")
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.relatedPersonService.List(r.Context(), patientID, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           ptr.To(fmt.Sprintf("bundle-%d", res.Total)),
+		Type:         "searchset",
+		Total:        ptr.To(int(res.Total)),
+		Entry:        make([]models.BundleEntry, 0, len(res.Items)),
+	}
+
+	for i := range res.Items {
+		resourceRaw, err := json.Marshal(res.Items[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
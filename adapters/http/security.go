@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// labelUpdater adds and removes the security labels of a resource and
+// returns its resulting meta.
+type labelUpdater func(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+
+func (h *Handler) AddObservationMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.observationService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteObservationMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.observationService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddDocumentMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.documentService.UpdateDocumentSecurityLabels, true)
+}
+
+func (h *Handler) DeleteDocumentMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.documentService.UpdateDocumentSecurityLabels, false)
+}
+
+func (h *Handler) AddConditionMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.conditionService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteConditionMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.conditionService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddMedicationStatementMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.medicationStatementService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteMedicationStatementMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.medicationStatementService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddMedicationRequestMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.medicationRequestService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteMedicationRequestMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.medicationRequestService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddAllergyIntoleranceMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.allergyIntoleranceService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteAllergyIntoleranceMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.allergyIntoleranceService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddImmunizationMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.immunizationService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteImmunizationMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.immunizationService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddDiagnosticReportMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.diagnosticReportService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteDiagnosticReportMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.diagnosticReportService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddProcedureMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.procedureService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteProcedureMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.procedureService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddFamilyMemberHistoryMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.familyMemberHistoryService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteFamilyMemberHistoryMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.familyMemberHistoryService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddAppointmentMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.appointmentService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteAppointmentMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.appointmentService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddEncounterMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.encounterService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteEncounterMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.encounterService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddCompositionMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.compositionService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteCompositionMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.compositionService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddQuestionnaireResponseMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.questionnaireResponseService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteQuestionnaireResponseMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.questionnaireResponseService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddGoalMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.goalService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteGoalMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.goalService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) AddCarePlanMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.carePlanService.UpdateSecurityLabels, true)
+}
+
+func (h *Handler) DeleteCarePlanMeta(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.carePlanService.UpdateSecurityLabels, false)
+}
+
+func (h *Handler) changeLabels(w http.ResponseWriter, r *http.Request, update labelUpdater, add bool) {
+	labels, err := decodeMetaParameter(r)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	var meta *models.Meta
+	if add {
+		meta, err = update(r.Context(), id, labels, nil)
+	} else {
+		meta, err = update(r.Context(), id, nil, labels)
+	}
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, metaParameters(meta))
+}
+
+// decodeMetaParameter reads the security labels from the meta parameter of a
+// $meta-add or $meta-delete request. Profiles and tags are not supported.
+func decodeMetaParameter(r *http.Request) ([]models.Coding, error) {
+	var params models.Parameters
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	for _, p := range params.Parameter {
+		if p.Name != "meta" || p.ValueMeta == nil {
+			continue
+		}
+		if len(p.ValueMeta.Profile) > 0 || len(p.ValueMeta.Tag) > 0 {
+			return nil, fmt.Errorf("%w: only security labels can be changed", domain.ErrInvalidInput)
+		}
+		return p.ValueMeta.Security, nil
+	}
+	return nil, fmt.Errorf("%w: meta parameter is required", domain.ErrInvalidInput)
+}
+
+func metaParameters(meta *models.Meta) *models.Parameters {
+	if meta == nil {
+		meta = &models.Meta{}
+	}
+	return &models.Parameters{
+		ResourceType: "Parameters",
+		Parameter: []models.ParametersParameter{{
+			Name:      "return",
+			ValueMeta: meta,
+		}},
+	}
+}
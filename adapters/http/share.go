@@ -2,9 +2,14 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/gorilla/mux"
 	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
 )
 
 type CreateShareRequest struct {
@@ -12,6 +17,14 @@ type CreateShareRequest struct {
 	TTLSeconds  int64    `json:"ttl_seconds"`
 }
 
+// ShareResponse keeps the field names clients already rely on and adds the
+// report of resources withheld by consent.
+type ShareResponse struct {
+	Token       string                   `json:"Token"`
+	ResourceURL string                   `json:"ResourceURL"`
+	Outcome     *models.OperationOutcome `json:"outcome,omitempty"`
+}
+
 func (h *Handler) CreateShare(w http.ResponseWriter, r *http.Request) {
 	var req CreateShareRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -33,7 +46,11 @@ func (h *Handler) CreateShare(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	err = json.NewEncoder(w).Encode(resp)
+	err = json.NewEncoder(w).Encode(ShareResponse{
+		Token:       resp.Token,
+		ResourceURL: resp.ResourceURL,
+		Outcome:     withheldOutcome(resp.Withheld),
+	})
 	if err != nil {
 		w.WriteHeader(http.StatusNoContent)
 	}
@@ -54,3 +71,172 @@ func (h *Handler) GetSharedResources(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+func (h *Handler) GetSharedBundle(w http.ResponseWriter, r *http.Request) {
+	var types []string
+	if raw := r.URL.Query().Get("_type"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, t)
+			}
+		}
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.shareService.GetSharedBundle(r.Context(), domain.SharedBundleRequest{
+		Types:  types,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, h.wrapSharedInBundle(res))
+}
+
+func (h *Handler) wrapSharedInBundle(res *domain.SharedBundle) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", res.Total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(res.Total)),
+		Entry:        make([]models.BundleEntry, 0, len(res.Observations)+len(res.DocumentReferences)+len(res.Resources)),
+	}
+
+	for i := range res.Observations {
+		h.appendSearchEntry(bundle, "Observation", res.Observations[i].Id, res.Observations[i])
+	}
+
+	for _, shared := range res.Resources {
+		h.appendSearchEntry(bundle, shared.Type, &shared.ID, shared.Resource)
+	}
+
+	for i := range res.DocumentReferences {
+		h.appendSearchEntry(bundle, "DocumentReference", res.DocumentReferences[i].Id, res.DocumentReferences[i])
+	}
+
+	appendWithheldEntry(bundle, res.Withheld)
+
+	return bundle
+}
+
+func (h *Handler) appendSearchEntry(bundle *models.Bundle, resourceType string, id *string, resource any) {
+	resourceRaw, err := json.Marshal(resource)
+	if err != nil {
+		return
+	}
+
+	entry := models.BundleEntry{
+		Resource: resourceRaw,
+		Search:   &models.BundleEntrySearch{Mode: ptr.To("match")},
+	}
+	if id != nil {
+		entry.FullUrl = ptr.To(fmt.Sprintf("%s/api/v1/%s/%s", strings.TrimSuffix(h.cfg.HTTP.PublicURL, "/"), resourceType, *id))
+	}
+
+	bundle.Entry = append(bundle.Entry, entry)
+}
+
+type CreateSHLRequest struct {
+	ResourceIDs []string `json:"resource_ids"`
+	TTLSeconds  int64    `json:"ttl_seconds"`
+	Passcode    string   `json:"passcode"`
+	Label       string   `json:"label"`
+}
+
+// SHLResponse keeps the field names clients already rely on and adds the
+// report of resources withheld by consent.
+type SHLResponse struct {
+	ID        string                   `json:"ID"`
+	Link      string                   `json:"Link"`
+	ExpiresAt int64                    `json:"ExpiresAt"`
+	Outcome   *models.OperationOutcome `json:"outcome,omitempty"`
+}
+
+type SHLManifestRequest struct {
+	Recipient string `json:"recipient"`
+	Passcode  string `json:"passcode"`
+}
+
+type SHLManifestFile struct {
+	ContentType string `json:"contentType"`
+	Embedded    string `json:"embedded"`
+}
+
+type SHLManifestResponse struct {
+	Files []SHLManifestFile `json:"files"`
+}
+
+func (h *Handler) CreateSHL(w http.ResponseWriter, r *http.Request) {
+	var req CreateSHLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	resp, err := h.shareService.CreateSHL(r.Context(), domain.SHLRequest{
+		ResourceIDs: req.ResourceIDs,
+		TTLSeconds:  req.TTLSeconds,
+		Passcode:    req.Passcode,
+		Label:       req.Label,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(SHLResponse{
+		ID:        resp.ID,
+		Link:      resp.Link,
+		ExpiresAt: resp.ExpiresAt,
+		Outcome:   withheldOutcome(resp.Withheld),
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *Handler) GetSHLManifest(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req SHLManifestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	manifest, err := h.shareService.GetSHLManifest(r.Context(), id, domain.SHLManifestRequest{
+		Recipient: req.Recipient,
+		Passcode:  req.Passcode,
+	})
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	resp := SHLManifestResponse{
+		Files: make([]SHLManifestFile, 0, len(manifest.Files)),
+	}
+	for _, f := range manifest.Files {
+		resp.Files = append(resp.Files, SHLManifestFile{
+			ContentType: f.ContentType,
+			Embedded:    f.Embedded,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
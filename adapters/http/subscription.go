@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var sub models.Subscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := sub.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.subscriptionService.Create(r.Context(), &sub)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	sub, err := h.subscriptionService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, sub)
+}
+
+func (h *Handler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var sub models.Subscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := sub.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if sub.Id == nil || *sub.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.subscriptionService.Update(r.Context(), &sub)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.subscriptionService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+// GetSubscriptionStatus returns the current status of a subscription as a
+// searchset bundle holding a SubscriptionStatus.
+func (h *Handler) GetSubscriptionStatus(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	status, err := h.subscriptionService.Status(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	resourceRaw, err := json.Marshal(status)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Type:         "searchset",
+		Total:        ptr.To(1),
+		Entry:        []models.BundleEntry{{Resource: resourceRaw}},
+	}
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.SubscriptionSearch{
+		Status: query.Get("status"),
+		Topic:  query.Get("topic"),
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.subscriptionService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapSubscriptionsInBundle(res.Items, res.Total)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapSubscriptionsInBundle(subscriptions []models.Subscription, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(subscriptions)),
+	}
+
+	for i := range subscriptions {
+		resourceRaw, err := json.Marshal(subscriptions[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
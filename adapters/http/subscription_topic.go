@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func (h *Handler) CreateSubscriptionTopic(w http.ResponseWriter, r *http.Request) {
+	var topic models.SubscriptionTopic
+	if err := json.NewDecoder(r.Body).Decode(&topic); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := topic.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	result, err := h.subscriptionTopicService.Create(r.Context(), &topic)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusCreated, result)
+}
+
+func (h *Handler) GetSubscriptionTopic(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	topic, err := h.subscriptionTopicService.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, topic)
+}
+
+func (h *Handler) UpdateSubscriptionTopic(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var topic models.SubscriptionTopic
+	if err := json.NewDecoder(r.Body).Decode(&topic); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	if err := topic.Validate(); err != nil {
+		h.respondWithError(w, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err))
+		return
+	}
+
+	if topic.Id == nil || *topic.Id != id {
+		h.respondWithError(w, fmt.Errorf("%w: id in body must match URL", domain.ErrInvalidInput))
+		return
+	}
+
+	result, err := h.subscriptionTopicService.Update(r.Context(), &topic)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, result)
+}
+
+func (h *Handler) DeleteSubscriptionTopic(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := h.subscriptionTopicService.Delete(r.Context(), id); err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	h.respondWithResource(w, http.StatusOK, nil)
+}
+
+func (h *Handler) ListSubscriptionTopics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	search := domain.SubscriptionTopicSearch{
+		Status:   query.Get("status"),
+		URL:      query.Get("url"),
+		Resource: query.Get("resource"),
+	}
+
+	limit, offset := h.parsePagination(r)
+
+	res, err := h.subscriptionTopicService.List(r.Context(), search, limit, offset)
+	if err != nil {
+		h.respondWithError(w, err)
+		return
+	}
+
+	bundle := h.wrapSubscriptionTopicsInBundle(res.Items, res.Total)
+	h.respondWithResource(w, http.StatusOK, bundle)
+}
+
+func (h *Handler) wrapSubscriptionTopicsInBundle(topics []models.SubscriptionTopic, total int64) *models.Bundle {
+	bundleID := fmt.Sprintf("bundle-%d", total)
+
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &bundleID,
+		Type:         "searchset",
+		Total:        ptr.To(int(total)),
+		Entry:        make([]models.BundleEntry, 0, len(topics)),
+	}
+
+	for i := range topics {
+		resourceRaw, err := json.Marshal(topics[i])
+		if err != nil {
+			continue
+		}
+
+		bundle.Entry = append(bundle.Entry, models.BundleEntry{
+			Resource: resourceRaw,
+		})
+	}
+
+	return bundle
+}
@@ -0,0 +1,153 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// allergyTokenPaths maps the token search parameters of allergy intolerances
+// to the fields they search.
+var allergyTokenPaths = map[string]string{
+	"code":            "code",
+	"clinical-status": "clinical_status",
+}
+
+type AllergyIntoleranceRepo struct {
+	collection *mongo.Collection
+}
+
+func NewAllergyIntoleranceRepo(db *mongo.Database) *AllergyIntoleranceRepo {
+	return &AllergyIntoleranceRepo{
+		collection: db.Collection("allergy_intolerances"),
+	}
+}
+
+func (r *AllergyIntoleranceRepo) Create(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	_, err := r.collection.InsertOne(ctx, ai)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert allergy intolerance: %w", err)
+	}
+	return ai, nil
+}
+
+func (r *AllergyIntoleranceRepo) GetByID(ctx context.Context, id string) (*models.AllergyIntolerance, error) {
+	var ai models.AllergyIntolerance
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&ai)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find allergy intolerance: %w", err)
+	}
+
+	return &ai, nil
+}
+
+func (r *AllergyIntoleranceRepo) GetByIDs(ctx context.Context, ids []string) ([]models.AllergyIntolerance, error) {
+	if len(ids) == 0 {
+		return []models.AllergyIntolerance{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find allergy intolerances: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var allergies []models.AllergyIntolerance
+	if err = cursor.All(ctx, &allergies); err != nil {
+		return nil, fmt.Errorf("failed to decode allergy intolerances: %w", err)
+	}
+
+	if allergies == nil {
+		allergies = []models.AllergyIntolerance{}
+	}
+
+	return allergies, nil
+}
+
+func (r *AllergyIntoleranceRepo) Update(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	if ai.Id == nil {
+		return nil, domain.ErrAllergyIntoleranceIDRequired
+	}
+
+	filter := bson.M{"id": *ai.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, ai)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update allergy intolerance: %w", err)
+	}
+
+	return ai, nil
+}
+
+func (r *AllergyIntoleranceRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete allergy intolerance: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AllergyIntoleranceRepo) Search(ctx context.Context, search domain.AllergySearch, restrictions []url.Values, limit, offset int) ([]models.AllergyIntolerance, int64, error) {
+	clauses := bson.A{bson.M{"patient.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.ClinicalStatus != "" {
+		clauses = append(clauses, tokenFilter(allergyTokenPaths["clinical-status"], search.ClinicalStatus))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(allergyTokenPaths["code"], search.Code))
+	}
+	if search.Category != "" {
+		clauses = append(clauses, codeFilter("category", search.Category))
+	}
+	if search.Criticality != "" {
+		clauses = append(clauses, codeFilter("criticality", search.Criticality))
+	}
+	if restricted := restrictionFilter(restrictions, allergyTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count allergy intolerances: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find allergy intolerances: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var allergies []models.AllergyIntolerance
+	if err = cursor.All(ctx, &allergies); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode allergy intolerances: %w", err)
+	}
+
+	if allergies == nil {
+		allergies = []models.AllergyIntolerance{}
+	}
+
+	return allergies, total, nil
+}
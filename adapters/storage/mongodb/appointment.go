@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const appointmentDatePath = "start"
+
+type AppointmentRepo struct {
+	collection *mongo.Collection
+}
+
+func NewAppointmentRepo(db *mongo.Database) *AppointmentRepo {
+	return &AppointmentRepo{
+		collection: db.Collection("appointments"),
+	}
+}
+
+func (r *AppointmentRepo) Create(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	_, err := r.collection.InsertOne(ctx, appt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert appointment: %w", err)
+	}
+	return appt, nil
+}
+
+func (r *AppointmentRepo) GetByID(ctx context.Context, id string) (*models.Appointment, error) {
+	var appt models.Appointment
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&appt)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find appointment: %w", err)
+	}
+
+	return &appt, nil
+}
+
+func (r *AppointmentRepo) GetByIDs(ctx context.Context, ids []string) ([]models.Appointment, error) {
+	if len(ids) == 0 {
+		return []models.Appointment{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find appointments: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var appointments []models.Appointment
+	if err = cursor.All(ctx, &appointments); err != nil {
+		return nil, fmt.Errorf("failed to decode appointments: %w", err)
+	}
+
+	if appointments == nil {
+		appointments = []models.Appointment{}
+	}
+
+	return appointments, nil
+}
+
+func (r *AppointmentRepo) Update(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	if appt.Id == nil {
+		return nil, domain.ErrAppointmentIDRequired
+	}
+
+	filter := bson.M{"id": *appt.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, appt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update appointment: %w", err)
+	}
+
+	return appt, nil
+}
+
+func (r *AppointmentRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete appointment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AppointmentRepo) Search(ctx context.Context, search domain.AppointmentSearch, restrictions []url.Values, limit, offset int) ([]models.Appointment, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, dateFilter(appointmentDatePath, date))
+	}
+	if restricted := restrictionFilter(restrictions, nil); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count appointments: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find appointments: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var appointments []models.Appointment
+	if err = cursor.All(ctx, &appointments); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode appointments: %w", err)
+	}
+
+	if appointments == nil {
+		appointments = []models.Appointment{}
+	}
+
+	return appointments, total, nil
+}
+
+func (r *AppointmentRepo) ListBooked(ctx context.Context, from, to time.Time) ([]models.Appointment, error) {
+	filter := bson.M{
+		"status": "booked",
+		"start": bson.M{
+			"$gt":  from.UTC().Format(time.RFC3339),
+			"$lte": to.UTC().Format(time.RFC3339),
+		},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find appointments: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var appointments []models.Appointment
+	if err = cursor.All(ctx, &appointments); err != nil {
+		return nil, fmt.Errorf("failed to decode appointments: %w", err)
+	}
+
+	return appointments, nil
+}
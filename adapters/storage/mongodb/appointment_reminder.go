@@ -0,0 +1,55 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type AppointmentReminderRepo struct {
+	collection *mongo.Collection
+}
+
+type appointmentReminderRecord struct {
+	Key           string `bson:"_id"`
+	AppointmentID string `bson:"appointment_id"`
+	PatientID     string `bson:"patient_id"`
+	ClaimedAt     int64  `bson:"claimed_at"`
+}
+
+func NewAppointmentReminderRepo(db *mongo.Database) *AppointmentReminderRepo {
+	return &AppointmentReminderRepo{
+		collection: db.Collection("appointment_reminders"),
+	}
+}
+
+func (r *AppointmentReminderRepo) Claim(ctx context.Context, reminder domain.AppointmentReminder) (bool, error) {
+	record := appointmentReminderRecord{
+		Key:           reminder.Key(),
+		AppointmentID: reminder.AppointmentID,
+		PatientID:     reminder.PatientID,
+		ClaimedAt:     time.Now().Unix(),
+	}
+
+	_, err := r.collection.InsertOne(ctx, record)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim appointment reminder: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *AppointmentReminderRepo) Release(ctx context.Context, reminder domain.AppointmentReminder) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": reminder.Key()}); err != nil {
+		return fmt.Errorf("failed to release appointment reminder: %w", err)
+	}
+	return nil
+}
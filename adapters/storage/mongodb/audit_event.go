@@ -0,0 +1,26 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type AuditEventRepo struct {
+	collection *mongo.Collection
+}
+
+func NewAuditEventRepo(db *mongo.Database) *AuditEventRepo {
+	return &AuditEventRepo{
+		collection: db.Collection("audit_events"),
+	}
+}
+
+func (r *AuditEventRepo) Create(ctx context.Context, event *models.AuditEvent) error {
+	if _, err := r.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+	return nil
+}
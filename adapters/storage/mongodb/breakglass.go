@@ -0,0 +1,123 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type BreakGlassRepo struct {
+	collection *mongo.Collection
+}
+
+type breakGlassRecord struct {
+	ID             string `bson:"id"`
+	PatientID      string `bson:"patient_id"`
+	PractitionerID string `bson:"practitioner_id"`
+	Reason         string `bson:"reason"`
+	CreatedAt      int64  `bson:"created_at"`
+	ExpiresAt      int64  `bson:"expires_at"`
+	ReviewedAt     int64  `bson:"reviewed_at"`
+	ReviewedBy     string `bson:"reviewed_by,omitempty"`
+	ReviewNote     string `bson:"review_note,omitempty"`
+}
+
+func NewBreakGlassRepo(db *mongo.Database) *BreakGlassRepo {
+	return &BreakGlassRepo{
+		collection: db.Collection("break_glass_grants"),
+	}
+}
+
+func (r *BreakGlassRepo) Create(ctx context.Context, grant *domain.BreakGlassGrant) (*domain.BreakGlassGrant, error) {
+	record := breakGlassRecord(*grant)
+	if _, err := r.collection.InsertOne(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to insert break-glass grant: %w", err)
+	}
+	return grant, nil
+}
+
+func (r *BreakGlassRepo) GetByID(ctx context.Context, id string) (*domain.BreakGlassGrant, error) {
+	return r.findOne(ctx, bson.M{"id": id}, options.FindOne())
+}
+
+func (r *BreakGlassRepo) FindActive(ctx context.Context, patientID, practitionerID string, now int64) (*domain.BreakGlassGrant, error) {
+	filter := bson.M{
+		"patient_id":      patientID,
+		"practitioner_id": practitionerID,
+		"expires_at":      bson.M{"$gt": now},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "expires_at", Value: -1}})
+	return r.findOne(ctx, filter, opts)
+}
+
+func (r *BreakGlassRepo) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptionsBuilder) (*domain.BreakGlassGrant, error) {
+	var record breakGlassRecord
+
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find break-glass grant: %w", err)
+	}
+
+	grant := domain.BreakGlassGrant(record)
+	return &grant, nil
+}
+
+func (r *BreakGlassRepo) ListUnreviewed(ctx context.Context, limit, offset int) ([]domain.BreakGlassGrant, int64, error) {
+	filter := bson.M{"reviewed_at": 0}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count break-glass grants: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find break-glass grants: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []breakGlassRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode break-glass grants: %w", err)
+	}
+
+	grants := make([]domain.BreakGlassGrant, 0, len(records))
+	for _, record := range records {
+		grants = append(grants, domain.BreakGlassGrant(record))
+	}
+
+	return grants, total, nil
+}
+
+func (r *BreakGlassRepo) MarkReviewed(ctx context.Context, id, reviewer, note string, at int64) error {
+	filter := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{
+		"reviewed_at": at,
+		"reviewed_by": reviewer,
+		"review_note": note,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to mark break-glass grant reviewed: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrBreakGlassNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// carePlanTokenPaths maps the token search parameters of care plans to the
+// fields they search.
+var carePlanTokenPaths = map[string]string{
+	"category": "category",
+}
+
+type CarePlanRepo struct {
+	collection *mongo.Collection
+}
+
+func NewCarePlanRepo(db *mongo.Database) *CarePlanRepo {
+	return &CarePlanRepo{
+		collection: db.Collection("care_plans"),
+	}
+}
+
+func (r *CarePlanRepo) Create(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	_, err := r.collection.InsertOne(ctx, plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert care plan: %w", err)
+	}
+	return plan, nil
+}
+
+func (r *CarePlanRepo) GetByID(ctx context.Context, id string) (*models.CarePlan, error) {
+	var plan models.CarePlan
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&plan)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find care plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (r *CarePlanRepo) Update(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	if plan.Id == nil {
+		return nil, domain.ErrCarePlanIDRequired
+	}
+
+	filter := bson.M{"id": *plan.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update care plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+func (r *CarePlanRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete care plan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CarePlanRepo) Search(ctx context.Context, search domain.CarePlanSearch, restrictions []url.Values, limit, offset int) ([]models.CarePlan, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Category != "" {
+		clauses = append(clauses, tokenFilter(carePlanTokenPaths["category"], search.Category))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, dateFilter("period.start", date))
+	}
+	if restricted := restrictionFilter(restrictions, carePlanTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count care plans: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find care plans: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var plans []models.CarePlan
+	if err = cursor.All(ctx, &plans); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode care plans: %w", err)
+	}
+
+	if plans == nil {
+		plans = []models.CarePlan{}
+	}
+
+	return plans, total, nil
+}
@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CareRelationshipRepo struct {
+	collection *mongo.Collection
+}
+
+type careRelationshipRecord struct {
+	ID             string   `bson:"id"`
+	PatientID      string   `bson:"patient_id"`
+	PractitionerID string   `bson:"practitioner_id"`
+	Access         []string `bson:"access"`
+	CreatedAt      int64    `bson:"created_at"`
+}
+
+func NewCareRelationshipRepo(db *mongo.Database) *CareRelationshipRepo {
+	return &CareRelationshipRepo{
+		collection: db.Collection("care_relationships"),
+	}
+}
+
+func (r *CareRelationshipRepo) Upsert(ctx context.Context, rel *domain.CareRelationship) (*domain.CareRelationship, error) {
+	filter := bson.M{"patient_id": rel.PatientID, "practitioner_id": rel.PractitionerID}
+	record := toCareRelationshipRecord(rel)
+	update := bson.M{
+		"$set": bson.M{"access": record.Access},
+		"$setOnInsert": bson.M{
+			"id":         record.ID,
+			"created_at": record.CreatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved careRelationshipRecord
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("failed to upsert care relationship: %w", err)
+	}
+
+	return fromCareRelationshipRecord(&saved), nil
+}
+
+func (r *CareRelationshipRepo) Find(ctx context.Context, patientID, practitionerID string) (*domain.CareRelationship, error) {
+	var record careRelationshipRecord
+
+	filter := bson.M{"patient_id": patientID, "practitioner_id": practitionerID}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find care relationship: %w", err)
+	}
+
+	return fromCareRelationshipRecord(&record), nil
+}
+
+func (r *CareRelationshipRepo) ListByPatient(ctx context.Context, patientID string) ([]domain.CareRelationship, error) {
+	filter := bson.M{"patient_id": patientID}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find care relationships: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []careRelationshipRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode care relationships: %w", err)
+	}
+
+	rels := make([]domain.CareRelationship, 0, len(records))
+	for i := range records {
+		rels = append(rels, *fromCareRelationshipRecord(&records[i]))
+	}
+
+	return rels, nil
+}
+
+func (r *CareRelationshipRepo) Delete(ctx context.Context, patientID, practitionerID string) error {
+	filter := bson.M{"patient_id": patientID, "practitioner_id": practitionerID}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete care relationship: %w", err)
+	}
+
+	return nil
+}
+
+func toCareRelationshipRecord(rel *domain.CareRelationship) *careRelationshipRecord {
+	access := make([]string, 0, len(rel.Access))
+	for _, a := range rel.Access {
+		access = append(access, string(a))
+	}
+
+	return &careRelationshipRecord{
+		ID:             rel.ID,
+		PatientID:      rel.PatientID,
+		PractitionerID: rel.PractitionerID,
+		Access:         access,
+		CreatedAt:      rel.CreatedAt,
+	}
+}
+
+func fromCareRelationshipRecord(record *careRelationshipRecord) *domain.CareRelationship {
+	access := make([]domain.CareAccess, 0, len(record.Access))
+	for _, a := range record.Access {
+		access = append(access, domain.CareAccess(a))
+	}
+
+	return &domain.CareRelationship{
+		ID:             record.ID,
+		PatientID:      record.PatientID,
+		PractitionerID: record.PractitionerID,
+		Access:         access,
+		CreatedAt:      record.CreatedAt,
+	}
+}
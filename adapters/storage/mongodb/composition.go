@@ -0,0 +1,125 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// compositionTokenPaths maps the token search parameters of compositions to
+// the fields they search.
+var compositionTokenPaths = map[string]string{
+	"category": "category",
+	"type":     "type",
+}
+
+type CompositionRepo struct {
+	collection *mongo.Collection
+}
+
+func NewCompositionRepo(db *mongo.Database) *CompositionRepo {
+	return &CompositionRepo{
+		collection: db.Collection("compositions"),
+	}
+}
+
+func (r *CompositionRepo) Create(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	_, err := r.collection.InsertOne(ctx, comp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert composition: %w", err)
+	}
+	return comp, nil
+}
+
+func (r *CompositionRepo) GetByID(ctx context.Context, id string) (*models.Composition, error) {
+	var comp models.Composition
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&comp)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find composition: %w", err)
+	}
+
+	return &comp, nil
+}
+
+func (r *CompositionRepo) Update(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	if comp.Id == nil {
+		return nil, domain.ErrCompositionIDRequired
+	}
+
+	filter := bson.M{"id": *comp.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, comp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update composition: %w", err)
+	}
+
+	return comp, nil
+}
+
+func (r *CompositionRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete composition: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CompositionRepo) Search(ctx context.Context, search domain.CompositionSearch, restrictions []url.Values, limit, offset int) ([]models.Composition, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Type != "" {
+		clauses = append(clauses, tokenFilter(compositionTokenPaths["type"], search.Type))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, dateFilter("date", date))
+	}
+	if restricted := restrictionFilter(restrictions, compositionTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count compositions: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find compositions: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var comps []models.Composition
+	if err = cursor.All(ctx, &comps); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode compositions: %w", err)
+	}
+
+	if comps == nil {
+		comps = []models.Composition{}
+	}
+
+	return comps, total, nil
+}
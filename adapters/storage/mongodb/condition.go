@@ -0,0 +1,156 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// conditionTokenPaths maps the token search parameters of conditions to the
+// fields they search.
+var conditionTokenPaths = map[string]string{
+	"category":        "category",
+	"code":            "code",
+	"clinical-status": "clinical_status",
+}
+
+const conditionOnsetPath = "onset_date_time"
+
+type ConditionRepo struct {
+	collection *mongo.Collection
+}
+
+func NewConditionRepo(db *mongo.Database) *ConditionRepo {
+	return &ConditionRepo{
+		collection: db.Collection("conditions"),
+	}
+}
+
+func (r *ConditionRepo) Create(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	_, err := r.collection.InsertOne(ctx, cond)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert condition: %w", err)
+	}
+	return cond, nil
+}
+
+func (r *ConditionRepo) GetByID(ctx context.Context, id string) (*models.Condition, error) {
+	var cond models.Condition
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&cond)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find condition: %w", err)
+	}
+
+	return &cond, nil
+}
+
+func (r *ConditionRepo) GetByIDs(ctx context.Context, ids []string) ([]models.Condition, error) {
+	if len(ids) == 0 {
+		return []models.Condition{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conditions: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var conditions []models.Condition
+	if err = cursor.All(ctx, &conditions); err != nil {
+		return nil, fmt.Errorf("failed to decode conditions: %w", err)
+	}
+
+	if conditions == nil {
+		conditions = []models.Condition{}
+	}
+
+	return conditions, nil
+}
+
+func (r *ConditionRepo) Update(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	if cond.Id == nil {
+		return nil, domain.ErrConditionIDRequired
+	}
+
+	filter := bson.M{"id": *cond.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, cond)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update condition: %w", err)
+	}
+
+	return cond, nil
+}
+
+func (r *ConditionRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete condition: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ConditionRepo) Search(ctx context.Context, search domain.ConditionSearch, restrictions []url.Values, limit, offset int) ([]models.Condition, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.ClinicalStatus != "" {
+		clauses = append(clauses, tokenFilter(conditionTokenPaths["clinical-status"], search.ClinicalStatus))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(conditionTokenPaths["code"], search.Code))
+	}
+	if search.Category != "" {
+		clauses = append(clauses, tokenFilter(conditionTokenPaths["category"], search.Category))
+	}
+	for _, date := range search.OnsetDate {
+		clauses = append(clauses, dateFilter(conditionOnsetPath, date))
+	}
+	if restricted := restrictionFilter(restrictions, conditionTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count conditions: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find conditions: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var conditions []models.Condition
+	if err = cursor.All(ctx, &conditions); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode conditions: %w", err)
+	}
+
+	if conditions == nil {
+		conditions = []models.Condition{}
+	}
+
+	return conditions, total, nil
+}
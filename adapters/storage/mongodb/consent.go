@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type ConsentRepo struct {
+	collection *mongo.Collection
+}
+
+func NewConsentRepo(db *mongo.Database) *ConsentRepo {
+	return &ConsentRepo{
+		collection: db.Collection("consents"),
+	}
+}
+
+func (r *ConsentRepo) Create(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	_, err := r.collection.InsertOne(ctx, consent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert consent: %w", err)
+	}
+	return consent, nil
+}
+
+func (r *ConsentRepo) GetByID(ctx context.Context, id string) (*models.Consent, error) {
+	var consent models.Consent
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&consent)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find consent: %w", err)
+	}
+
+	return &consent, nil
+}
+
+func (r *ConsentRepo) Update(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	if consent.Id == nil {
+		return nil, fmt.Errorf("%w: consent ID is required", domain.ErrInvalidInput)
+	}
+
+	filter := bson.M{"id": *consent.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, consent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update consent: %w", err)
+	}
+
+	return consent, nil
+}
+
+func (r *ConsentRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete consent: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ConsentRepo) Search(ctx context.Context, patientID string, limit, offset int) ([]models.Consent, int64, error) {
+	filter := bson.M{"subject.reference": fmt.Sprintf("Patient/%s", patientID)}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count consents: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find consents: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var consents []models.Consent
+	if err = cursor.All(ctx, &consents); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode consents: %w", err)
+	}
+
+	if consents == nil {
+		consents = []models.Consent{}
+	}
+
+	return consents, total, nil
+}
+
+func (r *ConsentRepo) ListActive(ctx context.Context, patientID string) ([]models.Consent, error) {
+	filter := bson.M{
+		"subject.reference": fmt.Sprintf("Patient/%s", patientID),
+		"status":            string(models.ConsentStateActive),
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find consents: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var consents []models.Consent
+	if err = cursor.All(ctx, &consents); err != nil {
+		return nil, fmt.Errorf("failed to decode consents: %w", err)
+	}
+
+	return consents, nil
+}
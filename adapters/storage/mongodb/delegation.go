@@ -0,0 +1,153 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type DelegationRepo struct {
+	collection *mongo.Collection
+}
+
+type delegationRecord struct {
+	ID              string   `bson:"id"`
+	PatientID       string   `bson:"patient_id"`
+	UserID          string   `bson:"user_id"`
+	RelatedPersonID string   `bson:"related_person_id"`
+	Access          []string `bson:"access"`
+	CreatedAt       int64    `bson:"created_at"`
+}
+
+func NewDelegationRepo(db *mongo.Database) *DelegationRepo {
+	return &DelegationRepo{
+		collection: db.Collection("delegations"),
+	}
+}
+
+func (r *DelegationRepo) Upsert(ctx context.Context, d *domain.Delegation) (*domain.Delegation, error) {
+	filter := bson.M{"patient_id": d.PatientID, "user_id": d.UserID}
+	record := toDelegationRecord(d)
+	update := bson.M{
+		"$set": bson.M{
+			"access":            record.Access,
+			"related_person_id": record.RelatedPersonID,
+		},
+		"$setOnInsert": bson.M{
+			"id":         record.ID,
+			"created_at": record.CreatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved delegationRecord
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("failed to upsert delegation: %w", err)
+	}
+
+	return fromDelegationRecord(&saved), nil
+}
+
+func (r *DelegationRepo) Find(ctx context.Context, patientID, userID string) (*domain.Delegation, error) {
+	var record delegationRecord
+
+	filter := bson.M{"patient_id": patientID, "user_id": userID}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find delegation: %w", err)
+	}
+
+	return fromDelegationRecord(&record), nil
+}
+
+func (r *DelegationRepo) ListByPatient(ctx context.Context, patientID string) ([]domain.Delegation, error) {
+	return r.list(ctx, bson.M{"patient_id": patientID})
+}
+
+func (r *DelegationRepo) ListByUser(ctx context.Context, userID string) ([]domain.Delegation, error) {
+	return r.list(ctx, bson.M{"user_id": userID})
+}
+
+func (r *DelegationRepo) list(ctx context.Context, filter bson.M) ([]domain.Delegation, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delegations: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []delegationRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode delegations: %w", err)
+	}
+
+	delegations := make([]domain.Delegation, 0, len(records))
+	for i := range records {
+		delegations = append(delegations, *fromDelegationRecord(&records[i]))
+	}
+
+	return delegations, nil
+}
+
+func (r *DelegationRepo) Delete(ctx context.Context, patientID, userID string) error {
+	filter := bson.M{"patient_id": patientID, "user_id": userID}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete delegation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DelegationRepo) DeleteByRelatedPerson(ctx context.Context, relatedPersonID string) error {
+	filter := bson.M{"related_person_id": relatedPersonID}
+
+	_, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete delegations: %w", err)
+	}
+
+	return nil
+}
+
+func toDelegationRecord(d *domain.Delegation) *delegationRecord {
+	access := make([]string, 0, len(d.Access))
+	for _, a := range d.Access {
+		access = append(access, string(a))
+	}
+
+	return &delegationRecord{
+		ID:              d.ID,
+		PatientID:       d.PatientID,
+		UserID:          d.UserID,
+		RelatedPersonID: d.RelatedPersonID,
+		Access:          access,
+		CreatedAt:       d.CreatedAt,
+	}
+}
+
+func fromDelegationRecord(record *delegationRecord) *domain.Delegation {
+	access := make([]domain.CareAccess, 0, len(record.Access))
+	for _, a := range record.Access {
+		access = append(access, domain.CareAccess(a))
+	}
+
+	return &domain.Delegation{
+		ID:              record.ID,
+		PatientID:       record.PatientID,
+		UserID:          record.UserID,
+		RelatedPersonID: record.RelatedPersonID,
+		Access:          access,
+		CreatedAt:       record.CreatedAt,
+	}
+}
@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// diagnosticReportTokenPaths maps the token search parameters of diagnostic
+// reports to the fields they search.
+var diagnosticReportTokenPaths = map[string]string{
+	"category": "category",
+	"code":     "code",
+}
+
+// diagnosticReportDatePaths are the fields the date search parameter of
+// diagnostic reports matches.
+var diagnosticReportDatePaths = []string{"effective_date_time", "effective_period.start"}
+
+type DiagnosticReportRepo struct {
+	collection *mongo.Collection
+}
+
+func NewDiagnosticReportRepo(db *mongo.Database) *DiagnosticReportRepo {
+	return &DiagnosticReportRepo{
+		collection: db.Collection("diagnostic_reports"),
+	}
+}
+
+func (r *DiagnosticReportRepo) Create(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	_, err := r.collection.InsertOne(ctx, report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert diagnostic report: %w", err)
+	}
+	return report, nil
+}
+
+func (r *DiagnosticReportRepo) GetByID(ctx context.Context, id string) (*models.DiagnosticReport, error) {
+	var report models.DiagnosticReport
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&report)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find diagnostic report: %w", err)
+	}
+
+	return &report, nil
+}
+
+func (r *DiagnosticReportRepo) GetByIDs(ctx context.Context, ids []string) ([]models.DiagnosticReport, error) {
+	if len(ids) == 0 {
+		return []models.DiagnosticReport{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find diagnostic reports: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var reports []models.DiagnosticReport
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, fmt.Errorf("failed to decode diagnostic reports: %w", err)
+	}
+
+	if reports == nil {
+		reports = []models.DiagnosticReport{}
+	}
+
+	return reports, nil
+}
+
+func (r *DiagnosticReportRepo) Update(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	if report.Id == nil {
+		return nil, domain.ErrDiagnosticReportIDRequired
+	}
+
+	filter := bson.M{"id": *report.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update diagnostic report: %w", err)
+	}
+
+	return report, nil
+}
+
+func (r *DiagnosticReportRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete diagnostic report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DiagnosticReportRepo) Search(ctx context.Context, search domain.DiagnosticReportSearch, restrictions []url.Values, limit, offset int) ([]models.DiagnosticReport, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(diagnosticReportTokenPaths["code"], search.Code))
+	}
+	if search.Category != "" {
+		clauses = append(clauses, tokenFilter(diagnosticReportTokenPaths["category"], search.Category))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, anyDateFilter(diagnosticReportDatePaths, date))
+	}
+	if restricted := restrictionFilter(restrictions, diagnosticReportTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count diagnostic reports: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find diagnostic reports: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var reports []models.DiagnosticReport
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode diagnostic reports: %w", err)
+	}
+
+	if reports == nil {
+		reports = []models.DiagnosticReport{}
+	}
+
+	return reports, total, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 
@@ -13,6 +14,13 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+// documentTokenPaths maps the token search parameters SMART scopes may be restricted
+// by to the fields they search.
+var documentTokenPaths = map[string]string{
+	"category": "category",
+	"type":     "type",
+}
+
 type DocumentRepo struct {
 	collection *mongo.Collection
 }
@@ -99,10 +107,16 @@ func (r *DocumentRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *DocumentRepo) Search(ctx context.Context, patientID string, limit, offset int) ([]models.DocumentReference, int64, error) {
+func (r *DocumentRepo) Search(ctx context.Context, search domain.DocumentSearch, restrictions []url.Values, limit, offset int) ([]models.DocumentReference, int64, error) {
 	// Фильтруем по subject.reference, который имеет формат "Patient/{patientID}"
-	patientRef := fmt.Sprintf("Patient/%s", patientID)
-	filter := bson.M{"subject.reference": patientRef}
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Encounter != "" {
+		clauses = append(clauses, encounterFilter("context.reference", search.Encounter))
+	}
+	if restricted := restrictionFilter(restrictions, documentTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
 
 	// Получаем общее количество для Bundle.total
 	total, err := r.collection.CountDocuments(ctx, filter)
@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// encounterTokenPaths maps the token search parameters of encounters to the
+// fields they search.
+var encounterTokenPaths = map[string]string{
+	"class":       "class",
+	"reason-code": "reason.value.concept",
+}
+
+const encounterDatePath = "actual_period.start"
+
+type EncounterRepo struct {
+	collection *mongo.Collection
+}
+
+func NewEncounterRepo(db *mongo.Database) *EncounterRepo {
+	return &EncounterRepo{
+		collection: db.Collection("encounters"),
+	}
+}
+
+func (r *EncounterRepo) Create(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	_, err := r.collection.InsertOne(ctx, enc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert encounter: %w", err)
+	}
+	return enc, nil
+}
+
+func (r *EncounterRepo) GetByID(ctx context.Context, id string) (*models.Encounter, error) {
+	var enc models.Encounter
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&enc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find encounter: %w", err)
+	}
+
+	return &enc, nil
+}
+
+func (r *EncounterRepo) GetByIDs(ctx context.Context, ids []string) ([]models.Encounter, error) {
+	if len(ids) == 0 {
+		return []models.Encounter{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find encounters: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var encounters []models.Encounter
+	if err = cursor.All(ctx, &encounters); err != nil {
+		return nil, fmt.Errorf("failed to decode encounters: %w", err)
+	}
+
+	if encounters == nil {
+		encounters = []models.Encounter{}
+	}
+
+	return encounters, nil
+}
+
+func (r *EncounterRepo) Update(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	if enc.Id == nil {
+		return nil, domain.ErrEncounterIDRequired
+	}
+
+	filter := bson.M{"id": *enc.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, enc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update encounter: %w", err)
+	}
+
+	return enc, nil
+}
+
+func (r *EncounterRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete encounter: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EncounterRepo) Search(ctx context.Context, search domain.EncounterSearch, restrictions []url.Values, limit, offset int) ([]models.Encounter, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Class != "" {
+		clauses = append(clauses, tokenFilter(encounterTokenPaths["class"], search.Class))
+	}
+	if search.ReasonCode != "" {
+		clauses = append(clauses, tokenFilter(encounterTokenPaths["reason-code"], search.ReasonCode))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, dateFilter(encounterDatePath, date))
+	}
+	if restricted := restrictionFilter(restrictions, encounterTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count encounters: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find encounters: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var encounters []models.Encounter
+	if err = cursor.All(ctx, &encounters); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode encounters: %w", err)
+	}
+
+	if encounters == nil {
+		encounters = []models.Encounter{}
+	}
+
+	return encounters, total, nil
+}
@@ -0,0 +1,146 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// familyMemberHistoryTokenPaths maps the token search parameters of family
+// member histories to the fields they search.
+var familyMemberHistoryTokenPaths = map[string]string{
+	"relationship": "relationship",
+}
+
+type FamilyMemberHistoryRepo struct {
+	collection *mongo.Collection
+}
+
+func NewFamilyMemberHistoryRepo(db *mongo.Database) *FamilyMemberHistoryRepo {
+	return &FamilyMemberHistoryRepo{
+		collection: db.Collection("family_member_histories"),
+	}
+}
+
+func (r *FamilyMemberHistoryRepo) Create(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	_, err := r.collection.InsertOne(ctx, fmh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert family member history: %w", err)
+	}
+	return fmh, nil
+}
+
+func (r *FamilyMemberHistoryRepo) GetByID(ctx context.Context, id string) (*models.FamilyMemberHistory, error) {
+	var fmh models.FamilyMemberHistory
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&fmh)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find family member history: %w", err)
+	}
+
+	return &fmh, nil
+}
+
+func (r *FamilyMemberHistoryRepo) GetByIDs(ctx context.Context, ids []string) ([]models.FamilyMemberHistory, error) {
+	if len(ids) == 0 {
+		return []models.FamilyMemberHistory{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find family member histories: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var histories []models.FamilyMemberHistory
+	if err = cursor.All(ctx, &histories); err != nil {
+		return nil, fmt.Errorf("failed to decode family member histories: %w", err)
+	}
+
+	if histories == nil {
+		histories = []models.FamilyMemberHistory{}
+	}
+
+	return histories, nil
+}
+
+func (r *FamilyMemberHistoryRepo) Update(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	if fmh.Id == nil {
+		return nil, domain.ErrFamilyMemberHistoryIDRequired
+	}
+
+	filter := bson.M{"id": *fmh.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, fmh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update family member history: %w", err)
+	}
+
+	return fmh, nil
+}
+
+func (r *FamilyMemberHistoryRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete family member history: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FamilyMemberHistoryRepo) Search(ctx context.Context, search domain.FamilyMemberHistorySearch, restrictions []url.Values, limit, offset int) ([]models.FamilyMemberHistory, int64, error) {
+	clauses := bson.A{bson.M{"patient.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Relationship != "" {
+		clauses = append(clauses, tokenFilter(familyMemberHistoryTokenPaths["relationship"], search.Relationship))
+	}
+	if restricted := restrictionFilter(restrictions, familyMemberHistoryTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count family member histories: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find family member histories: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var histories []models.FamilyMemberHistory
+	if err = cursor.All(ctx, &histories); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode family member histories: %w", err)
+	}
+
+	if histories == nil {
+		histories = []models.FamilyMemberHistory{}
+	}
+
+	return histories, total, nil
+}
@@ -0,0 +1,121 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// goalTokenPaths maps the token search parameters of goals to the fields
+// they search.
+var goalTokenPaths = map[string]string{
+	"category": "category",
+}
+
+type GoalRepo struct {
+	collection *mongo.Collection
+}
+
+func NewGoalRepo(db *mongo.Database) *GoalRepo {
+	return &GoalRepo{
+		collection: db.Collection("goals"),
+	}
+}
+
+func (r *GoalRepo) Create(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	_, err := r.collection.InsertOne(ctx, goal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert goal: %w", err)
+	}
+	return goal, nil
+}
+
+func (r *GoalRepo) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	var goal models.Goal
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&goal)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find goal: %w", err)
+	}
+
+	return &goal, nil
+}
+
+func (r *GoalRepo) Update(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	if goal.Id == nil {
+		return nil, domain.ErrGoalIDRequired
+	}
+
+	filter := bson.M{"id": *goal.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, goal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	return goal, nil
+}
+
+func (r *GoalRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GoalRepo) Search(ctx context.Context, search domain.GoalSearch, restrictions []url.Values, limit, offset int) ([]models.Goal, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.LifecycleStatus != "" {
+		clauses = append(clauses, codeFilter("lifecycle_status", search.LifecycleStatus))
+	}
+	if search.Category != "" {
+		clauses = append(clauses, tokenFilter(goalTokenPaths["category"], search.Category))
+	}
+	if restricted := restrictionFilter(restrictions, goalTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count goals: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find goals: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var goals []models.Goal
+	if err = cursor.All(ctx, &goals); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode goals: %w", err)
+	}
+
+	if goals == nil {
+		goals = []models.Goal{}
+	}
+
+	return goals, total, nil
+}
@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// immunizationTokenPaths maps the token search parameters of immunizations
+// to the fields they search.
+var immunizationTokenPaths = map[string]string{
+	"vaccine-code": "vaccine_code",
+}
+
+const immunizationDatePath = "occurrence_date_time"
+
+type ImmunizationRepo struct {
+	collection *mongo.Collection
+}
+
+func NewImmunizationRepo(db *mongo.Database) *ImmunizationRepo {
+	return &ImmunizationRepo{
+		collection: db.Collection("immunizations"),
+	}
+}
+
+func (r *ImmunizationRepo) Create(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	_, err := r.collection.InsertOne(ctx, imm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert immunization: %w", err)
+	}
+	return imm, nil
+}
+
+func (r *ImmunizationRepo) GetByID(ctx context.Context, id string) (*models.Immunization, error) {
+	var imm models.Immunization
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&imm)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find immunization: %w", err)
+	}
+
+	return &imm, nil
+}
+
+func (r *ImmunizationRepo) GetByIDs(ctx context.Context, ids []string) ([]models.Immunization, error) {
+	if len(ids) == 0 {
+		return []models.Immunization{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find immunizations: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var immunizations []models.Immunization
+	if err = cursor.All(ctx, &immunizations); err != nil {
+		return nil, fmt.Errorf("failed to decode immunizations: %w", err)
+	}
+
+	if immunizations == nil {
+		immunizations = []models.Immunization{}
+	}
+
+	return immunizations, nil
+}
+
+func (r *ImmunizationRepo) Update(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	if imm.Id == nil {
+		return nil, domain.ErrImmunizationIDRequired
+	}
+
+	filter := bson.M{"id": *imm.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, imm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update immunization: %w", err)
+	}
+
+	return imm, nil
+}
+
+func (r *ImmunizationRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete immunization: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImmunizationRepo) Search(ctx context.Context, search domain.ImmunizationSearch, restrictions []url.Values, limit, offset int) ([]models.Immunization, int64, error) {
+	clauses := bson.A{bson.M{"patient.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.VaccineCode != "" {
+		clauses = append(clauses, tokenFilter(immunizationTokenPaths["vaccine-code"], search.VaccineCode))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, dateFilter(immunizationDatePath, date))
+	}
+	if restricted := restrictionFilter(restrictions, immunizationTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count immunizations: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find immunizations: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var immunizations []models.Immunization
+	if err = cursor.All(ctx, &immunizations); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode immunizations: %w", err)
+	}
+
+	if immunizations == nil {
+		immunizations = []models.Immunization{}
+	}
+
+	return immunizations, total, nil
+}
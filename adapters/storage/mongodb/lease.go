@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// LeaseRepo keeps one document per lease, keyed by its name. The unique _id
+// makes taking a lease atomic: a replica whose conditional upsert matches no
+// document because another holds an unexpired lease fails with a duplicate
+// key error instead of inserting a second lease.
+type LeaseRepo struct {
+	collection *mongo.Collection
+}
+
+func NewLeaseRepo(db *mongo.Database) *LeaseRepo {
+	return &LeaseRepo{
+		collection: db.Collection("leases"),
+	}
+}
+
+func (r *LeaseRepo) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"holder": holder},
+			bson.M{"expires_at": bson.M{"$lte": now.Unix()}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"holder":     holder,
+			"expires_at": now.Add(ttl).Unix(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *LeaseRepo) Release(ctx context.Context, name, holder string) error {
+	filter := bson.M{"_id": name, "holder": holder}
+
+	if _, err := r.collection.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// locationNamePaths and locationAddressPaths are the fields the name and
+// address search parameters of locations match.
+var (
+	locationNamePaths    = []string{"name", "alias"}
+	locationAddressPaths = addressPaths("address")
+)
+
+type LocationRepo struct {
+	collection *mongo.Collection
+}
+
+func NewLocationRepo(db *mongo.Database) *LocationRepo {
+	return &LocationRepo{
+		collection: db.Collection("locations"),
+	}
+}
+
+func (r *LocationRepo) Create(ctx context.Context, loc *models.Location) (*models.Location, error) {
+	_, err := r.collection.InsertOne(ctx, loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert location: %w", err)
+	}
+	return loc, nil
+}
+
+func (r *LocationRepo) GetByID(ctx context.Context, id string) (*models.Location, error) {
+	var loc models.Location
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&loc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find location: %w", err)
+	}
+
+	return &loc, nil
+}
+
+func (r *LocationRepo) Update(ctx context.Context, loc *models.Location) (*models.Location, error) {
+	if loc.Id == nil {
+		return nil, domain.ErrLocationIDRequired
+	}
+
+	filter := bson.M{"id": *loc.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return loc, nil
+}
+
+func (r *LocationRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LocationRepo) Search(ctx context.Context, search domain.DirectorySearch, limit, offset int) ([]models.Location, int64, error) {
+	clauses := bson.A{directoryOwnerFilter(search.PatientID)}
+	if search.Name != "" {
+		clauses = append(clauses, prefixFilter(locationNamePaths, search.Name))
+	}
+	if search.Address != "" {
+		clauses = append(clauses, prefixFilter(locationAddressPaths, search.Address))
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count locations: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find locations: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var locations []models.Location
+	if err = cursor.All(ctx, &locations); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode locations: %w", err)
+	}
+
+	if locations == nil {
+		locations = []models.Location{}
+	}
+
+	return locations, total, nil
+}
@@ -0,0 +1,148 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// medicationRequestEffectivePaths are the fields the effective date of a
+// medication request is stored in: when it is to be taken, or when it was
+// authored.
+var medicationRequestEffectivePaths = []string{"effective_timing_period.start", "authored_on"}
+
+type MedicationRequestRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMedicationRequestRepo(db *mongo.Database) *MedicationRequestRepo {
+	return &MedicationRequestRepo{
+		collection: db.Collection("medication_requests"),
+	}
+}
+
+func (r *MedicationRequestRepo) Create(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	_, err := r.collection.InsertOne(ctx, mr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert medication request: %w", err)
+	}
+	return mr, nil
+}
+
+func (r *MedicationRequestRepo) GetByID(ctx context.Context, id string) (*models.MedicationRequest, error) {
+	var mr models.MedicationRequest
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&mr)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find medication request: %w", err)
+	}
+
+	return &mr, nil
+}
+
+func (r *MedicationRequestRepo) GetByIDs(ctx context.Context, ids []string) ([]models.MedicationRequest, error) {
+	if len(ids) == 0 {
+		return []models.MedicationRequest{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find medication requests: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var requests []models.MedicationRequest
+	if err = cursor.All(ctx, &requests); err != nil {
+		return nil, fmt.Errorf("failed to decode medication requests: %w", err)
+	}
+
+	if requests == nil {
+		requests = []models.MedicationRequest{}
+	}
+
+	return requests, nil
+}
+
+func (r *MedicationRequestRepo) Update(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	if mr.Id == nil {
+		return nil, domain.ErrMedicationRequestIDRequired
+	}
+
+	filter := bson.M{"id": *mr.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, mr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update medication request: %w", err)
+	}
+
+	return mr, nil
+}
+
+func (r *MedicationRequestRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete medication request: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MedicationRequestRepo) Search(ctx context.Context, search domain.MedicationSearch, restrictions []url.Values, limit, offset int) ([]models.MedicationRequest, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(medicationTokenPaths["code"], search.Code))
+	}
+	for _, date := range search.Effective {
+		clauses = append(clauses, anyDateFilter(medicationRequestEffectivePaths, date))
+	}
+	if restricted := restrictionFilter(restrictions, medicationTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count medication requests: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find medication requests: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var requests []models.MedicationRequest
+	if err = cursor.All(ctx, &requests); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode medication requests: %w", err)
+	}
+
+	if requests == nil {
+		requests = []models.MedicationRequest{}
+	}
+
+	return requests, total, nil
+}
@@ -0,0 +1,154 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// medicationTokenPaths maps the token search parameters of medication
+// statements and requests to the fields they search.
+var medicationTokenPaths = map[string]string{
+	"category": "category",
+	"code":     "medication.concept",
+}
+
+// medicationStatementEffectivePaths are the fields the effective date of a
+// medication statement is stored in.
+var medicationStatementEffectivePaths = []string{"effective_date_time", "effective_period.start"}
+
+type MedicationStatementRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMedicationStatementRepo(db *mongo.Database) *MedicationStatementRepo {
+	return &MedicationStatementRepo{
+		collection: db.Collection("medication_statements"),
+	}
+}
+
+func (r *MedicationStatementRepo) Create(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+	_, err := r.collection.InsertOne(ctx, ms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert medication statement: %w", err)
+	}
+	return ms, nil
+}
+
+func (r *MedicationStatementRepo) GetByID(ctx context.Context, id string) (*models.MedicationStatement, error) {
+	var ms models.MedicationStatement
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&ms)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find medication statement: %w", err)
+	}
+
+	return &ms, nil
+}
+
+func (r *MedicationStatementRepo) GetByIDs(ctx context.Context, ids []string) ([]models.MedicationStatement, error) {
+	if len(ids) == 0 {
+		return []models.MedicationStatement{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find medication statements: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var statements []models.MedicationStatement
+	if err = cursor.All(ctx, &statements); err != nil {
+		return nil, fmt.Errorf("failed to decode medication statements: %w", err)
+	}
+
+	if statements == nil {
+		statements = []models.MedicationStatement{}
+	}
+
+	return statements, nil
+}
+
+func (r *MedicationStatementRepo) Update(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+	if ms.Id == nil {
+		return nil, domain.ErrMedicationStatementIDRequired
+	}
+
+	filter := bson.M{"id": *ms.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, ms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update medication statement: %w", err)
+	}
+
+	return ms, nil
+}
+
+func (r *MedicationStatementRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete medication statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MedicationStatementRepo) Search(ctx context.Context, search domain.MedicationSearch, restrictions []url.Values, limit, offset int) ([]models.MedicationStatement, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(medicationTokenPaths["code"], search.Code))
+	}
+	for _, date := range search.Effective {
+		clauses = append(clauses, anyDateFilter(medicationStatementEffectivePaths, date))
+	}
+	if restricted := restrictionFilter(restrictions, medicationTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count medication statements: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find medication statements: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var statements []models.MedicationStatement
+	if err = cursor.All(ctx, &statements); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode medication statements: %w", err)
+	}
+
+	if statements == nil {
+		statements = []models.MedicationStatement{}
+	}
+
+	return statements, total, nil
+}
@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type NotificationRepo struct {
+	collection *mongo.Collection
+}
+
+type notificationRecord struct {
+	ID        string `bson:"id"`
+	PatientID string `bson:"patient_id"`
+	Type      string `bson:"type"`
+	Message   string `bson:"message"`
+	Reference string `bson:"reference,omitempty"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+func NewNotificationRepo(db *mongo.Database) *NotificationRepo {
+	return &NotificationRepo{
+		collection: db.Collection("notifications"),
+	}
+}
+
+func (r *NotificationRepo) Create(ctx context.Context, n *domain.Notification) error {
+	if _, err := r.collection.InsertOne(ctx, notificationRecord(*n)); err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepo) ListByPatient(ctx context.Context, patientID string, limit, offset int) ([]domain.Notification, int64, error) {
+	filter := bson.M{"patient_id": patientID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []notificationRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+
+	notifications := make([]domain.Notification, 0, len(records))
+	for _, record := range records {
+		notifications = append(notifications, domain.Notification(record))
+	}
+
+	return notifications, total, nil
+}
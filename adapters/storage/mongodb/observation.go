@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 
@@ -13,6 +14,17 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+// observationTokenPaths maps the token search parameters SMART scopes may be restricted
+// by to the fields they search.
+var observationTokenPaths = map[string]string{
+	"category": "category",
+	"code":     "code",
+}
+
+// observationDatePaths are the fields the date search parameter of
+// observations matches.
+var observationDatePaths = []string{"effective_date_time", "effective_instant", "effective_period.start"}
+
 type ObservationRepo struct {
 	collection *mongo.Collection
 }
@@ -99,20 +111,39 @@ func (r *ObservationRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *ObservationRepo) Search(ctx context.Context, patientID string, limit, offset int) ([]models.Observation, int64, error) {
-	patientRef := fmt.Sprintf("Patient/%s", patientID)
-	filter := bson.M{"subject.reference": patientRef}
+func (r *ObservationRepo) Search(ctx context.Context, search domain.ObservationSearch, restrictions []url.Values, limit, offset int) ([]models.Observation, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Encounter != "" {
+		clauses = append(clauses, encounterFilter("encounter.reference", search.Encounter))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(observationTokenPaths["code"], search.Code))
+	}
+	if search.Identifier != "" {
+		clauses = append(clauses, identifierFilter("identifier", search.Identifier))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, anyDateFilter(observationDatePaths, date))
+	}
+	if restricted := restrictionFilter(restrictions, observationTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
 
 	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count observations: %w", err)
 	}
 
-	findOptions := options.Find().
-		SetLimit(int64(limit)).
-		SetSkip(int64(offset))
-
-	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	var cursor *mongo.Cursor
+	if search.Newest {
+		cursor, err = r.collection.Aggregate(ctx, newestObservations(filter, limit, offset))
+	} else {
+		findOptions := options.Find().
+			SetLimit(int64(limit)).
+			SetSkip(int64(offset))
+		cursor, err = r.collection.Find(ctx, filter, findOptions)
+	}
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find observations: %w", err)
 	}
@@ -129,3 +160,23 @@ func (r *ObservationRepo) Search(ctx context.Context, patientID string, limit, o
 
 	return observations, total, nil
 }
+
+// newestObservations pages through the observations matching filter by
+// effective time, whichever of its forms is set, and then last update,
+// latest first.
+func newestObservations(filter bson.M, limit, offset int) mongo.Pipeline {
+	effective := bson.A{}
+	for _, path := range observationDatePaths {
+		effective = append(effective, "$"+path)
+	}
+	effective = append(effective, nil)
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"_effective": bson.M{"$ifNull": effective}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_effective", Value: -1}, {Key: "meta.last_updated", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$skip", Value: offset}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"_effective": 0}}},
+	}
+}
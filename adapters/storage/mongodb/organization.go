@@ -0,0 +1,118 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// organizationNamePaths and organizationAddressPaths are the fields the name
+// and address search parameters of organizations match.
+var (
+	organizationNamePaths    = []string{"name", "alias"}
+	organizationAddressPaths = addressPaths("contact.address")
+)
+
+type OrganizationRepo struct {
+	collection *mongo.Collection
+}
+
+func NewOrganizationRepo(db *mongo.Database) *OrganizationRepo {
+	return &OrganizationRepo{
+		collection: db.Collection("organizations"),
+	}
+}
+
+func (r *OrganizationRepo) Create(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	_, err := r.collection.InsertOne(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert organization: %w", err)
+	}
+	return org, nil
+}
+
+func (r *OrganizationRepo) GetByID(ctx context.Context, id string) (*models.Organization, error) {
+	var org models.Organization
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&org)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+func (r *OrganizationRepo) Update(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	if org.Id == nil {
+		return nil, domain.ErrOrganizationIDRequired
+	}
+
+	filter := bson.M{"id": *org.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return org, nil
+}
+
+func (r *OrganizationRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OrganizationRepo) Search(ctx context.Context, search domain.DirectorySearch, limit, offset int) ([]models.Organization, int64, error) {
+	clauses := bson.A{directoryOwnerFilter(search.PatientID)}
+	if search.Name != "" {
+		clauses = append(clauses, prefixFilter(organizationNamePaths, search.Name))
+	}
+	if search.Address != "" {
+		clauses = append(clauses, prefixFilter(organizationAddressPaths, search.Address))
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count organizations: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find organizations: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var organizations []models.Organization
+	if err = cursor.All(ctx, &organizations); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode organizations: %w", err)
+	}
+
+	if organizations == nil {
+		organizations = []models.Organization{}
+	}
+
+	return organizations, total, nil
+}
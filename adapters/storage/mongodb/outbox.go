@@ -0,0 +1,198 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type OutboxRepo struct {
+	collection  *mongo.Collection
+	sequences   *mongo.Collection
+	deadLetters *mongo.Collection
+}
+
+var outboxOrder = bson.D{{Key: "resource_key", Value: 1}, {Key: "sequence", Value: 1}}
+
+type outboxRecord struct {
+	ID           string    `bson:"_id"`
+	Type         string    `bson:"type"`
+	ResourceKey  string    `bson:"resource_key"`
+	ResourceType string    `bson:"resource_type"`
+	ResourceID   string    `bson:"resource_id"`
+	PatientID    string    `bson:"patient_id,omitempty"`
+	Sequence     int64     `bson:"sequence"`
+	OccurredAt   time.Time `bson:"occurred_at"`
+	Data         []byte    `bson:"data,omitempty"`
+	Attempts     int       `bson:"attempts,omitempty"`
+	NextAttempt  time.Time `bson:"next_attempt_at,omitempty"`
+	Handled      bool      `bson:"handled,omitempty"`
+}
+
+type outboxDeadLetter struct {
+	Event    outboxRecord `bson:",inline"`
+	Reason   string       `bson:"reason"`
+	FailedAt time.Time    `bson:"failed_at"`
+}
+
+type outboxSequence struct {
+	Key      string `bson:"_id"`
+	Sequence int64  `bson:"sequence"`
+}
+
+// NewOutboxRepo creates the index pending events are listed by.
+func NewOutboxRepo(db *mongo.Database) (*OutboxRepo, error) {
+	r := &OutboxRepo{
+		collection:  db.Collection("outbox"),
+		sequences:   db.Collection("outbox_sequences"),
+		deadLetters: db.Collection("outbox_dead_letters"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    outboxOrder,
+		Options: options.Index().SetName("resource_key_sequence").SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outbox index: %w", err)
+	}
+
+	return r, nil
+}
+
+// Append takes the next sequence number of every event's resource from a
+// counter document. Within a transaction, writers of the same resource
+// conflict on its counter, so events commit in the order they are numbered.
+func (r *OutboxRepo) Append(ctx context.Context, events ...domain.DomainEvent) error {
+	records := make([]outboxRecord, len(events))
+	for i, event := range events {
+		var counter outboxSequence
+		err := r.sequences.FindOneAndUpdate(ctx,
+			bson.M{"_id": event.Key()},
+			bson.M{"$inc": bson.M{"sequence": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if err != nil {
+			return fmt.Errorf("failed to number outbox event: %w", err)
+		}
+
+		event.Sequence = counter.Sequence
+		records[i] = toOutboxRecord(event)
+	}
+
+	if _, err := r.collection.InsertMany(ctx, records); err != nil {
+		return fmt.Errorf("failed to insert outbox events: %w", err)
+	}
+	return nil
+}
+
+// ListPending walks the resource_key_sequence index, so the events of every
+// resource come in sequence order and a page starts after the resource the
+// previous one ended with.
+func (r *OutboxRepo) ListPending(ctx context.Context, afterKey string, limit int) ([]domain.DomainEvent, error) {
+	filter := bson.M{"resource_key": bson.M{"$gt": afterKey}}
+	opts := options.Find().SetSort(outboxOrder).SetHint("resource_key_sequence").SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending outbox events: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []outboxRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox events: %w", err)
+	}
+
+	events := make([]domain.DomainEvent, len(records))
+	for i := range records {
+		events[i] = fromOutboxRecord(&records[i])
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepo) MarkHandled(ctx context.Context, id string) error {
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"handled": true}}); err != nil {
+		return fmt.Errorf("failed to mark outbox event handled: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, id string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("failed to delete published outbox event: %w", err)
+	}
+	return nil
+}
+
+func (r *OutboxRepo) Retry(ctx context.Context, id string, next time.Time) error {
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"next_attempt_at": next},
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to reschedule outbox event: %w", err)
+	}
+	return nil
+}
+
+// DeadLetter copies the event to the dead letters before removing it, so it
+// is kept even if the removal fails; a retried copy replaces the first one.
+func (r *OutboxRepo) DeadLetter(ctx context.Context, event domain.DomainEvent, reason string) error {
+	letter := outboxDeadLetter{
+		Event:    toOutboxRecord(event),
+		Reason:   reason,
+		FailedAt: time.Now(),
+	}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.deadLetters.ReplaceOne(ctx, bson.M{"_id": event.ID}, letter, opts); err != nil {
+		return fmt.Errorf("failed to store dead letter: %w", err)
+	}
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": event.ID}); err != nil {
+		return fmt.Errorf("failed to delete dead-lettered outbox event: %w", err)
+	}
+	return nil
+}
+
+func toOutboxRecord(event domain.DomainEvent) outboxRecord {
+	return outboxRecord{
+		ID:           event.ID,
+		Type:         string(event.Type),
+		ResourceKey:  event.Key(),
+		ResourceType: event.ResourceType,
+		ResourceID:   event.ResourceID,
+		PatientID:    event.PatientID,
+		Sequence:     event.Sequence,
+		OccurredAt:   event.OccurredAt,
+		Data:         event.Data,
+		Attempts:     event.Attempts,
+		NextAttempt:  event.NextAttemptAt,
+		Handled:      event.Handled,
+	}
+}
+
+func fromOutboxRecord(record *outboxRecord) domain.DomainEvent {
+	return domain.DomainEvent{
+		ID:            record.ID,
+		Type:          domain.EventType(record.Type),
+		ResourceType:  record.ResourceType,
+		ResourceID:    record.ResourceID,
+		PatientID:     record.PatientID,
+		Sequence:      record.Sequence,
+		OccurredAt:    record.OccurredAt,
+		Data:          record.Data,
+		Attempts:      record.Attempts,
+		NextAttemptAt: record.NextAttempt,
+		Handled:       record.Handled,
+	}
+}
@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type PractitionerRepo struct {
+	collection *mongo.Collection
+}
+
+func NewPractitionerRepo(db *mongo.Database) *PractitionerRepo {
+	return &PractitionerRepo{
+		collection: db.Collection("practitioners"),
+	}
+}
+
+func (r *PractitionerRepo) Create(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	_, err := r.collection.InsertOne(ctx, practitioner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert practitioner: %w", err)
+	}
+	return practitioner, nil
+}
+
+func (r *PractitionerRepo) GetByID(ctx context.Context, id string) (*models.Practitioner, error) {
+	var practitioner models.Practitioner
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&practitioner)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find practitioner: %w", err)
+	}
+
+	return &practitioner, nil
+}
+
+func (r *PractitionerRepo) Update(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	if practitioner.Id == nil {
+		return nil, domain.ErrPractitionerIDRequired
+	}
+
+	filter := bson.M{"id": *practitioner.Id}
+	update := bson.M{"$set": practitioner}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update practitioner: %w", err)
+	}
+
+	return practitioner, nil
+}
@@ -0,0 +1,90 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type PractitionerRoleRepo struct {
+	collection *mongo.Collection
+}
+
+func NewPractitionerRoleRepo(db *mongo.Database) *PractitionerRoleRepo {
+	return &PractitionerRoleRepo{
+		collection: db.Collection("practitioner_roles"),
+	}
+}
+
+func (r *PractitionerRoleRepo) Create(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error) {
+	_, err := r.collection.InsertOne(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert practitioner role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *PractitionerRoleRepo) GetByID(ctx context.Context, id string) (*models.PractitionerRole, error) {
+	var role models.PractitionerRole
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&role)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find practitioner role: %w", err)
+	}
+
+	return &role, nil
+}
+
+func (r *PractitionerRoleRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete practitioner role: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PractitionerRoleRepo) Search(ctx context.Context, practitionerID string, limit, offset int) ([]models.PractitionerRole, int64, error) {
+	filter := bson.M{}
+	if practitionerID != "" {
+		filter["practitioner.reference"] = fmt.Sprintf("Practitioner/%s", practitionerID)
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count practitioner roles: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find practitioner roles: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var roles []models.PractitionerRole
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode practitioner roles: %w", err)
+	}
+
+	if roles == nil {
+		roles = []models.PractitionerRole{}
+	}
+
+	return roles, total, nil
+}
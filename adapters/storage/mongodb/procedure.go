@@ -0,0 +1,153 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// procedureTokenPaths maps the token search parameters of procedures to the
+// fields they search.
+var procedureTokenPaths = map[string]string{
+	"code": "code",
+}
+
+// procedureDatePaths are the fields the date search parameter of procedures
+// matches.
+var procedureDatePaths = []string{"occurrence_date_time", "occurrence_period.start"}
+
+type ProcedureRepo struct {
+	collection *mongo.Collection
+}
+
+func NewProcedureRepo(db *mongo.Database) *ProcedureRepo {
+	return &ProcedureRepo{
+		collection: db.Collection("procedures"),
+	}
+}
+
+func (r *ProcedureRepo) Create(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+	_, err := r.collection.InsertOne(ctx, proc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert procedure: %w", err)
+	}
+	return proc, nil
+}
+
+func (r *ProcedureRepo) GetByID(ctx context.Context, id string) (*models.Procedure, error) {
+	var proc models.Procedure
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&proc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find procedure: %w", err)
+	}
+
+	return &proc, nil
+}
+
+func (r *ProcedureRepo) GetByIDs(ctx context.Context, ids []string) ([]models.Procedure, error) {
+	if len(ids) == 0 {
+		return []models.Procedure{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find procedures: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var procedures []models.Procedure
+	if err = cursor.All(ctx, &procedures); err != nil {
+		return nil, fmt.Errorf("failed to decode procedures: %w", err)
+	}
+
+	if procedures == nil {
+		procedures = []models.Procedure{}
+	}
+
+	return procedures, nil
+}
+
+func (r *ProcedureRepo) Update(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+	if proc.Id == nil {
+		return nil, domain.ErrProcedureIDRequired
+	}
+
+	filter := bson.M{"id": *proc.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, proc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update procedure: %w", err)
+	}
+
+	return proc, nil
+}
+
+func (r *ProcedureRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete procedure: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProcedureRepo) Search(ctx context.Context, search domain.ProcedureSearch, restrictions []url.Values, limit, offset int) ([]models.Procedure, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Code != "" {
+		clauses = append(clauses, tokenFilter(procedureTokenPaths["code"], search.Code))
+	}
+	for _, date := range search.Date {
+		clauses = append(clauses, anyDateFilter(procedureDatePaths, date))
+	}
+	if restricted := restrictionFilter(restrictions, procedureTokenPaths); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count procedures: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find procedures: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var procedures []models.Procedure
+	if err = cursor.All(ctx, &procedures); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode procedures: %w", err)
+	}
+
+	if procedures == nil {
+		procedures = []models.Procedure{}
+	}
+
+	return procedures, total, nil
+}
@@ -0,0 +1,142 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// questionnaireTitlePaths are the fields the title search parameter of
+// questionnaires matches.
+var questionnaireTitlePaths = []string{"title"}
+
+type QuestionnaireRepo struct {
+	collection *mongo.Collection
+}
+
+func NewQuestionnaireRepo(db *mongo.Database) *QuestionnaireRepo {
+	return &QuestionnaireRepo{
+		collection: db.Collection("questionnaires"),
+	}
+}
+
+func (r *QuestionnaireRepo) Create(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	_, err := r.collection.InsertOne(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert questionnaire: %w", err)
+	}
+	return q, nil
+}
+
+func (r *QuestionnaireRepo) GetByID(ctx context.Context, id string) (*models.Questionnaire, error) {
+	var q models.Questionnaire
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&q)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find questionnaire: %w", err)
+	}
+
+	return &q, nil
+}
+
+func (r *QuestionnaireRepo) GetByURL(ctx context.Context, url, version string) (*models.Questionnaire, error) {
+	var q models.Questionnaire
+
+	filter := bson.M{"url": url}
+	if version != "" {
+		filter["version"] = version
+	}
+	// Without a version the most recently stored questionnaire is returned.
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	err := r.collection.FindOne(ctx, filter, findOptions).Decode(&q)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find questionnaire: %w", err)
+	}
+
+	return &q, nil
+}
+
+func (r *QuestionnaireRepo) Update(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	if q.Id == nil {
+		return nil, domain.ErrQuestionnaireIDRequired
+	}
+
+	filter := bson.M{"id": *q.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update questionnaire: %w", err)
+	}
+
+	return q, nil
+}
+
+func (r *QuestionnaireRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete questionnaire: %w", err)
+	}
+
+	return nil
+}
+
+func (r *QuestionnaireRepo) Search(ctx context.Context, search domain.QuestionnaireSearch, limit, offset int) ([]models.Questionnaire, int64, error) {
+	filter := bson.M{}
+	clauses := bson.A{}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.URL != "" {
+		clauses = append(clauses, bson.M{"url": search.URL})
+	}
+	if search.Title != "" {
+		clauses = append(clauses, prefixFilter(questionnaireTitlePaths, search.Title))
+	}
+	if len(clauses) > 0 {
+		filter = bson.M{"$and": clauses}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count questionnaires: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find questionnaires: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var questionnaires []models.Questionnaire
+	if err = cursor.All(ctx, &questionnaires); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode questionnaires: %w", err)
+	}
+
+	if questionnaires == nil {
+		questionnaires = []models.Questionnaire{}
+	}
+
+	return questionnaires, total, nil
+}
@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type QuestionnaireResponseRepo struct {
+	collection *mongo.Collection
+}
+
+func NewQuestionnaireResponseRepo(db *mongo.Database) *QuestionnaireResponseRepo {
+	return &QuestionnaireResponseRepo{
+		collection: db.Collection("questionnaire_responses"),
+	}
+}
+
+func (r *QuestionnaireResponseRepo) Create(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	_, err := r.collection.InsertOne(ctx, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert questionnaire response: %w", err)
+	}
+	return resp, nil
+}
+
+func (r *QuestionnaireResponseRepo) GetByID(ctx context.Context, id string) (*models.QuestionnaireResponse, error) {
+	var resp models.QuestionnaireResponse
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&resp)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find questionnaire response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+func (r *QuestionnaireResponseRepo) GetByIDs(ctx context.Context, ids []string) ([]models.QuestionnaireResponse, error) {
+	if len(ids) == 0 {
+		return []models.QuestionnaireResponse{}, nil
+	}
+
+	filter := bson.M{"id": bson.M{"$in": ids}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find questionnaire responses: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var responses []models.QuestionnaireResponse
+	if err = cursor.All(ctx, &responses); err != nil {
+		return nil, fmt.Errorf("failed to decode questionnaire responses: %w", err)
+	}
+
+	if responses == nil {
+		responses = []models.QuestionnaireResponse{}
+	}
+
+	return responses, nil
+}
+
+func (r *QuestionnaireResponseRepo) Update(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	if resp.Id == nil {
+		return nil, domain.ErrQuestionnaireResponseIDRequired
+	}
+
+	filter := bson.M{"id": *resp.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update questionnaire response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (r *QuestionnaireResponseRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete questionnaire response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *QuestionnaireResponseRepo) Search(ctx context.Context, search domain.QuestionnaireResponseSearch, restrictions []url.Values, limit, offset int) ([]models.QuestionnaireResponse, int64, error) {
+	clauses := bson.A{bson.M{"subject.reference": fmt.Sprintf("Patient/%s", search.PatientID)}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Questionnaire != "" {
+		clauses = append(clauses, bson.M{"questionnaire": search.Questionnaire})
+	}
+	for _, date := range search.Authored {
+		clauses = append(clauses, dateFilter("authored", date))
+	}
+	// Only _security restricts scopes on questionnaire responses.
+	if restricted := restrictionFilter(restrictions, nil); restricted != nil {
+		clauses = append(clauses, restricted)
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count questionnaire responses: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find questionnaire responses: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var responses []models.QuestionnaireResponse
+	if err = cursor.All(ctx, &responses); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode questionnaire responses: %w", err)
+	}
+
+	if responses == nil {
+		responses = []models.QuestionnaireResponse{}
+	}
+
+	return responses, total, nil
+}
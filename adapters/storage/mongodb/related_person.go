@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type RelatedPersonRepo struct {
+	collection *mongo.Collection
+}
+
+func NewRelatedPersonRepo(db *mongo.Database) *RelatedPersonRepo {
+	return &RelatedPersonRepo{
+		collection: db.Collection("related_persons"),
+	}
+}
+
+func (r *RelatedPersonRepo) Create(ctx context.Context, person *models.RelatedPerson) (*models.RelatedPerson, error) {
+	_, err := r.collection.InsertOne(ctx, person)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert related person: %w", err)
+	}
+	return person, nil
+}
+
+func (r *RelatedPersonRepo) GetByID(ctx context.Context, id string) (*models.RelatedPerson, error) {
+	var person models.RelatedPerson
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&person)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find related person: %w", err)
+	}
+
+	return &person, nil
+}
+
+func (r *RelatedPersonRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete related person: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RelatedPersonRepo) Search(ctx context.Context, patientID string, limit, offset int) ([]models.RelatedPerson, int64, error) {
+	filter := bson.M{"patient.reference": fmt.Sprintf("Patient/%s", patientID)}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count related persons: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find related persons: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var persons []models.RelatedPerson
+	if err = cursor.All(ctx, &persons); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode related persons: %w", err)
+	}
+
+	if persons == nil {
+		persons = []models.RelatedPerson{}
+	}
+
+	return persons, total, nil
+}
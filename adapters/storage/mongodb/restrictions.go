@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"net/url"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// matchNothing is a filter clause no stored resource satisfies.
+var matchNothing = bson.M{"_id": bson.M{"$exists": false}}
+
+// securityLabelPath is the Coding array the _security parameter searches. It
+// is supported for every resource type.
+const securityLabelPath = "meta.security"
+
+// restrictionFilter translates the query restrictions of SMART scopes into a
+// filter clause. A resource must satisfy one of the restrictions; within a
+// restriction every parameter must match. tokenPaths maps the supported token
+// parameters to the CodeableConcept field they search. Unsupported parameters
+// match nothing so that restricted access fails closed. It returns nil when
+// there are no restrictions.
+func restrictionFilter(restrictions []url.Values, tokenPaths map[string]string) bson.M {
+	if len(restrictions) == 0 {
+		return nil
+	}
+
+	alternatives := make(bson.A, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		clauses := make(bson.A, 0, len(restriction))
+		for name, values := range restriction {
+			if name == "_security" {
+				for _, value := range values {
+					clauses = append(clauses, codingFilter(securityLabelPath, value))
+				}
+				continue
+			}
+			path, ok := tokenPaths[name]
+			if !ok {
+				clauses = append(clauses, matchNothing)
+				continue
+			}
+			for _, value := range values {
+				clauses = append(clauses, tokenFilter(path, value))
+			}
+		}
+		alternatives = append(alternatives, bson.M{"$and": clauses})
+	}
+
+	return bson.M{"$or": alternatives}
+}
+
+// identifierFilter matches an Identifier array field against a "value" or
+// "system|value" token.
+func identifierFilter(path, value string) bson.M {
+	if system, value, ok := strings.Cut(value, "|"); ok {
+		return bson.M{path: bson.M{"$elemMatch": bson.M{"system": system, "value": value}}}
+	}
+	return bson.M{path + ".value": value}
+}
+
+// tokenFilter matches a CodeableConcept field against a comma-separated list
+// of "code" or "system|code" tokens.
+func tokenFilter(path, value string) bson.M {
+	return codingFilter(path+".coding", value)
+}
+
+// codingFilter matches a Coding array field against a comma-separated list
+// of "code" or "system|code" tokens.
+func codingFilter(path, value string) bson.M {
+	tokens := strings.Split(value, ",")
+	alternatives := make(bson.A, 0, len(tokens))
+	for _, token := range tokens {
+		if system, code, ok := strings.Cut(token, "|"); ok {
+			alternatives = append(alternatives, bson.M{path: bson.M{
+				"$elemMatch": bson.M{"system": system, "code": code},
+			}})
+			continue
+		}
+		alternatives = append(alternatives, bson.M{path + ".code": token})
+	}
+	return bson.M{"$or": alternatives}
+}
@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// dateFilter matches a string date field against a FHIR date search value.
+// Stored dates compare as ISO 8601 strings against the bounds of the range the
+// value covers.
+func dateFilter(path string, date domain.DateFilter) bson.M {
+	switch date.Prefix {
+	case "ne":
+		return bson.M{"$or": bson.A{
+			bson.M{path: bson.M{"$lt": date.Start}},
+			bson.M{path: bson.M{"$gte": date.End}},
+		}}
+	case "gt":
+		return bson.M{path: bson.M{"$gte": date.End}}
+	case "lt":
+		return bson.M{path: bson.M{"$lt": date.Start}}
+	case "ge":
+		return bson.M{path: bson.M{"$gte": date.Start}}
+	case "le":
+		return bson.M{path: bson.M{"$lt": date.End}}
+	default:
+		return bson.M{path: bson.M{"$gte": date.Start, "$lt": date.End}}
+	}
+}
+
+// anyDateFilter matches when any of the date fields matches the FHIR date
+// search value.
+func anyDateFilter(paths []string, date domain.DateFilter) bson.M {
+	alternatives := make(bson.A, 0, len(paths))
+	for _, path := range paths {
+		alternatives = append(alternatives, dateFilter(path, date))
+	}
+	return bson.M{"$or": alternatives}
+}
+
+// codeFilter matches a code field against a comma-separated list of codes.
+func codeFilter(path, value string) bson.M {
+	return bson.M{path: bson.M{"$in": strings.Split(value, ",")}}
+}
+
+// encounterFilter matches a reference field against an encounter id or
+// "Encounter/<id>" reference.
+func encounterFilter(path, value string) bson.M {
+	return bson.M{path: "Encounter/" + strings.TrimPrefix(value, "Encounter/")}
+}
+
+// prefixFilter matches when any of the string fields starts with value,
+// ignoring case, the way FHIR string search parameters match.
+func prefixFilter(paths []string, value string) bson.M {
+	pattern := bson.Regex{Pattern: "^" + regexp.QuoteMeta(value), Options: "i"}
+	alternatives := make(bson.A, 0, len(paths))
+	for _, path := range paths {
+		alternatives = append(alternatives, bson.M{path: pattern})
+	}
+	return bson.M{"$or": alternatives}
+}
+
+// directoryOwnerFilter matches the shared directory entries and, when
+// patientID is set, the private entries of that patient.
+func directoryOwnerFilter(patientID string) bson.M {
+	shared := bson.M{"meta.tag": bson.M{"$not": bson.M{"$elemMatch": bson.M{"system": domain.DirectoryOwnerSystem}}}}
+	if patientID == "" {
+		return shared
+	}
+	return bson.M{"$or": bson.A{
+		shared,
+		bson.M{"meta.tag": bson.M{"$elemMatch": bson.M{"system": domain.DirectoryOwnerSystem, "code": patientID}}},
+	}}
+}
+
+// addressPaths returns the fields of the Address at path that the address
+// search parameter matches.
+func addressPaths(path string) []string {
+	parts := []string{"text", "line", "city", "district", "state", "postal_code", "country"}
+	paths := make([]string, len(parts))
+	for i, part := range parts {
+		paths[i] = path + "." + part
+	}
+	return paths
+}
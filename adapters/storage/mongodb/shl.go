@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SHLRepo struct {
+	collection *mongo.Collection
+}
+
+type shlFile struct {
+	ContentType string `bson:"content_type"`
+	Embedded    string `bson:"embedded"`
+}
+
+type shlRecord struct {
+	ID                string    `bson:"id"`
+	PatientID         string    `bson:"patient_id"`
+	Label             string    `bson:"label,omitempty"`
+	Files             []shlFile `bson:"files"`
+	PasscodeHash      []byte    `bson:"passcode_hash,omitempty"`
+	PasscodeSalt      []byte    `bson:"passcode_salt,omitempty"`
+	RemainingAttempts int       `bson:"remaining_attempts"`
+	ExpiresAt         int64     `bson:"expires_at,omitempty"`
+	CreatedAt         int64     `bson:"created_at"`
+}
+
+func NewSHLRepo(db *mongo.Database) *SHLRepo {
+	return &SHLRepo{
+		collection: db.Collection("smart_health_links"),
+	}
+}
+
+func (r *SHLRepo) Create(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error) {
+	_, err := r.collection.InsertOne(ctx, toSHLRecord(link))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert smart health link: %w", err)
+	}
+	return link, nil
+}
+
+func (r *SHLRepo) GetByID(ctx context.Context, id string) (*domain.SmartHealthLink, error) {
+	var record shlRecord
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find smart health link: %w", err)
+	}
+
+	return fromSHLRecord(&record), nil
+}
+
+func (r *SHLRepo) DecrementAttempts(ctx context.Context, id string) (int, bool, error) {
+	filter := bson.M{"id": id, "remaining_attempts": bson.M{"$gt": 0}}
+	update := bson.M{"$inc": bson.M{"remaining_attempts": -1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var record shlRecord
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to update smart health link: %w", err)
+	}
+
+	return record.RemainingAttempts, true, nil
+}
+
+func (r *SHLRepo) ResetAttempts(ctx context.Context, id string, attempts int) error {
+	filter := bson.M{"id": id}
+	update := bson.M{"$set": bson.M{"remaining_attempts": attempts}}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update smart health link: %w", err)
+	}
+
+	return nil
+}
+
+func toSHLRecord(link *domain.SmartHealthLink) *shlRecord {
+	files := make([]shlFile, 0, len(link.Files))
+	for _, f := range link.Files {
+		files = append(files, shlFile{ContentType: f.ContentType, Embedded: f.Embedded})
+	}
+
+	return &shlRecord{
+		ID:                link.ID,
+		PatientID:         link.PatientID,
+		Label:             link.Label,
+		Files:             files,
+		PasscodeHash:      link.PasscodeHash,
+		PasscodeSalt:      link.PasscodeSalt,
+		RemainingAttempts: link.RemainingAttempts,
+		ExpiresAt:         link.ExpiresAt,
+		CreatedAt:         link.CreatedAt,
+	}
+}
+
+func fromSHLRecord(record *shlRecord) *domain.SmartHealthLink {
+	files := make([]domain.SHLManifestFile, 0, len(record.Files))
+	for _, f := range record.Files {
+		files = append(files, domain.SHLManifestFile{ContentType: f.ContentType, Embedded: f.Embedded})
+	}
+
+	return &domain.SmartHealthLink{
+		ID:                record.ID,
+		PatientID:         record.PatientID,
+		Label:             record.Label,
+		Files:             files,
+		PasscodeHash:      record.PasscodeHash,
+		PasscodeSalt:      record.PasscodeSalt,
+		RemainingAttempts: record.RemainingAttempts,
+		ExpiresAt:         record.ExpiresAt,
+		CreatedAt:         record.CreatedAt,
+	}
+}
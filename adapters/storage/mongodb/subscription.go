@@ -0,0 +1,247 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SubscriptionRepo struct {
+	collection *mongo.Collection
+}
+
+type subscriptionOwner struct {
+	UserID         string `bson:"user_id"`
+	PatientID      string `bson:"patient_id,omitempty"`
+	PractitionerID string `bson:"practitioner_id,omitempty"`
+}
+
+// subscriptionRecord stores the subscription resource inline, so that it is
+// queried by the same fields as other resources, next to its owner and
+// delivery state.
+type subscriptionRecord struct {
+	Subscription models.Subscription `bson:",inline"`
+	Owner        subscriptionOwner   `bson:"owner"`
+	EventCount   int64               `bson:"event_count"`
+	LastSentAt   int64               `bson:"last_sent_at"`
+}
+
+func NewSubscriptionRepo(db *mongo.Database) *SubscriptionRepo {
+	return &SubscriptionRepo{
+		collection: db.Collection("subscriptions"),
+	}
+}
+
+func (r *SubscriptionRepo) Create(ctx context.Context, record *domain.SubscriptionRecord) error {
+	_, err := r.collection.InsertOne(ctx, toSubscriptionRecord(record))
+	if err != nil {
+		return fmt.Errorf("failed to insert subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *SubscriptionRepo) GetByID(ctx context.Context, id string) (*domain.SubscriptionRecord, error) {
+	var record subscriptionRecord
+
+	filter := bson.M{"id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find subscription: %w", err)
+	}
+
+	return fromSubscriptionRecord(&record), nil
+}
+
+func (r *SubscriptionRepo) Update(ctx context.Context, sub *models.Subscription) error {
+	if sub.Id == nil {
+		return domain.ErrSubscriptionIDRequired
+	}
+
+	resource, err := bson.Marshal(sub)
+	if err != nil {
+		return fmt.Errorf("failed to encode subscription: %w", err)
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(resource, &fields); err != nil {
+		return fmt.Errorf("failed to encode subscription: %w", err)
+	}
+
+	// Fields the new version leaves out are removed; the owner and delivery
+	// state are kept.
+	unset := bson.M{}
+	for _, field := range subscriptionResourceFields {
+		if _, ok := fields[field]; !ok {
+			unset[field] = ""
+		}
+	}
+	update := bson.M{"$set": fields}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"id": *sub.Id}, update); err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) Search(ctx context.Context, search domain.SubscriptionSearch, limit, offset int) ([]models.Subscription, int64, error) {
+	clauses := bson.A{bson.M{"owner.user_id": search.OwnerID}}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.Topic != "" {
+		clauses = append(clauses, bson.M{"topic": search.Topic})
+	}
+	filter := bson.M{"$and": clauses}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count subscriptions: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	records, err := r.find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	subscriptions := make([]models.Subscription, len(records))
+	for i := range records {
+		subscriptions[i] = records[i].Subscription
+	}
+
+	return subscriptions, total, nil
+}
+
+func (r *SubscriptionRepo) ListActive(ctx context.Context, topics []string) ([]domain.SubscriptionRecord, error) {
+	filter := bson.M{
+		"status": domain.SubscriptionStatusActive,
+		"topic":  bson.M{"$in": topics},
+	}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *SubscriptionRepo) ListHeartbeat(ctx context.Context) ([]domain.SubscriptionRecord, error) {
+	filter := bson.M{
+		"status":           domain.SubscriptionStatusActive,
+		"heartbeat_period": bson.M{"$gt": 0},
+	}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *SubscriptionRepo) NextEventNumber(ctx context.Context, id string) (int64, error) {
+	var record subscriptionRecord
+
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"id": id},
+		bson.M{"$inc": bson.M{"event_count": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&record)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count subscription event: %w", err)
+	}
+
+	return record.EventCount, nil
+}
+
+func (r *SubscriptionRepo) MarkSent(ctx context.Context, id string, at time.Time) error {
+	update := bson.M{"$set": bson.M{"last_sent_at": at.Unix()}}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"id": id}, update); err != nil {
+		return fmt.Errorf("failed to record subscription delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) SetStatus(ctx context.Context, id, status string) error {
+	update := bson.M{"$set": bson.M{"status": status}}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"id": id}, update); err != nil {
+		return fmt.Errorf("failed to set subscription status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) find(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]domain.SubscriptionRecord, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subscriptions: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []subscriptionRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode subscriptions: %w", err)
+	}
+
+	result := make([]domain.SubscriptionRecord, len(records))
+	for i := range records {
+		result[i] = *fromSubscriptionRecord(&records[i])
+	}
+
+	return result, nil
+}
+
+// subscriptionResourceFields are the stored fields of the subscription
+// resource, as opposed to its owner and delivery state.
+var subscriptionResourceFields = []string{
+	"meta", "implicit_rules", "language", "text", "contained", "identifier", "name",
+	"contact", "end", "managing_entity", "reason", "filter_by", "endpoint", "parameter",
+	"heartbeat_period", "timeout", "content_type", "content", "max_count",
+}
+
+func toSubscriptionRecord(record *domain.SubscriptionRecord) subscriptionRecord {
+	return subscriptionRecord{
+		Subscription: record.Subscription,
+		Owner: subscriptionOwner{
+			UserID:         record.Owner.UserID,
+			PatientID:      record.Owner.PatientID,
+			PractitionerID: record.Owner.PractitionerID,
+		},
+		EventCount: record.EventCount,
+		LastSentAt: record.LastSentAt,
+	}
+}
+
+func fromSubscriptionRecord(record *subscriptionRecord) *domain.SubscriptionRecord {
+	return &domain.SubscriptionRecord{
+		Subscription: record.Subscription,
+		Owner: domain.Principal{
+			UserID:         record.Owner.UserID,
+			PatientID:      record.Owner.PatientID,
+			PractitionerID: record.Owner.PractitionerID,
+		},
+		EventCount: record.EventCount,
+		LastSentAt: record.LastSentAt,
+	}
+}
@@ -0,0 +1,171 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SubscriptionNotificationRepo struct {
+	collection *mongo.Collection
+}
+
+type resourceEventRecord struct {
+	Interaction  string              `bson:"interaction"`
+	ResourceType string              `bson:"resource_type"`
+	ResourceID   string              `bson:"resource_id"`
+	PatientID    string              `bson:"patient_id,omitempty"`
+	SearchParams map[string][]string `bson:"search_params,omitempty"`
+	Status       string              `bson:"status,omitempty"`
+	Resource     []byte              `bson:"resource,omitempty"`
+	OccurredAt   int64               `bson:"occurred_at"`
+}
+
+type subscriptionNotificationRecord struct {
+	ID             string               `bson:"_id"`
+	SubscriptionID string               `bson:"subscription_id"`
+	Type           string               `bson:"type"`
+	EventNumber    int64                `bson:"event_number"`
+	Event          *resourceEventRecord `bson:"event,omitempty"`
+	Attempts       int                  `bson:"attempts"`
+	NextAttemptAt  int64                `bson:"next_attempt_at"`
+}
+
+func NewSubscriptionNotificationRepo(db *mongo.Database) *SubscriptionNotificationRepo {
+	return &SubscriptionNotificationRepo{
+		collection: db.Collection("subscription_notifications"),
+	}
+}
+
+func (r *SubscriptionNotificationRepo) Enqueue(ctx context.Context, n *domain.SubscriptionNotification) error {
+	_, err := r.collection.InsertOne(ctx, toSubscriptionNotificationRecord(n))
+	if err != nil {
+		return fmt.Errorf("failed to queue subscription notification: %w", err)
+	}
+	return nil
+}
+
+// ListDue takes the queue of every subscription in event order and returns
+// its due notifications unless its first one is held for a retry, which
+// keeps later notifications from overtaking it.
+func (r *SubscriptionNotificationRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]domain.SubscriptionNotification, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "subscription_id", Value: 1}, {Key: "event_number", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$subscription_id",
+			"first_attempt": bson.M{"$first": "$next_attempt_at"},
+			"queue":         bson.M{"$push": "$$ROOT"},
+		}}},
+		{{Key: "$match", Value: bson.M{"first_attempt": bson.M{"$lte": now.Unix()}}}},
+		{{Key: "$unwind", Value: "$queue"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$queue"}}},
+		{{Key: "$match", Value: bson.M{"next_attempt_at": bson.M{"$lte": now.Unix()}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "subscription_id", Value: 1}, {Key: "event_number", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due subscription notifications: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var records []subscriptionNotificationRecord
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription notifications: %w", err)
+	}
+
+	notifications := make([]domain.SubscriptionNotification, len(records))
+	for i := range records {
+		notifications[i] = fromSubscriptionNotificationRecord(&records[i])
+	}
+
+	return notifications, nil
+}
+
+func (r *SubscriptionNotificationRepo) Delete(ctx context.Context, id string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("failed to delete subscription notification: %w", err)
+	}
+	return nil
+}
+
+func (r *SubscriptionNotificationRepo) Retry(ctx context.Context, n domain.SubscriptionNotification, next time.Time) error {
+	update := bson.M{"$set": bson.M{"attempts": n.Attempts + 1, "next_attempt_at": next.Unix()}}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": n.ID}, update); err != nil {
+		return fmt.Errorf("failed to reschedule subscription notification: %w", err)
+	}
+
+	held := bson.M{
+		"subscription_id": n.SubscriptionID,
+		"next_attempt_at": bson.M{"$lt": next.Unix()},
+	}
+	if _, err := r.collection.UpdateMany(ctx, held, bson.M{"$set": bson.M{"next_attempt_at": next.Unix()}}); err != nil {
+		return fmt.Errorf("failed to hold subscription notifications: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionNotificationRepo) DeleteBySubscription(ctx context.Context, subscriptionID string) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"subscription_id": subscriptionID}); err != nil {
+		return fmt.Errorf("failed to delete subscription notifications: %w", err)
+	}
+	return nil
+}
+
+func toSubscriptionNotificationRecord(n *domain.SubscriptionNotification) subscriptionNotificationRecord {
+	record := subscriptionNotificationRecord{
+		ID:             n.ID,
+		SubscriptionID: n.SubscriptionID,
+		Type:           n.Type,
+		EventNumber:    n.EventNumber,
+		Attempts:       n.Attempts,
+		NextAttemptAt:  n.NextAttemptAt,
+	}
+	if n.Event != nil {
+		record.Event = &resourceEventRecord{
+			Interaction:  string(n.Event.Interaction),
+			ResourceType: n.Event.Ref.Type,
+			ResourceID:   n.Event.Ref.ID,
+			PatientID:    n.Event.Ref.PatientID,
+			SearchParams: n.Event.Ref.SearchParams,
+			Status:       n.Event.Status,
+			Resource:     n.Event.Resource,
+			OccurredAt:   n.Event.OccurredAt.Unix(),
+		}
+	}
+	return record
+}
+
+func fromSubscriptionNotificationRecord(record *subscriptionNotificationRecord) domain.SubscriptionNotification {
+	n := domain.SubscriptionNotification{
+		ID:             record.ID,
+		SubscriptionID: record.SubscriptionID,
+		Type:           record.Type,
+		EventNumber:    record.EventNumber,
+		Attempts:       record.Attempts,
+		NextAttemptAt:  record.NextAttemptAt,
+	}
+	if record.Event != nil {
+		n.Event = &domain.ResourceEvent{
+			Interaction: domain.Interaction(record.Event.Interaction),
+			Ref: domain.ResourceRef{
+				Type:         record.Event.ResourceType,
+				ID:           record.Event.ResourceID,
+				PatientID:    record.Event.PatientID,
+				SearchParams: record.Event.SearchParams,
+			},
+			Status:     record.Event.Status,
+			Resource:   record.Event.Resource,
+			OccurredAt: time.Unix(record.Event.OccurredAt, 0),
+		}
+	}
+	return n
+}
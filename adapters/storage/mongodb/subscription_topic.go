@@ -0,0 +1,150 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SubscriptionTopicRepo struct {
+	collection *mongo.Collection
+}
+
+func NewSubscriptionTopicRepo(db *mongo.Database) *SubscriptionTopicRepo {
+	return &SubscriptionTopicRepo{
+		collection: db.Collection("subscription_topics"),
+	}
+}
+
+func (r *SubscriptionTopicRepo) Create(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	_, err := r.collection.InsertOne(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert subscription topic: %w", err)
+	}
+	return topic, nil
+}
+
+func (r *SubscriptionTopicRepo) GetByID(ctx context.Context, id string) (*models.SubscriptionTopic, error) {
+	return r.findOne(ctx, bson.M{"id": id}, options.FindOne())
+}
+
+func (r *SubscriptionTopicRepo) GetByURL(ctx context.Context, url string) (*models.SubscriptionTopic, error) {
+	// Of several versions the most recently stored topic is returned.
+	return r.findOne(ctx, bson.M{"url": url}, options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}}))
+}
+
+func (r *SubscriptionTopicRepo) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptionsBuilder) (*models.SubscriptionTopic, error) {
+	var topic models.SubscriptionTopic
+
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&topic)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find subscription topic: %w", err)
+	}
+
+	return &topic, nil
+}
+
+func (r *SubscriptionTopicRepo) Update(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	if topic.Id == nil {
+		return nil, domain.ErrSubscriptionTopicIDRequired
+	}
+
+	filter := bson.M{"id": *topic.Id}
+
+	_, err := r.collection.ReplaceOne(ctx, filter, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update subscription topic: %w", err)
+	}
+
+	return topic, nil
+}
+
+func (r *SubscriptionTopicRepo) Delete(ctx context.Context, id string) error {
+	filter := bson.M{"id": id}
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription topic: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionTopicRepo) Search(ctx context.Context, search domain.SubscriptionTopicSearch, limit, offset int) ([]models.SubscriptionTopic, int64, error) {
+	filter := bson.M{}
+	clauses := bson.A{}
+	if search.Status != "" {
+		clauses = append(clauses, codeFilter("status", search.Status))
+	}
+	if search.URL != "" {
+		clauses = append(clauses, bson.M{"url": search.URL})
+	}
+	if search.Resource != "" {
+		clauses = append(clauses, triggerResourceFilter(search.Resource))
+	}
+	if len(clauses) > 0 {
+		filter = bson.M{"$and": clauses}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count subscription topics: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	topics, err := r.find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return topics, total, nil
+}
+
+func (r *SubscriptionTopicRepo) ListActive(ctx context.Context, resourceType string) ([]models.SubscriptionTopic, error) {
+	filter := bson.M{"$and": bson.A{
+		bson.M{"status": "active"},
+		triggerResourceFilter(resourceType),
+	}}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *SubscriptionTopicRepo) find(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]models.SubscriptionTopic, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subscription topics: %w", err)
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var topics []models.SubscriptionTopic
+	if err = cursor.All(ctx, &topics); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription topics: %w", err)
+	}
+
+	if topics == nil {
+		topics = []models.SubscriptionTopic{}
+	}
+
+	return topics, nil
+}
+
+// triggerResourceFilter matches topics with a trigger naming the resource type
+// plainly or by the canonical URL of its structure definition.
+func triggerResourceFilter(resourceType string) bson.M {
+	return bson.M{"trigger.resource": bson.M{"$in": bson.A{
+		resourceType,
+		"http://hl7.org/fhir/StructureDefinition/" + resourceType,
+	}}}
+}
@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/configs"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type Transactor struct {
+	client    *mongo.Client
+	supported bool
+}
+
+// NewTransactor checks that the deployment supports transactions, which
+// only replica sets and sharded clusters do. A standalone server is refused
+// unless cfg.MongoDB.WithoutTransactions is set, in which case units of work
+// run without a transaction.
+func NewTransactor(cfg *configs.Config, db *mongo.Database) (*Transactor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return nil, fmt.Errorf("failed to check mongo deployment: %w", err)
+	}
+
+	supported := hello.SetName != "" || hello.Msg == "isdbgrid"
+	if !supported {
+		if !cfg.MongoDB.WithoutTransactions {
+			return nil, errors.New("mongo deployment does not support transactions: run a replica set or set MONGO_WITHOUT_TRANSACTIONS")
+		}
+		log.Printf("MongoDB is a standalone server: outbox events are written without transactions")
+	}
+
+	return &Transactor{client: db.Client(), supported: supported}, nil
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !t.supported {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
@@ -6,7 +6,9 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/gruzdev-dev/codex-documents/adapters/clients/auth"
+	"github.com/gruzdev-dev/codex-documents/adapters/clients/events"
 	"github.com/gruzdev-dev/codex-documents/adapters/clients/files"
+	"github.com/gruzdev-dev/codex-documents/adapters/clients/resthook"
 	"github.com/gruzdev-dev/codex-documents/adapters/grpc"
 	"github.com/gruzdev-dev/codex-documents/adapters/http"
 	"github.com/gruzdev-dev/codex-documents/adapters/storage/mongodb"
@@ -15,6 +17,7 @@ import (
 	"github.com/gruzdev-dev/codex-documents/core/services"
 	"github.com/gruzdev-dev/codex-documents/core/validator"
 	"github.com/gruzdev-dev/codex-documents/pkg/database"
+	"github.com/gruzdev-dev/codex-documents/pkg/netguard"
 )
 
 func BuildContainer() (*dig.Container, error) {
@@ -24,6 +27,44 @@ func BuildContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
+	if err := c.Provide(func(cfg *configs.Config) services.LinkOptions {
+		return services.LinkOptions{PublicURL: cfg.HTTP.PublicURL}
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(func(cfg *configs.Config) services.ReminderOptions {
+		return services.ReminderOptions{
+			Offsets:  cfg.Reminders.Offsets,
+			Interval: cfg.Reminders.Interval,
+			LeaseTTL: cfg.Reminders.LeaseTTL,
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(func(cfg *configs.Config) services.DeliveryOptions {
+		return services.DeliveryOptions{
+			Interval:     cfg.Subscriptions.Interval,
+			LeaseTTL:     cfg.Subscriptions.LeaseTTL,
+			MaxAttempts:  cfg.Subscriptions.MaxAttempts,
+			RetryBackoff: cfg.Subscriptions.RetryBackoff,
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(func(cfg *configs.Config) services.RelayOptions {
+		return services.RelayOptions{
+			Interval:     cfg.Events.Interval,
+			LeaseTTL:     cfg.Events.LeaseTTL,
+			MaxAttempts:  cfg.Events.MaxAttempts,
+			RetryBackoff: cfg.Events.RetryBackoff,
+		}
+	}); err != nil {
+		return nil, err
+	}
+
 	if err := c.Provide(database.NewMongoDB); err != nil {
 		return nil, err
 	}
@@ -40,6 +81,42 @@ func BuildContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
+	if err := c.Provide(mongodb.NewCareRelationshipRepo, dig.As(new(ports.CareRelationshipRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewDelegationRepo, dig.As(new(ports.DelegationRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewBreakGlassRepo, dig.As(new(ports.BreakGlassRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewAuditEventRepo, dig.As(new(ports.AuditEventRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewNotificationRepo, dig.As(new(ports.NotificationRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewBreakGlassAuditor, dig.As(new(ports.BreakGlassAuditor))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewConsentRepo, dig.As(new(ports.ConsentRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewPolicyConsentEvaluator, dig.As(new(ports.ConsentEvaluator))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewPolicyAuthorizer, dig.As(new(ports.Authorizer))); err != nil {
+		return nil, err
+	}
+
 	if err := c.Provide(mongodb.NewDocumentRepo, dig.As(new(ports.DocumentRepository))); err != nil {
 		return nil, err
 	}
@@ -56,7 +133,28 @@ func BuildContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
-	if err := c.Provide(services.NewShareService, dig.As(new(ports.ShareService))); err != nil {
+	if err := c.Provide(mongodb.NewSHLRepo, dig.As(new(ports.SHLRepository))); err != nil {
+		return nil, err
+	}
+
+	for _, shareable := range []any{
+		services.NewConditionShareable,
+		services.NewMedicationStatementShareable,
+		services.NewMedicationRequestShareable,
+		services.NewAllergyIntoleranceShareable,
+		services.NewImmunizationShareable,
+		services.NewDiagnosticReportShareable,
+		services.NewProcedureShareable,
+		services.NewFamilyMemberHistoryShareable,
+	} {
+		if err := c.Provide(shareable, dig.Group("shareables")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Provide(func(p shareServiceParams) *services.ShareService {
+		return services.NewShareService(p.Links, p.ObsRepo, p.DocRepo, p.Shareables, p.SHLRepo, p.TmpAccessClient, p.Files, p.Authz, p.Consent, p.Outbox)
+	}, dig.As(new(ports.ShareService))); err != nil {
 		return nil, err
 	}
 
@@ -76,6 +174,356 @@ func BuildContainer() (*dig.Container, error) {
 		return nil, err
 	}
 
+	if err := c.Provide(mongodb.NewConditionRepo, dig.As(new(ports.ConditionRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewConditionValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewConditionService, dig.As(new(ports.ConditionService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewMedicationStatementRepo, dig.As(new(ports.MedicationStatementRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewMedicationStatementValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewMedicationStatementService, dig.As(new(ports.MedicationStatementService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewMedicationRequestRepo, dig.As(new(ports.MedicationRequestRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewMedicationRequestValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewMedicationRequestService, dig.As(new(ports.MedicationRequestService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewAllergyIntoleranceRepo, dig.As(new(ports.AllergyIntoleranceRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewAllergyIntoleranceValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewAllergyIntoleranceService, dig.As(new(ports.AllergyIntoleranceService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewImmunizationRepo, dig.As(new(ports.ImmunizationRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewImmunizationValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewImmunizationService, dig.As(new(ports.ImmunizationService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewProcedureRepo, dig.As(new(ports.ProcedureRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewProcedureValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewProcedureService, dig.As(new(ports.ProcedureService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewFamilyMemberHistoryRepo, dig.As(new(ports.FamilyMemberHistoryRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewFamilyMemberHistoryValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewFamilyMemberHistoryService, dig.As(new(ports.FamilyMemberHistoryService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewAppointmentRepo, dig.As(new(ports.AppointmentRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewAppointmentValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewAppointmentService, dig.As(new(ports.AppointmentService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewAppointmentReminderRepo, dig.As(new(ports.ReminderRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewLeaseRepo, dig.As(new(ports.LeaseRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewNotificationReminderNotifier, dig.As(new(ports.ReminderNotifier))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewReminderScheduler); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewDiagnosticReportRepo, dig.As(new(ports.DiagnosticReportRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewDiagnosticReportValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewDiagnosticReportService, dig.As(new(ports.DiagnosticReportService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewOrganizationRepo, dig.As(new(ports.OrganizationRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewOrganizationValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewOrganizationService, dig.As(new(ports.OrganizationService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewLocationRepo, dig.As(new(ports.LocationRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewLocationValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewLocationService, dig.As(new(ports.LocationService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewEncounterRepo, dig.As(new(ports.EncounterRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewEncounterValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewEncounterService, dig.As(new(ports.EncounterService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewCompositionRepo, dig.As(new(ports.CompositionRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewCompositionValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewCompositionService, dig.As(new(ports.CompositionService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewQuestionnaireRepo, dig.As(new(ports.QuestionnaireRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewQuestionnaireValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewQuestionnaireService, dig.As(new(ports.QuestionnaireService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewQuestionnaireResponseRepo, dig.As(new(ports.QuestionnaireResponseRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewQuestionnaireResponseValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewQuestionnaireResponseService, dig.As(new(ports.QuestionnaireResponseService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewSubscriptionTopicRepo, dig.As(new(ports.SubscriptionTopicRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewSubscriptionTopicValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewSubscriptionTopicService, dig.As(new(ports.SubscriptionTopicService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewSubscriptionRepo, dig.As(new(ports.SubscriptionRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewSubscriptionNotificationRepo, dig.As(new(ports.SubscriptionNotificationRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(func(cfg *configs.Config) (*netguard.Guard, error) {
+		return netguard.New(cfg.Subscriptions.EndpointAllowlist)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewSubscriptionValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewSubscriptionService, dig.As(new(ports.SubscriptionService), new(ports.EventHandler))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(resthook.NewClient); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewSubscriptionDispatcher); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewTransactor, dig.As(new(ports.Transactor))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewOutboxRepo, dig.As(new(ports.OutboxRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewEventOutbox); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(events.NewPublisher); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewOutboxRelay); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewGoalRepo, dig.As(new(ports.GoalRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewGoalValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewGoalService, dig.As(new(ports.GoalService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewCarePlanRepo, dig.As(new(ports.CarePlanRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewCarePlanValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewCarePlanService, dig.As(new(ports.CarePlanService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewEverythingService, dig.As(new(ports.EverythingService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewSummaryService, dig.As(new(ports.SummaryService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewPractitionerRepo, dig.As(new(ports.PractitionerRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewPractitionerValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewPractitionerService, dig.As(new(ports.PractitionerService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewPractitionerRoleRepo, dig.As(new(ports.PractitionerRoleRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewPractitionerRoleValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewPractitionerRoleService, dig.As(new(ports.PractitionerRoleService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewCareRelationshipService, dig.As(new(ports.CareRelationshipService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(mongodb.NewRelatedPersonRepo, dig.As(new(ports.RelatedPersonRepository))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewRelatedPersonValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewRelatedPersonService, dig.As(new(ports.RelatedPersonService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewDelegationService, dig.As(new(ports.DelegationService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewBreakGlassService, dig.As(new(ports.BreakGlassService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewNotificationService, dig.As(new(ports.NotificationService))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(validator.NewConsentValidator); err != nil {
+		return nil, err
+	}
+
+	if err := c.Provide(services.NewConsentService, dig.As(new(ports.ConsentService))); err != nil {
+		return nil, err
+	}
+
 	if err := c.Provide(http.NewHandler); err != nil {
 		return nil, err
 	}
@@ -94,3 +542,20 @@ func BuildContainer() (*dig.Container, error) {
 
 	return c, nil
 }
+
+// shareServiceParams collects the ShareService dependencies, including every
+// Shareable provided to the "shareables" group.
+type shareServiceParams struct {
+	dig.In
+
+	Links           services.LinkOptions
+	ObsRepo         ports.ObservationRepository
+	DocRepo         ports.DocumentRepository
+	Shareables      []services.Shareable `group:"shareables"`
+	SHLRepo         ports.SHLRepository
+	TmpAccessClient ports.TmpAccessClient
+	Files           ports.FileProvider
+	Authz           ports.Authorizer
+	Consent         ports.ConsentEvaluator
+	Outbox          *services.EventOutbox
+}
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/gruzdev-dev/codex-documents/adapters/grpc"
+	"github.com/gruzdev-dev/codex-documents/core/services"
 	"github.com/gruzdev-dev/codex-documents/proto"
 	grpcServer "github.com/gruzdev-dev/codex-documents/servers/grpc"
 	httpServer "github.com/gruzdev-dev/codex-documents/servers/http"
@@ -24,6 +25,9 @@ func main() {
 		httpSrv *httpServer.Server,
 		grpcSrv *grpcServer.Server,
 		authHandler *grpc.AuthHandler,
+		reminders *services.ReminderScheduler,
+		subscriptions *services.SubscriptionDispatcher,
+		outbox *services.OutboxRelay,
 	) error {
 		proto.RegisterAuthIntegrationServer(grpcSrv.GetGRPCServer(), authHandler)
 
@@ -40,6 +44,18 @@ func main() {
 			return grpcSrv.Start(ctx)
 		})
 
+		g.Go(func() error {
+			return reminders.Run(ctx)
+		})
+
+		g.Go(func() error {
+			return subscriptions.Run(ctx)
+		})
+
+		g.Go(func() error {
+			return outbox.Run(ctx)
+		})
+
 		return g.Wait()
 	})
 
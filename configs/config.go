@@ -1,12 +1,21 @@
 package configs
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	HTTP struct {
 		Port string
+		// PublicURL is the absolute http(s) URL clients reach the API at.
+		// Links in documents, bundles and notifications start with it.
+		PublicURL string
 	}
 	GRPC struct {
 		Port string
@@ -14,6 +23,21 @@ type Config struct {
 	Auth struct {
 		JWTSecret      string
 		InternalSecret string
+		// JWKSURL or JWKSFile enable RS256, ES256 and EdDSA access tokens.
+		JWKSURL      string
+		JWKSFile     string
+		JWKSCacheTTL time.Duration
+		// JWKSRefreshInterval limits refetches caused by unknown key IDs.
+		JWKSRefreshInterval time.Duration
+		// JWKSMaxStale is how long past the cache TTL the keys are still used
+		// while the key set cannot be fetched.
+		JWKSMaxStale time.Duration
+		// Issuer and Audience are checked when set. Leeway applies to exp
+		// and nbf.
+		Issuer     string
+		Audience   []string
+		RequireExp bool
+		Leeway     time.Duration
 	}
 	MongoDB struct {
 		Host       string
@@ -22,10 +46,60 @@ type Config struct {
 		Password   string
 		Database   string
 		AuthSource string
+		// WithoutTransactions lets the service run on a standalone server,
+		// which has no transactions. Changes and their outbox events are
+		// then written separately, so an event can be lost if the second
+		// write fails. Meant for local development only.
+		WithoutTransactions bool
 	}
 	FileService struct {
 		Addr string
 	}
+	Reminders struct {
+		// Offsets are how long before the start of a booked appointment
+		// reminders are sent.
+		Offsets []time.Duration
+		// Interval is how often the scheduler looks for due reminders.
+		// LeaseTTL bounds how long a replica that stopped keeps others from
+		// taking over.
+		Interval time.Duration
+		LeaseTTL time.Duration
+	}
+	Subscriptions struct {
+		// Interval is how often the dispatcher delivers queued
+		// notifications and heartbeats; LeaseTTL is as for reminders.
+		Interval time.Duration
+		LeaseTTL time.Duration
+		// MaxAttempts is how many times a notification is tried before its
+		// subscription is set to error. RetryBackoff is the wait after the
+		// first failure, doubled after each further one.
+		MaxAttempts  int
+		RetryBackoff time.Duration
+		// EndpointAllowlist lists the host names, IP addresses and CIDR
+		// ranges endpoints may be on besides public addresses, e.g. for
+		// receivers inside the cluster.
+		EndpointAllowlist []string
+	}
+	Events struct {
+		// Publisher is where the outbox relay publishes domain events:
+		// "log" (the default), "memory" or "nats".
+		Publisher string
+		// NATSURL is the nats:// URL of the server events are published to,
+		// under subjects starting with SubjectPrefix. With JetStream each
+		// event waits for the acknowledgement of the stream storing it.
+		NATSURL       string
+		SubjectPrefix string
+		JetStream     bool
+		// Interval is how often the relay publishes pending events; LeaseTTL
+		// is as for reminders.
+		Interval time.Duration
+		LeaseTTL time.Duration
+		// MaxAttempts is how many times an event is tried before it is moved
+		// to the dead letters. RetryBackoff is the wait after the first
+		// failure, doubled after each further one.
+		MaxAttempts  int
+		RetryBackoff time.Duration
+	}
 }
 
 func NewConfig() (*Config, error) {
@@ -34,12 +108,63 @@ func NewConfig() (*Config, error) {
 	if envPort := os.Getenv("HTTP_PORT"); envPort != "" {
 		cfg.HTTP.Port = envPort
 	}
+	cfg.HTTP.PublicURL = os.Getenv("HTTP_PUBLIC_URL")
+	if err := validatePublicURL(cfg.HTTP.PublicURL); err != nil {
+		return nil, fmt.Errorf("invalid HTTP_PUBLIC_URL: %w", err)
+	}
 	if envGRPCPort := os.Getenv("GRPC_PORT"); envGRPCPort != "" {
 		cfg.GRPC.Port = envGRPCPort
 	}
 	if envSecret := os.Getenv("JWT_SECRET"); envSecret != "" {
 		cfg.Auth.JWTSecret = envSecret
 	}
+	if envJWKSURL := os.Getenv("JWT_JWKS_URL"); envJWKSURL != "" {
+		cfg.Auth.JWKSURL = envJWKSURL
+	}
+	if envJWKSFile := os.Getenv("JWT_JWKS_FILE"); envJWKSFile != "" {
+		cfg.Auth.JWKSFile = envJWKSFile
+	}
+	if envJWKSCacheTTL := os.Getenv("JWT_JWKS_CACHE_TTL"); envJWKSCacheTTL != "" {
+		ttl, err := time.ParseDuration(envJWKSCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_JWKS_CACHE_TTL: %w", err)
+		}
+		cfg.Auth.JWKSCacheTTL = ttl
+	}
+	if envJWKSRefresh := os.Getenv("JWT_JWKS_REFRESH_INTERVAL"); envJWKSRefresh != "" {
+		interval, err := time.ParseDuration(envJWKSRefresh)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_JWKS_REFRESH_INTERVAL: %w", err)
+		}
+		cfg.Auth.JWKSRefreshInterval = interval
+	}
+	if envJWKSMaxStale := os.Getenv("JWT_JWKS_MAX_STALE"); envJWKSMaxStale != "" {
+		maxStale, err := time.ParseDuration(envJWKSMaxStale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_JWKS_MAX_STALE: %w", err)
+		}
+		cfg.Auth.JWKSMaxStale = maxStale
+	}
+	if envIssuer := os.Getenv("JWT_ISSUER"); envIssuer != "" {
+		cfg.Auth.Issuer = envIssuer
+	}
+	if envAudience := os.Getenv("JWT_AUDIENCE"); envAudience != "" {
+		cfg.Auth.Audience = strings.Split(envAudience, ",")
+	}
+	if envRequireExp := os.Getenv("JWT_REQUIRE_EXP"); envRequireExp != "" {
+		requireExp, err := strconv.ParseBool(envRequireExp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_REQUIRE_EXP: %w", err)
+		}
+		cfg.Auth.RequireExp = requireExp
+	}
+	if envLeeway := os.Getenv("JWT_LEEWAY"); envLeeway != "" {
+		leeway, err := time.ParseDuration(envLeeway)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT_LEEWAY: %w", err)
+		}
+		cfg.Auth.Leeway = leeway
+	}
 	if envInternalSecret := os.Getenv("INTERNAL_SERVICE_SECRET"); envInternalSecret != "" {
 		cfg.Auth.InternalSecret = envInternalSecret
 	}
@@ -55,6 +180,13 @@ func NewConfig() (*Config, error) {
 	if envMongoPassword := os.Getenv("MONGO_PASSWORD"); envMongoPassword != "" {
 		cfg.MongoDB.Password = envMongoPassword
 	}
+	if envWithoutTx := os.Getenv("MONGO_WITHOUT_TRANSACTIONS"); envWithoutTx != "" {
+		withoutTx, err := strconv.ParseBool(envWithoutTx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGO_WITHOUT_TRANSACTIONS: %w", err)
+		}
+		cfg.MongoDB.WithoutTransactions = withoutTx
+	}
 	if envMongoDB := os.Getenv("MONGO_DATABASE"); envMongoDB != "" {
 		cfg.MongoDB.Database = envMongoDB
 	}
@@ -64,6 +196,126 @@ func NewConfig() (*Config, error) {
 	if envFileServiceAddr := os.Getenv("FILE_SERVICE_ADDR"); envFileServiceAddr != "" {
 		cfg.FileService.Addr = envFileServiceAddr
 	}
+	if envOffsets := os.Getenv("REMINDER_OFFSETS"); envOffsets != "" {
+		for _, value := range strings.Split(envOffsets, ",") {
+			offset, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil || offset <= 0 {
+				return nil, fmt.Errorf("invalid REMINDER_OFFSETS: %q", value)
+			}
+			cfg.Reminders.Offsets = append(cfg.Reminders.Offsets, offset)
+		}
+	}
+	if envInterval := os.Getenv("REMINDER_INTERVAL"); envInterval != "" {
+		interval, err := time.ParseDuration(envInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REMINDER_INTERVAL: %w", err)
+		}
+		cfg.Reminders.Interval = interval
+	}
+	if envLeaseTTL := os.Getenv("REMINDER_LEASE_TTL"); envLeaseTTL != "" {
+		ttl, err := time.ParseDuration(envLeaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REMINDER_LEASE_TTL: %w", err)
+		}
+		cfg.Reminders.LeaseTTL = ttl
+	}
+	if envInterval := os.Getenv("SUBSCRIPTION_INTERVAL"); envInterval != "" {
+		interval, err := time.ParseDuration(envInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SUBSCRIPTION_INTERVAL: %w", err)
+		}
+		cfg.Subscriptions.Interval = interval
+	}
+	if envLeaseTTL := os.Getenv("SUBSCRIPTION_LEASE_TTL"); envLeaseTTL != "" {
+		ttl, err := time.ParseDuration(envLeaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SUBSCRIPTION_LEASE_TTL: %w", err)
+		}
+		cfg.Subscriptions.LeaseTTL = ttl
+	}
+	if envMaxAttempts := os.Getenv("SUBSCRIPTION_MAX_ATTEMPTS"); envMaxAttempts != "" {
+		attempts, err := strconv.Atoi(envMaxAttempts)
+		if err != nil || attempts <= 0 {
+			return nil, fmt.Errorf("invalid SUBSCRIPTION_MAX_ATTEMPTS: %q", envMaxAttempts)
+		}
+		cfg.Subscriptions.MaxAttempts = attempts
+	}
+	if envBackoff := os.Getenv("SUBSCRIPTION_RETRY_BACKOFF"); envBackoff != "" {
+		backoff, err := time.ParseDuration(envBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SUBSCRIPTION_RETRY_BACKOFF: %w", err)
+		}
+		cfg.Subscriptions.RetryBackoff = backoff
+	}
+	if envAllowlist := os.Getenv("SUBSCRIPTION_ENDPOINT_ALLOWLIST"); envAllowlist != "" {
+		cfg.Subscriptions.EndpointAllowlist = strings.Split(envAllowlist, ",")
+	}
+	if envPublisher := os.Getenv("EVENTS_PUBLISHER"); envPublisher != "" {
+		cfg.Events.Publisher = envPublisher
+	}
+	if envNATSURL := os.Getenv("EVENTS_NATS_URL"); envNATSURL != "" {
+		cfg.Events.NATSURL = envNATSURL
+	}
+	if envSubjectPrefix := os.Getenv("EVENTS_SUBJECT_PREFIX"); envSubjectPrefix != "" {
+		cfg.Events.SubjectPrefix = envSubjectPrefix
+	}
+	if envJetStream := os.Getenv("EVENTS_NATS_JETSTREAM"); envJetStream != "" {
+		jetStream, err := strconv.ParseBool(envJetStream)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVENTS_NATS_JETSTREAM: %w", err)
+		}
+		cfg.Events.JetStream = jetStream
+	}
+	if envInterval := os.Getenv("EVENTS_RELAY_INTERVAL"); envInterval != "" {
+		interval, err := time.ParseDuration(envInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVENTS_RELAY_INTERVAL: %w", err)
+		}
+		cfg.Events.Interval = interval
+	}
+	if envLeaseTTL := os.Getenv("EVENTS_LEASE_TTL"); envLeaseTTL != "" {
+		ttl, err := time.ParseDuration(envLeaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVENTS_LEASE_TTL: %w", err)
+		}
+		cfg.Events.LeaseTTL = ttl
+	}
+	if envMaxAttempts := os.Getenv("EVENTS_MAX_ATTEMPTS"); envMaxAttempts != "" {
+		attempts, err := strconv.Atoi(envMaxAttempts)
+		if err != nil || attempts <= 0 {
+			return nil, fmt.Errorf("invalid EVENTS_MAX_ATTEMPTS: %q", envMaxAttempts)
+		}
+		cfg.Events.MaxAttempts = attempts
+	}
+	if envBackoff := os.Getenv("EVENTS_RETRY_BACKOFF"); envBackoff != "" {
+		backoff, err := time.ParseDuration(envBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EVENTS_RETRY_BACKOFF: %w", err)
+		}
+		cfg.Events.RetryBackoff = backoff
+	}
 
 	return &cfg, nil
 }
+
+// validatePublicURL checks that the public URL is an absolute http(s) URL
+// that resource paths can be appended to.
+func validatePublicURL(raw string) error {
+	if raw == "" {
+		return errors.New("must be set")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q is not an http or https URL", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("%q must not have user info, a query or a fragment", raw)
+	}
+	return nil
+}
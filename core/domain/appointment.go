@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+const NotificationTypeAppointmentReminder = "appointment-reminder"
+
+// AppointmentReminder is a reminder of an upcoming appointment, due Offset
+// before its start.
+type AppointmentReminder struct {
+	AppointmentID string
+	PatientID     string
+	Description   string
+	Start         time.Time
+	Offset        time.Duration
+}
+
+// Key identifies the reminder. It includes the start time so that a
+// rescheduled appointment is reminded of again.
+func (r AppointmentReminder) Key() string {
+	return r.AppointmentID + "|" + r.Start.UTC().Format(time.RFC3339) + "|" + r.Offset.String()
+}
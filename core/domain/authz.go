@@ -0,0 +1,68 @@
+package domain
+
+import "net/url"
+
+// Action is the interaction an authorization decision is about. The first five
+// mirror the SMART v2 cruds permissions.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionRead   Action = "read"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionSearch Action = "search"
+	// ActionShare discloses a resource to a third party. Only the patient that
+	// owns the compartment may share from it, and it needs read permission.
+	ActionShare Action = "share"
+)
+
+// Permission returns the SMART permission the action requires.
+func (a Action) Permission() Permission {
+	switch a {
+	case ActionCreate:
+		return PermissionCreate
+	case ActionRead, ActionShare:
+		return PermissionRead
+	case ActionUpdate:
+		return PermissionUpdate
+	case ActionDelete:
+		return PermissionDelete
+	case ActionSearch:
+		return PermissionSearch
+	}
+	return 0
+}
+
+// ResourceRef identifies what an authorization decision is about. PatientID is
+// the compartment the resource belongs to and is empty for resources that live
+// outside any patient compartment, such as Practitioner. OwnerID names the
+// practitioner that owns such a directory resource. SearchParams holds the
+// resource's values for search parameters scopes may be restricted by, such as
+// its Observation category.
+type ResourceRef struct {
+	Type         string
+	ID           string
+	PatientID    string
+	OwnerID      string
+	SearchParams url.Values
+}
+
+// Decision is the outcome of an authorization check. Err is the error a
+// service should return when the decision denies access. For searches,
+// Restrictions lists the alternative query restrictions of the granting
+// scopes; results must match one of them. It is nil when unrestricted.
+type Decision struct {
+	Allowed      bool
+	Reason       string
+	Err          error
+	Restrictions []url.Values
+}
+
+func Allow(reason string) Decision {
+	return Decision{Allowed: true, Reason: reason}
+}
+
+func Deny(err error, reason string) Decision {
+	return Decision{Allowed: false, Reason: reason, Err: err}
+}
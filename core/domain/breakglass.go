@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+const (
+	// ScopeBreakGlass lets a practitioner declare emergency access to a
+	// patient they have no care relationship with.
+	ScopeBreakGlass = "break-glass"
+	// ScopeBreakGlassReview lets privacy officers work the review queue.
+	ScopeBreakGlassReview = "break-glass:review"
+
+	BreakGlassMaxDuration = time.Hour
+)
+
+// BreakGlassGrant is a time-boxed emergency read access a practitioner
+// declared for a patient. Every grant stays in the review queue until a
+// reviewer signs it off.
+type BreakGlassGrant struct {
+	ID             string
+	PatientID      string
+	PractitionerID string
+	Reason         string
+	CreatedAt      int64
+	ExpiresAt      int64
+	ReviewedAt     int64
+	ReviewedBy     string
+	ReviewNote     string
+}
+
+func (g *BreakGlassGrant) Active(now int64) bool {
+	return now < g.ExpiresAt
+}
+
+func (g *BreakGlassGrant) Reviewed() bool {
+	return g.ReviewedAt != 0
+}
+
+type BreakGlassRequest struct {
+	PatientID string
+	Reason    string
+	Duration  time.Duration
+}
+
+type BreakGlassReview struct {
+	GrantID string
+	Note    string
+}
+
+const NotificationTypeBreakGlass = "break-glass"
+
+// Notification is a message to a patient about activity on their records.
+type Notification struct {
+	ID        string
+	PatientID string
+	Type      string
+	Message   string
+	Reference string
+	CreatedAt int64
+}
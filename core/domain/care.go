@@ -0,0 +1,35 @@
+package domain
+
+import "slices"
+
+type CareAccess string
+
+const (
+	CareAccessRead  CareAccess = "read"
+	CareAccessWrite CareAccess = "write"
+)
+
+// CareRelationship records that a practitioner treats a patient and which
+// kind of access the patient granted to their compartment.
+type CareRelationship struct {
+	ID             string
+	PatientID      string
+	PractitionerID string
+	Access         []CareAccess
+	CreatedAt      int64
+}
+
+// Allows reports whether the relationship grants the given action. Read access
+// covers reads and searches; write access covers every action.
+func (r *CareRelationship) Allows(action Action) bool {
+	if slices.Contains(r.Access, CareAccessWrite) {
+		return true
+	}
+	return slices.Contains(r.Access, CareAccessRead) && (action == ActionRead || action == ActionSearch)
+}
+
+type GrantCareRequest struct {
+	PatientID      string
+	PractitionerID string
+	Access         []CareAccess
+}
@@ -4,12 +4,26 @@ import (
 	models "github.com/gruzdev-dev/fhir/r5"
 )
 
+// ListResponse is a page of search results. Total counts every match, the
+// withheld ones included, so a page with withheld matches holds fewer items
+// than were asked for while Total and the page offsets stay consistent
+// across pages.
 type ListResponse[T any] struct {
 	Items []T
 	Total int64
+	// Withheld lists matches left out because a patient consent denies
+	// releasing them.
+	Withheld []ConsentDenial
 }
 
 type CreateDocumentResult struct {
 	Document   *models.DocumentReference
 	UploadUrls map[string]string
 }
+
+// TypedResource is a resource of any type together with its type and ID.
+type TypedResource struct {
+	Type     string
+	ID       string
+	Resource any
+}
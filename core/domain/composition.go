@@ -0,0 +1,12 @@
+package domain
+
+import (
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// GeneratedDocument is a FHIR Document assembled by Composition/$document.
+// DocumentReference is set when the document was persisted.
+type GeneratedDocument struct {
+	Bundle            *models.Bundle
+	DocumentReference *models.DocumentReference
+}
@@ -0,0 +1,31 @@
+package domain
+
+const (
+	ConsentActionSystem   = "http://terminology.hl7.org/CodeSystem/consentaction"
+	ConsentActionAccess   = "access"
+	ConsentActionDisclose = "disclose"
+
+	PurposeOfUseSystem        = "http://terminology.hl7.org/CodeSystem/v3-ActReason"
+	PurposeTreatment          = "TREAT"
+	PurposeEmergencyTreatment = "ETREAT"
+	PurposePatientRequest     = "PATRQT"
+	PurposeOperations         = "HOPERAT"
+	PurposeResearch           = "HRESCH"
+	PurposeClinicalResearch   = "CLINTRCH"
+)
+
+// ConsentRequest describes a release of patient data that consents are
+// evaluated against.
+type ConsentRequest struct {
+	// Actor is the reference of the requester, e.g. "Practitioner/123". It is
+	// empty when the recipient is unknown, as for shares.
+	Actor   string
+	Action  string
+	Purpose string
+}
+
+// ConsentDenial names a resource withheld from a request and why.
+type ConsentDenial struct {
+	Reference string
+	Reason    string
+}
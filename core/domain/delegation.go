@@ -0,0 +1,32 @@
+package domain
+
+import "slices"
+
+// Delegation lets another user, described by a RelatedPerson in the patient's
+// compartment, act on that compartment. Parents managing their children's
+// records and adult children caring for their parents are the typical cases.
+// Delegations use the same access levels as care relationships.
+type Delegation struct {
+	ID              string
+	PatientID       string
+	UserID          string
+	RelatedPersonID string
+	Access          []CareAccess
+	CreatedAt       int64
+}
+
+// Allows reports whether the delegation grants the given action. Read access
+// covers reads and searches; write access covers every action.
+func (d *Delegation) Allows(action Action) bool {
+	if slices.Contains(d.Access, CareAccessWrite) {
+		return true
+	}
+	return slices.Contains(d.Access, CareAccessRead) && (action == ActionRead || action == ActionSearch)
+}
+
+type GrantDelegationRequest struct {
+	PatientID       string
+	UserID          string
+	RelatedPersonID string
+	Access          []CareAccess
+}
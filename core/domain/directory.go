@@ -0,0 +1,7 @@
+package domain
+
+// DirectoryOwnerSystem is the meta.tag system that makes an Organization or
+// Location a private directory entry of one patient; the tag code is the
+// patient ID. Entries without the tag are shared with all patients and are
+// curated by administrators.
+const DirectoryOwnerSystem = "https://codex.gruzdev.dev/fhir/CodeSystem/directory-owner"
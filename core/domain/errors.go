@@ -14,10 +14,92 @@ var (
 	ErrInvalidDerivedFromRef  = errors.New("derivedFrom must reference DocumentReference resources")
 	ErrDerivedFromDocNotFound = errors.New("referenced document not found")
 
+	ErrConditionNotFound   = errors.New("condition not found")
+	ErrConditionIDRequired = errors.New("condition id is required")
+	ErrInvalidEvidenceRef  = errors.New("evidence must reference DocumentReference or Observation resources")
+	ErrEvidenceNotFound    = errors.New("referenced evidence not found")
+
+	ErrMedicationStatementNotFound   = errors.New("medication statement not found")
+	ErrMedicationStatementIDRequired = errors.New("medication statement id is required")
+	ErrMedicationRequestNotFound     = errors.New("medication request not found")
+	ErrMedicationRequestIDRequired   = errors.New("medication request id is required")
+
+	ErrAllergyIntoleranceNotFound   = errors.New("allergy intolerance not found")
+	ErrAllergyIntoleranceIDRequired = errors.New("allergy intolerance id is required")
+	ErrImmunizationNotFound         = errors.New("immunization not found")
+	ErrImmunizationIDRequired       = errors.New("immunization id is required")
+
+	ErrDiagnosticReportNotFound   = errors.New("diagnostic report not found")
+	ErrDiagnosticReportIDRequired = errors.New("diagnostic report id is required")
+	ErrInvalidResultRef           = errors.New("result must reference Observation resources")
+	ErrResultNotFound             = errors.New("referenced result not found")
+
+	ErrProcedureNotFound             = errors.New("procedure not found")
+	ErrProcedureIDRequired           = errors.New("procedure id is required")
+	ErrFamilyMemberHistoryNotFound   = errors.New("family member history not found")
+	ErrFamilyMemberHistoryIDRequired = errors.New("family member history id is required")
+
+	ErrAppointmentNotFound   = errors.New("appointment not found")
+	ErrAppointmentIDRequired = errors.New("appointment id is required")
+
+	ErrEncounterNotFound    = errors.New("encounter not found")
+	ErrEncounterIDRequired  = errors.New("encounter id is required")
+	ErrInvalidEncounterRef  = errors.New("encounter must reference an Encounter resource")
+	ErrEncounterRefNotFound = errors.New("referenced encounter not found")
+
+	ErrCompositionNotFound   = errors.New("composition not found")
+	ErrCompositionIDRequired = errors.New("composition id is required")
+	ErrInvalidSectionRef     = errors.New("section entries must reference Observation, Condition or DocumentReference resources")
+	ErrSectionEntryNotFound  = errors.New("referenced section entry not found")
+
+	ErrGoalNotFound       = errors.New("goal not found")
+	ErrGoalIDRequired     = errors.New("goal id is required")
+	ErrCarePlanNotFound   = errors.New("care plan not found")
+	ErrCarePlanIDRequired = errors.New("care plan id is required")
+	ErrInvalidPlanRef     = errors.New("goals must reference Goal resources and addressed issues Condition resources")
+	ErrPlanRefNotFound    = errors.New("referenced goal or condition not found")
+
+	ErrQuestionnaireNotFound           = errors.New("questionnaire not found")
+	ErrQuestionnaireIDRequired         = errors.New("questionnaire id is required")
+	ErrQuestionnaireResponseNotFound   = errors.New("questionnaire response not found")
+	ErrQuestionnaireResponseIDRequired = errors.New("questionnaire response id is required")
+	ErrQuestionnaireRefNotFound        = errors.New("answered questionnaire not found")
+	ErrInvalidAnswers                  = errors.New("answers do not match the questionnaire")
+
+	ErrSubscriptionTopicNotFound   = errors.New("subscription topic not found")
+	ErrSubscriptionTopicIDRequired = errors.New("subscription topic id is required")
+	ErrSubscriptionNotFound        = errors.New("subscription not found")
+	ErrSubscriptionIDRequired      = errors.New("subscription id is required")
+	ErrTopicRefNotFound            = errors.New("subscribed topic not found or not active")
+
+	ErrOrganizationNotFound   = errors.New("organization not found")
+	ErrOrganizationIDRequired = errors.New("organization id is required")
+	ErrLocationNotFound       = errors.New("location not found")
+	ErrLocationIDRequired     = errors.New("location id is required")
+	ErrDirectoryRefNotFound   = errors.New("referenced organization or location not found")
+
+	ErrPractitionerNotFound     = errors.New("practitioner not found")
+	ErrPractitionerIDRequired   = errors.New("practitioner id is required")
+	ErrPractitionerRoleNotFound = errors.New("practitioner role not found")
+	ErrCareRelationshipNotFound = errors.New("care relationship not found")
+
+	ErrRelatedPersonNotFound = errors.New("related person not found")
+	ErrDelegationNotFound    = errors.New("delegation not found")
+	ErrUserIDRequired        = errors.New("user id is required")
+
 	ErrAccessDenied       = errors.New("access denied: identity mismatch or insufficient scopes")
 	ErrTmpTokenForbidden  = errors.New("temporary token cannot perform this operation")
 	ErrInvalidInput       = errors.New("invalid input data")
 	ErrInternal           = errors.New("internal server error")
 	ErrResourceNotOwned   = errors.New("one or more resources do not belong to the user")
 	ErrNoResourcesToShare = errors.New("no resources provided to share")
+
+	ErrConsentNotFound = errors.New("consent not found")
+	ErrConsentDenied   = errors.New("withheld by patient consent")
+
+	ErrBreakGlassNotFound       = errors.New("break-glass grant not found")
+	ErrBreakGlassReasonRequired = errors.New("break-glass reason is required")
+
+	ErrSHLNotFound        = errors.New("smart health link not found or no longer active")
+	ErrSHLPasscodeInvalid = errors.New("invalid smart health link passcode")
 )
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType is the kind of change a domain event announces.
+type EventType string
+
+const (
+	EventResourceCreated EventType = "ResourceCreated"
+	EventResourceUpdated EventType = "ResourceUpdated"
+	EventResourceDeleted EventType = "ResourceDeleted"
+	EventResourceShared  EventType = "ResourceShared"
+)
+
+// DomainEvent announces a change to other services. It is written to the
+// outbox together with the change and published from there at least once,
+// so consumers should skip IDs they have seen. Sequence numbers the events of
+// a resource from 1 in the order they are published. The events of a write
+// carry the resource as Data, those of a delete its last state.
+type DomainEvent struct {
+	ID           string          `json:"id"`
+	Type         EventType       `json:"type"`
+	ResourceType string          `json:"resourceType"`
+	ResourceID   string          `json:"resourceId"`
+	PatientID    string          `json:"patientId,omitempty"`
+	Sequence     int64           `json:"sequence"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+	Data         json.RawMessage `json:"data,omitempty"`
+
+	// Attempts counts the failed attempts to publish the event, which is
+	// not retried before NextAttemptAt. Handled tells that the service's own
+	// EventHandler is done with it, so a retry only publishes it.
+	Attempts      int       `json:"-"`
+	NextAttemptAt time.Time `json:"-"`
+	Handled       bool      `json:"-"`
+}
+
+// Key returns the relative reference of the resource the event is about,
+// which its events are ordered by.
+func (e DomainEvent) Key() string {
+	return e.ResourceType + "/" + e.ResourceID
+}
+
+// ShareEventData is the data of a ResourceShared event.
+type ShareEventData struct {
+	// Via is "token" for resources shared through a temporary access token
+	// and "smart-health-link" for those shared through a SMART Health Link.
+	Via       string `json:"via"`
+	LinkID    string `json:"linkId,omitempty"`
+	ExpiresAt int64  `json:"expiresAt,omitempty"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// PatientEverything is the patient record returned by Patient/$everything.
+// Resources are paged per resource type.
+type PatientEverything struct {
+	Patient   *models.Patient
+	Resources []TypedResource
+	Total     int64
+	Withheld  []ConsentDenial
+}
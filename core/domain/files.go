@@ -1,5 +1,7 @@
 package domain
 
+import "errors"
+
 type GetPresignedUrlsRequest struct {
 	UserId      string
 	ContentType string
@@ -11,3 +13,7 @@ type PresignedUrlsResponse struct {
 	UploadUrl   string
 	DownloadUrl string
 }
+
+// ErrDownloadUrlUnsupported is returned by a FileProvider that cannot sign
+// download URLs of stored files.
+var ErrDownloadUrlUnsupported = errors.New("file provider cannot sign download URLs")
@@ -0,0 +1,23 @@
+package domain
+
+import models "github.com/gruzdev-dev/fhir/r5"
+
+// GoalAchievementSystem is the code system of goal achievement statuses.
+const GoalAchievementSystem = "http://terminology.hl7.org/CodeSystem/goal-achievement"
+
+// GoalProgress is the evaluation of a goal against the observations of its
+// subject. AchievementStatus is a code of GoalAchievementSystem.
+type GoalProgress struct {
+	Goal              *models.Goal
+	AchievementStatus string
+	Targets           []TargetProgress
+}
+
+// TargetProgress is the evaluation of one measurable goal target. Latest is
+// the most recent matching observation and is nil when none was found.
+type TargetProgress struct {
+	Measure           *models.CodeableConcept
+	AchievementStatus string
+	Latest            *models.Observation
+	Observations      int
+}
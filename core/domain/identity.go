@@ -6,22 +6,73 @@ import (
 )
 
 type Identity struct {
-	UserID    string
-	PatientID string
-	Scopes    []string
+	UserID         string
+	PatientID      string
+	PractitionerID string
+	// ActivePatientID is the compartment a caregiver selected for this
+	// request. It is empty when the user acts on their own records.
+	ActivePatientID string
+	// PurposeOfUse is the v3-ActReason code the client declared for the
+	// request, e.g. HRESCH for research. Empty means the default for the
+	// kind of user.
+	PurposeOfUse string
+	// SystemClient is set for tokens a backend client obtained through the
+	// client-credentials grant. Only such clients may use system/ scopes.
+	SystemClient bool
+	Scopes       []string
 }
 
-func (i *Identity) HasScope(scope string) bool {
-	if i.Scopes == nil {
-		return false
+// Principal names who an identity acts as, without what the token it came
+// with grants. It is what is kept of an identity beyond the request, so
+// access is decided by the grants in force when it is used.
+type Principal struct {
+	UserID         string
+	PatientID      string
+	PractitionerID string
+}
+
+func (i *Identity) Principal() Principal {
+	return Principal{UserID: i.UserID, PatientID: i.PatientID, PractitionerID: i.PractitionerID}
+}
+
+// Identity returns the principal as an identity without scopes.
+func (p Principal) Identity() Identity {
+	return Identity{UserID: p.UserID, PatientID: p.PatientID, PractitionerID: p.PractitionerID}
+}
+
+// CompartmentID returns the patient compartment the request acts on: the
+// selected active patient, or the user's own record.
+func (i *Identity) CompartmentID() string {
+	if i.ActivePatientID != "" {
+		return i.ActivePatientID
 	}
-	return slices.Contains(i.Scopes, scope)
+	return i.PatientID
+}
+
+// GrantingScopes returns the identity's SMART clinical scopes in any of the
+// given contexts that grant perm on resourceType.
+func (i *Identity) GrantingScopes(resourceType string, perm Permission, contexts ...ScopeContext) []Scope {
+	var granting []Scope
+	for _, raw := range i.Scopes {
+		scope, ok := ParseScope(raw)
+		if !ok || !slices.Contains(contexts, scope.Context) {
+			continue
+		}
+		if scope.Covers(resourceType, perm) {
+			granting = append(granting, scope)
+		}
+	}
+	return granting
 }
 
 func (i *Identity) IsPatient(id string) bool {
 	return i.PatientID == id
 }
 
+func (i *Identity) IsPractitioner() bool {
+	return i.PractitionerID != ""
+}
+
 func (i *Identity) HasResourceScope(service, resource, id, action string) bool {
 	if i.Scopes == nil {
 		return false
@@ -31,5 +82,5 @@ func (i *Identity) HasResourceScope(service, resource, id, action string) bool {
 }
 
 func (i *Identity) IsTmpToken() bool {
-	return i.UserID == "" && i.PatientID == ""
+	return i.UserID == "" && i.PatientID == "" && i.PractitionerID == ""
 }
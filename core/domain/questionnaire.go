@@ -0,0 +1,7 @@
+package domain
+
+// ObservationExtractSystem marks Questionnaire items whose answers $extract
+// turns into Observations. The model carries no extensions, so a coding of
+// this system among the item codes stands in for the SDC observationExtract
+// extension; the other codings of the item become the Observation code.
+const ObservationExtractSystem = "https://codex.gruzdev.dev/fhir/CodeSystem/observation-extract"
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+type ScopeContext string
+
+const (
+	ScopeContextPatient ScopeContext = "patient"
+	ScopeContextUser    ScopeContext = "user"
+	ScopeContextSystem  ScopeContext = "system"
+)
+
+// Permission is a set of SMART v2 interaction flags (c, r, u, d, s).
+type Permission uint8
+
+const (
+	PermissionCreate Permission = 1 << iota
+	PermissionRead
+	PermissionUpdate
+	PermissionDelete
+	PermissionSearch
+)
+
+const permissionAll = PermissionCreate | PermissionRead | PermissionUpdate | PermissionDelete | PermissionSearch
+
+var permissionLetters = []struct {
+	letter byte
+	perm   Permission
+}{
+	{'c', PermissionCreate},
+	{'r', PermissionRead},
+	{'u', PermissionUpdate},
+	{'d', PermissionDelete},
+	{'s', PermissionSearch},
+}
+
+// Scope is a parsed SMART on FHIR clinical scope such as
+// "patient/Observation.rs?category=laboratory".
+type Scope struct {
+	Context      ScopeContext
+	ResourceType string
+	Permissions  Permission
+	Params       url.Values
+}
+
+// ParseScope parses a SMART v2 clinical scope. SMART v1 "read", "write" and
+// "*" suffixes are accepted and mapped to "rs", "cud" and "cruds".
+func ParseScope(raw string) (Scope, bool) {
+	rest, query, _ := strings.Cut(raw, "?")
+
+	context, rest, ok := strings.Cut(rest, "/")
+	if !ok {
+		return Scope{}, false
+	}
+	switch ScopeContext(context) {
+	case ScopeContextPatient, ScopeContextUser, ScopeContextSystem:
+	default:
+		return Scope{}, false
+	}
+
+	resourceType, suffix, ok := strings.Cut(rest, ".")
+	if !ok || resourceType == "" {
+		return Scope{}, false
+	}
+
+	perms, ok := parsePermissions(suffix)
+	if !ok {
+		return Scope{}, false
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return Scope{}, false
+	}
+	if len(params) == 0 {
+		params = nil
+	}
+
+	return Scope{
+		Context:      ScopeContext(context),
+		ResourceType: resourceType,
+		Permissions:  perms,
+		Params:       params,
+	}, true
+}
+
+func parsePermissions(suffix string) (Permission, bool) {
+	switch suffix {
+	case "read":
+		return PermissionRead | PermissionSearch, true
+	case "write":
+		return PermissionCreate | PermissionUpdate | PermissionDelete, true
+	case "*":
+		return permissionAll, true
+	case "":
+		return 0, false
+	}
+
+	// v2 permissions must appear at most once and in cruds order.
+	var perms Permission
+	next := 0
+	for i := 0; i < len(suffix); i++ {
+		found := false
+		for next < len(permissionLetters) {
+			l := permissionLetters[next]
+			next++
+			if l.letter == suffix[i] {
+				perms |= l.perm
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return perms, true
+}
+
+// Covers reports whether the scope grants perm on resourceType.
+func (s Scope) Covers(resourceType string, perm Permission) bool {
+	if s.ResourceType != "*" && s.ResourceType != resourceType {
+		return false
+	}
+	return s.Permissions&perm == perm
+}
+
+// Matches reports whether a resource with the given search parameter values
+// satisfies the scope's query restrictions. Every restricted parameter must
+// match one of its comma-separated values.
+func (s Scope) Matches(values url.Values) bool {
+	for name, wanted := range s.Params {
+		have := values[name]
+		if !anyTokenMatches(wanted, have) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyTokenMatches(wanted, have []string) bool {
+	for _, w := range wanted {
+		for _, alt := range strings.Split(w, ",") {
+			for _, h := range have {
+				if alt == h {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// NamesSecurityLabel reports whether the scope's _security restriction names
+// the label, either by code or as "system|code".
+func (s Scope) NamesSecurityLabel(system, code string) bool {
+	have := []string{code}
+	if system != "" {
+		have = append(have, system+"|"+code)
+	}
+	return anyTokenMatches(s.Params["_security"], have)
+}
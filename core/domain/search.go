@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateFilter is a parsed FHIR date search value. The value covers the
+// half-open range [Start, End) at the precision it was given in, formatted so
+// that ISO 8601 dates and date-times compare correctly as strings.
+type DateFilter struct {
+	Prefix string
+	Start  string
+	End    string
+}
+
+var datePrefixes = []string{"eq", "ne", "gt", "lt", "ge", "le"}
+
+var datePrecisions = []struct {
+	layout string
+	next   func(time.Time) time.Time
+}{
+	{time.RFC3339, func(t time.Time) time.Time { return t.Add(time.Second) }},
+	{"2006-01-02T15:04:05", func(t time.Time) time.Time { return t.Add(time.Second) }},
+	{"2006-01-02", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+	{"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+	{"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+}
+
+// ParseDateFilter parses a date search value such as "ge2020-01" or
+// "2021-06-15". The prefix defaults to eq.
+func ParseDateFilter(value string) (DateFilter, error) {
+	prefix := "eq"
+	for _, p := range datePrefixes {
+		if strings.HasPrefix(value, p) {
+			prefix, value = p, strings.TrimPrefix(value, p)
+			break
+		}
+	}
+
+	for _, precision := range datePrecisions {
+		t, err := time.Parse(precision.layout, value)
+		if err != nil {
+			continue
+		}
+		return DateFilter{
+			Prefix: prefix,
+			Start:  value,
+			End:    precision.next(t).Format(precision.layout),
+		}, nil
+	}
+	return DateFilter{}, fmt.Errorf("invalid date %q", value)
+}
+
+// ConditionSearch holds the search parameters of a Condition search. Token
+// parameters take comma-separated "code" or "system|code" values and are
+// ignored when empty.
+type ConditionSearch struct {
+	PatientID      string
+	ClinicalStatus string
+	Code           string
+	Category       string
+	OnsetDate      []DateFilter
+}
+
+// MedicationSearch holds the search parameters of a MedicationStatement or
+// MedicationRequest search. Status takes comma-separated codes and Code
+// comma-separated "code" or "system|code" values; both are ignored when
+// empty. Effective matches when the medication is taken, or for requests when
+// it is to be taken or was authored.
+type MedicationSearch struct {
+	PatientID string
+	Status    string
+	Code      string
+	Effective []DateFilter
+}
+
+// AllergySearch holds the search parameters of an AllergyIntolerance search.
+// Token parameters take comma-separated "code" or "system|code" values,
+// Category and Criticality comma-separated codes; all are ignored when empty.
+type AllergySearch struct {
+	PatientID      string
+	ClinicalStatus string
+	Code           string
+	Category       string
+	Criticality    string
+}
+
+// ImmunizationSearch holds the search parameters of an Immunization search.
+// Status takes comma-separated codes and VaccineCode comma-separated "code"
+// or "system|code" values; both are ignored when empty.
+type ImmunizationSearch struct {
+	PatientID   string
+	Status      string
+	VaccineCode string
+	Date        []DateFilter
+}
+
+// AppointmentSearch holds the search parameters of an Appointment search.
+// Status takes comma-separated codes and is ignored when empty. Date matches
+// appointments starting in the range.
+type AppointmentSearch struct {
+	PatientID string
+	Status    string
+	Date      []DateFilter
+}
+
+// ProcedureSearch holds the search parameters of a Procedure search. Status
+// takes comma-separated codes and Code comma-separated "code" or
+// "system|code" values; both are ignored when empty. Date matches procedures
+// performed in the range.
+type ProcedureSearch struct {
+	PatientID string
+	Status    string
+	Code      string
+	Date      []DateFilter
+}
+
+// FamilyMemberHistorySearch holds the search parameters of a
+// FamilyMemberHistory search. Status takes comma-separated codes and
+// Relationship comma-separated "code" or "system|code" values; both are
+// ignored when empty.
+type FamilyMemberHistorySearch struct {
+	PatientID    string
+	Status       string
+	Relationship string
+}
+
+// DiagnosticReportSearch holds the search parameters of a DiagnosticReport
+// search. Status takes comma-separated codes, Code and Category
+// comma-separated "code" or "system|code" values; all are ignored when empty.
+type DiagnosticReportSearch struct {
+	PatientID string
+	Status    string
+	Code      string
+	Category  string
+	Date      []DateFilter
+}
+
+// EncounterSearch holds the search parameters of an Encounter search. Status
+// takes comma-separated codes, Class and ReasonCode comma-separated "code" or
+// "system|code" values; all are ignored when empty. Date matches encounters
+// that started in the range.
+type EncounterSearch struct {
+	PatientID  string
+	Status     string
+	Class      string
+	ReasonCode string
+	Date       []DateFilter
+}
+
+// DocumentSearch holds the search parameters of a DocumentReference search.
+// Encounter takes an encounter id or "Encounter/<id>" reference and is
+// ignored when empty.
+type DocumentSearch struct {
+	PatientID string
+	Encounter string
+}
+
+// ObservationSearch holds the search parameters of an Observation search.
+// Encounter takes an encounter id or "Encounter/<id>" reference and Code
+// comma-separated "code" or "system|code" values, and Identifier a "value"
+// or "system|value" identifier; all are ignored when empty. Date matches the
+// effective time of observations. Newest orders the results by effective time
+// and then last update, latest first; otherwise they come in storage order.
+type ObservationSearch struct {
+	PatientID  string
+	Encounter  string
+	Code       string
+	Identifier string
+	Date       []DateFilter
+	Newest     bool
+}
+
+// DirectorySearch holds the search parameters of an Organization or Location
+// search. Name and Address match case-insensitively from the start of the
+// name, an alias or any address part and are ignored when empty. PatientID
+// names the compartment whose private entries are searched along with the
+// shared ones; when empty only shared entries are searched.
+type DirectorySearch struct {
+	PatientID string
+	Name      string
+	Address   string
+}
+
+// CompositionSearch holds the search parameters of a Composition search.
+// Status takes comma-separated codes and Type comma-separated "code" or
+// "system|code" values; both are ignored when empty.
+type CompositionSearch struct {
+	PatientID string
+	Status    string
+	Type      string
+	Date      []DateFilter
+}
+
+// QuestionnaireSearch holds the search parameters of a Questionnaire search.
+// Title matches titles starting with it, case-insensitively; all parameters
+// are ignored when empty.
+type QuestionnaireSearch struct {
+	Status string
+	URL    string
+	Title  string
+}
+
+// QuestionnaireResponseSearch holds the search parameters of a
+// QuestionnaireResponse search. Questionnaire is the canonical URL or
+// "Questionnaire/{id}" reference the responses answer.
+type QuestionnaireResponseSearch struct {
+	PatientID     string
+	Status        string
+	Questionnaire string
+	Authored      []DateFilter
+}
+
+// GoalSearch holds the search parameters of a Goal search. LifecycleStatus
+// takes comma-separated codes and Category comma-separated "code" or
+// "system|code" values; both are ignored when empty.
+type GoalSearch struct {
+	PatientID       string
+	LifecycleStatus string
+	Category        string
+}
+
+// CarePlanSearch holds the search parameters of a CarePlan search. Status
+// takes comma-separated codes and Category comma-separated "code" or
+// "system|code" values; both are ignored when empty. Date matches plans
+// whose period starts in the range.
+type CarePlanSearch struct {
+	PatientID string
+	Status    string
+	Category  string
+	Date      []DateFilter
+}
+
+// SubscriptionTopicSearch holds the search parameters of a SubscriptionTopic
+// search. Resource matches topics triggered by that resource type; all
+// parameters are ignored when empty.
+type SubscriptionTopicSearch struct {
+	Status   string
+	URL      string
+	Resource string
+}
+
+// SubscriptionSearch holds the search parameters of a Subscription search.
+// OwnerID limits the results to the subscriptions a user created; Topic is
+// the canonical URL of the subscribed topic.
+type SubscriptionSearch struct {
+	OwnerID string
+	Status  string
+	Topic   string
+}
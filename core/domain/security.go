@@ -0,0 +1,69 @@
+package domain
+
+import "strings"
+
+const (
+	ConfidentialitySystem         = "http://terminology.hl7.org/CodeSystem/v3-Confidentiality"
+	ConfidentialityRestricted     = "R"
+	ConfidentialityVeryRestricted = "V"
+
+	ActCodeSystem  = "http://terminology.hl7.org/CodeSystem/v3-ActCode"
+	SensitivityHIV = "HIV"
+	SensitivityPSY = "PSY"
+	SensitivitySUD = "SUD"
+	SensitivityETH = "ETH"
+)
+
+// restrictedSecurityLabels are the confidentiality and sensitivity labels that
+// hide a resource from everyone but the patient unless a scope or consent
+// releases them explicitly.
+var restrictedSecurityLabels = map[string][]string{
+	ConfidentialitySystem: {ConfidentialityRestricted, ConfidentialityVeryRestricted},
+	ActCodeSystem:         {SensitivityHIV, SensitivityPSY, SensitivitySUD, SensitivityETH},
+}
+
+// IsRestrictedSecurityLabel reports whether a security label restricts access.
+// A label without a system is treated as restricted when its code is one of
+// the restricted codes of any system.
+func IsRestrictedSecurityLabel(system, code string) bool {
+	for labelSystem, codes := range restrictedSecurityLabels {
+		if system != "" && system != labelSystem {
+			continue
+		}
+		for _, c := range codes {
+			if c == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RestrictedSecurityLabels picks the restricted labels out of the "code" and
+// "system|code" token values of a resource's security labels. Each label is
+// returned once, as "system|code" when its system is known.
+func RestrictedSecurityLabels(tokens []string) []string {
+	var restricted []string
+	for _, token := range tokens {
+		system, code, hasSystem := strings.Cut(token, "|")
+		if !hasSystem {
+			code, system = token, ""
+			if hasSystemToken(tokens, code) {
+				continue
+			}
+		}
+		if IsRestrictedSecurityLabel(system, code) {
+			restricted = append(restricted, token)
+		}
+	}
+	return restricted
+}
+
+func hasSystemToken(tokens []string, code string) bool {
+	for _, token := range tokens {
+		if _, c, ok := strings.Cut(token, "|"); ok && c == code {
+			return true
+		}
+	}
+	return false
+}
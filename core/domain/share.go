@@ -1,5 +1,9 @@
 package domain
 
+import (
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
 type ShareRequest struct {
 	ResourceIDs []string
 	TTLSeconds  int64
@@ -8,9 +12,67 @@ type ShareRequest struct {
 type ShareResponse struct {
 	Token       string
 	ResourceURL string
+	Withheld    []ConsentDenial
 }
 
 type SharedResourcesResponse struct {
 	Observations       []string
 	DocumentReferences []string
+	Resources          []string
+}
+
+type SharedBundleRequest struct {
+	Types  []string
+	Limit  int
+	Offset int
+}
+
+// SharedBundle is a page of the resources of a share. Like the Total of a
+// ListResponse, Total counts the withheld resources as well.
+type SharedBundle struct {
+	Observations       []models.Observation
+	DocumentReferences []models.DocumentReference
+	Resources          []TypedResource
+	Total              int64
+	Withheld           []ConsentDenial
+}
+
+type SHLRequest struct {
+	ResourceIDs []string
+	TTLSeconds  int64
+	Passcode    string
+	Label       string
+}
+
+type SHLResponse struct {
+	ID        string
+	Link      string
+	ExpiresAt int64
+	Withheld  []ConsentDenial
+}
+
+type SHLManifestRequest struct {
+	Recipient string
+	Passcode  string
+}
+
+type SHLManifest struct {
+	Files []SHLManifestFile
+}
+
+type SHLManifestFile struct {
+	ContentType string
+	Embedded    string
+}
+
+type SmartHealthLink struct {
+	ID                string
+	PatientID         string
+	Label             string
+	Files             []SHLManifestFile
+	PasscodeHash      []byte
+	PasscodeSalt      []byte
+	RemainingAttempts int
+	ExpiresAt         int64
+	CreatedAt         int64
 }
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// Interaction is the kind of write that raised a resource event.
+type Interaction string
+
+const (
+	InteractionCreate Interaction = "create"
+	InteractionUpdate Interaction = "update"
+	InteractionDelete Interaction = "delete"
+)
+
+// ResourceEvent describes a resource that has been written. Resource holds
+// its stored JSON, or its last state before a delete.
+type ResourceEvent struct {
+	Interaction Interaction
+	Ref         ResourceRef
+	Status      string
+	Resource    json.RawMessage
+	OccurredAt  time.Time
+}
+
+// Focus returns the relative reference of the resource the event is about.
+func (e ResourceEvent) Focus() string {
+	return e.Ref.Type + "/" + e.Ref.ID
+}
+
+const structureDefinitionBase = "http://hl7.org/fhir/StructureDefinition/"
+
+// TopicResourceType returns the resource type a topic trigger or filter names,
+// either plainly or by the canonical URL of its structure definition.
+func TopicResourceType(resource string) string {
+	return strings.TrimPrefix(resource, structureDefinitionBase)
+}
+
+const (
+	SubscriptionChannelSystem   = "http://terminology.hl7.org/CodeSystem/subscription-channel-type"
+	SubscriptionChannelRestHook = "rest-hook"
+
+	SubscriptionStatusRequested = "requested"
+	SubscriptionStatusActive    = "active"
+	SubscriptionStatusError     = "error"
+	SubscriptionStatusOff       = "off"
+
+	SubscriptionContentEmpty  = "empty"
+	SubscriptionContentIDOnly = "id-only"
+	SubscriptionContentFull   = "full-resource"
+
+	SubscriptionNotificationHandshake = "handshake"
+	SubscriptionNotificationHeartbeat = "heartbeat"
+	SubscriptionNotificationEvent     = "event-notification"
+
+	// SubscriptionMaxTimeout is the longest timeout, in seconds, a
+	// subscription may give its endpoint to answer a notification.
+	SubscriptionMaxTimeout = 20
+)
+
+// SubscriptionRecord is a stored subscription together with the principal
+// that created it, whose current access decides which events it is notified
+// of, and its delivery state.
+type SubscriptionRecord struct {
+	Subscription models.Subscription
+	Owner        Principal
+	// EventCount is the number of events raised since the subscription
+	// started; each event notification carries its number.
+	EventCount int64
+	// LastSentAt is the Unix time of the last notification delivered, from
+	// which the next heartbeat is due.
+	LastSentAt int64
+}
+
+// SubscriptionNotification is a notification waiting to be delivered to a
+// subscription endpoint.
+type SubscriptionNotification struct {
+	ID             string
+	SubscriptionID string
+	Type           string
+	EventNumber    int64
+	Event          *ResourceEvent
+	Attempts       int
+	NextAttemptAt  int64
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=allergy_intolerance.go -destination=allergy_intolerance_mocks.go -package=ports AllergyIntoleranceRepository,AllergyIntoleranceService
+
+type AllergyIntoleranceRepository interface {
+	Create(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error)
+	GetByID(ctx context.Context, id string) (*models.AllergyIntolerance, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.AllergyIntolerance, error)
+	Update(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.AllergySearch, restrictions []url.Values, limit, offset int) ([]models.AllergyIntolerance, int64, error)
+}
+
+type AllergyIntoleranceService interface {
+	Create(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error)
+	Get(ctx context.Context, id string) (*models.AllergyIntolerance, error)
+	Update(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.AllergySearch, limit, offset int) (*domain.ListResponse[models.AllergyIntolerance], error)
+}
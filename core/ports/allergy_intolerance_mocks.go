@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: allergy_intolerance.go
+//
+// Generated by this command:
+//
+//	mockgen -source=allergy_intolerance.go -destination=allergy_intolerance_mocks.go -package=ports AllergyIntoleranceRepository,AllergyIntoleranceService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAllergyIntoleranceRepository is a mock of AllergyIntoleranceRepository interface.
+type MockAllergyIntoleranceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAllergyIntoleranceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAllergyIntoleranceRepositoryMockRecorder is the mock recorder for MockAllergyIntoleranceRepository.
+type MockAllergyIntoleranceRepositoryMockRecorder struct {
+	mock *MockAllergyIntoleranceRepository
+}
+
+// NewMockAllergyIntoleranceRepository creates a new mock instance.
+func NewMockAllergyIntoleranceRepository(ctrl *gomock.Controller) *MockAllergyIntoleranceRepository {
+	mock := &MockAllergyIntoleranceRepository{ctrl: ctrl}
+	mock.recorder = &MockAllergyIntoleranceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAllergyIntoleranceRepository) EXPECT() *MockAllergyIntoleranceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAllergyIntoleranceRepository) Create(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ai)
+	ret0, _ := ret[0].(*models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAllergyIntoleranceRepositoryMockRecorder) Create(ctx, ai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAllergyIntoleranceRepository)(nil).Create), ctx, ai)
+}
+
+// Delete mocks base method.
+func (m *MockAllergyIntoleranceRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAllergyIntoleranceRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAllergyIntoleranceRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockAllergyIntoleranceRepository) GetByID(ctx context.Context, id string) (*models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAllergyIntoleranceRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAllergyIntoleranceRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockAllergyIntoleranceRepository) GetByIDs(ctx context.Context, ids []string) ([]models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockAllergyIntoleranceRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockAllergyIntoleranceRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockAllergyIntoleranceRepository) Search(ctx context.Context, search domain.AllergySearch, restrictions []url.Values, limit, offset int) ([]models.AllergyIntolerance, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.AllergyIntolerance)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAllergyIntoleranceRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAllergyIntoleranceRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockAllergyIntoleranceRepository) Update(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, ai)
+	ret0, _ := ret[0].(*models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAllergyIntoleranceRepositoryMockRecorder) Update(ctx, ai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAllergyIntoleranceRepository)(nil).Update), ctx, ai)
+}
+
+// MockAllergyIntoleranceService is a mock of AllergyIntoleranceService interface.
+type MockAllergyIntoleranceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAllergyIntoleranceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAllergyIntoleranceServiceMockRecorder is the mock recorder for MockAllergyIntoleranceService.
+type MockAllergyIntoleranceServiceMockRecorder struct {
+	mock *MockAllergyIntoleranceService
+}
+
+// NewMockAllergyIntoleranceService creates a new mock instance.
+func NewMockAllergyIntoleranceService(ctrl *gomock.Controller) *MockAllergyIntoleranceService {
+	mock := &MockAllergyIntoleranceService{ctrl: ctrl}
+	mock.recorder = &MockAllergyIntoleranceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAllergyIntoleranceService) EXPECT() *MockAllergyIntoleranceServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAllergyIntoleranceService) Create(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ai)
+	ret0, _ := ret[0].(*models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAllergyIntoleranceServiceMockRecorder) Create(ctx, ai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAllergyIntoleranceService)(nil).Create), ctx, ai)
+}
+
+// Delete mocks base method.
+func (m *MockAllergyIntoleranceService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAllergyIntoleranceServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAllergyIntoleranceService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockAllergyIntoleranceService) Get(ctx context.Context, id string) (*models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAllergyIntoleranceServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAllergyIntoleranceService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockAllergyIntoleranceService) List(ctx context.Context, search domain.AllergySearch, limit, offset int) (*domain.ListResponse[models.AllergyIntolerance], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.AllergyIntolerance])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAllergyIntoleranceServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAllergyIntoleranceService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockAllergyIntoleranceService) Update(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, ai)
+	ret0, _ := ret[0].(*models.AllergyIntolerance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAllergyIntoleranceServiceMockRecorder) Update(ctx, ai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAllergyIntoleranceService)(nil).Update), ctx, ai)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockAllergyIntoleranceService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockAllergyIntoleranceServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockAllergyIntoleranceService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
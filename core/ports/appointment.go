@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=appointment.go -destination=appointment_mocks.go -package=ports AppointmentRepository,AppointmentService,ReminderRepository,ReminderNotifier
+
+type AppointmentRepository interface {
+	Create(ctx context.Context, appt *models.Appointment) (*models.Appointment, error)
+	GetByID(ctx context.Context, id string) (*models.Appointment, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Appointment, error)
+	Update(ctx context.Context, appt *models.Appointment) (*models.Appointment, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.AppointmentSearch, restrictions []url.Values, limit, offset int) ([]models.Appointment, int64, error)
+	// ListBooked returns the booked appointments starting in (from, to].
+	ListBooked(ctx context.Context, from, to time.Time) ([]models.Appointment, error)
+}
+
+type AppointmentService interface {
+	Create(ctx context.Context, appt *models.Appointment) (*models.Appointment, error)
+	Get(ctx context.Context, id string) (*models.Appointment, error)
+	Update(ctx context.Context, appt *models.Appointment) (*models.Appointment, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.AppointmentSearch, limit, offset int) (*domain.ListResponse[models.Appointment], error)
+}
+
+// ReminderRepository records which appointment reminders have been sent so
+// that each is sent once, whichever replica schedules it.
+type ReminderRepository interface {
+	// Claim marks the reminder as sent and reports whether it was not
+	// already.
+	Claim(ctx context.Context, reminder domain.AppointmentReminder) (bool, error)
+	// Release undoes a claim whose reminder could not be delivered.
+	Release(ctx context.Context, reminder domain.AppointmentReminder) error
+}
+
+// ReminderNotifier delivers appointment reminders to the patient.
+type ReminderNotifier interface {
+	NotifyReminder(ctx context.Context, reminder domain.AppointmentReminder) error
+}
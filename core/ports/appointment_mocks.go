@@ -0,0 +1,354 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: appointment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=appointment.go -destination=appointment_mocks.go -package=ports AppointmentRepository,AppointmentService,ReminderRepository,ReminderNotifier
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAppointmentRepository is a mock of AppointmentRepository interface.
+type MockAppointmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppointmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAppointmentRepositoryMockRecorder is the mock recorder for MockAppointmentRepository.
+type MockAppointmentRepositoryMockRecorder struct {
+	mock *MockAppointmentRepository
+}
+
+// NewMockAppointmentRepository creates a new mock instance.
+func NewMockAppointmentRepository(ctrl *gomock.Controller) *MockAppointmentRepository {
+	mock := &MockAppointmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAppointmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAppointmentRepository) EXPECT() *MockAppointmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAppointmentRepository) Create(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, appt)
+	ret0, _ := ret[0].(*models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAppointmentRepositoryMockRecorder) Create(ctx, appt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAppointmentRepository)(nil).Create), ctx, appt)
+}
+
+// Delete mocks base method.
+func (m *MockAppointmentRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAppointmentRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAppointmentRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockAppointmentRepository) GetByID(ctx context.Context, id string) (*models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAppointmentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAppointmentRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockAppointmentRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockAppointmentRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockAppointmentRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// ListBooked mocks base method.
+func (m *MockAppointmentRepository) ListBooked(ctx context.Context, from, to time.Time) ([]models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooked", ctx, from, to)
+	ret0, _ := ret[0].([]models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBooked indicates an expected call of ListBooked.
+func (mr *MockAppointmentRepositoryMockRecorder) ListBooked(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooked", reflect.TypeOf((*MockAppointmentRepository)(nil).ListBooked), ctx, from, to)
+}
+
+// Search mocks base method.
+func (m *MockAppointmentRepository) Search(ctx context.Context, search domain.AppointmentSearch, restrictions []url.Values, limit, offset int) ([]models.Appointment, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Appointment)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAppointmentRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAppointmentRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockAppointmentRepository) Update(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, appt)
+	ret0, _ := ret[0].(*models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAppointmentRepositoryMockRecorder) Update(ctx, appt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAppointmentRepository)(nil).Update), ctx, appt)
+}
+
+// MockAppointmentService is a mock of AppointmentService interface.
+type MockAppointmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppointmentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAppointmentServiceMockRecorder is the mock recorder for MockAppointmentService.
+type MockAppointmentServiceMockRecorder struct {
+	mock *MockAppointmentService
+}
+
+// NewMockAppointmentService creates a new mock instance.
+func NewMockAppointmentService(ctrl *gomock.Controller) *MockAppointmentService {
+	mock := &MockAppointmentService{ctrl: ctrl}
+	mock.recorder = &MockAppointmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAppointmentService) EXPECT() *MockAppointmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAppointmentService) Create(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, appt)
+	ret0, _ := ret[0].(*models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAppointmentServiceMockRecorder) Create(ctx, appt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAppointmentService)(nil).Create), ctx, appt)
+}
+
+// Delete mocks base method.
+func (m *MockAppointmentService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAppointmentServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAppointmentService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockAppointmentService) Get(ctx context.Context, id string) (*models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAppointmentServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAppointmentService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockAppointmentService) List(ctx context.Context, search domain.AppointmentSearch, limit, offset int) (*domain.ListResponse[models.Appointment], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Appointment])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAppointmentServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAppointmentService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockAppointmentService) Update(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, appt)
+	ret0, _ := ret[0].(*models.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAppointmentServiceMockRecorder) Update(ctx, appt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAppointmentService)(nil).Update), ctx, appt)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockAppointmentService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockAppointmentServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockAppointmentService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
+
+// MockReminderRepository is a mock of ReminderRepository interface.
+type MockReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReminderRepositoryMockRecorder is the mock recorder for MockReminderRepository.
+type MockReminderRepositoryMockRecorder struct {
+	mock *MockReminderRepository
+}
+
+// NewMockReminderRepository creates a new mock instance.
+func NewMockReminderRepository(ctrl *gomock.Controller) *MockReminderRepository {
+	mock := &MockReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockReminderRepository) Claim(ctx context.Context, reminder domain.AppointmentReminder) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, reminder)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockReminderRepositoryMockRecorder) Claim(ctx, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockReminderRepository)(nil).Claim), ctx, reminder)
+}
+
+// Release mocks base method.
+func (m *MockReminderRepository) Release(ctx context.Context, reminder domain.AppointmentReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReminderRepositoryMockRecorder) Release(ctx, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReminderRepository)(nil).Release), ctx, reminder)
+}
+
+// MockReminderNotifier is a mock of ReminderNotifier interface.
+type MockReminderNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockReminderNotifierMockRecorder is the mock recorder for MockReminderNotifier.
+type MockReminderNotifierMockRecorder struct {
+	mock *MockReminderNotifier
+}
+
+// NewMockReminderNotifier creates a new mock instance.
+func NewMockReminderNotifier(ctrl *gomock.Controller) *MockReminderNotifier {
+	mock := &MockReminderNotifier{ctrl: ctrl}
+	mock.recorder = &MockReminderNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderNotifier) EXPECT() *MockReminderNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyReminder mocks base method.
+func (m *MockReminderNotifier) NotifyReminder(ctx context.Context, reminder domain.AppointmentReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyReminder indicates an expected call of NotifyReminder.
+func (mr *MockReminderNotifierMockRecorder) NotifyReminder(ctx, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyReminder", reflect.TypeOf((*MockReminderNotifier)(nil).NotifyReminder), ctx, reminder)
+}
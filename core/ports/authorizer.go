@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+//go:generate mockgen -source=authorizer.go -destination=authorizer_mocks.go -package=ports Authorizer
+
+// Authorizer is the single place access rules are evaluated. Services describe
+// what they are about to do and return the decision's error when it denies.
+type Authorizer interface {
+	// AuthorizeType decides whether the identity may perform the action on a
+	// resource type at all, before a concrete resource has been loaded.
+	AuthorizeType(user domain.Identity, action domain.Action, resourceType string) domain.Decision
+	// Authorize decides whether the identity may perform the action on a
+	// concrete resource.
+	Authorize(ctx context.Context, user domain.Identity, action domain.Action, resource domain.ResourceRef) (domain.Decision, error)
+	// AuthorizeStanding decides whether a principal acting without a request,
+	// such as the owner of a subscription, may perform the action on a
+	// resource of a patient compartment. Only the patient and the care
+	// relationships and delegations in force at the time count.
+	AuthorizeStanding(ctx context.Context, owner domain.Principal, action domain.Action, resource domain.ResourceRef) (domain.Decision, error)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: authorizer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=authorizer.go -destination=authorizer_mocks.go -package=ports Authorizer
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthorizer is a mock of Authorizer interface.
+type MockAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizerMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthorizerMockRecorder is the mock recorder for MockAuthorizer.
+type MockAuthorizerMockRecorder struct {
+	mock *MockAuthorizer
+}
+
+// NewMockAuthorizer creates a new mock instance.
+func NewMockAuthorizer(ctrl *gomock.Controller) *MockAuthorizer {
+	mock := &MockAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizer) EXPECT() *MockAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockAuthorizer) Authorize(ctx context.Context, user domain.Identity, action domain.Action, resource domain.ResourceRef) (domain.Decision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, user, action, resource)
+	ret0, _ := ret[0].(domain.Decision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockAuthorizerMockRecorder) Authorize(ctx, user, action, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthorizer)(nil).Authorize), ctx, user, action, resource)
+}
+
+// AuthorizeStanding mocks base method.
+func (m *MockAuthorizer) AuthorizeStanding(ctx context.Context, owner domain.Principal, action domain.Action, resource domain.ResourceRef) (domain.Decision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeStanding", ctx, owner, action, resource)
+	ret0, _ := ret[0].(domain.Decision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeStanding indicates an expected call of AuthorizeStanding.
+func (mr *MockAuthorizerMockRecorder) AuthorizeStanding(ctx, owner, action, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeStanding", reflect.TypeOf((*MockAuthorizer)(nil).AuthorizeStanding), ctx, owner, action, resource)
+}
+
+// AuthorizeType mocks base method.
+func (m *MockAuthorizer) AuthorizeType(user domain.Identity, action domain.Action, resourceType string) domain.Decision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeType", user, action, resourceType)
+	ret0, _ := ret[0].(domain.Decision)
+	return ret0
+}
+
+// AuthorizeType indicates an expected call of AuthorizeType.
+func (mr *MockAuthorizerMockRecorder) AuthorizeType(user, action, resourceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeType", reflect.TypeOf((*MockAuthorizer)(nil).AuthorizeType), user, action, resourceType)
+}
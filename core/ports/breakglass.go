@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=breakglass.go -destination=breakglass_mocks.go -package=ports BreakGlassRepository,BreakGlassAuditor,BreakGlassService,AuditEventRepository,NotificationRepository,NotificationService
+
+type BreakGlassRepository interface {
+	Create(ctx context.Context, grant *domain.BreakGlassGrant) (*domain.BreakGlassGrant, error)
+	GetByID(ctx context.Context, id string) (*domain.BreakGlassGrant, error)
+	// FindActive returns the practitioner's unexpired grant for the patient.
+	FindActive(ctx context.Context, patientID, practitionerID string, now int64) (*domain.BreakGlassGrant, error)
+	ListUnreviewed(ctx context.Context, limit, offset int) ([]domain.BreakGlassGrant, int64, error)
+	MarkReviewed(ctx context.Context, id, reviewer, note string, at int64) error
+}
+
+// BreakGlassAuditor records the mandatory audit trail of emergency access:
+// an AuditEvent and a patient notification.
+type BreakGlassAuditor interface {
+	RecordGrant(ctx context.Context, grant *domain.BreakGlassGrant) error
+	RecordAccess(ctx context.Context, grant *domain.BreakGlassGrant, action domain.Action, res domain.ResourceRef) error
+}
+
+type BreakGlassService interface {
+	Request(ctx context.Context, req domain.BreakGlassRequest) (*domain.BreakGlassGrant, error)
+	ListForReview(ctx context.Context, limit, offset int) (*domain.ListResponse[domain.BreakGlassGrant], error)
+	Review(ctx context.Context, review domain.BreakGlassReview) error
+}
+
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+}
+
+type NotificationRepository interface {
+	Create(ctx context.Context, n *domain.Notification) error
+	ListByPatient(ctx context.Context, patientID string, limit, offset int) ([]domain.Notification, int64, error)
+}
+
+type NotificationService interface {
+	List(ctx context.Context, limit, offset int) (*domain.ListResponse[domain.Notification], error)
+}
@@ -0,0 +1,369 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: breakglass.go
+//
+// Generated by this command:
+//
+//	mockgen -source=breakglass.go -destination=breakglass_mocks.go -package=ports BreakGlassRepository,BreakGlassAuditor,BreakGlassService,AuditEventRepository,NotificationRepository,NotificationService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBreakGlassRepository is a mock of BreakGlassRepository interface.
+type MockBreakGlassRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreakGlassRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBreakGlassRepositoryMockRecorder is the mock recorder for MockBreakGlassRepository.
+type MockBreakGlassRepositoryMockRecorder struct {
+	mock *MockBreakGlassRepository
+}
+
+// NewMockBreakGlassRepository creates a new mock instance.
+func NewMockBreakGlassRepository(ctrl *gomock.Controller) *MockBreakGlassRepository {
+	mock := &MockBreakGlassRepository{ctrl: ctrl}
+	mock.recorder = &MockBreakGlassRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreakGlassRepository) EXPECT() *MockBreakGlassRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBreakGlassRepository) Create(ctx context.Context, grant *domain.BreakGlassGrant) (*domain.BreakGlassGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, grant)
+	ret0, _ := ret[0].(*domain.BreakGlassGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBreakGlassRepositoryMockRecorder) Create(ctx, grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBreakGlassRepository)(nil).Create), ctx, grant)
+}
+
+// FindActive mocks base method.
+func (m *MockBreakGlassRepository) FindActive(ctx context.Context, patientID, practitionerID string, now int64) (*domain.BreakGlassGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx, patientID, practitionerID, now)
+	ret0, _ := ret[0].(*domain.BreakGlassGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockBreakGlassRepositoryMockRecorder) FindActive(ctx, patientID, practitionerID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockBreakGlassRepository)(nil).FindActive), ctx, patientID, practitionerID, now)
+}
+
+// GetByID mocks base method.
+func (m *MockBreakGlassRepository) GetByID(ctx context.Context, id string) (*domain.BreakGlassGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.BreakGlassGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBreakGlassRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBreakGlassRepository)(nil).GetByID), ctx, id)
+}
+
+// ListUnreviewed mocks base method.
+func (m *MockBreakGlassRepository) ListUnreviewed(ctx context.Context, limit, offset int) ([]domain.BreakGlassGrant, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnreviewed", ctx, limit, offset)
+	ret0, _ := ret[0].([]domain.BreakGlassGrant)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUnreviewed indicates an expected call of ListUnreviewed.
+func (mr *MockBreakGlassRepositoryMockRecorder) ListUnreviewed(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnreviewed", reflect.TypeOf((*MockBreakGlassRepository)(nil).ListUnreviewed), ctx, limit, offset)
+}
+
+// MarkReviewed mocks base method.
+func (m *MockBreakGlassRepository) MarkReviewed(ctx context.Context, id, reviewer, note string, at int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReviewed", ctx, id, reviewer, note, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReviewed indicates an expected call of MarkReviewed.
+func (mr *MockBreakGlassRepositoryMockRecorder) MarkReviewed(ctx, id, reviewer, note, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReviewed", reflect.TypeOf((*MockBreakGlassRepository)(nil).MarkReviewed), ctx, id, reviewer, note, at)
+}
+
+// MockBreakGlassAuditor is a mock of BreakGlassAuditor interface.
+type MockBreakGlassAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreakGlassAuditorMockRecorder
+	isgomock struct{}
+}
+
+// MockBreakGlassAuditorMockRecorder is the mock recorder for MockBreakGlassAuditor.
+type MockBreakGlassAuditorMockRecorder struct {
+	mock *MockBreakGlassAuditor
+}
+
+// NewMockBreakGlassAuditor creates a new mock instance.
+func NewMockBreakGlassAuditor(ctrl *gomock.Controller) *MockBreakGlassAuditor {
+	mock := &MockBreakGlassAuditor{ctrl: ctrl}
+	mock.recorder = &MockBreakGlassAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreakGlassAuditor) EXPECT() *MockBreakGlassAuditorMockRecorder {
+	return m.recorder
+}
+
+// RecordAccess mocks base method.
+func (m *MockBreakGlassAuditor) RecordAccess(ctx context.Context, grant *domain.BreakGlassGrant, action domain.Action, res domain.ResourceRef) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAccess", ctx, grant, action, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAccess indicates an expected call of RecordAccess.
+func (mr *MockBreakGlassAuditorMockRecorder) RecordAccess(ctx, grant, action, res any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAccess", reflect.TypeOf((*MockBreakGlassAuditor)(nil).RecordAccess), ctx, grant, action, res)
+}
+
+// RecordGrant mocks base method.
+func (m *MockBreakGlassAuditor) RecordGrant(ctx context.Context, grant *domain.BreakGlassGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordGrant", ctx, grant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordGrant indicates an expected call of RecordGrant.
+func (mr *MockBreakGlassAuditorMockRecorder) RecordGrant(ctx, grant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordGrant", reflect.TypeOf((*MockBreakGlassAuditor)(nil).RecordGrant), ctx, grant)
+}
+
+// MockBreakGlassService is a mock of BreakGlassService interface.
+type MockBreakGlassService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreakGlassServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBreakGlassServiceMockRecorder is the mock recorder for MockBreakGlassService.
+type MockBreakGlassServiceMockRecorder struct {
+	mock *MockBreakGlassService
+}
+
+// NewMockBreakGlassService creates a new mock instance.
+func NewMockBreakGlassService(ctrl *gomock.Controller) *MockBreakGlassService {
+	mock := &MockBreakGlassService{ctrl: ctrl}
+	mock.recorder = &MockBreakGlassServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreakGlassService) EXPECT() *MockBreakGlassServiceMockRecorder {
+	return m.recorder
+}
+
+// ListForReview mocks base method.
+func (m *MockBreakGlassService) ListForReview(ctx context.Context, limit, offset int) (*domain.ListResponse[domain.BreakGlassGrant], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForReview", ctx, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[domain.BreakGlassGrant])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForReview indicates an expected call of ListForReview.
+func (mr *MockBreakGlassServiceMockRecorder) ListForReview(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForReview", reflect.TypeOf((*MockBreakGlassService)(nil).ListForReview), ctx, limit, offset)
+}
+
+// Request mocks base method.
+func (m *MockBreakGlassService) Request(ctx context.Context, req domain.BreakGlassRequest) (*domain.BreakGlassGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", ctx, req)
+	ret0, _ := ret[0].(*domain.BreakGlassGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockBreakGlassServiceMockRecorder) Request(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockBreakGlassService)(nil).Request), ctx, req)
+}
+
+// Review mocks base method.
+func (m *MockBreakGlassService) Review(ctx context.Context, review domain.BreakGlassReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockBreakGlassServiceMockRecorder) Review(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockBreakGlassService)(nil).Review), ctx, review)
+}
+
+// MockAuditEventRepository is a mock of AuditEventRepository interface.
+type MockAuditEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditEventRepositoryMockRecorder is the mock recorder for MockAuditEventRepository.
+type MockAuditEventRepositoryMockRecorder struct {
+	mock *MockAuditEventRepository
+}
+
+// NewMockAuditEventRepository creates a new mock instance.
+func NewMockAuditEventRepository(ctrl *gomock.Controller) *MockAuditEventRepository {
+	mock := &MockAuditEventRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEventRepository) EXPECT() *MockAuditEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditEventRepositoryMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditEventRepository)(nil).Create), ctx, event)
+}
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationRepositoryMockRecorder) Create(ctx, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationRepository)(nil).Create), ctx, n)
+}
+
+// ListByPatient mocks base method.
+func (m *MockNotificationRepository) ListByPatient(ctx context.Context, patientID string, limit, offset int) ([]domain.Notification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPatient", ctx, patientID, limit, offset)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByPatient indicates an expected call of ListByPatient.
+func (mr *MockNotificationRepositoryMockRecorder) ListByPatient(ctx, patientID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPatient", reflect.TypeOf((*MockNotificationRepository)(nil).ListByPatient), ctx, patientID, limit, offset)
+}
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockNotificationService) List(ctx context.Context, limit, offset int) (*domain.ListResponse[domain.Notification], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[domain.Notification])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationServiceMockRecorder) List(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationService)(nil).List), ctx, limit, offset)
+}
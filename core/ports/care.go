@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+//go:generate mockgen -source=care.go -destination=care_mocks.go -package=ports CareRelationshipRepository,CareRelationshipService
+
+type CareRelationshipRepository interface {
+	Upsert(ctx context.Context, rel *domain.CareRelationship) (*domain.CareRelationship, error)
+	Find(ctx context.Context, patientID, practitionerID string) (*domain.CareRelationship, error)
+	ListByPatient(ctx context.Context, patientID string) ([]domain.CareRelationship, error)
+	Delete(ctx context.Context, patientID, practitionerID string) error
+}
+
+type CareRelationshipService interface {
+	Grant(ctx context.Context, req domain.GrantCareRequest) (*domain.CareRelationship, error)
+	List(ctx context.Context, patientID string) ([]domain.CareRelationship, error)
+	Revoke(ctx context.Context, patientID, practitionerID string) error
+}
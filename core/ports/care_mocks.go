@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: care.go
+//
+// Generated by this command:
+//
+//	mockgen -source=care.go -destination=care_mocks.go -package=ports CareRelationshipRepository,CareRelationshipService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCareRelationshipRepository is a mock of CareRelationshipRepository interface.
+type MockCareRelationshipRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCareRelationshipRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCareRelationshipRepositoryMockRecorder is the mock recorder for MockCareRelationshipRepository.
+type MockCareRelationshipRepositoryMockRecorder struct {
+	mock *MockCareRelationshipRepository
+}
+
+// NewMockCareRelationshipRepository creates a new mock instance.
+func NewMockCareRelationshipRepository(ctrl *gomock.Controller) *MockCareRelationshipRepository {
+	mock := &MockCareRelationshipRepository{ctrl: ctrl}
+	mock.recorder = &MockCareRelationshipRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCareRelationshipRepository) EXPECT() *MockCareRelationshipRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCareRelationshipRepository) Delete(ctx context.Context, patientID, practitionerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, patientID, practitionerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCareRelationshipRepositoryMockRecorder) Delete(ctx, patientID, practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCareRelationshipRepository)(nil).Delete), ctx, patientID, practitionerID)
+}
+
+// Find mocks base method.
+func (m *MockCareRelationshipRepository) Find(ctx context.Context, patientID, practitionerID string) (*domain.CareRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, patientID, practitionerID)
+	ret0, _ := ret[0].(*domain.CareRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCareRelationshipRepositoryMockRecorder) Find(ctx, patientID, practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCareRelationshipRepository)(nil).Find), ctx, patientID, practitionerID)
+}
+
+// ListByPatient mocks base method.
+func (m *MockCareRelationshipRepository) ListByPatient(ctx context.Context, patientID string) ([]domain.CareRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPatient", ctx, patientID)
+	ret0, _ := ret[0].([]domain.CareRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPatient indicates an expected call of ListByPatient.
+func (mr *MockCareRelationshipRepositoryMockRecorder) ListByPatient(ctx, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPatient", reflect.TypeOf((*MockCareRelationshipRepository)(nil).ListByPatient), ctx, patientID)
+}
+
+// Upsert mocks base method.
+func (m *MockCareRelationshipRepository) Upsert(ctx context.Context, rel *domain.CareRelationship) (*domain.CareRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, rel)
+	ret0, _ := ret[0].(*domain.CareRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCareRelationshipRepositoryMockRecorder) Upsert(ctx, rel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCareRelationshipRepository)(nil).Upsert), ctx, rel)
+}
+
+// MockCareRelationshipService is a mock of CareRelationshipService interface.
+type MockCareRelationshipService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCareRelationshipServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCareRelationshipServiceMockRecorder is the mock recorder for MockCareRelationshipService.
+type MockCareRelationshipServiceMockRecorder struct {
+	mock *MockCareRelationshipService
+}
+
+// NewMockCareRelationshipService creates a new mock instance.
+func NewMockCareRelationshipService(ctrl *gomock.Controller) *MockCareRelationshipService {
+	mock := &MockCareRelationshipService{ctrl: ctrl}
+	mock.recorder = &MockCareRelationshipServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCareRelationshipService) EXPECT() *MockCareRelationshipServiceMockRecorder {
+	return m.recorder
+}
+
+// Grant mocks base method.
+func (m *MockCareRelationshipService) Grant(ctx context.Context, req domain.GrantCareRequest) (*domain.CareRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", ctx, req)
+	ret0, _ := ret[0].(*domain.CareRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockCareRelationshipServiceMockRecorder) Grant(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockCareRelationshipService)(nil).Grant), ctx, req)
+}
+
+// List mocks base method.
+func (m *MockCareRelationshipService) List(ctx context.Context, patientID string) ([]domain.CareRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, patientID)
+	ret0, _ := ret[0].([]domain.CareRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCareRelationshipServiceMockRecorder) List(ctx, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCareRelationshipService)(nil).List), ctx, patientID)
+}
+
+// Revoke mocks base method.
+func (m *MockCareRelationshipService) Revoke(ctx context.Context, patientID, practitionerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, patientID, practitionerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockCareRelationshipServiceMockRecorder) Revoke(ctx, patientID, practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockCareRelationshipService)(nil).Revoke), ctx, patientID, practitionerID)
+}
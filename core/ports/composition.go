@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=composition.go -destination=composition_mocks.go -package=ports CompositionRepository,CompositionService
+
+type CompositionRepository interface {
+	Create(ctx context.Context, comp *models.Composition) (*models.Composition, error)
+	GetByID(ctx context.Context, id string) (*models.Composition, error)
+	Update(ctx context.Context, comp *models.Composition) (*models.Composition, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.CompositionSearch, restrictions []url.Values, limit, offset int) ([]models.Composition, int64, error)
+}
+
+type CompositionService interface {
+	Create(ctx context.Context, comp *models.Composition) (*models.Composition, error)
+	Get(ctx context.Context, id string) (*models.Composition, error)
+	Update(ctx context.Context, comp *models.Composition) (*models.Composition, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.CompositionSearch, limit, offset int) (*domain.ListResponse[models.Composition], error)
+	Document(ctx context.Context, id string, persist bool) (*domain.GeneratedDocument, error)
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: composition.go
+//
+// Generated by this command:
+//
+//	mockgen -source=composition.go -destination=composition_mocks.go -package=ports CompositionRepository,CompositionService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCompositionRepository is a mock of CompositionRepository interface.
+type MockCompositionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompositionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCompositionRepositoryMockRecorder is the mock recorder for MockCompositionRepository.
+type MockCompositionRepositoryMockRecorder struct {
+	mock *MockCompositionRepository
+}
+
+// NewMockCompositionRepository creates a new mock instance.
+func NewMockCompositionRepository(ctrl *gomock.Controller) *MockCompositionRepository {
+	mock := &MockCompositionRepository{ctrl: ctrl}
+	mock.recorder = &MockCompositionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompositionRepository) EXPECT() *MockCompositionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCompositionRepository) Create(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comp)
+	ret0, _ := ret[0].(*models.Composition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCompositionRepositoryMockRecorder) Create(ctx, comp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompositionRepository)(nil).Create), ctx, comp)
+}
+
+// Delete mocks base method.
+func (m *MockCompositionRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCompositionRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompositionRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockCompositionRepository) GetByID(ctx context.Context, id string) (*models.Composition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Composition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCompositionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCompositionRepository)(nil).GetByID), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockCompositionRepository) Search(ctx context.Context, search domain.CompositionSearch, restrictions []url.Values, limit, offset int) ([]models.Composition, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Composition)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockCompositionRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCompositionRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockCompositionRepository) Update(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comp)
+	ret0, _ := ret[0].(*models.Composition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCompositionRepositoryMockRecorder) Update(ctx, comp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompositionRepository)(nil).Update), ctx, comp)
+}
+
+// MockCompositionService is a mock of CompositionService interface.
+type MockCompositionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompositionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCompositionServiceMockRecorder is the mock recorder for MockCompositionService.
+type MockCompositionServiceMockRecorder struct {
+	mock *MockCompositionService
+}
+
+// NewMockCompositionService creates a new mock instance.
+func NewMockCompositionService(ctrl *gomock.Controller) *MockCompositionService {
+	mock := &MockCompositionService{ctrl: ctrl}
+	mock.recorder = &MockCompositionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompositionService) EXPECT() *MockCompositionServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCompositionService) Create(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comp)
+	ret0, _ := ret[0].(*models.Composition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCompositionServiceMockRecorder) Create(ctx, comp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompositionService)(nil).Create), ctx, comp)
+}
+
+// Delete mocks base method.
+func (m *MockCompositionService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCompositionServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompositionService)(nil).Delete), ctx, id)
+}
+
+// Document mocks base method.
+func (m *MockCompositionService) Document(ctx context.Context, id string, persist bool) (*domain.GeneratedDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Document", ctx, id, persist)
+	ret0, _ := ret[0].(*domain.GeneratedDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Document indicates an expected call of Document.
+func (mr *MockCompositionServiceMockRecorder) Document(ctx, id, persist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Document", reflect.TypeOf((*MockCompositionService)(nil).Document), ctx, id, persist)
+}
+
+// Get mocks base method.
+func (m *MockCompositionService) Get(ctx context.Context, id string) (*models.Composition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Composition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCompositionServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCompositionService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockCompositionService) List(ctx context.Context, search domain.CompositionSearch, limit, offset int) (*domain.ListResponse[models.Composition], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Composition])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCompositionServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCompositionService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockCompositionService) Update(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comp)
+	ret0, _ := ret[0].(*models.Composition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCompositionServiceMockRecorder) Update(ctx, comp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompositionService)(nil).Update), ctx, comp)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockCompositionService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockCompositionServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockCompositionService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
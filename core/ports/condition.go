@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=condition.go -destination=condition_mocks.go -package=ports ConditionRepository,ConditionService
+
+type ConditionRepository interface {
+	Create(ctx context.Context, cond *models.Condition) (*models.Condition, error)
+	GetByID(ctx context.Context, id string) (*models.Condition, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Condition, error)
+	Update(ctx context.Context, cond *models.Condition) (*models.Condition, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.ConditionSearch, restrictions []url.Values, limit, offset int) ([]models.Condition, int64, error)
+}
+
+type ConditionService interface {
+	Create(ctx context.Context, cond *models.Condition) (*models.Condition, error)
+	Get(ctx context.Context, id string) (*models.Condition, error)
+	Update(ctx context.Context, cond *models.Condition) (*models.Condition, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.ConditionSearch, limit, offset int) (*domain.ListResponse[models.Condition], error)
+}
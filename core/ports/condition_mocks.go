@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: condition.go
+//
+// Generated by this command:
+//
+//	mockgen -source=condition.go -destination=condition_mocks.go -package=ports ConditionRepository,ConditionService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConditionRepository is a mock of ConditionRepository interface.
+type MockConditionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConditionRepositoryMockRecorder is the mock recorder for MockConditionRepository.
+type MockConditionRepositoryMockRecorder struct {
+	mock *MockConditionRepository
+}
+
+// NewMockConditionRepository creates a new mock instance.
+func NewMockConditionRepository(ctrl *gomock.Controller) *MockConditionRepository {
+	mock := &MockConditionRepository{ctrl: ctrl}
+	mock.recorder = &MockConditionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConditionRepository) EXPECT() *MockConditionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockConditionRepository) Create(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cond)
+	ret0, _ := ret[0].(*models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConditionRepositoryMockRecorder) Create(ctx, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConditionRepository)(nil).Create), ctx, cond)
+}
+
+// Delete mocks base method.
+func (m *MockConditionRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockConditionRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockConditionRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockConditionRepository) GetByID(ctx context.Context, id string) (*models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockConditionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConditionRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockConditionRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockConditionRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockConditionRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockConditionRepository) Search(ctx context.Context, search domain.ConditionSearch, restrictions []url.Values, limit, offset int) ([]models.Condition, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Condition)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockConditionRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockConditionRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockConditionRepository) Update(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, cond)
+	ret0, _ := ret[0].(*models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConditionRepositoryMockRecorder) Update(ctx, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConditionRepository)(nil).Update), ctx, cond)
+}
+
+// MockConditionService is a mock of ConditionService interface.
+type MockConditionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConditionServiceMockRecorder is the mock recorder for MockConditionService.
+type MockConditionServiceMockRecorder struct {
+	mock *MockConditionService
+}
+
+// NewMockConditionService creates a new mock instance.
+func NewMockConditionService(ctrl *gomock.Controller) *MockConditionService {
+	mock := &MockConditionService{ctrl: ctrl}
+	mock.recorder = &MockConditionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConditionService) EXPECT() *MockConditionServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockConditionService) Create(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cond)
+	ret0, _ := ret[0].(*models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConditionServiceMockRecorder) Create(ctx, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConditionService)(nil).Create), ctx, cond)
+}
+
+// Delete mocks base method.
+func (m *MockConditionService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockConditionServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockConditionService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockConditionService) Get(ctx context.Context, id string) (*models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConditionServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConditionService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockConditionService) List(ctx context.Context, search domain.ConditionSearch, limit, offset int) (*domain.ListResponse[models.Condition], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Condition])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockConditionServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConditionService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockConditionService) Update(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, cond)
+	ret0, _ := ret[0].(*models.Condition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConditionServiceMockRecorder) Update(ctx, cond any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConditionService)(nil).Update), ctx, cond)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockConditionService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockConditionServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockConditionService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=consent.go -destination=consent_mocks.go -package=ports ConsentRepository,ConsentService,ConsentEvaluator
+
+type ConsentRepository interface {
+	Create(ctx context.Context, consent *models.Consent) (*models.Consent, error)
+	GetByID(ctx context.Context, id string) (*models.Consent, error)
+	Update(ctx context.Context, consent *models.Consent) (*models.Consent, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, patientID string, limit, offset int) ([]models.Consent, int64, error)
+	// ListActive returns every consent of the patient with status active.
+	ListActive(ctx context.Context, patientID string) ([]models.Consent, error)
+}
+
+type ConsentService interface {
+	Create(ctx context.Context, consent *models.Consent) (*models.Consent, error)
+	Get(ctx context.Context, id string) (*models.Consent, error)
+	Update(ctx context.Context, consent *models.Consent) (*models.Consent, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Consent], error)
+}
+
+// ConsentEvaluator applies patient consents to resources about to be
+// released. It runs after authorization: a consent can only take away access
+// the user otherwise has.
+type ConsentEvaluator interface {
+	Withheld(ctx context.Context, user domain.Identity, action domain.Action, resources []domain.ResourceRef) ([]domain.ConsentDenial, error)
+}
@@ -0,0 +1,270 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: consent.go
+//
+// Generated by this command:
+//
+//	mockgen -source=consent.go -destination=consent_mocks.go -package=ports ConsentRepository,ConsentService,ConsentEvaluator
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsentRepository is a mock of ConsentRepository interface.
+type MockConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentRepositoryMockRecorder is the mock recorder for MockConsentRepository.
+type MockConsentRepositoryMockRecorder struct {
+	mock *MockConsentRepository
+}
+
+// NewMockConsentRepository creates a new mock instance.
+func NewMockConsentRepository(ctrl *gomock.Controller) *MockConsentRepository {
+	mock := &MockConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentRepository) EXPECT() *MockConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockConsentRepository) Create(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, consent)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConsentRepositoryMockRecorder) Create(ctx, consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConsentRepository)(nil).Create), ctx, consent)
+}
+
+// Delete mocks base method.
+func (m *MockConsentRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockConsentRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockConsentRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockConsentRepository) GetByID(ctx context.Context, id string) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockConsentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConsentRepository)(nil).GetByID), ctx, id)
+}
+
+// ListActive mocks base method.
+func (m *MockConsentRepository) ListActive(ctx context.Context, patientID string) ([]models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, patientID)
+	ret0, _ := ret[0].([]models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockConsentRepositoryMockRecorder) ListActive(ctx, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockConsentRepository)(nil).ListActive), ctx, patientID)
+}
+
+// Search mocks base method.
+func (m *MockConsentRepository) Search(ctx context.Context, patientID string, limit, offset int) ([]models.Consent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, patientID, limit, offset)
+	ret0, _ := ret[0].([]models.Consent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockConsentRepositoryMockRecorder) Search(ctx, patientID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockConsentRepository)(nil).Search), ctx, patientID, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockConsentRepository) Update(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, consent)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConsentRepositoryMockRecorder) Update(ctx, consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConsentRepository)(nil).Update), ctx, consent)
+}
+
+// MockConsentService is a mock of ConsentService interface.
+type MockConsentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentServiceMockRecorder is the mock recorder for MockConsentService.
+type MockConsentServiceMockRecorder struct {
+	mock *MockConsentService
+}
+
+// NewMockConsentService creates a new mock instance.
+func NewMockConsentService(ctrl *gomock.Controller) *MockConsentService {
+	mock := &MockConsentService{ctrl: ctrl}
+	mock.recorder = &MockConsentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentService) EXPECT() *MockConsentServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockConsentService) Create(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, consent)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConsentServiceMockRecorder) Create(ctx, consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConsentService)(nil).Create), ctx, consent)
+}
+
+// Delete mocks base method.
+func (m *MockConsentService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockConsentServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockConsentService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockConsentService) Get(ctx context.Context, id string) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConsentServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConsentService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockConsentService) List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Consent], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, patientID, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Consent])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockConsentServiceMockRecorder) List(ctx, patientID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConsentService)(nil).List), ctx, patientID, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockConsentService) Update(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, consent)
+	ret0, _ := ret[0].(*models.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConsentServiceMockRecorder) Update(ctx, consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConsentService)(nil).Update), ctx, consent)
+}
+
+// MockConsentEvaluator is a mock of ConsentEvaluator interface.
+type MockConsentEvaluator struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentEvaluatorMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentEvaluatorMockRecorder is the mock recorder for MockConsentEvaluator.
+type MockConsentEvaluatorMockRecorder struct {
+	mock *MockConsentEvaluator
+}
+
+// NewMockConsentEvaluator creates a new mock instance.
+func NewMockConsentEvaluator(ctrl *gomock.Controller) *MockConsentEvaluator {
+	mock := &MockConsentEvaluator{ctrl: ctrl}
+	mock.recorder = &MockConsentEvaluatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentEvaluator) EXPECT() *MockConsentEvaluatorMockRecorder {
+	return m.recorder
+}
+
+// Withheld mocks base method.
+func (m *MockConsentEvaluator) Withheld(ctx context.Context, user domain.Identity, action domain.Action, resources []domain.ResourceRef) ([]domain.ConsentDenial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withheld", ctx, user, action, resources)
+	ret0, _ := ret[0].([]domain.ConsentDenial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withheld indicates an expected call of Withheld.
+func (mr *MockConsentEvaluatorMockRecorder) Withheld(ctx, user, action, resources any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withheld", reflect.TypeOf((*MockConsentEvaluator)(nil).Withheld), ctx, user, action, resources)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=diagnostic_report.go -destination=diagnostic_report_mocks.go -package=ports DiagnosticReportRepository,DiagnosticReportService
+
+type DiagnosticReportRepository interface {
+	Create(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error)
+	GetByID(ctx context.Context, id string) (*models.DiagnosticReport, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.DiagnosticReport, error)
+	Update(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.DiagnosticReportSearch, restrictions []url.Values, limit, offset int) ([]models.DiagnosticReport, int64, error)
+}
+
+type DiagnosticReportService interface {
+	Create(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error)
+	Get(ctx context.Context, id string) (*models.DiagnosticReport, error)
+	Update(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.DiagnosticReportSearch, limit, offset int) (*domain.ListResponse[models.DiagnosticReport], error)
+}
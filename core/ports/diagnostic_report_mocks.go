@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: diagnostic_report.go
+//
+// Generated by this command:
+//
+//	mockgen -source=diagnostic_report.go -destination=diagnostic_report_mocks.go -package=ports DiagnosticReportRepository,DiagnosticReportService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDiagnosticReportRepository is a mock of DiagnosticReportRepository interface.
+type MockDiagnosticReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiagnosticReportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDiagnosticReportRepositoryMockRecorder is the mock recorder for MockDiagnosticReportRepository.
+type MockDiagnosticReportRepositoryMockRecorder struct {
+	mock *MockDiagnosticReportRepository
+}
+
+// NewMockDiagnosticReportRepository creates a new mock instance.
+func NewMockDiagnosticReportRepository(ctrl *gomock.Controller) *MockDiagnosticReportRepository {
+	mock := &MockDiagnosticReportRepository{ctrl: ctrl}
+	mock.recorder = &MockDiagnosticReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiagnosticReportRepository) EXPECT() *MockDiagnosticReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDiagnosticReportRepository) Create(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, report)
+	ret0, _ := ret[0].(*models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDiagnosticReportRepositoryMockRecorder) Create(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDiagnosticReportRepository)(nil).Create), ctx, report)
+}
+
+// Delete mocks base method.
+func (m *MockDiagnosticReportRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDiagnosticReportRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDiagnosticReportRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockDiagnosticReportRepository) GetByID(ctx context.Context, id string) (*models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDiagnosticReportRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDiagnosticReportRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockDiagnosticReportRepository) GetByIDs(ctx context.Context, ids []string) ([]models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockDiagnosticReportRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockDiagnosticReportRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockDiagnosticReportRepository) Search(ctx context.Context, search domain.DiagnosticReportSearch, restrictions []url.Values, limit, offset int) ([]models.DiagnosticReport, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.DiagnosticReport)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockDiagnosticReportRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockDiagnosticReportRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockDiagnosticReportRepository) Update(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, report)
+	ret0, _ := ret[0].(*models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDiagnosticReportRepositoryMockRecorder) Update(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDiagnosticReportRepository)(nil).Update), ctx, report)
+}
+
+// MockDiagnosticReportService is a mock of DiagnosticReportService interface.
+type MockDiagnosticReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiagnosticReportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDiagnosticReportServiceMockRecorder is the mock recorder for MockDiagnosticReportService.
+type MockDiagnosticReportServiceMockRecorder struct {
+	mock *MockDiagnosticReportService
+}
+
+// NewMockDiagnosticReportService creates a new mock instance.
+func NewMockDiagnosticReportService(ctrl *gomock.Controller) *MockDiagnosticReportService {
+	mock := &MockDiagnosticReportService{ctrl: ctrl}
+	mock.recorder = &MockDiagnosticReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiagnosticReportService) EXPECT() *MockDiagnosticReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDiagnosticReportService) Create(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, report)
+	ret0, _ := ret[0].(*models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDiagnosticReportServiceMockRecorder) Create(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDiagnosticReportService)(nil).Create), ctx, report)
+}
+
+// Delete mocks base method.
+func (m *MockDiagnosticReportService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDiagnosticReportServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDiagnosticReportService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockDiagnosticReportService) Get(ctx context.Context, id string) (*models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDiagnosticReportServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDiagnosticReportService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockDiagnosticReportService) List(ctx context.Context, search domain.DiagnosticReportSearch, limit, offset int) (*domain.ListResponse[models.DiagnosticReport], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.DiagnosticReport])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDiagnosticReportServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDiagnosticReportService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockDiagnosticReportService) Update(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, report)
+	ret0, _ := ret[0].(*models.DiagnosticReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDiagnosticReportServiceMockRecorder) Update(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDiagnosticReportService)(nil).Update), ctx, report)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockDiagnosticReportService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockDiagnosticReportServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockDiagnosticReportService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 	models "github.com/gruzdev-dev/fhir/r5"
@@ -15,12 +16,13 @@ type DocumentRepository interface {
 	GetByIDs(ctx context.Context, ids []string) ([]models.DocumentReference, error)
 	Update(ctx context.Context, doc *models.DocumentReference) (*models.DocumentReference, error)
 	Delete(ctx context.Context, id string) error
-	Search(ctx context.Context, patientID string, limit, offset int) ([]models.DocumentReference, int64, error)
+	Search(ctx context.Context, search domain.DocumentSearch, restrictions []url.Values, limit, offset int) ([]models.DocumentReference, int64, error)
 }
 
 type DocumentService interface {
 	CreateDocument(ctx context.Context, doc *models.DocumentReference) (*domain.CreateDocumentResult, error)
 	GetDocument(ctx context.Context, id string) (*models.DocumentReference, error)
+	UpdateDocumentSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
 	DeleteDocument(ctx context.Context, id string) error
-	ListDocuments(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.DocumentReference], error)
+	ListDocuments(ctx context.Context, search domain.DocumentSearch, limit, offset int) (*domain.ListResponse[models.DocumentReference], error)
 }
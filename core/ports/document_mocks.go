@@ -11,6 +11,7 @@ package ports
 
 import (
 	context "context"
+	url "net/url"
 	reflect "reflect"
 
 	domain "github.com/gruzdev-dev/codex-documents/core/domain"
@@ -102,9 +103,9 @@ func (mr *MockDocumentRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Cal
 }
 
 // Search mocks base method.
-func (m *MockDocumentRepository) Search(ctx context.Context, patientID string, limit, offset int) ([]models.DocumentReference, int64, error) {
+func (m *MockDocumentRepository) Search(ctx context.Context, search domain.DocumentSearch, restrictions []url.Values, limit, offset int) ([]models.DocumentReference, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, patientID, limit, offset)
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
 	ret0, _ := ret[0].([]models.DocumentReference)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -112,9 +113,9 @@ func (m *MockDocumentRepository) Search(ctx context.Context, patientID string, l
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockDocumentRepositoryMockRecorder) Search(ctx, patientID, limit, offset any) *gomock.Call {
+func (mr *MockDocumentRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockDocumentRepository)(nil).Search), ctx, patientID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockDocumentRepository)(nil).Search), ctx, search, restrictions, limit, offset)
 }
 
 // Update mocks base method.
@@ -201,16 +202,31 @@ func (mr *MockDocumentServiceMockRecorder) GetDocument(ctx, id any) *gomock.Call
 }
 
 // ListDocuments mocks base method.
-func (m *MockDocumentService) ListDocuments(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.DocumentReference], error) {
+func (m *MockDocumentService) ListDocuments(ctx context.Context, search domain.DocumentSearch, limit, offset int) (*domain.ListResponse[models.DocumentReference], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDocuments", ctx, patientID, limit, offset)
+	ret := m.ctrl.Call(m, "ListDocuments", ctx, search, limit, offset)
 	ret0, _ := ret[0].(*domain.ListResponse[models.DocumentReference])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListDocuments indicates an expected call of ListDocuments.
-func (mr *MockDocumentServiceMockRecorder) ListDocuments(ctx, patientID, limit, offset any) *gomock.Call {
+func (mr *MockDocumentServiceMockRecorder) ListDocuments(ctx, search, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDocuments", reflect.TypeOf((*MockDocumentService)(nil).ListDocuments), ctx, patientID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDocuments", reflect.TypeOf((*MockDocumentService)(nil).ListDocuments), ctx, search, limit, offset)
+}
+
+// UpdateDocumentSecurityLabels mocks base method.
+func (m *MockDocumentService) UpdateDocumentSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDocumentSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDocumentSecurityLabels indicates an expected call of UpdateDocumentSecurityLabels.
+func (mr *MockDocumentServiceMockRecorder) UpdateDocumentSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDocumentSecurityLabels", reflect.TypeOf((*MockDocumentService)(nil).UpdateDocumentSecurityLabels), ctx, id, add, remove)
 }
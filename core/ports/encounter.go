@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=encounter.go -destination=encounter_mocks.go -package=ports EncounterRepository,EncounterService
+
+type EncounterRepository interface {
+	Create(ctx context.Context, enc *models.Encounter) (*models.Encounter, error)
+	GetByID(ctx context.Context, id string) (*models.Encounter, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Encounter, error)
+	Update(ctx context.Context, enc *models.Encounter) (*models.Encounter, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.EncounterSearch, restrictions []url.Values, limit, offset int) ([]models.Encounter, int64, error)
+}
+
+type EncounterService interface {
+	Create(ctx context.Context, enc *models.Encounter) (*models.Encounter, error)
+	Get(ctx context.Context, id string) (*models.Encounter, error)
+	Update(ctx context.Context, enc *models.Encounter) (*models.Encounter, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.EncounterSearch, limit, offset int) (*domain.ListResponse[models.Encounter], error)
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: encounter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=encounter.go -destination=encounter_mocks.go -package=ports EncounterRepository,EncounterService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEncounterRepository is a mock of EncounterRepository interface.
+type MockEncounterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncounterRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEncounterRepositoryMockRecorder is the mock recorder for MockEncounterRepository.
+type MockEncounterRepositoryMockRecorder struct {
+	mock *MockEncounterRepository
+}
+
+// NewMockEncounterRepository creates a new mock instance.
+func NewMockEncounterRepository(ctrl *gomock.Controller) *MockEncounterRepository {
+	mock := &MockEncounterRepository{ctrl: ctrl}
+	mock.recorder = &MockEncounterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncounterRepository) EXPECT() *MockEncounterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEncounterRepository) Create(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, enc)
+	ret0, _ := ret[0].(*models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEncounterRepositoryMockRecorder) Create(ctx, enc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEncounterRepository)(nil).Create), ctx, enc)
+}
+
+// Delete mocks base method.
+func (m *MockEncounterRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEncounterRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEncounterRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockEncounterRepository) GetByID(ctx context.Context, id string) (*models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockEncounterRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEncounterRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockEncounterRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockEncounterRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockEncounterRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockEncounterRepository) Search(ctx context.Context, search domain.EncounterSearch, restrictions []url.Values, limit, offset int) ([]models.Encounter, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Encounter)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockEncounterRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockEncounterRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockEncounterRepository) Update(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, enc)
+	ret0, _ := ret[0].(*models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEncounterRepositoryMockRecorder) Update(ctx, enc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEncounterRepository)(nil).Update), ctx, enc)
+}
+
+// MockEncounterService is a mock of EncounterService interface.
+type MockEncounterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncounterServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEncounterServiceMockRecorder is the mock recorder for MockEncounterService.
+type MockEncounterServiceMockRecorder struct {
+	mock *MockEncounterService
+}
+
+// NewMockEncounterService creates a new mock instance.
+func NewMockEncounterService(ctrl *gomock.Controller) *MockEncounterService {
+	mock := &MockEncounterService{ctrl: ctrl}
+	mock.recorder = &MockEncounterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncounterService) EXPECT() *MockEncounterServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEncounterService) Create(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, enc)
+	ret0, _ := ret[0].(*models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEncounterServiceMockRecorder) Create(ctx, enc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEncounterService)(nil).Create), ctx, enc)
+}
+
+// Delete mocks base method.
+func (m *MockEncounterService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEncounterServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEncounterService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockEncounterService) Get(ctx context.Context, id string) (*models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockEncounterServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockEncounterService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockEncounterService) List(ctx context.Context, search domain.EncounterSearch, limit, offset int) (*domain.ListResponse[models.Encounter], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Encounter])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEncounterServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEncounterService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockEncounterService) Update(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, enc)
+	ret0, _ := ret[0].(*models.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEncounterServiceMockRecorder) Update(ctx, enc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEncounterService)(nil).Update), ctx, enc)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockEncounterService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockEncounterServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockEncounterService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+//go:generate mockgen -source=event.go -destination=event_mocks.go -package=ports Transactor,OutboxRepository,EventPublisher,EventHandler
+
+// Transactor runs a unit of work in a storage transaction.
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction that commits when fn
+	// returns nil and aborts otherwise. Repositories called with the context
+	// fn is given take part in the transaction. fn may be run again when the
+	// transaction conflicts with another one.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxRepository keeps domain events until they are published.
+type OutboxRepository interface {
+	// Append numbers the events within their resources and stores them.
+	Append(ctx context.Context, events ...domain.DomainEvent) error
+	// ListPending returns up to limit unpublished events of the resources
+	// whose keys sort after afterKey, ordered by resource key and then
+	// sequence. An empty afterKey starts at the first resource.
+	ListPending(ctx context.Context, afterKey string, limit int) ([]domain.DomainEvent, error)
+	// MarkHandled records that the EventHandler has handled the event.
+	MarkHandled(ctx context.Context, id string) error
+	// MarkPublished removes a published event from the outbox.
+	MarkPublished(ctx context.Context, id string) error
+	// Retry counts a failed attempt to publish the event and holds it until
+	// next.
+	Retry(ctx context.Context, id string, next time.Time) error
+	// DeadLetter moves an event that could not be published out of the
+	// outbox, keeping it with the reason for inspection.
+	DeadLetter(ctx context.Context, event domain.DomainEvent, reason string) error
+}
+
+// EventPublisher hands domain events to a message broker. It returns nil
+// only once the broker has accepted the event.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.DomainEvent) error
+}
+
+// EventHandler reacts to domain events within the service. The relay calls
+// it before publishing an event, in one transaction with marking the event
+// handled, so a failed handler is retried with the event and a handled event
+// is not handled again.
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event domain.DomainEvent) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+//go:generate mockgen -source=everything.go -destination=everything_mocks.go -package=ports EverythingService
+
+type EverythingService interface {
+	Everything(ctx context.Context, patientID string, limit, offset int) (*domain.PatientEverything, error)
+}
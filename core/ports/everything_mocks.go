@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: everything.go
+//
+// Generated by this command:
+//
+//	mockgen -source=everything.go -destination=everything_mocks.go -package=ports EverythingService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEverythingService is a mock of EverythingService interface.
+type MockEverythingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEverythingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEverythingServiceMockRecorder is the mock recorder for MockEverythingService.
+type MockEverythingServiceMockRecorder struct {
+	mock *MockEverythingService
+}
+
+// NewMockEverythingService creates a new mock instance.
+func NewMockEverythingService(ctrl *gomock.Controller) *MockEverythingService {
+	mock := &MockEverythingService{ctrl: ctrl}
+	mock.recorder = &MockEverythingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEverythingService) EXPECT() *MockEverythingServiceMockRecorder {
+	return m.recorder
+}
+
+// Everything mocks base method.
+func (m *MockEverythingService) Everything(ctx context.Context, patientID string, limit, offset int) (*domain.PatientEverything, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Everything", ctx, patientID, limit, offset)
+	ret0, _ := ret[0].(*domain.PatientEverything)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Everything indicates an expected call of Everything.
+func (mr *MockEverythingServiceMockRecorder) Everything(ctx, patientID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Everything", reflect.TypeOf((*MockEverythingService)(nil).Everything), ctx, patientID, limit, offset)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=family_member_history.go -destination=family_member_history_mocks.go -package=ports FamilyMemberHistoryRepository,FamilyMemberHistoryService
+
+type FamilyMemberHistoryRepository interface {
+	Create(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error)
+	GetByID(ctx context.Context, id string) (*models.FamilyMemberHistory, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.FamilyMemberHistory, error)
+	Update(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.FamilyMemberHistorySearch, restrictions []url.Values, limit, offset int) ([]models.FamilyMemberHistory, int64, error)
+}
+
+type FamilyMemberHistoryService interface {
+	Create(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error)
+	Get(ctx context.Context, id string) (*models.FamilyMemberHistory, error)
+	Update(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.FamilyMemberHistorySearch, limit, offset int) (*domain.ListResponse[models.FamilyMemberHistory], error)
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: family_member_history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=family_member_history.go -destination=family_member_history_mocks.go -package=ports FamilyMemberHistoryRepository,FamilyMemberHistoryService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFamilyMemberHistoryRepository is a mock of FamilyMemberHistoryRepository interface.
+type MockFamilyMemberHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFamilyMemberHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFamilyMemberHistoryRepositoryMockRecorder is the mock recorder for MockFamilyMemberHistoryRepository.
+type MockFamilyMemberHistoryRepositoryMockRecorder struct {
+	mock *MockFamilyMemberHistoryRepository
+}
+
+// NewMockFamilyMemberHistoryRepository creates a new mock instance.
+func NewMockFamilyMemberHistoryRepository(ctrl *gomock.Controller) *MockFamilyMemberHistoryRepository {
+	mock := &MockFamilyMemberHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockFamilyMemberHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFamilyMemberHistoryRepository) EXPECT() *MockFamilyMemberHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFamilyMemberHistoryRepository) Create(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, fmh)
+	ret0, _ := ret[0].(*models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFamilyMemberHistoryRepositoryMockRecorder) Create(ctx, fmh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFamilyMemberHistoryRepository)(nil).Create), ctx, fmh)
+}
+
+// Delete mocks base method.
+func (m *MockFamilyMemberHistoryRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFamilyMemberHistoryRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFamilyMemberHistoryRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockFamilyMemberHistoryRepository) GetByID(ctx context.Context, id string) (*models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockFamilyMemberHistoryRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockFamilyMemberHistoryRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockFamilyMemberHistoryRepository) GetByIDs(ctx context.Context, ids []string) ([]models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockFamilyMemberHistoryRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockFamilyMemberHistoryRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockFamilyMemberHistoryRepository) Search(ctx context.Context, search domain.FamilyMemberHistorySearch, restrictions []url.Values, limit, offset int) ([]models.FamilyMemberHistory, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.FamilyMemberHistory)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockFamilyMemberHistoryRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockFamilyMemberHistoryRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockFamilyMemberHistoryRepository) Update(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, fmh)
+	ret0, _ := ret[0].(*models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFamilyMemberHistoryRepositoryMockRecorder) Update(ctx, fmh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFamilyMemberHistoryRepository)(nil).Update), ctx, fmh)
+}
+
+// MockFamilyMemberHistoryService is a mock of FamilyMemberHistoryService interface.
+type MockFamilyMemberHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFamilyMemberHistoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFamilyMemberHistoryServiceMockRecorder is the mock recorder for MockFamilyMemberHistoryService.
+type MockFamilyMemberHistoryServiceMockRecorder struct {
+	mock *MockFamilyMemberHistoryService
+}
+
+// NewMockFamilyMemberHistoryService creates a new mock instance.
+func NewMockFamilyMemberHistoryService(ctrl *gomock.Controller) *MockFamilyMemberHistoryService {
+	mock := &MockFamilyMemberHistoryService{ctrl: ctrl}
+	mock.recorder = &MockFamilyMemberHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFamilyMemberHistoryService) EXPECT() *MockFamilyMemberHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFamilyMemberHistoryService) Create(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, fmh)
+	ret0, _ := ret[0].(*models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFamilyMemberHistoryServiceMockRecorder) Create(ctx, fmh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFamilyMemberHistoryService)(nil).Create), ctx, fmh)
+}
+
+// Delete mocks base method.
+func (m *MockFamilyMemberHistoryService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFamilyMemberHistoryServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFamilyMemberHistoryService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockFamilyMemberHistoryService) Get(ctx context.Context, id string) (*models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFamilyMemberHistoryServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFamilyMemberHistoryService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockFamilyMemberHistoryService) List(ctx context.Context, search domain.FamilyMemberHistorySearch, limit, offset int) (*domain.ListResponse[models.FamilyMemberHistory], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.FamilyMemberHistory])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFamilyMemberHistoryServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFamilyMemberHistoryService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockFamilyMemberHistoryService) Update(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, fmh)
+	ret0, _ := ret[0].(*models.FamilyMemberHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFamilyMemberHistoryServiceMockRecorder) Update(ctx, fmh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFamilyMemberHistoryService)(nil).Update), ctx, fmh)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockFamilyMemberHistoryService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockFamilyMemberHistoryServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockFamilyMemberHistoryService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 )
@@ -11,4 +12,7 @@ import (
 type FileProvider interface {
 	GetPresignedUrls(ctx context.Context, data domain.GetPresignedUrlsRequest) (*domain.PresignedUrlsResponse, error)
 	DeleteFile(ctx context.Context, fileId string) error
+	// GetDownloadUrl signs a URL the file can be downloaded from without
+	// other credentials until ttl has passed.
+	GetDownloadUrl(ctx context.Context, fileId string, ttl time.Duration) (string, error)
 }
@@ -12,6 +12,7 @@ package ports
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/gruzdev-dev/codex-documents/core/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,21 @@ func (mr *MockFileProviderMockRecorder) DeleteFile(ctx, fileId any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileProvider)(nil).DeleteFile), ctx, fileId)
 }
 
+// GetDownloadUrl mocks base method.
+func (m *MockFileProvider) GetDownloadUrl(ctx context.Context, fileId string, ttl time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDownloadUrl", ctx, fileId, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDownloadUrl indicates an expected call of GetDownloadUrl.
+func (mr *MockFileProviderMockRecorder) GetDownloadUrl(ctx, fileId, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDownloadUrl", reflect.TypeOf((*MockFileProvider)(nil).GetDownloadUrl), ctx, fileId, ttl)
+}
+
 // GetPresignedUrls mocks base method.
 func (m *MockFileProvider) GetPresignedUrls(ctx context.Context, data domain.GetPresignedUrlsRequest) (*domain.PresignedUrlsResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=goal.go -destination=goal_mocks.go -package=ports GoalRepository,GoalService,CarePlanRepository,CarePlanService
+
+type GoalRepository interface {
+	Create(ctx context.Context, goal *models.Goal) (*models.Goal, error)
+	GetByID(ctx context.Context, id string) (*models.Goal, error)
+	Update(ctx context.Context, goal *models.Goal) (*models.Goal, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.GoalSearch, restrictions []url.Values, limit, offset int) ([]models.Goal, int64, error)
+}
+
+type GoalService interface {
+	Create(ctx context.Context, goal *models.Goal) (*models.Goal, error)
+	Get(ctx context.Context, id string) (*models.Goal, error)
+	Update(ctx context.Context, goal *models.Goal) (*models.Goal, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.GoalSearch, limit, offset int) (*domain.ListResponse[models.Goal], error)
+	Progress(ctx context.Context, id string) (*domain.GoalProgress, error)
+}
+
+type CarePlanRepository interface {
+	Create(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error)
+	GetByID(ctx context.Context, id string) (*models.CarePlan, error)
+	Update(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.CarePlanSearch, restrictions []url.Values, limit, offset int) ([]models.CarePlan, int64, error)
+}
+
+type CarePlanService interface {
+	Create(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error)
+	Get(ctx context.Context, id string) (*models.CarePlan, error)
+	Update(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.CarePlanSearch, limit, offset int) (*domain.ListResponse[models.CarePlan], error)
+}
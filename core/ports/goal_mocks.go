@@ -0,0 +1,459 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: goal.go
+//
+// Generated by this command:
+//
+//	mockgen -source=goal.go -destination=goal_mocks.go -package=ports GoalRepository,GoalService,CarePlanRepository,CarePlanService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGoalRepository is a mock of GoalRepository interface.
+type MockGoalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockGoalRepositoryMockRecorder is the mock recorder for MockGoalRepository.
+type MockGoalRepositoryMockRecorder struct {
+	mock *MockGoalRepository
+}
+
+// NewMockGoalRepository creates a new mock instance.
+func NewMockGoalRepository(ctrl *gomock.Controller) *MockGoalRepository {
+	mock := &MockGoalRepository{ctrl: ctrl}
+	mock.recorder = &MockGoalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoalRepository) EXPECT() *MockGoalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGoalRepository) Create(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, goal)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGoalRepositoryMockRecorder) Create(ctx, goal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGoalRepository)(nil).Create), ctx, goal)
+}
+
+// Delete mocks base method.
+func (m *MockGoalRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGoalRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGoalRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockGoalRepository) GetByID(ctx context.Context, id string) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGoalRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGoalRepository)(nil).GetByID), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockGoalRepository) Search(ctx context.Context, search domain.GoalSearch, restrictions []url.Values, limit, offset int) ([]models.Goal, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Goal)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockGoalRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockGoalRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockGoalRepository) Update(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, goal)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGoalRepositoryMockRecorder) Update(ctx, goal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGoalRepository)(nil).Update), ctx, goal)
+}
+
+// MockGoalService is a mock of GoalService interface.
+type MockGoalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGoalServiceMockRecorder is the mock recorder for MockGoalService.
+type MockGoalServiceMockRecorder struct {
+	mock *MockGoalService
+}
+
+// NewMockGoalService creates a new mock instance.
+func NewMockGoalService(ctrl *gomock.Controller) *MockGoalService {
+	mock := &MockGoalService{ctrl: ctrl}
+	mock.recorder = &MockGoalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoalService) EXPECT() *MockGoalServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGoalService) Create(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, goal)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGoalServiceMockRecorder) Create(ctx, goal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGoalService)(nil).Create), ctx, goal)
+}
+
+// Delete mocks base method.
+func (m *MockGoalService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGoalServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGoalService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockGoalService) Get(ctx context.Context, id string) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockGoalServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGoalService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockGoalService) List(ctx context.Context, search domain.GoalSearch, limit, offset int) (*domain.ListResponse[models.Goal], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Goal])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGoalServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGoalService)(nil).List), ctx, search, limit, offset)
+}
+
+// Progress mocks base method.
+func (m *MockGoalService) Progress(ctx context.Context, id string) (*domain.GoalProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress", ctx, id)
+	ret0, _ := ret[0].(*domain.GoalProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockGoalServiceMockRecorder) Progress(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockGoalService)(nil).Progress), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockGoalService) Update(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, goal)
+	ret0, _ := ret[0].(*models.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGoalServiceMockRecorder) Update(ctx, goal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGoalService)(nil).Update), ctx, goal)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockGoalService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockGoalServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockGoalService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
+
+// MockCarePlanRepository is a mock of CarePlanRepository interface.
+type MockCarePlanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarePlanRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCarePlanRepositoryMockRecorder is the mock recorder for MockCarePlanRepository.
+type MockCarePlanRepositoryMockRecorder struct {
+	mock *MockCarePlanRepository
+}
+
+// NewMockCarePlanRepository creates a new mock instance.
+func NewMockCarePlanRepository(ctrl *gomock.Controller) *MockCarePlanRepository {
+	mock := &MockCarePlanRepository{ctrl: ctrl}
+	mock.recorder = &MockCarePlanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarePlanRepository) EXPECT() *MockCarePlanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCarePlanRepository) Create(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, plan)
+	ret0, _ := ret[0].(*models.CarePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCarePlanRepositoryMockRecorder) Create(ctx, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCarePlanRepository)(nil).Create), ctx, plan)
+}
+
+// Delete mocks base method.
+func (m *MockCarePlanRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCarePlanRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCarePlanRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockCarePlanRepository) GetByID(ctx context.Context, id string) (*models.CarePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.CarePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCarePlanRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCarePlanRepository)(nil).GetByID), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockCarePlanRepository) Search(ctx context.Context, search domain.CarePlanSearch, restrictions []url.Values, limit, offset int) ([]models.CarePlan, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.CarePlan)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockCarePlanRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCarePlanRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockCarePlanRepository) Update(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, plan)
+	ret0, _ := ret[0].(*models.CarePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCarePlanRepositoryMockRecorder) Update(ctx, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCarePlanRepository)(nil).Update), ctx, plan)
+}
+
+// MockCarePlanService is a mock of CarePlanService interface.
+type MockCarePlanService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarePlanServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCarePlanServiceMockRecorder is the mock recorder for MockCarePlanService.
+type MockCarePlanServiceMockRecorder struct {
+	mock *MockCarePlanService
+}
+
+// NewMockCarePlanService creates a new mock instance.
+func NewMockCarePlanService(ctrl *gomock.Controller) *MockCarePlanService {
+	mock := &MockCarePlanService{ctrl: ctrl}
+	mock.recorder = &MockCarePlanServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarePlanService) EXPECT() *MockCarePlanServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCarePlanService) Create(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, plan)
+	ret0, _ := ret[0].(*models.CarePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCarePlanServiceMockRecorder) Create(ctx, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCarePlanService)(nil).Create), ctx, plan)
+}
+
+// Delete mocks base method.
+func (m *MockCarePlanService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCarePlanServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCarePlanService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCarePlanService) Get(ctx context.Context, id string) (*models.CarePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.CarePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCarePlanServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCarePlanService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockCarePlanService) List(ctx context.Context, search domain.CarePlanSearch, limit, offset int) (*domain.ListResponse[models.CarePlan], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.CarePlan])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCarePlanServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCarePlanService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockCarePlanService) Update(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, plan)
+	ret0, _ := ret[0].(*models.CarePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCarePlanServiceMockRecorder) Update(ctx, plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCarePlanService)(nil).Update), ctx, plan)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockCarePlanService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockCarePlanServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockCarePlanService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
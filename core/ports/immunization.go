@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=immunization.go -destination=immunization_mocks.go -package=ports ImmunizationRepository,ImmunizationService
+
+type ImmunizationRepository interface {
+	Create(ctx context.Context, imm *models.Immunization) (*models.Immunization, error)
+	GetByID(ctx context.Context, id string) (*models.Immunization, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Immunization, error)
+	Update(ctx context.Context, imm *models.Immunization) (*models.Immunization, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.ImmunizationSearch, restrictions []url.Values, limit, offset int) ([]models.Immunization, int64, error)
+}
+
+type ImmunizationService interface {
+	Create(ctx context.Context, imm *models.Immunization) (*models.Immunization, error)
+	Get(ctx context.Context, id string) (*models.Immunization, error)
+	Update(ctx context.Context, imm *models.Immunization) (*models.Immunization, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.ImmunizationSearch, limit, offset int) (*domain.ListResponse[models.Immunization], error)
+}
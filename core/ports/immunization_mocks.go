@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: immunization.go
+//
+// Generated by this command:
+//
+//	mockgen -source=immunization.go -destination=immunization_mocks.go -package=ports ImmunizationRepository,ImmunizationService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImmunizationRepository is a mock of ImmunizationRepository interface.
+type MockImmunizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImmunizationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImmunizationRepositoryMockRecorder is the mock recorder for MockImmunizationRepository.
+type MockImmunizationRepositoryMockRecorder struct {
+	mock *MockImmunizationRepository
+}
+
+// NewMockImmunizationRepository creates a new mock instance.
+func NewMockImmunizationRepository(ctrl *gomock.Controller) *MockImmunizationRepository {
+	mock := &MockImmunizationRepository{ctrl: ctrl}
+	mock.recorder = &MockImmunizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImmunizationRepository) EXPECT() *MockImmunizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImmunizationRepository) Create(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, imm)
+	ret0, _ := ret[0].(*models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImmunizationRepositoryMockRecorder) Create(ctx, imm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImmunizationRepository)(nil).Create), ctx, imm)
+}
+
+// Delete mocks base method.
+func (m *MockImmunizationRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImmunizationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImmunizationRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockImmunizationRepository) GetByID(ctx context.Context, id string) (*models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImmunizationRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImmunizationRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockImmunizationRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockImmunizationRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockImmunizationRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockImmunizationRepository) Search(ctx context.Context, search domain.ImmunizationSearch, restrictions []url.Values, limit, offset int) ([]models.Immunization, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Immunization)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockImmunizationRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockImmunizationRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockImmunizationRepository) Update(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, imm)
+	ret0, _ := ret[0].(*models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockImmunizationRepositoryMockRecorder) Update(ctx, imm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockImmunizationRepository)(nil).Update), ctx, imm)
+}
+
+// MockImmunizationService is a mock of ImmunizationService interface.
+type MockImmunizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImmunizationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockImmunizationServiceMockRecorder is the mock recorder for MockImmunizationService.
+type MockImmunizationServiceMockRecorder struct {
+	mock *MockImmunizationService
+}
+
+// NewMockImmunizationService creates a new mock instance.
+func NewMockImmunizationService(ctrl *gomock.Controller) *MockImmunizationService {
+	mock := &MockImmunizationService{ctrl: ctrl}
+	mock.recorder = &MockImmunizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImmunizationService) EXPECT() *MockImmunizationServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImmunizationService) Create(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, imm)
+	ret0, _ := ret[0].(*models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImmunizationServiceMockRecorder) Create(ctx, imm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImmunizationService)(nil).Create), ctx, imm)
+}
+
+// Delete mocks base method.
+func (m *MockImmunizationService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImmunizationServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImmunizationService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockImmunizationService) Get(ctx context.Context, id string) (*models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImmunizationServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImmunizationService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockImmunizationService) List(ctx context.Context, search domain.ImmunizationSearch, limit, offset int) (*domain.ListResponse[models.Immunization], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Immunization])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockImmunizationServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockImmunizationService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockImmunizationService) Update(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, imm)
+	ret0, _ := ret[0].(*models.Immunization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockImmunizationServiceMockRecorder) Update(ctx, imm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockImmunizationService)(nil).Update), ctx, imm)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockImmunizationService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockImmunizationServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockImmunizationService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=lease.go -destination=lease_mocks.go -package=ports LeaseRepository
+
+// LeaseRepository hands out named, expiring leases so that background jobs
+// run on a single replica at a time.
+type LeaseRepository interface {
+	// Acquire takes or renews the lease for holder until ttl from now and
+	// reports whether holder has it. A lease held by another holder can be
+	// taken once it has expired.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release gives up the lease if holder has it.
+	Release(ctx context.Context, name, holder string) error
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=medication_request.go -destination=medication_request_mocks.go -package=ports MedicationRequestRepository,MedicationRequestService
+
+type MedicationRequestRepository interface {
+	Create(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error)
+	GetByID(ctx context.Context, id string) (*models.MedicationRequest, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.MedicationRequest, error)
+	Update(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.MedicationSearch, restrictions []url.Values, limit, offset int) ([]models.MedicationRequest, int64, error)
+}
+
+type MedicationRequestService interface {
+	Create(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error)
+	Get(ctx context.Context, id string) (*models.MedicationRequest, error)
+	Update(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.MedicationSearch, limit, offset int) (*domain.ListResponse[models.MedicationRequest], error)
+}
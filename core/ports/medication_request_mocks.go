@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: medication_request.go
+//
+// Generated by this command:
+//
+//	mockgen -source=medication_request.go -destination=medication_request_mocks.go -package=ports MedicationRequestRepository,MedicationRequestService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMedicationRequestRepository is a mock of MedicationRequestRepository interface.
+type MockMedicationRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMedicationRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMedicationRequestRepositoryMockRecorder is the mock recorder for MockMedicationRequestRepository.
+type MockMedicationRequestRepositoryMockRecorder struct {
+	mock *MockMedicationRequestRepository
+}
+
+// NewMockMedicationRequestRepository creates a new mock instance.
+func NewMockMedicationRequestRepository(ctrl *gomock.Controller) *MockMedicationRequestRepository {
+	mock := &MockMedicationRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockMedicationRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedicationRequestRepository) EXPECT() *MockMedicationRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMedicationRequestRepository) Create(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, mr)
+	ret0, _ := ret[0].(*models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr_2 *MockMedicationRequestRepositoryMockRecorder) Create(ctx, mr any) *gomock.Call {
+	mr_2.mock.ctrl.T.Helper()
+	return mr_2.mock.ctrl.RecordCallWithMethodType(mr_2.mock, "Create", reflect.TypeOf((*MockMedicationRequestRepository)(nil).Create), ctx, mr)
+}
+
+// Delete mocks base method.
+func (m *MockMedicationRequestRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMedicationRequestRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMedicationRequestRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockMedicationRequestRepository) GetByID(ctx context.Context, id string) (*models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMedicationRequestRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMedicationRequestRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockMedicationRequestRepository) GetByIDs(ctx context.Context, ids []string) ([]models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockMedicationRequestRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockMedicationRequestRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockMedicationRequestRepository) Search(ctx context.Context, search domain.MedicationSearch, restrictions []url.Values, limit, offset int) ([]models.MedicationRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.MedicationRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockMedicationRequestRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMedicationRequestRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockMedicationRequestRepository) Update(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, mr)
+	ret0, _ := ret[0].(*models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr_2 *MockMedicationRequestRepositoryMockRecorder) Update(ctx, mr any) *gomock.Call {
+	mr_2.mock.ctrl.T.Helper()
+	return mr_2.mock.ctrl.RecordCallWithMethodType(mr_2.mock, "Update", reflect.TypeOf((*MockMedicationRequestRepository)(nil).Update), ctx, mr)
+}
+
+// MockMedicationRequestService is a mock of MedicationRequestService interface.
+type MockMedicationRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMedicationRequestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMedicationRequestServiceMockRecorder is the mock recorder for MockMedicationRequestService.
+type MockMedicationRequestServiceMockRecorder struct {
+	mock *MockMedicationRequestService
+}
+
+// NewMockMedicationRequestService creates a new mock instance.
+func NewMockMedicationRequestService(ctrl *gomock.Controller) *MockMedicationRequestService {
+	mock := &MockMedicationRequestService{ctrl: ctrl}
+	mock.recorder = &MockMedicationRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedicationRequestService) EXPECT() *MockMedicationRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMedicationRequestService) Create(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, mr)
+	ret0, _ := ret[0].(*models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr_2 *MockMedicationRequestServiceMockRecorder) Create(ctx, mr any) *gomock.Call {
+	mr_2.mock.ctrl.T.Helper()
+	return mr_2.mock.ctrl.RecordCallWithMethodType(mr_2.mock, "Create", reflect.TypeOf((*MockMedicationRequestService)(nil).Create), ctx, mr)
+}
+
+// Delete mocks base method.
+func (m *MockMedicationRequestService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMedicationRequestServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMedicationRequestService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockMedicationRequestService) Get(ctx context.Context, id string) (*models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMedicationRequestServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMedicationRequestService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockMedicationRequestService) List(ctx context.Context, search domain.MedicationSearch, limit, offset int) (*domain.ListResponse[models.MedicationRequest], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.MedicationRequest])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMedicationRequestServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMedicationRequestService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockMedicationRequestService) Update(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, mr)
+	ret0, _ := ret[0].(*models.MedicationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr_2 *MockMedicationRequestServiceMockRecorder) Update(ctx, mr any) *gomock.Call {
+	mr_2.mock.ctrl.T.Helper()
+	return mr_2.mock.ctrl.RecordCallWithMethodType(mr_2.mock, "Update", reflect.TypeOf((*MockMedicationRequestService)(nil).Update), ctx, mr)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockMedicationRequestService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockMedicationRequestServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockMedicationRequestService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
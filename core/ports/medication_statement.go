@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=medication_statement.go -destination=medication_statement_mocks.go -package=ports MedicationStatementRepository,MedicationStatementService
+
+type MedicationStatementRepository interface {
+	Create(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error)
+	GetByID(ctx context.Context, id string) (*models.MedicationStatement, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.MedicationStatement, error)
+	Update(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.MedicationSearch, restrictions []url.Values, limit, offset int) ([]models.MedicationStatement, int64, error)
+}
+
+type MedicationStatementService interface {
+	Create(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error)
+	Get(ctx context.Context, id string) (*models.MedicationStatement, error)
+	Update(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.MedicationSearch, limit, offset int) (*domain.ListResponse[models.MedicationStatement], error)
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: medication_statement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=medication_statement.go -destination=medication_statement_mocks.go -package=ports MedicationStatementRepository,MedicationStatementService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMedicationStatementRepository is a mock of MedicationStatementRepository interface.
+type MockMedicationStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMedicationStatementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMedicationStatementRepositoryMockRecorder is the mock recorder for MockMedicationStatementRepository.
+type MockMedicationStatementRepositoryMockRecorder struct {
+	mock *MockMedicationStatementRepository
+}
+
+// NewMockMedicationStatementRepository creates a new mock instance.
+func NewMockMedicationStatementRepository(ctrl *gomock.Controller) *MockMedicationStatementRepository {
+	mock := &MockMedicationStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockMedicationStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedicationStatementRepository) EXPECT() *MockMedicationStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMedicationStatementRepository) Create(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ms)
+	ret0, _ := ret[0].(*models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMedicationStatementRepositoryMockRecorder) Create(ctx, ms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMedicationStatementRepository)(nil).Create), ctx, ms)
+}
+
+// Delete mocks base method.
+func (m *MockMedicationStatementRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMedicationStatementRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMedicationStatementRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockMedicationStatementRepository) GetByID(ctx context.Context, id string) (*models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMedicationStatementRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMedicationStatementRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockMedicationStatementRepository) GetByIDs(ctx context.Context, ids []string) ([]models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockMedicationStatementRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockMedicationStatementRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockMedicationStatementRepository) Search(ctx context.Context, search domain.MedicationSearch, restrictions []url.Values, limit, offset int) ([]models.MedicationStatement, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.MedicationStatement)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockMedicationStatementRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMedicationStatementRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockMedicationStatementRepository) Update(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, ms)
+	ret0, _ := ret[0].(*models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMedicationStatementRepositoryMockRecorder) Update(ctx, ms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMedicationStatementRepository)(nil).Update), ctx, ms)
+}
+
+// MockMedicationStatementService is a mock of MedicationStatementService interface.
+type MockMedicationStatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMedicationStatementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMedicationStatementServiceMockRecorder is the mock recorder for MockMedicationStatementService.
+type MockMedicationStatementServiceMockRecorder struct {
+	mock *MockMedicationStatementService
+}
+
+// NewMockMedicationStatementService creates a new mock instance.
+func NewMockMedicationStatementService(ctrl *gomock.Controller) *MockMedicationStatementService {
+	mock := &MockMedicationStatementService{ctrl: ctrl}
+	mock.recorder = &MockMedicationStatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedicationStatementService) EXPECT() *MockMedicationStatementServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMedicationStatementService) Create(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ms)
+	ret0, _ := ret[0].(*models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMedicationStatementServiceMockRecorder) Create(ctx, ms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMedicationStatementService)(nil).Create), ctx, ms)
+}
+
+// Delete mocks base method.
+func (m *MockMedicationStatementService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMedicationStatementServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMedicationStatementService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockMedicationStatementService) Get(ctx context.Context, id string) (*models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMedicationStatementServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMedicationStatementService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockMedicationStatementService) List(ctx context.Context, search domain.MedicationSearch, limit, offset int) (*domain.ListResponse[models.MedicationStatement], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.MedicationStatement])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMedicationStatementServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMedicationStatementService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockMedicationStatementService) Update(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, ms)
+	ret0, _ := ret[0].(*models.MedicationStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMedicationStatementServiceMockRecorder) Update(ctx, ms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMedicationStatementService)(nil).Update), ctx, ms)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockMedicationStatementService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockMedicationStatementServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockMedicationStatementService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
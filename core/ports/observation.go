@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 	models "github.com/gruzdev-dev/fhir/r5"
@@ -15,13 +16,14 @@ type ObservationRepository interface {
 	GetByIDs(ctx context.Context, ids []string) ([]models.Observation, error)
 	Update(ctx context.Context, obs *models.Observation) (*models.Observation, error)
 	Delete(ctx context.Context, id string) error
-	Search(ctx context.Context, patientID string, limit, offset int) ([]models.Observation, int64, error)
+	Search(ctx context.Context, search domain.ObservationSearch, restrictions []url.Values, limit, offset int) ([]models.Observation, int64, error)
 }
 
 type ObservationService interface {
 	Create(ctx context.Context, obs *models.Observation) (*models.Observation, error)
 	Get(ctx context.Context, id string) (*models.Observation, error)
 	Update(ctx context.Context, obs *models.Observation) (*models.Observation, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Observation], error)
+	List(ctx context.Context, search domain.ObservationSearch, limit, offset int) (*domain.ListResponse[models.Observation], error)
 }
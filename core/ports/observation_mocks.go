@@ -11,6 +11,7 @@ package ports
 
 import (
 	context "context"
+	url "net/url"
 	reflect "reflect"
 
 	domain "github.com/gruzdev-dev/codex-documents/core/domain"
@@ -102,9 +103,9 @@ func (mr *MockObservationRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.
 }
 
 // Search mocks base method.
-func (m *MockObservationRepository) Search(ctx context.Context, patientID string, limit, offset int) ([]models.Observation, int64, error) {
+func (m *MockObservationRepository) Search(ctx context.Context, search domain.ObservationSearch, restrictions []url.Values, limit, offset int) ([]models.Observation, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, patientID, limit, offset)
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
 	ret0, _ := ret[0].([]models.Observation)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -112,9 +113,9 @@ func (m *MockObservationRepository) Search(ctx context.Context, patientID string
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockObservationRepositoryMockRecorder) Search(ctx, patientID, limit, offset any) *gomock.Call {
+func (mr *MockObservationRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockObservationRepository)(nil).Search), ctx, patientID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockObservationRepository)(nil).Search), ctx, search, restrictions, limit, offset)
 }
 
 // Update mocks base method.
@@ -201,18 +202,18 @@ func (mr *MockObservationServiceMockRecorder) Get(ctx, id any) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockObservationService) List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Observation], error) {
+func (m *MockObservationService) List(ctx context.Context, search domain.ObservationSearch, limit, offset int) (*domain.ListResponse[models.Observation], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, patientID, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
 	ret0, _ := ret[0].(*domain.ListResponse[models.Observation])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockObservationServiceMockRecorder) List(ctx, patientID, limit, offset any) *gomock.Call {
+func (mr *MockObservationServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockObservationService)(nil).List), ctx, patientID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockObservationService)(nil).List), ctx, search, limit, offset)
 }
 
 // Update mocks base method.
@@ -229,3 +230,18 @@ func (mr *MockObservationServiceMockRecorder) Update(ctx, obs any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockObservationService)(nil).Update), ctx, obs)
 }
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockObservationService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockObservationServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockObservationService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
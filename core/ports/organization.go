@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=organization.go -destination=organization_mocks.go -package=ports OrganizationRepository,OrganizationService,LocationRepository,LocationService
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) (*models.Organization, error)
+	GetByID(ctx context.Context, id string) (*models.Organization, error)
+	Update(ctx context.Context, org *models.Organization) (*models.Organization, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.DirectorySearch, limit, offset int) ([]models.Organization, int64, error)
+}
+
+type OrganizationService interface {
+	Create(ctx context.Context, org *models.Organization) (*models.Organization, error)
+	Get(ctx context.Context, id string) (*models.Organization, error)
+	Update(ctx context.Context, org *models.Organization) (*models.Organization, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.DirectorySearch, limit, offset int) (*domain.ListResponse[models.Organization], error)
+}
+
+type LocationRepository interface {
+	Create(ctx context.Context, loc *models.Location) (*models.Location, error)
+	GetByID(ctx context.Context, id string) (*models.Location, error)
+	Update(ctx context.Context, loc *models.Location) (*models.Location, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.DirectorySearch, limit, offset int) ([]models.Location, int64, error)
+}
+
+type LocationService interface {
+	Create(ctx context.Context, loc *models.Location) (*models.Location, error)
+	Get(ctx context.Context, id string) (*models.Location, error)
+	Update(ctx context.Context, loc *models.Location) (*models.Location, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.DirectorySearch, limit, offset int) (*domain.ListResponse[models.Location], error)
+}
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=practitioner.go -destination=practitioner_mocks.go -package=ports PractitionerRepository,PractitionerService,PractitionerRoleRepository,PractitionerRoleService
+
+type PractitionerRepository interface {
+	Create(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error)
+	GetByID(ctx context.Context, id string) (*models.Practitioner, error)
+	Update(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error)
+}
+
+type PractitionerService interface {
+	Create(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error)
+	Get(ctx context.Context, id string) (*models.Practitioner, error)
+	Update(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error)
+}
+
+type PractitionerRoleRepository interface {
+	Create(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error)
+	GetByID(ctx context.Context, id string) (*models.PractitionerRole, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, practitionerID string, limit, offset int) ([]models.PractitionerRole, int64, error)
+}
+
+type PractitionerRoleService interface {
+	Create(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error)
+	Get(ctx context.Context, id string) (*models.PractitionerRole, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, practitionerID string, limit, offset int) (*domain.ListResponse[models.PractitionerRole], error)
+}
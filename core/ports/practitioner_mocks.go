@@ -0,0 +1,324 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: practitioner.go
+//
+// Generated by this command:
+//
+//	mockgen -source=practitioner.go -destination=practitioner_mocks.go -package=ports PractitionerRepository,PractitionerService,PractitionerRoleRepository,PractitionerRoleService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPractitionerRepository is a mock of PractitionerRepository interface.
+type MockPractitionerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPractitionerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPractitionerRepositoryMockRecorder is the mock recorder for MockPractitionerRepository.
+type MockPractitionerRepositoryMockRecorder struct {
+	mock *MockPractitionerRepository
+}
+
+// NewMockPractitionerRepository creates a new mock instance.
+func NewMockPractitionerRepository(ctrl *gomock.Controller) *MockPractitionerRepository {
+	mock := &MockPractitionerRepository{ctrl: ctrl}
+	mock.recorder = &MockPractitionerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPractitionerRepository) EXPECT() *MockPractitionerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPractitionerRepository) Create(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, practitioner)
+	ret0, _ := ret[0].(*models.Practitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPractitionerRepositoryMockRecorder) Create(ctx, practitioner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPractitionerRepository)(nil).Create), ctx, practitioner)
+}
+
+// GetByID mocks base method.
+func (m *MockPractitionerRepository) GetByID(ctx context.Context, id string) (*models.Practitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Practitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPractitionerRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPractitionerRepository)(nil).GetByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPractitionerRepository) Update(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, practitioner)
+	ret0, _ := ret[0].(*models.Practitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPractitionerRepositoryMockRecorder) Update(ctx, practitioner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPractitionerRepository)(nil).Update), ctx, practitioner)
+}
+
+// MockPractitionerService is a mock of PractitionerService interface.
+type MockPractitionerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPractitionerServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPractitionerServiceMockRecorder is the mock recorder for MockPractitionerService.
+type MockPractitionerServiceMockRecorder struct {
+	mock *MockPractitionerService
+}
+
+// NewMockPractitionerService creates a new mock instance.
+func NewMockPractitionerService(ctrl *gomock.Controller) *MockPractitionerService {
+	mock := &MockPractitionerService{ctrl: ctrl}
+	mock.recorder = &MockPractitionerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPractitionerService) EXPECT() *MockPractitionerServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPractitionerService) Create(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, practitioner)
+	ret0, _ := ret[0].(*models.Practitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPractitionerServiceMockRecorder) Create(ctx, practitioner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPractitionerService)(nil).Create), ctx, practitioner)
+}
+
+// Get mocks base method.
+func (m *MockPractitionerService) Get(ctx context.Context, id string) (*models.Practitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Practitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPractitionerServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPractitionerService)(nil).Get), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPractitionerService) Update(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, practitioner)
+	ret0, _ := ret[0].(*models.Practitioner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPractitionerServiceMockRecorder) Update(ctx, practitioner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPractitionerService)(nil).Update), ctx, practitioner)
+}
+
+// MockPractitionerRoleRepository is a mock of PractitionerRoleRepository interface.
+type MockPractitionerRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPractitionerRoleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPractitionerRoleRepositoryMockRecorder is the mock recorder for MockPractitionerRoleRepository.
+type MockPractitionerRoleRepositoryMockRecorder struct {
+	mock *MockPractitionerRoleRepository
+}
+
+// NewMockPractitionerRoleRepository creates a new mock instance.
+func NewMockPractitionerRoleRepository(ctrl *gomock.Controller) *MockPractitionerRoleRepository {
+	mock := &MockPractitionerRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockPractitionerRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPractitionerRoleRepository) EXPECT() *MockPractitionerRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPractitionerRoleRepository) Create(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(*models.PractitionerRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPractitionerRoleRepositoryMockRecorder) Create(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPractitionerRoleRepository)(nil).Create), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockPractitionerRoleRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPractitionerRoleRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPractitionerRoleRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockPractitionerRoleRepository) GetByID(ctx context.Context, id string) (*models.PractitionerRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.PractitionerRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPractitionerRoleRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPractitionerRoleRepository)(nil).GetByID), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockPractitionerRoleRepository) Search(ctx context.Context, practitionerID string, limit, offset int) ([]models.PractitionerRole, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, practitionerID, limit, offset)
+	ret0, _ := ret[0].([]models.PractitionerRole)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPractitionerRoleRepositoryMockRecorder) Search(ctx, practitionerID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPractitionerRoleRepository)(nil).Search), ctx, practitionerID, limit, offset)
+}
+
+// MockPractitionerRoleService is a mock of PractitionerRoleService interface.
+type MockPractitionerRoleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPractitionerRoleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPractitionerRoleServiceMockRecorder is the mock recorder for MockPractitionerRoleService.
+type MockPractitionerRoleServiceMockRecorder struct {
+	mock *MockPractitionerRoleService
+}
+
+// NewMockPractitionerRoleService creates a new mock instance.
+func NewMockPractitionerRoleService(ctrl *gomock.Controller) *MockPractitionerRoleService {
+	mock := &MockPractitionerRoleService{ctrl: ctrl}
+	mock.recorder = &MockPractitionerRoleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPractitionerRoleService) EXPECT() *MockPractitionerRoleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPractitionerRoleService) Create(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(*models.PractitionerRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPractitionerRoleServiceMockRecorder) Create(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPractitionerRoleService)(nil).Create), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockPractitionerRoleService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPractitionerRoleServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPractitionerRoleService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockPractitionerRoleService) Get(ctx context.Context, id string) (*models.PractitionerRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.PractitionerRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPractitionerRoleServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPractitionerRoleService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockPractitionerRoleService) List(ctx context.Context, practitionerID string, limit, offset int) (*domain.ListResponse[models.PractitionerRole], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, practitionerID, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.PractitionerRole])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPractitionerRoleServiceMockRecorder) List(ctx, practitionerID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPractitionerRoleService)(nil).List), ctx, practitionerID, limit, offset)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=procedure.go -destination=procedure_mocks.go -package=ports ProcedureRepository,ProcedureService
+
+type ProcedureRepository interface {
+	Create(ctx context.Context, proc *models.Procedure) (*models.Procedure, error)
+	GetByID(ctx context.Context, id string) (*models.Procedure, error)
+	GetByIDs(ctx context.Context, ids []string) ([]models.Procedure, error)
+	Update(ctx context.Context, proc *models.Procedure) (*models.Procedure, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.ProcedureSearch, restrictions []url.Values, limit, offset int) ([]models.Procedure, int64, error)
+}
+
+type ProcedureService interface {
+	Create(ctx context.Context, proc *models.Procedure) (*models.Procedure, error)
+	Get(ctx context.Context, id string) (*models.Procedure, error)
+	Update(ctx context.Context, proc *models.Procedure) (*models.Procedure, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.ProcedureSearch, limit, offset int) (*domain.ListResponse[models.Procedure], error)
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: procedure.go
+//
+// Generated by this command:
+//
+//	mockgen -source=procedure.go -destination=procedure_mocks.go -package=ports ProcedureRepository,ProcedureService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProcedureRepository is a mock of ProcedureRepository interface.
+type MockProcedureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcedureRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProcedureRepositoryMockRecorder is the mock recorder for MockProcedureRepository.
+type MockProcedureRepositoryMockRecorder struct {
+	mock *MockProcedureRepository
+}
+
+// NewMockProcedureRepository creates a new mock instance.
+func NewMockProcedureRepository(ctrl *gomock.Controller) *MockProcedureRepository {
+	mock := &MockProcedureRepository{ctrl: ctrl}
+	mock.recorder = &MockProcedureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcedureRepository) EXPECT() *MockProcedureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProcedureRepository) Create(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, proc)
+	ret0, _ := ret[0].(*models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProcedureRepositoryMockRecorder) Create(ctx, proc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProcedureRepository)(nil).Create), ctx, proc)
+}
+
+// Delete mocks base method.
+func (m *MockProcedureRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProcedureRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProcedureRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockProcedureRepository) GetByID(ctx context.Context, id string) (*models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockProcedureRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProcedureRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockProcedureRepository) GetByIDs(ctx context.Context, ids []string) ([]models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockProcedureRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockProcedureRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// Search mocks base method.
+func (m *MockProcedureRepository) Search(ctx context.Context, search domain.ProcedureSearch, restrictions []url.Values, limit, offset int) ([]models.Procedure, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.Procedure)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockProcedureRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockProcedureRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockProcedureRepository) Update(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, proc)
+	ret0, _ := ret[0].(*models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProcedureRepositoryMockRecorder) Update(ctx, proc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProcedureRepository)(nil).Update), ctx, proc)
+}
+
+// MockProcedureService is a mock of ProcedureService interface.
+type MockProcedureService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcedureServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProcedureServiceMockRecorder is the mock recorder for MockProcedureService.
+type MockProcedureServiceMockRecorder struct {
+	mock *MockProcedureService
+}
+
+// NewMockProcedureService creates a new mock instance.
+func NewMockProcedureService(ctrl *gomock.Controller) *MockProcedureService {
+	mock := &MockProcedureService{ctrl: ctrl}
+	mock.recorder = &MockProcedureServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcedureService) EXPECT() *MockProcedureServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProcedureService) Create(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, proc)
+	ret0, _ := ret[0].(*models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProcedureServiceMockRecorder) Create(ctx, proc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProcedureService)(nil).Create), ctx, proc)
+}
+
+// Delete mocks base method.
+func (m *MockProcedureService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProcedureServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProcedureService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockProcedureService) Get(ctx context.Context, id string) (*models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProcedureServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProcedureService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockProcedureService) List(ctx context.Context, search domain.ProcedureSearch, limit, offset int) (*domain.ListResponse[models.Procedure], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Procedure])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProcedureServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProcedureService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockProcedureService) Update(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, proc)
+	ret0, _ := ret[0].(*models.Procedure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProcedureServiceMockRecorder) Update(ctx, proc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProcedureService)(nil).Update), ctx, proc)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockProcedureService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockProcedureServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockProcedureService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=questionnaire.go -destination=questionnaire_mocks.go -package=ports QuestionnaireRepository,QuestionnaireService,QuestionnaireResponseRepository,QuestionnaireResponseService
+
+type QuestionnaireRepository interface {
+	Create(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error)
+	GetByID(ctx context.Context, id string) (*models.Questionnaire, error)
+	// GetByURL returns the questionnaire with a canonical URL, in the given
+	// version or, when version is empty, the most recently stored one.
+	GetByURL(ctx context.Context, url, version string) (*models.Questionnaire, error)
+	Update(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.QuestionnaireSearch, limit, offset int) ([]models.Questionnaire, int64, error)
+}
+
+type QuestionnaireService interface {
+	Create(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error)
+	Get(ctx context.Context, id string) (*models.Questionnaire, error)
+	Update(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.QuestionnaireSearch, limit, offset int) (*domain.ListResponse[models.Questionnaire], error)
+}
+
+type QuestionnaireResponseRepository interface {
+	Create(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error)
+	GetByID(ctx context.Context, id string) (*models.QuestionnaireResponse, error)
+	Update(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.QuestionnaireResponseSearch, restrictions []url.Values, limit, offset int) ([]models.QuestionnaireResponse, int64, error)
+}
+
+type QuestionnaireResponseService interface {
+	Create(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error)
+	Get(ctx context.Context, id string) (*models.QuestionnaireResponse, error)
+	Update(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error)
+	UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.QuestionnaireResponseSearch, limit, offset int) (*domain.ListResponse[models.QuestionnaireResponse], error)
+	Extract(ctx context.Context, id string) ([]models.Observation, error)
+}
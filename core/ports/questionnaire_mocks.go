@@ -0,0 +1,459 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: questionnaire.go
+//
+// Generated by this command:
+//
+//	mockgen -source=questionnaire.go -destination=questionnaire_mocks.go -package=ports QuestionnaireRepository,QuestionnaireService,QuestionnaireResponseRepository,QuestionnaireResponseService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuestionnaireRepository is a mock of QuestionnaireRepository interface.
+type MockQuestionnaireRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionnaireRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuestionnaireRepositoryMockRecorder is the mock recorder for MockQuestionnaireRepository.
+type MockQuestionnaireRepositoryMockRecorder struct {
+	mock *MockQuestionnaireRepository
+}
+
+// NewMockQuestionnaireRepository creates a new mock instance.
+func NewMockQuestionnaireRepository(ctrl *gomock.Controller) *MockQuestionnaireRepository {
+	mock := &MockQuestionnaireRepository{ctrl: ctrl}
+	mock.recorder = &MockQuestionnaireRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionnaireRepository) EXPECT() *MockQuestionnaireRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockQuestionnaireRepository) Create(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, q)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuestionnaireRepositoryMockRecorder) Create(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuestionnaireRepository)(nil).Create), ctx, q)
+}
+
+// Delete mocks base method.
+func (m *MockQuestionnaireRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockQuestionnaireRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuestionnaireRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockQuestionnaireRepository) GetByID(ctx context.Context, id string) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockQuestionnaireRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockQuestionnaireRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByURL mocks base method.
+func (m *MockQuestionnaireRepository) GetByURL(ctx context.Context, arg1, version string) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByURL", ctx, arg1, version)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByURL indicates an expected call of GetByURL.
+func (mr *MockQuestionnaireRepositoryMockRecorder) GetByURL(ctx, arg1, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByURL", reflect.TypeOf((*MockQuestionnaireRepository)(nil).GetByURL), ctx, arg1, version)
+}
+
+// Search mocks base method.
+func (m *MockQuestionnaireRepository) Search(ctx context.Context, search domain.QuestionnaireSearch, limit, offset int) ([]models.Questionnaire, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, limit, offset)
+	ret0, _ := ret[0].([]models.Questionnaire)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockQuestionnaireRepositoryMockRecorder) Search(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockQuestionnaireRepository)(nil).Search), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockQuestionnaireRepository) Update(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, q)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQuestionnaireRepositoryMockRecorder) Update(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuestionnaireRepository)(nil).Update), ctx, q)
+}
+
+// MockQuestionnaireService is a mock of QuestionnaireService interface.
+type MockQuestionnaireService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionnaireServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuestionnaireServiceMockRecorder is the mock recorder for MockQuestionnaireService.
+type MockQuestionnaireServiceMockRecorder struct {
+	mock *MockQuestionnaireService
+}
+
+// NewMockQuestionnaireService creates a new mock instance.
+func NewMockQuestionnaireService(ctrl *gomock.Controller) *MockQuestionnaireService {
+	mock := &MockQuestionnaireService{ctrl: ctrl}
+	mock.recorder = &MockQuestionnaireServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionnaireService) EXPECT() *MockQuestionnaireServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockQuestionnaireService) Create(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, q)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuestionnaireServiceMockRecorder) Create(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuestionnaireService)(nil).Create), ctx, q)
+}
+
+// Delete mocks base method.
+func (m *MockQuestionnaireService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockQuestionnaireServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuestionnaireService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockQuestionnaireService) Get(ctx context.Context, id string) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockQuestionnaireServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQuestionnaireService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockQuestionnaireService) List(ctx context.Context, search domain.QuestionnaireSearch, limit, offset int) (*domain.ListResponse[models.Questionnaire], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Questionnaire])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockQuestionnaireServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockQuestionnaireService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockQuestionnaireService) Update(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, q)
+	ret0, _ := ret[0].(*models.Questionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQuestionnaireServiceMockRecorder) Update(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuestionnaireService)(nil).Update), ctx, q)
+}
+
+// MockQuestionnaireResponseRepository is a mock of QuestionnaireResponseRepository interface.
+type MockQuestionnaireResponseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionnaireResponseRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuestionnaireResponseRepositoryMockRecorder is the mock recorder for MockQuestionnaireResponseRepository.
+type MockQuestionnaireResponseRepositoryMockRecorder struct {
+	mock *MockQuestionnaireResponseRepository
+}
+
+// NewMockQuestionnaireResponseRepository creates a new mock instance.
+func NewMockQuestionnaireResponseRepository(ctrl *gomock.Controller) *MockQuestionnaireResponseRepository {
+	mock := &MockQuestionnaireResponseRepository{ctrl: ctrl}
+	mock.recorder = &MockQuestionnaireResponseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionnaireResponseRepository) EXPECT() *MockQuestionnaireResponseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockQuestionnaireResponseRepository) Create(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, resp)
+	ret0, _ := ret[0].(*models.QuestionnaireResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuestionnaireResponseRepositoryMockRecorder) Create(ctx, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuestionnaireResponseRepository)(nil).Create), ctx, resp)
+}
+
+// Delete mocks base method.
+func (m *MockQuestionnaireResponseRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockQuestionnaireResponseRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuestionnaireResponseRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockQuestionnaireResponseRepository) GetByID(ctx context.Context, id string) (*models.QuestionnaireResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.QuestionnaireResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockQuestionnaireResponseRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockQuestionnaireResponseRepository)(nil).GetByID), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockQuestionnaireResponseRepository) Search(ctx context.Context, search domain.QuestionnaireResponseSearch, restrictions []url.Values, limit, offset int) ([]models.QuestionnaireResponse, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, restrictions, limit, offset)
+	ret0, _ := ret[0].([]models.QuestionnaireResponse)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockQuestionnaireResponseRepositoryMockRecorder) Search(ctx, search, restrictions, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockQuestionnaireResponseRepository)(nil).Search), ctx, search, restrictions, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockQuestionnaireResponseRepository) Update(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, resp)
+	ret0, _ := ret[0].(*models.QuestionnaireResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQuestionnaireResponseRepositoryMockRecorder) Update(ctx, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuestionnaireResponseRepository)(nil).Update), ctx, resp)
+}
+
+// MockQuestionnaireResponseService is a mock of QuestionnaireResponseService interface.
+type MockQuestionnaireResponseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionnaireResponseServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuestionnaireResponseServiceMockRecorder is the mock recorder for MockQuestionnaireResponseService.
+type MockQuestionnaireResponseServiceMockRecorder struct {
+	mock *MockQuestionnaireResponseService
+}
+
+// NewMockQuestionnaireResponseService creates a new mock instance.
+func NewMockQuestionnaireResponseService(ctrl *gomock.Controller) *MockQuestionnaireResponseService {
+	mock := &MockQuestionnaireResponseService{ctrl: ctrl}
+	mock.recorder = &MockQuestionnaireResponseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionnaireResponseService) EXPECT() *MockQuestionnaireResponseServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockQuestionnaireResponseService) Create(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, resp)
+	ret0, _ := ret[0].(*models.QuestionnaireResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) Create(ctx, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).Create), ctx, resp)
+}
+
+// Delete mocks base method.
+func (m *MockQuestionnaireResponseService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).Delete), ctx, id)
+}
+
+// Extract mocks base method.
+func (m *MockQuestionnaireResponseService) Extract(ctx context.Context, id string) ([]models.Observation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extract", ctx, id)
+	ret0, _ := ret[0].([]models.Observation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Extract indicates an expected call of Extract.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) Extract(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extract", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).Extract), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockQuestionnaireResponseService) Get(ctx context.Context, id string) (*models.QuestionnaireResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.QuestionnaireResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockQuestionnaireResponseService) List(ctx context.Context, search domain.QuestionnaireResponseSearch, limit, offset int) (*domain.ListResponse[models.QuestionnaireResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.QuestionnaireResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockQuestionnaireResponseService) Update(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, resp)
+	ret0, _ := ret[0].(*models.QuestionnaireResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) Update(ctx, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).Update), ctx, resp)
+}
+
+// UpdateSecurityLabels mocks base method.
+func (m *MockQuestionnaireResponseService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecurityLabels", ctx, id, add, remove)
+	ret0, _ := ret[0].(*models.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecurityLabels indicates an expected call of UpdateSecurityLabels.
+func (mr *MockQuestionnaireResponseServiceMockRecorder) UpdateSecurityLabels(ctx, id, add, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecurityLabels", reflect.TypeOf((*MockQuestionnaireResponseService)(nil).UpdateSecurityLabels), ctx, id, add, remove)
+}
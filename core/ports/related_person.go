@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=related_person.go -destination=related_person_mocks.go -package=ports RelatedPersonRepository,RelatedPersonService,DelegationRepository,DelegationService
+
+type RelatedPersonRepository interface {
+	Create(ctx context.Context, person *models.RelatedPerson) (*models.RelatedPerson, error)
+	GetByID(ctx context.Context, id string) (*models.RelatedPerson, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, patientID string, limit, offset int) ([]models.RelatedPerson, int64, error)
+}
+
+type RelatedPersonService interface {
+	Create(ctx context.Context, person *models.RelatedPerson) (*models.RelatedPerson, error)
+	Get(ctx context.Context, id string) (*models.RelatedPerson, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.RelatedPerson], error)
+}
+
+type DelegationRepository interface {
+	Upsert(ctx context.Context, d *domain.Delegation) (*domain.Delegation, error)
+	Find(ctx context.Context, patientID, userID string) (*domain.Delegation, error)
+	ListByPatient(ctx context.Context, patientID string) ([]domain.Delegation, error)
+	ListByUser(ctx context.Context, userID string) ([]domain.Delegation, error)
+	Delete(ctx context.Context, patientID, userID string) error
+	DeleteByRelatedPerson(ctx context.Context, relatedPersonID string) error
+}
+
+type DelegationService interface {
+	Grant(ctx context.Context, req domain.GrantDelegationRequest) (*domain.Delegation, error)
+	List(ctx context.Context, patientID string) ([]domain.Delegation, error)
+	ListMine(ctx context.Context) ([]domain.Delegation, error)
+	Revoke(ctx context.Context, patientID, userID string) error
+}
@@ -0,0 +1,381 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: related_person.go
+//
+// Generated by this command:
+//
+//	mockgen -source=related_person.go -destination=related_person_mocks.go -package=ports RelatedPersonRepository,RelatedPersonService,DelegationRepository,DelegationService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRelatedPersonRepository is a mock of RelatedPersonRepository interface.
+type MockRelatedPersonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelatedPersonRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRelatedPersonRepositoryMockRecorder is the mock recorder for MockRelatedPersonRepository.
+type MockRelatedPersonRepositoryMockRecorder struct {
+	mock *MockRelatedPersonRepository
+}
+
+// NewMockRelatedPersonRepository creates a new mock instance.
+func NewMockRelatedPersonRepository(ctrl *gomock.Controller) *MockRelatedPersonRepository {
+	mock := &MockRelatedPersonRepository{ctrl: ctrl}
+	mock.recorder = &MockRelatedPersonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelatedPersonRepository) EXPECT() *MockRelatedPersonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRelatedPersonRepository) Create(ctx context.Context, person *models.RelatedPerson) (*models.RelatedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, person)
+	ret0, _ := ret[0].(*models.RelatedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRelatedPersonRepositoryMockRecorder) Create(ctx, person any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRelatedPersonRepository)(nil).Create), ctx, person)
+}
+
+// Delete mocks base method.
+func (m *MockRelatedPersonRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRelatedPersonRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRelatedPersonRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockRelatedPersonRepository) GetByID(ctx context.Context, id string) (*models.RelatedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.RelatedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRelatedPersonRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRelatedPersonRepository)(nil).GetByID), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockRelatedPersonRepository) Search(ctx context.Context, patientID string, limit, offset int) ([]models.RelatedPerson, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, patientID, limit, offset)
+	ret0, _ := ret[0].([]models.RelatedPerson)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRelatedPersonRepositoryMockRecorder) Search(ctx, patientID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRelatedPersonRepository)(nil).Search), ctx, patientID, limit, offset)
+}
+
+// MockRelatedPersonService is a mock of RelatedPersonService interface.
+type MockRelatedPersonService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelatedPersonServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRelatedPersonServiceMockRecorder is the mock recorder for MockRelatedPersonService.
+type MockRelatedPersonServiceMockRecorder struct {
+	mock *MockRelatedPersonService
+}
+
+// NewMockRelatedPersonService creates a new mock instance.
+func NewMockRelatedPersonService(ctrl *gomock.Controller) *MockRelatedPersonService {
+	mock := &MockRelatedPersonService{ctrl: ctrl}
+	mock.recorder = &MockRelatedPersonServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelatedPersonService) EXPECT() *MockRelatedPersonServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRelatedPersonService) Create(ctx context.Context, person *models.RelatedPerson) (*models.RelatedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, person)
+	ret0, _ := ret[0].(*models.RelatedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRelatedPersonServiceMockRecorder) Create(ctx, person any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRelatedPersonService)(nil).Create), ctx, person)
+}
+
+// Delete mocks base method.
+func (m *MockRelatedPersonService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRelatedPersonServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRelatedPersonService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockRelatedPersonService) Get(ctx context.Context, id string) (*models.RelatedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.RelatedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRelatedPersonServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRelatedPersonService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockRelatedPersonService) List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.RelatedPerson], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, patientID, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.RelatedPerson])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRelatedPersonServiceMockRecorder) List(ctx, patientID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRelatedPersonService)(nil).List), ctx, patientID, limit, offset)
+}
+
+// MockDelegationRepository is a mock of DelegationRepository interface.
+type MockDelegationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDelegationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDelegationRepositoryMockRecorder is the mock recorder for MockDelegationRepository.
+type MockDelegationRepositoryMockRecorder struct {
+	mock *MockDelegationRepository
+}
+
+// NewMockDelegationRepository creates a new mock instance.
+func NewMockDelegationRepository(ctrl *gomock.Controller) *MockDelegationRepository {
+	mock := &MockDelegationRepository{ctrl: ctrl}
+	mock.recorder = &MockDelegationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDelegationRepository) EXPECT() *MockDelegationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDelegationRepository) Delete(ctx context.Context, patientID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, patientID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDelegationRepositoryMockRecorder) Delete(ctx, patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDelegationRepository)(nil).Delete), ctx, patientID, userID)
+}
+
+// DeleteByRelatedPerson mocks base method.
+func (m *MockDelegationRepository) DeleteByRelatedPerson(ctx context.Context, relatedPersonID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByRelatedPerson", ctx, relatedPersonID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByRelatedPerson indicates an expected call of DeleteByRelatedPerson.
+func (mr *MockDelegationRepositoryMockRecorder) DeleteByRelatedPerson(ctx, relatedPersonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByRelatedPerson", reflect.TypeOf((*MockDelegationRepository)(nil).DeleteByRelatedPerson), ctx, relatedPersonID)
+}
+
+// Find mocks base method.
+func (m *MockDelegationRepository) Find(ctx context.Context, patientID, userID string) (*domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, patientID, userID)
+	ret0, _ := ret[0].(*domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockDelegationRepositoryMockRecorder) Find(ctx, patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockDelegationRepository)(nil).Find), ctx, patientID, userID)
+}
+
+// ListByPatient mocks base method.
+func (m *MockDelegationRepository) ListByPatient(ctx context.Context, patientID string) ([]domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPatient", ctx, patientID)
+	ret0, _ := ret[0].([]domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPatient indicates an expected call of ListByPatient.
+func (mr *MockDelegationRepositoryMockRecorder) ListByPatient(ctx, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPatient", reflect.TypeOf((*MockDelegationRepository)(nil).ListByPatient), ctx, patientID)
+}
+
+// ListByUser mocks base method.
+func (m *MockDelegationRepository) ListByUser(ctx context.Context, userID string) ([]domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockDelegationRepositoryMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockDelegationRepository)(nil).ListByUser), ctx, userID)
+}
+
+// Upsert mocks base method.
+func (m *MockDelegationRepository) Upsert(ctx context.Context, d *domain.Delegation) (*domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, d)
+	ret0, _ := ret[0].(*domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockDelegationRepositoryMockRecorder) Upsert(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockDelegationRepository)(nil).Upsert), ctx, d)
+}
+
+// MockDelegationService is a mock of DelegationService interface.
+type MockDelegationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDelegationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDelegationServiceMockRecorder is the mock recorder for MockDelegationService.
+type MockDelegationServiceMockRecorder struct {
+	mock *MockDelegationService
+}
+
+// NewMockDelegationService creates a new mock instance.
+func NewMockDelegationService(ctrl *gomock.Controller) *MockDelegationService {
+	mock := &MockDelegationService{ctrl: ctrl}
+	mock.recorder = &MockDelegationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDelegationService) EXPECT() *MockDelegationServiceMockRecorder {
+	return m.recorder
+}
+
+// Grant mocks base method.
+func (m *MockDelegationService) Grant(ctx context.Context, req domain.GrantDelegationRequest) (*domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", ctx, req)
+	ret0, _ := ret[0].(*domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockDelegationServiceMockRecorder) Grant(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockDelegationService)(nil).Grant), ctx, req)
+}
+
+// List mocks base method.
+func (m *MockDelegationService) List(ctx context.Context, patientID string) ([]domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, patientID)
+	ret0, _ := ret[0].([]domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDelegationServiceMockRecorder) List(ctx, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDelegationService)(nil).List), ctx, patientID)
+}
+
+// ListMine mocks base method.
+func (m *MockDelegationService) ListMine(ctx context.Context) ([]domain.Delegation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMine", ctx)
+	ret0, _ := ret[0].([]domain.Delegation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMine indicates an expected call of ListMine.
+func (mr *MockDelegationServiceMockRecorder) ListMine(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMine", reflect.TypeOf((*MockDelegationService)(nil).ListMine), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockDelegationService) Revoke(ctx context.Context, patientID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, patientID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockDelegationServiceMockRecorder) Revoke(ctx, patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockDelegationService)(nil).Revoke), ctx, patientID, userID)
+}
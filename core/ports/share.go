@@ -6,9 +6,22 @@ import (
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 )
 
-//go:generate mockgen -source=share.go -destination=share_mocks.go -package=ports ShareService
+//go:generate mockgen -source=share.go -destination=share_mocks.go -package=ports ShareService,SHLRepository
 
 type ShareService interface {
 	Share(ctx context.Context, req domain.ShareRequest) (*domain.ShareResponse, error)
 	GetSharedResources(ctx context.Context) (*domain.SharedResourcesResponse, error)
+	GetSharedBundle(ctx context.Context, req domain.SharedBundleRequest) (*domain.SharedBundle, error)
+	CreateSHL(ctx context.Context, req domain.SHLRequest) (*domain.SHLResponse, error)
+	GetSHLManifest(ctx context.Context, id string, req domain.SHLManifestRequest) (*domain.SHLManifest, error)
+}
+
+type SHLRepository interface {
+	Create(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error)
+	GetByID(ctx context.Context, id string) (*domain.SmartHealthLink, error)
+	// DecrementAttempts uses up one passcode attempt of a link and returns
+	// the attempts left. It reports false, leaving the link unchanged, when
+	// the link has no attempts left.
+	DecrementAttempts(ctx context.Context, id string) (int, bool, error)
+	ResetAttempts(ctx context.Context, id string, attempts int) error
 }
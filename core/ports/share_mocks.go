@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=share.go -destination=share_mocks.go -package=ports ShareService
+//	mockgen -source=share.go -destination=share_mocks.go -package=ports ShareService,SHLRepository
 //
 
 // Package ports is a generated GoMock package.
@@ -41,6 +41,51 @@ func (m *MockShareService) EXPECT() *MockShareServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateSHL mocks base method.
+func (m *MockShareService) CreateSHL(ctx context.Context, req domain.SHLRequest) (*domain.SHLResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSHL", ctx, req)
+	ret0, _ := ret[0].(*domain.SHLResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSHL indicates an expected call of CreateSHL.
+func (mr *MockShareServiceMockRecorder) CreateSHL(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSHL", reflect.TypeOf((*MockShareService)(nil).CreateSHL), ctx, req)
+}
+
+// GetSHLManifest mocks base method.
+func (m *MockShareService) GetSHLManifest(ctx context.Context, id string, req domain.SHLManifestRequest) (*domain.SHLManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSHLManifest", ctx, id, req)
+	ret0, _ := ret[0].(*domain.SHLManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSHLManifest indicates an expected call of GetSHLManifest.
+func (mr *MockShareServiceMockRecorder) GetSHLManifest(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSHLManifest", reflect.TypeOf((*MockShareService)(nil).GetSHLManifest), ctx, id, req)
+}
+
+// GetSharedBundle mocks base method.
+func (m *MockShareService) GetSharedBundle(ctx context.Context, req domain.SharedBundleRequest) (*domain.SharedBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedBundle", ctx, req)
+	ret0, _ := ret[0].(*domain.SharedBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedBundle indicates an expected call of GetSharedBundle.
+func (mr *MockShareServiceMockRecorder) GetSharedBundle(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedBundle", reflect.TypeOf((*MockShareService)(nil).GetSharedBundle), ctx, req)
+}
+
 // GetSharedResources mocks base method.
 func (m *MockShareService) GetSharedResources(ctx context.Context) (*domain.SharedResourcesResponse, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +115,87 @@ func (mr *MockShareServiceMockRecorder) Share(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockShareService)(nil).Share), ctx, req)
 }
+
+// MockSHLRepository is a mock of SHLRepository interface.
+type MockSHLRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSHLRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSHLRepositoryMockRecorder is the mock recorder for MockSHLRepository.
+type MockSHLRepositoryMockRecorder struct {
+	mock *MockSHLRepository
+}
+
+// NewMockSHLRepository creates a new mock instance.
+func NewMockSHLRepository(ctrl *gomock.Controller) *MockSHLRepository {
+	mock := &MockSHLRepository{ctrl: ctrl}
+	mock.recorder = &MockSHLRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSHLRepository) EXPECT() *MockSHLRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSHLRepository) Create(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, link)
+	ret0, _ := ret[0].(*domain.SmartHealthLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSHLRepositoryMockRecorder) Create(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSHLRepository)(nil).Create), ctx, link)
+}
+
+// DecrementAttempts mocks base method.
+func (m *MockSHLRepository) DecrementAttempts(ctx context.Context, id string) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementAttempts", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DecrementAttempts indicates an expected call of DecrementAttempts.
+func (mr *MockSHLRepositoryMockRecorder) DecrementAttempts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementAttempts", reflect.TypeOf((*MockSHLRepository)(nil).DecrementAttempts), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockSHLRepository) GetByID(ctx context.Context, id string) (*domain.SmartHealthLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.SmartHealthLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSHLRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSHLRepository)(nil).GetByID), ctx, id)
+}
+
+// ResetAttempts mocks base method.
+func (m *MockSHLRepository) ResetAttempts(ctx context.Context, id string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAttempts", ctx, id, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAttempts indicates an expected call of ResetAttempts.
+func (mr *MockSHLRepositoryMockRecorder) ResetAttempts(ctx, id, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockSHLRepository)(nil).ResetAttempts), ctx, id, attempts)
+}
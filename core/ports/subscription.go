@@ -0,0 +1,84 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+//go:generate mockgen -source=subscription.go -destination=subscription_mocks.go -package=ports SubscriptionTopicRepository,SubscriptionTopicService,SubscriptionRepository,SubscriptionService,SubscriptionNotificationRepository,NotificationChannel
+
+type SubscriptionTopicRepository interface {
+	Create(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error)
+	GetByID(ctx context.Context, id string) (*models.SubscriptionTopic, error)
+	// GetByURL returns the topic with a canonical URL, the most recently
+	// stored one if there are several versions.
+	GetByURL(ctx context.Context, url string) (*models.SubscriptionTopic, error)
+	Update(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.SubscriptionTopicSearch, limit, offset int) ([]models.SubscriptionTopic, int64, error)
+	// ListActive returns the active topics triggered by a resource type.
+	ListActive(ctx context.Context, resourceType string) ([]models.SubscriptionTopic, error)
+}
+
+type SubscriptionTopicService interface {
+	Create(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error)
+	Get(ctx context.Context, id string) (*models.SubscriptionTopic, error)
+	Update(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.SubscriptionTopicSearch, limit, offset int) (*domain.ListResponse[models.SubscriptionTopic], error)
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, record *domain.SubscriptionRecord) error
+	GetByID(ctx context.Context, id string) (*domain.SubscriptionRecord, error)
+	// Update replaces the subscription resource, keeping its owner and
+	// delivery state.
+	Update(ctx context.Context, sub *models.Subscription) error
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, search domain.SubscriptionSearch, limit, offset int) ([]models.Subscription, int64, error)
+	// ListActive returns the active subscriptions to any of the topics.
+	ListActive(ctx context.Context, topics []string) ([]domain.SubscriptionRecord, error)
+	// ListHeartbeat returns the active subscriptions that asked for
+	// heartbeats.
+	ListHeartbeat(ctx context.Context) ([]domain.SubscriptionRecord, error)
+	// NextEventNumber counts a new event of the subscription and returns its
+	// number.
+	NextEventNumber(ctx context.Context, id string) (int64, error)
+	MarkSent(ctx context.Context, id string, at time.Time) error
+	SetStatus(ctx context.Context, id, status string) error
+}
+
+type SubscriptionService interface {
+	Create(ctx context.Context, sub *models.Subscription) (*models.Subscription, error)
+	Get(ctx context.Context, id string) (*models.Subscription, error)
+	Update(ctx context.Context, sub *models.Subscription) (*models.Subscription, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, search domain.SubscriptionSearch, limit, offset int) (*domain.ListResponse[models.Subscription], error)
+	// Status returns the current status of a subscription as a
+	// SubscriptionStatus resource.
+	Status(ctx context.Context, id string) (*models.SubscriptionStatus, error)
+}
+
+// SubscriptionNotificationRepository queues notifications until they are
+// delivered.
+type SubscriptionNotificationRepository interface {
+	Enqueue(ctx context.Context, n *domain.SubscriptionNotification) error
+	// ListDue returns up to limit notifications due at now, ordered by
+	// subscription and event number. A subscription whose first queued
+	// notification is held for a retry has none due.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]domain.SubscriptionNotification, error)
+	Delete(ctx context.Context, id string) error
+	// Retry counts a failed attempt of the notification and holds it and the
+	// later notifications of its subscription until next.
+	Retry(ctx context.Context, n domain.SubscriptionNotification, next time.Time) error
+	DeleteBySubscription(ctx context.Context, subscriptionID string) error
+}
+
+// NotificationChannel delivers a subscription-notification bundle to the
+// endpoint of a subscription.
+type NotificationChannel interface {
+	Send(ctx context.Context, sub *models.Subscription, bundle *models.Bundle) error
+}
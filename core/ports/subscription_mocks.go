@@ -0,0 +1,663 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: subscription.go
+//
+// Generated by this command:
+//
+//	mockgen -source=subscription.go -destination=subscription_mocks.go -package=ports SubscriptionTopicRepository,SubscriptionTopicService,SubscriptionRepository,SubscriptionService,SubscriptionNotificationRepository,NotificationChannel
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	models "github.com/gruzdev-dev/fhir/r5"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionTopicRepository is a mock of SubscriptionTopicRepository interface.
+type MockSubscriptionTopicRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionTopicRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionTopicRepositoryMockRecorder is the mock recorder for MockSubscriptionTopicRepository.
+type MockSubscriptionTopicRepositoryMockRecorder struct {
+	mock *MockSubscriptionTopicRepository
+}
+
+// NewMockSubscriptionTopicRepository creates a new mock instance.
+func NewMockSubscriptionTopicRepository(ctrl *gomock.Controller) *MockSubscriptionTopicRepository {
+	mock := &MockSubscriptionTopicRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionTopicRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionTopicRepository) EXPECT() *MockSubscriptionTopicRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubscriptionTopicRepository) Create(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, topic)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) Create(ctx, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).Create), ctx, topic)
+}
+
+// Delete mocks base method.
+func (m *MockSubscriptionTopicRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockSubscriptionTopicRepository) GetByID(ctx context.Context, id string) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByURL mocks base method.
+func (m *MockSubscriptionTopicRepository) GetByURL(ctx context.Context, url string) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByURL", ctx, url)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByURL indicates an expected call of GetByURL.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) GetByURL(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByURL", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).GetByURL), ctx, url)
+}
+
+// ListActive mocks base method.
+func (m *MockSubscriptionTopicRepository) ListActive(ctx context.Context, resourceType string) ([]models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, resourceType)
+	ret0, _ := ret[0].([]models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) ListActive(ctx, resourceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).ListActive), ctx, resourceType)
+}
+
+// Search mocks base method.
+func (m *MockSubscriptionTopicRepository) Search(ctx context.Context, search domain.SubscriptionTopicSearch, limit, offset int) ([]models.SubscriptionTopic, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, limit, offset)
+	ret0, _ := ret[0].([]models.SubscriptionTopic)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) Search(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).Search), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockSubscriptionTopicRepository) Update(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, topic)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubscriptionTopicRepositoryMockRecorder) Update(ctx, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscriptionTopicRepository)(nil).Update), ctx, topic)
+}
+
+// MockSubscriptionTopicService is a mock of SubscriptionTopicService interface.
+type MockSubscriptionTopicService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionTopicServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionTopicServiceMockRecorder is the mock recorder for MockSubscriptionTopicService.
+type MockSubscriptionTopicServiceMockRecorder struct {
+	mock *MockSubscriptionTopicService
+}
+
+// NewMockSubscriptionTopicService creates a new mock instance.
+func NewMockSubscriptionTopicService(ctrl *gomock.Controller) *MockSubscriptionTopicService {
+	mock := &MockSubscriptionTopicService{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionTopicServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionTopicService) EXPECT() *MockSubscriptionTopicServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubscriptionTopicService) Create(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, topic)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubscriptionTopicServiceMockRecorder) Create(ctx, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubscriptionTopicService)(nil).Create), ctx, topic)
+}
+
+// Delete mocks base method.
+func (m *MockSubscriptionTopicService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubscriptionTopicServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionTopicService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockSubscriptionTopicService) Get(ctx context.Context, id string) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSubscriptionTopicServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubscriptionTopicService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockSubscriptionTopicService) List(ctx context.Context, search domain.SubscriptionTopicSearch, limit, offset int) (*domain.ListResponse[models.SubscriptionTopic], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.SubscriptionTopic])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSubscriptionTopicServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSubscriptionTopicService)(nil).List), ctx, search, limit, offset)
+}
+
+// Update mocks base method.
+func (m *MockSubscriptionTopicService) Update(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, topic)
+	ret0, _ := ret[0].(*models.SubscriptionTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubscriptionTopicServiceMockRecorder) Update(ctx, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscriptionTopicService)(nil).Update), ctx, topic)
+}
+
+// MockSubscriptionRepository is a mock of SubscriptionRepository interface.
+type MockSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionRepositoryMockRecorder is the mock recorder for MockSubscriptionRepository.
+type MockSubscriptionRepositoryMockRecorder struct {
+	mock *MockSubscriptionRepository
+}
+
+// NewMockSubscriptionRepository creates a new mock instance.
+func NewMockSubscriptionRepository(ctrl *gomock.Controller) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubscriptionRepository) Create(ctx context.Context, record *domain.SubscriptionRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubscriptionRepositoryMockRecorder) Create(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubscriptionRepository)(nil).Create), ctx, record)
+}
+
+// Delete mocks base method.
+func (m *MockSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubscriptionRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id string) (*domain.SubscriptionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.SubscriptionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSubscriptionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSubscriptionRepository)(nil).GetByID), ctx, id)
+}
+
+// ListActive mocks base method.
+func (m *MockSubscriptionRepository) ListActive(ctx context.Context, topics []string) ([]domain.SubscriptionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, topics)
+	ret0, _ := ret[0].([]domain.SubscriptionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockSubscriptionRepositoryMockRecorder) ListActive(ctx, topics any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockSubscriptionRepository)(nil).ListActive), ctx, topics)
+}
+
+// ListHeartbeat mocks base method.
+func (m *MockSubscriptionRepository) ListHeartbeat(ctx context.Context) ([]domain.SubscriptionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHeartbeat", ctx)
+	ret0, _ := ret[0].([]domain.SubscriptionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHeartbeat indicates an expected call of ListHeartbeat.
+func (mr *MockSubscriptionRepositoryMockRecorder) ListHeartbeat(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHeartbeat", reflect.TypeOf((*MockSubscriptionRepository)(nil).ListHeartbeat), ctx)
+}
+
+// MarkSent mocks base method.
+func (m *MockSubscriptionRepository) MarkSent(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockSubscriptionRepositoryMockRecorder) MarkSent(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockSubscriptionRepository)(nil).MarkSent), ctx, id, at)
+}
+
+// NextEventNumber mocks base method.
+func (m *MockSubscriptionRepository) NextEventNumber(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextEventNumber", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextEventNumber indicates an expected call of NextEventNumber.
+func (mr *MockSubscriptionRepositoryMockRecorder) NextEventNumber(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextEventNumber", reflect.TypeOf((*MockSubscriptionRepository)(nil).NextEventNumber), ctx, id)
+}
+
+// Search mocks base method.
+func (m *MockSubscriptionRepository) Search(ctx context.Context, search domain.SubscriptionSearch, limit, offset int) ([]models.Subscription, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, search, limit, offset)
+	ret0, _ := ret[0].([]models.Subscription)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSubscriptionRepositoryMockRecorder) Search(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSubscriptionRepository)(nil).Search), ctx, search, limit, offset)
+}
+
+// SetStatus mocks base method.
+func (m *MockSubscriptionRepository) SetStatus(ctx context.Context, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockSubscriptionRepositoryMockRecorder) SetStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockSubscriptionRepository)(nil).SetStatus), ctx, id, status)
+}
+
+// Update mocks base method.
+func (m *MockSubscriptionRepository) Update(ctx context.Context, sub *models.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubscriptionRepositoryMockRecorder) Update(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscriptionRepository)(nil).Update), ctx, sub)
+}
+
+// MockSubscriptionService is a mock of SubscriptionService interface.
+type MockSubscriptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionServiceMockRecorder is the mock recorder for MockSubscriptionService.
+type MockSubscriptionServiceMockRecorder struct {
+	mock *MockSubscriptionService
+}
+
+// NewMockSubscriptionService creates a new mock instance.
+func NewMockSubscriptionService(ctrl *gomock.Controller) *MockSubscriptionService {
+	mock := &MockSubscriptionService{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionService) EXPECT() *MockSubscriptionServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubscriptionService) Create(ctx context.Context, sub *models.Subscription) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, sub)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubscriptionServiceMockRecorder) Create(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubscriptionService)(nil).Create), ctx, sub)
+}
+
+// Delete mocks base method.
+func (m *MockSubscriptionService) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubscriptionServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockSubscriptionService) Get(ctx context.Context, id string) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSubscriptionServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubscriptionService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockSubscriptionService) List(ctx context.Context, search domain.SubscriptionSearch, limit, offset int) (*domain.ListResponse[models.Subscription], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search, limit, offset)
+	ret0, _ := ret[0].(*domain.ListResponse[models.Subscription])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSubscriptionServiceMockRecorder) List(ctx, search, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSubscriptionService)(nil).List), ctx, search, limit, offset)
+}
+
+// Status mocks base method.
+func (m *MockSubscriptionService) Status(ctx context.Context, id string) (*models.SubscriptionStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx, id)
+	ret0, _ := ret[0].(*models.SubscriptionStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockSubscriptionServiceMockRecorder) Status(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockSubscriptionService)(nil).Status), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockSubscriptionService) Update(ctx context.Context, sub *models.Subscription) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, sub)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubscriptionServiceMockRecorder) Update(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscriptionService)(nil).Update), ctx, sub)
+}
+
+// MockSubscriptionNotificationRepository is a mock of SubscriptionNotificationRepository interface.
+type MockSubscriptionNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionNotificationRepositoryMockRecorder is the mock recorder for MockSubscriptionNotificationRepository.
+type MockSubscriptionNotificationRepositoryMockRecorder struct {
+	mock *MockSubscriptionNotificationRepository
+}
+
+// NewMockSubscriptionNotificationRepository creates a new mock instance.
+func NewMockSubscriptionNotificationRepository(ctrl *gomock.Controller) *MockSubscriptionNotificationRepository {
+	mock := &MockSubscriptionNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionNotificationRepository) EXPECT() *MockSubscriptionNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSubscriptionNotificationRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubscriptionNotificationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionNotificationRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteBySubscription mocks base method.
+func (m *MockSubscriptionNotificationRepository) DeleteBySubscription(ctx context.Context, subscriptionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBySubscription", ctx, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBySubscription indicates an expected call of DeleteBySubscription.
+func (mr *MockSubscriptionNotificationRepositoryMockRecorder) DeleteBySubscription(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySubscription", reflect.TypeOf((*MockSubscriptionNotificationRepository)(nil).DeleteBySubscription), ctx, subscriptionID)
+}
+
+// Enqueue mocks base method.
+func (m *MockSubscriptionNotificationRepository) Enqueue(ctx context.Context, n *domain.SubscriptionNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockSubscriptionNotificationRepositoryMockRecorder) Enqueue(ctx, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockSubscriptionNotificationRepository)(nil).Enqueue), ctx, n)
+}
+
+// ListDue mocks base method.
+func (m *MockSubscriptionNotificationRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]domain.SubscriptionNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.SubscriptionNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockSubscriptionNotificationRepositoryMockRecorder) ListDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockSubscriptionNotificationRepository)(nil).ListDue), ctx, now, limit)
+}
+
+// Retry mocks base method.
+func (m *MockSubscriptionNotificationRepository) Retry(ctx context.Context, n domain.SubscriptionNotification, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, n, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockSubscriptionNotificationRepositoryMockRecorder) Retry(ctx, n, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockSubscriptionNotificationRepository)(nil).Retry), ctx, n, next)
+}
+
+// MockNotificationChannel is a mock of NotificationChannel interface.
+type MockNotificationChannel struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationChannelMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationChannelMockRecorder is the mock recorder for MockNotificationChannel.
+type MockNotificationChannelMockRecorder struct {
+	mock *MockNotificationChannel
+}
+
+// NewMockNotificationChannel creates a new mock instance.
+func NewMockNotificationChannel(ctrl *gomock.Controller) *MockNotificationChannel {
+	mock := &MockNotificationChannel{ctrl: ctrl}
+	mock.recorder = &MockNotificationChannelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationChannel) EXPECT() *MockNotificationChannelMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockNotificationChannel) Send(ctx context.Context, sub *models.Subscription, bundle *models.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, sub, bundle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockNotificationChannelMockRecorder) Send(ctx, sub, bundle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNotificationChannel)(nil).Send), ctx, sub, bundle)
+}
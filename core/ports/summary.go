@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+//go:generate mockgen -source=summary.go -destination=summary_mocks.go -package=ports SummaryService
+
+type SummaryService interface {
+	Summary(ctx context.Context, patientID string, persist bool) (*domain.GeneratedDocument, error)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: summary.go
+//
+// Generated by this command:
+//
+//	mockgen -source=summary.go -destination=summary_mocks.go -package=ports SummaryService
+//
+
+// Package ports is a generated GoMock package.
+package ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/gruzdev-dev/codex-documents/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSummaryService is a mock of SummaryService interface.
+type MockSummaryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSummaryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSummaryServiceMockRecorder is the mock recorder for MockSummaryService.
+type MockSummaryServiceMockRecorder struct {
+	mock *MockSummaryService
+}
+
+// NewMockSummaryService creates a new mock instance.
+func NewMockSummaryService(ctrl *gomock.Controller) *MockSummaryService {
+	mock := &MockSummaryService{ctrl: ctrl}
+	mock.recorder = &MockSummaryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSummaryService) EXPECT() *MockSummaryServiceMockRecorder {
+	return m.recorder
+}
+
+// Summary mocks base method.
+func (m *MockSummaryService) Summary(ctx context.Context, patientID string, persist bool) (*domain.GeneratedDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", ctx, patientID, persist)
+	ret0, _ := ret[0].(*domain.GeneratedDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockSummaryServiceMockRecorder) Summary(ctx, patientID, persist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockSummaryService)(nil).Summary), ctx, patientID, persist)
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type AllergyIntoleranceService struct {
+	repo      ports.AllergyIntoleranceRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.AllergyIntoleranceValidator
+}
+
+func NewAllergyIntoleranceService(
+	repo ports.AllergyIntoleranceRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.AllergyIntoleranceValidator,
+) *AllergyIntoleranceService {
+	return &AllergyIntoleranceService{
+		repo:      repo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *AllergyIntoleranceService) Create(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	if err := s.validator.Validate(ai); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "AllergyIntolerance"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, ai.Patient)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "AllergyIntolerance", PatientID: patientID, SearchParams: allergyIntoleranceSearchParams(ai)}); err != nil {
+		return nil, err
+	}
+
+	if ai.Id != nil && *ai.Id != "" {
+		return nil, fmt.Errorf("%w: allergy intolerance ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	ai.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	ai.Patient = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	var created *models.AllergyIntolerance
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, ai); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, allergyIntoleranceRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *AllergyIntoleranceService) Get(ctx context.Context, id string) (*models.AllergyIntolerance, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrAllergyIntoleranceIDRequired
+	}
+
+	ai, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if ai == nil {
+		return nil, domain.ErrAllergyIntoleranceNotFound
+	}
+
+	ref := allergyIntoleranceRef(ai)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return ai, nil
+}
+
+func (s *AllergyIntoleranceService) Update(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "AllergyIntolerance"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if ai.Id == nil {
+		return nil, domain.ErrAllergyIntoleranceIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *ai.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrAllergyIntoleranceNotFound
+	}
+
+	ref := allergyIntoleranceRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(ai); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		ai.Meta = keepSecurityLabels(ai.Meta, existing.Meta)
+	}
+
+	// The patient cannot move the allergy intolerance to another
+	// compartment.
+	ai.Patient = existing.Patient
+
+	// A restricted scope must also cover the allergy intolerance as it will be
+	// stored.
+	ref.SearchParams = allergyIntoleranceSearchParams(ai)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	var updated *models.AllergyIntolerance
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, ai); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, allergyIntoleranceRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of an allergy
+// intolerance and returns its resulting meta.
+func (s *AllergyIntoleranceService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "AllergyIntolerance"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	ai, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if ai == nil {
+		return nil, domain.ErrAllergyIntoleranceNotFound
+	}
+
+	ref := allergyIntoleranceRef(ai)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	ai.Meta = changeSecurityLabels(ai.Meta, add, remove)
+	var updated *models.AllergyIntolerance
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, ai); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, allergyIntoleranceRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *AllergyIntoleranceService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "AllergyIntolerance"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrAllergyIntoleranceNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, allergyIntoleranceRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, allergyIntoleranceRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *AllergyIntoleranceService) List(ctx context.Context, search domain.AllergySearch, limit, offset int) (*domain.ListResponse[models.AllergyIntolerance], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "AllergyIntolerance"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "AllergyIntolerance", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = allergyIntoleranceRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.AllergyIntolerance]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testAllergyID = "allergy-123"
+)
+
+func createTestAllergy(id, patientID string) *models.AllergyIntolerance {
+	patientRef := "Patient/" + patientID
+	ai := &models.AllergyIntolerance{
+		ResourceType: "AllergyIntolerance",
+		ClinicalStatus: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://terminology.hl7.org/CodeSystem/allergyintolerance-clinical", "active"),
+		}},
+		Category:    []string{"medication"},
+		Criticality: strPtr("high"),
+		Code: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://snomed.info/sct", "91936005"),
+		}},
+		Patient: &models.Reference{Reference: &patientRef},
+	}
+	if id != "" {
+		ai.Id = strPtr(id)
+	}
+	return ai
+}
+
+func newTestAllergyService(ctrl *gomock.Controller, repo ports.AllergyIntoleranceRepository, careRepo ports.CareRelationshipRepository) *AllergyIntoleranceService {
+	authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewAllergyIntoleranceService(repo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewAllergyIntoleranceValidator())
+}
+
+func TestAllergyIntoleranceService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/AllergyIntolerance.c"})
+
+	tests := []struct {
+		name          string
+		ai            *models.AllergyIntolerance
+		user          domain.Identity
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name:         "success path",
+			ai:           createTestAllergy("", testPatientID),
+			user:         patient,
+			expectCreate: true,
+		},
+		{
+			name: "error - missing code",
+			ai: func() *models.AllergyIntolerance {
+				ai := createTestAllergy("", testPatientID)
+				ai.Code = nil
+				return ai
+			}(),
+			user:          patient,
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - unknown criticality",
+			ai: func() *models.AllergyIntolerance {
+				ai := createTestAllergy("", testPatientID)
+				ai.Criticality = strPtr("severe")
+				return ai
+			}(),
+			user:          patient,
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - no create scope",
+			ai:            createTestAllergy("", testPatientID),
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/AllergyIntolerance.rs"}),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockAllergyIntoleranceRepository(ctrl)
+			if tt.expectCreate {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, ai *models.AllergyIntolerance) (*models.AllergyIntolerance, error) {
+						assert.Equal(t, "Patient/"+testPatientID, *ai.Patient.Reference)
+						assert.NotEmpty(t, *ai.Id)
+						return ai, nil
+					})
+			}
+
+			service := newTestAllergyService(ctrl, repo, ports.NewMockCareRelationshipRepository(ctrl))
+			result, err := service.Create(identity.WithCtx(context.Background(), tt.user), tt.ai)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result)
+		})
+	}
+}
+
+func TestAllergyIntoleranceService_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		stored        *models.AllergyIntolerance
+		setupCare     func(*ports.MockCareRelationshipRepository)
+		expectedError error
+	}{
+		{
+			name:   "success path - treating practitioner",
+			user:   createTestPractitionerIdentity(testPractitionerID, []string{"user/AllergyIntolerance.rs"}),
+			stored: createTestAllergy(testAllergyID, testPatientID),
+			setupCare: func(careRepo *ports.MockCareRelationshipRepository) {
+				careRepo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessRead), nil)
+			},
+		},
+		{
+			name:          "error - another patient's allergy",
+			user:          createTestIdentity("other-patient", "other-user", []string{"patient/AllergyIntolerance.rs"}),
+			stored:        createTestAllergy(testAllergyID, testPatientID),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - not found",
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/AllergyIntolerance.rs"}),
+			expectedError: domain.ErrAllergyIntoleranceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockAllergyIntoleranceRepository(ctrl)
+			careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), testAllergyID).Return(tt.stored, nil)
+			if tt.setupCare != nil {
+				tt.setupCare(careRepo)
+			}
+
+			service := newTestAllergyService(ctrl, repo, careRepo)
+			result, err := service.Get(identity.WithCtx(context.Background(), tt.user), testAllergyID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testAllergyID, *result.Id)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type AppointmentService struct {
+	repo      ports.AppointmentRepository
+	docRepo   ports.DocumentRepository
+	encRepo   ports.EncounterRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.AppointmentValidator
+}
+
+func NewAppointmentService(
+	repo ports.AppointmentRepository,
+	docRepo ports.DocumentRepository,
+	encRepo ports.EncounterRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.AppointmentValidator,
+) *AppointmentService {
+	return &AppointmentService{
+		repo:      repo,
+		docRepo:   docRepo,
+		encRepo:   encRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *AppointmentService) Create(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	if err := s.validator.Validate(appt); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Appointment"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, appt.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Appointment", PatientID: patientID, SearchParams: appointmentSearchParams(appt)}); err != nil {
+		return nil, err
+	}
+
+	if appt.Id != nil && *appt.Id != "" {
+		return nil, fmt.Errorf("%w: appointment ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	appt.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	appt.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := s.validateLinks(ctx, appt, patientID); err != nil {
+		return nil, err
+	}
+	normalizeAppointmentTimes(appt)
+
+	var created *models.Appointment
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, appt); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, appointmentRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *AppointmentService) Get(ctx context.Context, id string) (*models.Appointment, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrAppointmentIDRequired
+	}
+
+	appt, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if appt == nil {
+		return nil, domain.ErrAppointmentNotFound
+	}
+
+	ref := appointmentRef(appt)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return appt, nil
+}
+
+func (s *AppointmentService) Update(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Appointment"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if appt.Id == nil {
+		return nil, domain.ErrAppointmentIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *appt.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrAppointmentNotFound
+	}
+
+	ref := appointmentRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(appt); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		appt.Meta = keepSecurityLabels(appt.Meta, existing.Meta)
+	}
+
+	// The patient cannot move the appointment to another compartment.
+	appt.Subject = existing.Subject
+
+	// A restricted scope must also cover the appointment as it will be stored.
+	ref.SearchParams = appointmentSearchParams(appt)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if derivedFromChanged(existing.SupportingInformation, appt.SupportingInformation) {
+		if err := s.validateLinks(ctx, appt, ref.PatientID); err != nil {
+			return nil, err
+		}
+	}
+	normalizeAppointmentTimes(appt)
+
+	var updated *models.Appointment
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, appt); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, appointmentRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of an appointment and
+// returns its resulting meta.
+func (s *AppointmentService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Appointment"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	appt, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if appt == nil {
+		return nil, domain.ErrAppointmentNotFound
+	}
+
+	ref := appointmentRef(appt)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	appt.Meta = changeSecurityLabels(appt.Meta, add, remove)
+	var updated *models.Appointment
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, appt); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, appointmentRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *AppointmentService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Appointment"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrAppointmentNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, appointmentRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, appointmentRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *AppointmentService) List(ctx context.Context, search domain.AppointmentSearch, limit, offset int) (*domain.ListResponse[models.Appointment], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Appointment"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Appointment", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = appointmentRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.Appointment]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// validateLinks checks that the referral letters and the encounter of the
+// visit linked as supporting information belong to the same patient.
+func (s *AppointmentService) validateLinks(ctx context.Context, appt *models.Appointment, patientID string) error {
+	if err := validateDerivedFrom(ctx, s.docRepo, documentLinks(appt.SupportingInformation), patientID); err != nil {
+		return err
+	}
+	return validateEncounters(ctx, s.encRepo, encounterLinks(appt.SupportingInformation), patientID)
+}
+
+// normalizeAppointmentTimes stores start and end in UTC so that they compare
+// correctly as strings in date searches and when reminders are scheduled.
+// The validator has already checked that they are instants.
+func normalizeAppointmentTimes(appt *models.Appointment) {
+	for _, value := range []*string{appt.Start, appt.End} {
+		if value == nil {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, *value); err == nil {
+			*value = t.UTC().Format(time.RFC3339)
+		}
+	}
+}
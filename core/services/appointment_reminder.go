@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	reminderLease           = "appointment-reminders"
+	defaultReminderInterval = time.Minute
+	defaultReminderLeaseTTL = 5 * time.Minute
+)
+
+var defaultReminderOffsets = []time.Duration{24 * time.Hour, time.Hour}
+
+// ReminderOptions configure the ReminderScheduler; zero values take the
+// defaults.
+type ReminderOptions struct {
+	// Offsets are how long before the start of a booked appointment
+	// reminders are sent.
+	Offsets []time.Duration
+	// Interval is how often the scheduler looks for due reminders. LeaseTTL
+	// bounds how long a replica that stopped keeps others from taking over.
+	Interval time.Duration
+	LeaseTTL time.Duration
+}
+
+// ReminderScheduler sends reminders of booked appointments at the configured
+// offsets before their start. Every replica runs it; a lease lets only one of
+// them look for due reminders at a time, and claims keep a reminder from being
+// sent twice when the lease changes hands.
+type ReminderScheduler struct {
+	appointments ports.AppointmentRepository
+	reminders    ports.ReminderRepository
+	leases       ports.LeaseRepository
+	notifier     ports.ReminderNotifier
+	offsets      []time.Duration
+	interval     time.Duration
+	leaseTTL     time.Duration
+	holder       string
+	now          func() time.Time
+}
+
+func NewReminderScheduler(
+	opts ReminderOptions,
+	appointments ports.AppointmentRepository,
+	reminders ports.ReminderRepository,
+	leases ports.LeaseRepository,
+	notifier ports.ReminderNotifier,
+) *ReminderScheduler {
+	offsets := slices.Clone(opts.Offsets)
+	if len(offsets) == 0 {
+		offsets = slices.Clone(defaultReminderOffsets)
+	}
+	slices.Sort(offsets)
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultReminderInterval
+	}
+	leaseTTL := opts.LeaseTTL
+	if leaseTTL <= interval {
+		leaseTTL = max(defaultReminderLeaseTTL, 2*interval)
+	}
+
+	hostname, _ := os.Hostname()
+	return &ReminderScheduler{
+		appointments: appointments,
+		reminders:    reminders,
+		leases:       leases,
+		notifier:     notifier,
+		offsets:      offsets,
+		interval:     interval,
+		leaseTTL:     leaseTTL,
+		holder:       hostname + "-" + uuid.New().String(),
+		now:          time.Now,
+	}
+}
+
+// Run sends due reminders every interval until ctx is done.
+func (s *ReminderScheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.tick(ctx); err != nil {
+			log.Printf("Appointment reminders: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.releaseLease()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// releaseLease lets another replica take over without waiting for the lease
+// to expire.
+func (s *ReminderScheduler) releaseLease() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.leases.Release(ctx, reminderLease, s.holder); err != nil {
+		log.Printf("Appointment reminders: releasing lease: %v", err)
+	}
+}
+
+// tick sends the reminders due now if this replica holds the lease. Only the
+// reminder with the smallest due offset is sent; larger ones that fell due
+// together, e.g. for an appointment booked at short notice, are claimed
+// without being sent.
+func (s *ReminderScheduler) tick(ctx context.Context) error {
+	acquired, err := s.leases.Acquire(ctx, reminderLease, s.holder, s.leaseTTL)
+	if err != nil {
+		return fmt.Errorf("acquiring lease: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+
+	now := s.now()
+	appointments, err := s.appointments.ListBooked(ctx, now, now.Add(s.offsets[len(s.offsets)-1]))
+	if err != nil {
+		return fmt.Errorf("listing appointments: %w", err)
+	}
+
+	for i := range appointments {
+		due := s.dueReminders(&appointments[i], now)
+		for j, reminder := range due {
+			claimed, err := s.reminders.Claim(ctx, reminder)
+			if err != nil {
+				return fmt.Errorf("claiming reminder %s: %w", reminder.Key(), err)
+			}
+			if !claimed || j > 0 {
+				continue
+			}
+
+			if err := s.notifier.NotifyReminder(ctx, reminder); err != nil {
+				log.Printf("Appointment reminders: notifying %s: %v", reminder.Key(), err)
+				if err := s.reminders.Release(ctx, reminder); err != nil {
+					return fmt.Errorf("releasing reminder %s: %w", reminder.Key(), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// dueReminders returns the reminders of an appointment whose time has come,
+// smallest offset first.
+func (s *ReminderScheduler) dueReminders(appt *models.Appointment, now time.Time) []domain.AppointmentReminder {
+	if appt.Id == nil || appt.Start == nil {
+		return nil
+	}
+	start, err := time.Parse(time.RFC3339, *appt.Start)
+	if err != nil || !start.After(now) {
+		return nil
+	}
+
+	var due []domain.AppointmentReminder
+	for _, offset := range s.offsets {
+		if start.Add(-offset).After(now) {
+			continue
+		}
+		reminder := domain.AppointmentReminder{
+			AppointmentID: *appt.Id,
+			PatientID:     patientIDFromReference(appt.Subject),
+			Start:         start,
+			Offset:        offset,
+		}
+		if appt.Description != nil {
+			reminder.Description = *appt.Description
+		}
+		due = append(due, reminder)
+	}
+	return due
+}
+
+// NotificationReminderNotifier delivers appointment reminders as patient
+// notifications.
+type NotificationReminderNotifier struct {
+	notificationRepo ports.NotificationRepository
+}
+
+func NewNotificationReminderNotifier(notificationRepo ports.NotificationRepository) *NotificationReminderNotifier {
+	return &NotificationReminderNotifier{notificationRepo: notificationRepo}
+}
+
+func (n *NotificationReminderNotifier) NotifyReminder(ctx context.Context, reminder domain.AppointmentReminder) error {
+	what := "Your appointment"
+	if reminder.Description != "" {
+		what = fmt.Sprintf("Your appointment %q", reminder.Description)
+	}
+
+	return n.notificationRepo.Create(ctx, &domain.Notification{
+		ID:        uuid.New().String(),
+		PatientID: reminder.PatientID,
+		Type:      domain.NotificationTypeAppointmentReminder,
+		Message:   fmt.Sprintf("%s starts at %s", what, reminder.Start.UTC().Format(time.RFC3339)),
+		Reference: "Appointment/" + reminder.AppointmentID,
+		CreatedAt: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testAppointmentID = "appt-123"
+)
+
+func createTestAppointment(id, patientID, start string, supportingInformation ...string) *models.Appointment {
+	patientRef := "Patient/" + patientID
+	end := start
+	if t, err := time.Parse(time.RFC3339, start); err == nil {
+		end = t.Add(30 * time.Minute).Format(time.RFC3339)
+	}
+	appt := &models.Appointment{
+		ResourceType: "Appointment",
+		Status:       "booked",
+		Description:  strPtr("Cardiology consultation"),
+		Subject:      &models.Reference{Reference: &patientRef},
+		Participant: []models.AppointmentParticipant{{
+			Actor:  &models.Reference{Reference: &patientRef},
+			Status: "accepted",
+		}},
+		Start: strPtr(start),
+		End:   strPtr(end),
+	}
+	if id != "" {
+		appt.Id = strPtr(id)
+	}
+	for _, ref := range supportingInformation {
+		appt.SupportingInformation = append(appt.SupportingInformation, models.Reference{Reference: strPtr(ref)})
+	}
+	return appt
+}
+
+func TestAppointmentService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Appointment.c"})
+
+	tests := []struct {
+		name          string
+		appt          *models.Appointment
+		setupMocks    func(*ports.MockAppointmentRepository, *ports.MockDocumentRepository, *ports.MockEncounterRepository)
+		expectedError error
+		expectedStart string
+	}{
+		{
+			name: "success path - referral and encounter linked, start stored in UTC",
+			appt: createTestAppointment("", testPatientID, "2030-05-01T10:00:00+02:00", "DocumentReference/"+testDocID, "Encounter/"+testEncounterID),
+			setupMocks: func(repo *ports.MockAppointmentRepository, docRepo *ports.MockDocumentRepository, encRepo *ports.MockEncounterRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				encRepo.EXPECT().GetByID(gomock.Any(), testEncounterID).Return(createTestEncounter(testEncounterID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, appt *models.Appointment) (*models.Appointment, error) {
+						return appt, nil
+					})
+			},
+			expectedStart: "2030-05-01T08:00:00Z",
+		},
+		{
+			name: "error - referral of another patient",
+			appt: createTestAppointment("", testPatientID, "2030-05-01T10:00:00Z", "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockAppointmentRepository, docRepo *ports.MockDocumentRepository, encRepo *ports.MockEncounterRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - encounter not found",
+			appt: createTestAppointment("", testPatientID, "2030-05-01T10:00:00Z", "Encounter/"+testEncounterID),
+			setupMocks: func(repo *ports.MockAppointmentRepository, docRepo *ports.MockDocumentRepository, encRepo *ports.MockEncounterRepository) {
+				encRepo.EXPECT().GetByID(gomock.Any(), testEncounterID).Return(nil, nil)
+			},
+			expectedError: domain.ErrEncounterRefNotFound,
+		},
+		{
+			name: "error - booked without start",
+			appt: func() *models.Appointment {
+				appt := createTestAppointment("", testPatientID, "2030-05-01T10:00:00Z")
+				appt.Start, appt.End = nil, nil
+				return appt
+			}(),
+			setupMocks:    func(*ports.MockAppointmentRepository, *ports.MockDocumentRepository, *ports.MockEncounterRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - start without time zone",
+			appt:          createTestAppointment("", testPatientID, "2030-05-01T10:00:00"),
+			setupMocks:    func(*ports.MockAppointmentRepository, *ports.MockDocumentRepository, *ports.MockEncounterRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockAppointmentRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			encRepo := ports.NewMockEncounterRepository(ctrl)
+			tt.setupMocks(repo, docRepo, encRepo)
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewAppointmentService(repo, docRepo, encRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewAppointmentValidator())
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.appt)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+			assert.Equal(t, tt.expectedStart, *result.Start)
+		})
+	}
+}
+
+func TestReminderScheduler_Tick(t *testing.T) {
+	now := time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		leaseHeld       bool
+		start           time.Time
+		alreadySent     bool
+		notifyErr       error
+		expectedClaims  []time.Duration
+		expectedNotify  time.Duration
+		expectedRelease bool
+	}{
+		{
+			name:           "day-before reminder sent",
+			leaseHeld:      true,
+			start:          now.Add(20 * time.Hour),
+			expectedClaims: []time.Duration{24 * time.Hour},
+			expectedNotify: 24 * time.Hour,
+		},
+		{
+			name:           "booked at short notice - only the nearest reminder sent",
+			leaseHeld:      true,
+			start:          now.Add(30 * time.Minute),
+			expectedClaims: []time.Duration{time.Hour, 24 * time.Hour},
+			expectedNotify: time.Hour,
+		},
+		{
+			name:           "already sent by another replica",
+			leaseHeld:      true,
+			start:          now.Add(20 * time.Hour),
+			alreadySent:    true,
+			expectedClaims: []time.Duration{24 * time.Hour},
+		},
+		{
+			name:            "notifier failure releases the claim for a retry",
+			leaseHeld:       true,
+			start:           now.Add(20 * time.Hour),
+			notifyErr:       errors.New("unavailable"),
+			expectedClaims:  []time.Duration{24 * time.Hour},
+			expectedNotify:  24 * time.Hour,
+			expectedRelease: true,
+		},
+		{
+			name:  "lease held by another replica",
+			start: now.Add(20 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			appointments := ports.NewMockAppointmentRepository(ctrl)
+			reminders := ports.NewMockReminderRepository(ctrl)
+			leases := ports.NewMockLeaseRepository(ctrl)
+			notifier := ports.NewMockReminderNotifier(ctrl)
+
+			scheduler := NewReminderScheduler(ReminderOptions{Offsets: []time.Duration{24 * time.Hour, time.Hour}}, appointments, reminders, leases, notifier)
+			scheduler.now = func() time.Time { return now }
+
+			leases.EXPECT().Acquire(gomock.Any(), reminderLease, scheduler.holder, scheduler.leaseTTL).Return(tt.leaseHeld, nil)
+			if tt.leaseHeld {
+				appt := createTestAppointment(testAppointmentID, testPatientID, tt.start.Format(time.RFC3339))
+				appointments.EXPECT().ListBooked(gomock.Any(), now, now.Add(24*time.Hour)).Return([]models.Appointment{*appt}, nil)
+			}
+
+			var claims []time.Duration
+			reminders.EXPECT().
+				Claim(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, reminder domain.AppointmentReminder) (bool, error) {
+					assert.Equal(t, testAppointmentID, reminder.AppointmentID)
+					assert.Equal(t, testPatientID, reminder.PatientID)
+					claims = append(claims, reminder.Offset)
+					return !tt.alreadySent, nil
+				}).
+				Times(len(tt.expectedClaims))
+			if tt.expectedNotify != 0 {
+				notifier.EXPECT().
+					NotifyReminder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, reminder domain.AppointmentReminder) error {
+						assert.Equal(t, tt.expectedNotify, reminder.Offset)
+						assert.True(t, tt.start.Equal(reminder.Start))
+						return tt.notifyErr
+					})
+			}
+			if tt.expectedRelease {
+				reminders.EXPECT().Release(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			require.NoError(t, scheduler.tick(context.Background()))
+			assert.Equal(t, tt.expectedClaims, claims)
+		})
+	}
+}
@@ -0,0 +1,557 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// directoryResourceTypes live outside patient compartments: any signed-in user
+// may read them and only the owning practitioner may change them.
+var directoryResourceTypes = map[string]bool{
+	"Practitioner":     true,
+	"PractitionerRole": true,
+}
+
+// curatedDirectoryResourceTypes live in a directory shared by all patients,
+// curated by administrators acting as system clients. A patient may also keep
+// private entries in their compartment, which follow the compartment rules.
+var curatedDirectoryResourceTypes = map[string]bool{
+	"Location":     true,
+	"Organization": true,
+}
+
+// definitionResourceTypes are definitions published to all patients, such as
+// the forms they fill in. Anyone signed in may read them; only administrators
+// acting as system clients may change them.
+var definitionResourceTypes = map[string]bool{
+	"Questionnaire":     true,
+	"SubscriptionTopic": true,
+}
+
+// ownedResourceTypes live outside patient compartments and belong to the user
+// or client that created them, recorded as the OwnerID of the resource.
+var ownedResourceTypes = map[string]bool{
+	"Subscription": true,
+}
+
+// patientManagedResourceTypes sit in a patient compartment but can only be
+// managed by the patient, never by a practitioner acting on their behalf.
+var patientManagedResourceTypes = map[string]bool{
+	"CareRelationship": true,
+	"Consent":          true,
+	"Delegation":       true,
+	"Notification":     true,
+	"RelatedPerson":    true,
+}
+
+// breakGlassResourceType names emergency access grants in authorization
+// checks. They are not FHIR resources and are governed by dedicated scopes.
+const breakGlassResourceType = "BreakGlass"
+
+// resourceScopeNames maps resource types to the service and resource segments
+// of per-resource scopes when they differ from "docs" and the snake_cased type.
+var resourceScopeNames = map[string][2]string{
+	"File": {"files", "file"},
+}
+
+type PolicyAuthorizer struct {
+	careRepo       ports.CareRelationshipRepository
+	delegationRepo ports.DelegationRepository
+	breakGlassRepo ports.BreakGlassRepository
+	auditor        ports.BreakGlassAuditor
+}
+
+func NewPolicyAuthorizer(
+	careRepo ports.CareRelationshipRepository,
+	delegationRepo ports.DelegationRepository,
+	breakGlassRepo ports.BreakGlassRepository,
+	auditor ports.BreakGlassAuditor,
+) *PolicyAuthorizer {
+	return &PolicyAuthorizer{
+		careRepo:       careRepo,
+		delegationRepo: delegationRepo,
+		breakGlassRepo: breakGlassRepo,
+		auditor:        auditor,
+	}
+}
+
+func (a *PolicyAuthorizer) AuthorizeType(user domain.Identity, action domain.Action, resourceType string) domain.Decision {
+	decision := a.decideType(user, action, resourceType)
+	logDecision(user, action, domain.ResourceRef{Type: resourceType}, decision)
+	return decision
+}
+
+func (a *PolicyAuthorizer) Authorize(ctx context.Context, user domain.Identity, action domain.Action, resource domain.ResourceRef) (domain.Decision, error) {
+	decision, err := a.decide(ctx, user, action, resource)
+	if err != nil {
+		return domain.Decision{}, err
+	}
+	logDecision(user, action, resource, decision)
+	return decision, nil
+}
+
+func (a *PolicyAuthorizer) AuthorizeStanding(ctx context.Context, owner domain.Principal, action domain.Action, resource domain.ResourceRef) (domain.Decision, error) {
+	decision, err := a.decideStanding(ctx, owner, action, resource)
+	if err != nil {
+		return domain.Decision{}, err
+	}
+	logDecision(owner.Identity(), action, resource, decision)
+	return decision, nil
+}
+
+func (a *PolicyAuthorizer) decideType(user domain.Identity, action domain.Action, resourceType string) domain.Decision {
+	if user.IsTmpToken() {
+		return domain.Deny(domain.ErrTmpTokenForbidden, "temporary tokens are limited to shared resources")
+	}
+
+	if resourceType == breakGlassResourceType {
+		return decideBreakGlassType(user, action)
+	}
+
+	perm := action.Permission()
+
+	if directoryResourceTypes[resourceType] {
+		if action == domain.ActionRead || action == domain.ActionSearch {
+			return domain.Allow("directory resource")
+		}
+		if user.IsPractitioner() && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextUser)) > 0 {
+			return domain.Allow("practitioner maintains own directory entry")
+		}
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("requires practitioner with user/%s.%s scope", resourceType, permissionLetter(perm)))
+	}
+
+	if definitionResourceTypes[resourceType] {
+		if action == domain.ActionRead || action == domain.ActionSearch {
+			return domain.Allow("published definition")
+		}
+		if isSystemClient(user) && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextSystem)) > 0 {
+			return domain.Allow("administrator publishes definitions")
+		}
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("%s definitions are managed by administrators", resourceType))
+	}
+
+	if curatedDirectoryResourceTypes[resourceType] {
+		if action == domain.ActionRead || action == domain.ActionSearch {
+			return domain.Allow("directory resource")
+		}
+		if user.CompartmentID() != "" && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextPatient, domain.ScopeContextUser)) > 0 {
+			return domain.Allow(fmt.Sprintf("patient scope grants %s on private %s", action, resourceType))
+		}
+		if isSystemClient(user) && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextSystem)) > 0 {
+			return domain.Allow("administrator curates directory")
+		}
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no scope grants %s on %s", action, resourceType))
+	}
+
+	if user.CompartmentID() != "" && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextPatient, domain.ScopeContextUser)) > 0 {
+		return domain.Allow(fmt.Sprintf("patient scope grants %s on %s", action, resourceType))
+	}
+
+	if action == domain.ActionShare || patientManagedResourceTypes[resourceType] {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("only the patient may %s %s", action, resourceType))
+	}
+
+	if user.IsPractitioner() && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextUser)) > 0 {
+		return domain.Allow(fmt.Sprintf("user scope grants %s on %s", action, resourceType))
+	}
+
+	if isSystemClient(user) && len(user.GrantingScopes(resourceType, perm, domain.ScopeContextSystem)) > 0 {
+		return domain.Allow(fmt.Sprintf("system scope grants %s on %s", action, resourceType))
+	}
+
+	return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no scope grants %s on %s", action, resourceType))
+}
+
+func (a *PolicyAuthorizer) decide(ctx context.Context, user domain.Identity, action domain.Action, res domain.ResourceRef) (domain.Decision, error) {
+	if action == domain.ActionRead && res.ID != "" {
+		service, name := resourceScopeName(res.Type)
+		if user.HasResourceScope(service, name, res.ID, string(action)) {
+			return domain.Allow("per-resource scope"), nil
+		}
+	}
+
+	if user.IsTmpToken() {
+		return domain.Deny(domain.ErrAccessDenied, "temporary token has no scope for this resource"), nil
+	}
+
+	perm := action.Permission()
+
+	if directoryResourceTypes[res.Type] {
+		if action == domain.ActionRead || action == domain.ActionSearch {
+			return domain.Allow("directory resource"), nil
+		}
+		if user.IsPractitioner() && user.PractitionerID == res.OwnerID &&
+			len(user.GrantingScopes(res.Type, perm, domain.ScopeContextUser)) > 0 {
+			return domain.Allow("practitioner owns directory entry"), nil
+		}
+		return domain.Deny(domain.ErrAccessDenied, "directory entry owned by another practitioner"), nil
+	}
+
+	if definitionResourceTypes[res.Type] {
+		if action == domain.ActionRead || action == domain.ActionSearch {
+			return domain.Allow("published definition"), nil
+		}
+		if isSystemClient(user) && len(user.GrantingScopes(res.Type, perm, domain.ScopeContextSystem)) > 0 {
+			return domain.Allow("administrator publishes definitions"), nil
+		}
+		return domain.Deny(domain.ErrAccessDenied, "definitions are managed by administrators"), nil
+	}
+
+	if curatedDirectoryResourceTypes[res.Type] && res.PatientID == "" {
+		if action == domain.ActionRead || action == domain.ActionSearch {
+			return domain.Allow("shared directory entry"), nil
+		}
+		if isSystemClient(user) && len(user.GrantingScopes(res.Type, perm, domain.ScopeContextSystem)) > 0 {
+			return domain.Allow("administrator curates shared directory"), nil
+		}
+		return domain.Deny(domain.ErrAccessDenied, "shared directory entries are curated by administrators"), nil
+	}
+
+	if ownedResourceTypes[res.Type] {
+		if res.OwnerID == "" || res.OwnerID != user.UserID {
+			return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("%s owned by another user", res.Type)), nil
+		}
+		contexts := []domain.ScopeContext{domain.ScopeContextPatient, domain.ScopeContextUser}
+		if isSystemClient(user) {
+			contexts = append(contexts, domain.ScopeContextSystem)
+		}
+		if len(user.GrantingScopes(res.Type, perm, contexts...)) > 0 {
+			return domain.Allow(fmt.Sprintf("owner of the %s", res.Type)), nil
+		}
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no scope grants %s on %s", action, res.Type)), nil
+	}
+
+	if res.PatientID == "" {
+		return domain.Deny(domain.ErrAccessDenied, "resource is not in a patient compartment"), nil
+	}
+
+	if user.PatientID == res.PatientID {
+		scopes := user.GrantingScopes(res.Type, perm, domain.ScopeContextPatient, domain.ScopeContextUser)
+		if len(scopes) > 0 {
+			return evaluateScopes(scopes, action, res, "compartment owner"), nil
+		}
+		if res.Type == "Patient" && res.ID == user.PatientID && action != domain.ActionShare {
+			return domain.Allow("own patient record"), nil
+		}
+		if res.Type == "Notification" && (action == domain.ActionRead || action == domain.ActionSearch) {
+			return domain.Allow("own notifications"), nil
+		}
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no patient scope grants %s on %s", action, res.Type)), nil
+	}
+
+	if patientManagedResourceTypes[res.Type] {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("only the patient may %s %s", action, res.Type)), nil
+	}
+
+	if user.ActivePatientID != "" && !user.IsPractitioner() {
+		if user.ActivePatientID != res.PatientID {
+			return domain.Deny(domain.ErrAccessDenied, "resource is outside the active patient compartment"), nil
+		}
+		return a.decideDelegate(ctx, user, action, res)
+	}
+
+	if action == domain.ActionShare {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("only the patient or a delegate may share %s", res.Type)), nil
+	}
+
+	if isSystemClient(user) {
+		scopes := user.GrantingScopes(res.Type, perm, domain.ScopeContextSystem)
+		if len(scopes) > 0 {
+			return evaluateScopes(scopes, action, res, "system client"), nil
+		}
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no system scope grants %s on %s", action, res.Type)), nil
+	}
+
+	if !user.IsPractitioner() {
+		return domain.Deny(domain.ErrAccessDenied, "not the patient of this compartment"), nil
+	}
+
+	scopes := user.GrantingScopes(res.Type, perm, domain.ScopeContextUser)
+	if len(scopes) == 0 {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no user scope grants %s on %s", action, res.Type)), nil
+	}
+
+	rel, err := a.careRepo.Find(ctx, res.PatientID, user.PractitionerID)
+	if err != nil {
+		return domain.Decision{}, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if rel != nil && rel.Allows(action) {
+		return evaluateScopes(scopes, action, res, "care relationship"), nil
+	}
+
+	if action == domain.ActionRead || action == domain.ActionSearch {
+		grant, err := a.breakGlassRepo.FindActive(ctx, res.PatientID, user.PractitionerID, time.Now().Unix())
+		if err != nil {
+			return domain.Decision{}, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if grant != nil {
+			return a.decideBreakGlass(ctx, grant, scopes, action, res)
+		}
+	}
+
+	if rel == nil {
+		return domain.Deny(domain.ErrAccessDenied, "no care relationship with patient"), nil
+	}
+	return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("care relationship does not grant %s", action)), nil
+}
+
+// decideStanding covers principals acting without a token, whose access
+// rests on the patient's grants alone. Break-glass grants never count: they
+// cover a practitioner's own requests, not what is sent on their behalf.
+func (a *PolicyAuthorizer) decideStanding(ctx context.Context, owner domain.Principal, action domain.Action, res domain.ResourceRef) (domain.Decision, error) {
+	if res.PatientID == "" {
+		return domain.Deny(domain.ErrAccessDenied, "resource is not in a patient compartment"), nil
+	}
+
+	if owner.PatientID == res.PatientID {
+		return domain.Allow("compartment owner"), nil
+	}
+
+	if action == domain.ActionShare || patientManagedResourceTypes[res.Type] {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("only the patient may %s %s", action, res.Type)), nil
+	}
+
+	if owner.PractitionerID != "" {
+		rel, err := a.careRepo.Find(ctx, res.PatientID, owner.PractitionerID)
+		if err != nil {
+			return domain.Decision{}, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if rel != nil && rel.Allows(action) {
+			return domain.Allow("care relationship"), nil
+		}
+	}
+
+	if owner.UserID != "" {
+		d, err := a.delegationRepo.Find(ctx, res.PatientID, owner.UserID)
+		if err != nil {
+			return domain.Decision{}, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if d != nil && d.Allows(action) {
+			return domain.Allow("delegation"), nil
+		}
+	}
+
+	return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no grant from the patient allows %s", action)), nil
+}
+
+// decideBreakGlass covers emergency reads under an active break-glass grant.
+// Every allowed access is audited before it is granted: if the audit trail
+// cannot be written the request fails instead of going unrecorded.
+func (a *PolicyAuthorizer) decideBreakGlass(ctx context.Context, grant *domain.BreakGlassGrant, scopes []domain.Scope, action domain.Action, res domain.ResourceRef) (domain.Decision, error) {
+	decision := evaluateScopes(scopes, action, res, "break-glass grant "+grant.ID)
+	if !decision.Allowed {
+		return decision, nil
+	}
+	if err := a.auditor.RecordAccess(ctx, grant, action, res); err != nil {
+		return domain.Decision{}, fmt.Errorf("%w: break-glass audit: %v", domain.ErrInternal, err)
+	}
+	return decision, nil
+}
+
+// decideBreakGlassType guards the break-glass workflow itself: declaring
+// emergency access needs a practitioner with the break-glass scope, working
+// the review queue needs the review scope.
+func decideBreakGlassType(user domain.Identity, action domain.Action) domain.Decision {
+	if action == domain.ActionCreate {
+		if user.IsPractitioner() && slices.Contains(user.Scopes, domain.ScopeBreakGlass) {
+			return domain.Allow("practitioner may declare emergency access")
+		}
+		return domain.Deny(domain.ErrAccessDenied, "requires practitioner with "+domain.ScopeBreakGlass+" scope")
+	}
+	if slices.Contains(user.Scopes, domain.ScopeBreakGlassReview) {
+		return domain.Allow("break-glass reviewer")
+	}
+	return domain.Deny(domain.ErrAccessDenied, "requires "+domain.ScopeBreakGlassReview+" scope")
+}
+
+// decideDelegate covers caregivers acting on the active patient through a
+// delegation. The patient/ scopes of the token apply to that compartment.
+func (a *PolicyAuthorizer) decideDelegate(ctx context.Context, user domain.Identity, action domain.Action, res domain.ResourceRef) (domain.Decision, error) {
+	scopes := user.GrantingScopes(res.Type, action.Permission(), domain.ScopeContextPatient, domain.ScopeContextUser)
+	if len(scopes) == 0 {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("no patient scope grants %s on %s", action, res.Type)), nil
+	}
+
+	d, err := a.delegationRepo.Find(ctx, res.PatientID, user.UserID)
+	if err != nil {
+		return domain.Decision{}, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if d == nil {
+		return domain.Deny(domain.ErrAccessDenied, "no delegation from patient"), nil
+	}
+	if !d.Allows(action) {
+		return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("delegation does not grant %s", action)), nil
+	}
+
+	return evaluateScopes(scopes, action, res, "delegation"), nil
+}
+
+// evaluateScopes applies the query restrictions of the granting scopes. A
+// search is allowed with the restrictions attached for the repository to
+// apply; any other action needs one scope whose restrictions the resource
+// satisfies.
+func evaluateScopes(scopes []domain.Scope, action domain.Action, res domain.ResourceRef, basis string) domain.Decision {
+	if action == domain.ActionSearch {
+		var restrictions []url.Values
+		for _, scope := range scopes {
+			if scope.Params == nil {
+				return domain.Allow(basis + " with unrestricted scope")
+			}
+			restrictions = append(restrictions, scope.Params)
+		}
+		decision := domain.Allow(basis + " with restricted scope")
+		decision.Restrictions = restrictions
+		return decision
+	}
+
+	for _, scope := range scopes {
+		if scope.Matches(res.SearchParams) {
+			return domain.Allow(basis + " with matching scope")
+		}
+	}
+	return domain.Deny(domain.ErrAccessDenied, fmt.Sprintf("scope restrictions exclude this %s", res.Type))
+}
+
+// isSystemClient reports whether the identity is a backend client rather than
+// a patient or practitioner. Only such clients may use system/ scopes, and a
+// token counts as one only when it says so explicitly.
+func isSystemClient(user domain.Identity) bool {
+	return user.SystemClient && user.UserID != "" && user.PatientID == "" && user.ActivePatientID == "" && !user.IsPractitioner()
+}
+
+// logDecision logs every policy decision, allowed or denied, with the reason
+// it was reached.
+func logDecision(user domain.Identity, action domain.Action, res domain.ResourceRef, decision domain.Decision) {
+	verdict := "deny"
+	if decision.Allowed {
+		verdict = "allow"
+	}
+	log.Printf("authz: %s subject=%s action=%s resource=%s/%s patient=%s reason=%q",
+		verdict, subjectOf(user), action, res.Type, res.ID, res.PatientID, decision.Reason)
+}
+
+func subjectOf(user domain.Identity) string {
+	switch {
+	case user.IsPractitioner():
+		return "Practitioner/" + user.PractitionerID
+	case user.PatientID != "":
+		return "Patient/" + user.PatientID
+	case user.UserID != "":
+		return "User/" + user.UserID
+	default:
+		return "tmp"
+	}
+}
+
+// authorize runs a resource-level check and turns a denial into its error.
+func authorize(ctx context.Context, authz ports.Authorizer, user domain.Identity, action domain.Action, res domain.ResourceRef) error {
+	decision, err := authz.Authorize(ctx, user, action, res)
+	if err != nil {
+		return err
+	}
+	if !decision.Allowed {
+		return decision.Err
+	}
+	return nil
+}
+
+// authorizeSearch runs a search check and returns the scope restrictions the
+// repository must apply to the results.
+func authorizeSearch(ctx context.Context, authz ports.Authorizer, user domain.Identity, res domain.ResourceRef) ([]url.Values, error) {
+	decision, err := authz.Authorize(ctx, user, domain.ActionSearch, res)
+	if err != nil {
+		return nil, err
+	}
+	if !decision.Allowed {
+		return nil, decision.Err
+	}
+	return decision.Restrictions, nil
+}
+
+func permissionLetter(perm domain.Permission) string {
+	switch perm {
+	case domain.PermissionCreate:
+		return "c"
+	case domain.PermissionRead:
+		return "r"
+	case domain.PermissionUpdate:
+		return "u"
+	case domain.PermissionDelete:
+		return "d"
+	case domain.PermissionSearch:
+		return "s"
+	}
+	return ""
+}
+
+// resourceScopeName returns the service and resource segments of per-resource
+// scopes, e.g. "docs" and "document_reference" for DocumentReference.
+func resourceScopeName(resourceType string) (string, string) {
+	if names, ok := resourceScopeNames[resourceType]; ok {
+		return names[0], names[1]
+	}
+
+	var b strings.Builder
+	for i, r := range resourceType {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return "docs", b.String()
+}
+
+// patientIDFromReference returns the patient ID of a "Patient/{id}" reference.
+func patientIDFromReference(ref *models.Reference) string {
+	if ref == nil || ref.Reference == nil {
+		return ""
+	}
+	if !strings.HasPrefix(*ref.Reference, "Patient/") {
+		return ""
+	}
+	return strings.TrimPrefix(*ref.Reference, "Patient/")
+}
+
+// practitionerIDFromReference returns the practitioner ID of a
+// "Practitioner/{id}" reference.
+func practitionerIDFromReference(ref *models.Reference) string {
+	if ref == nil || ref.Reference == nil {
+		return ""
+	}
+	if !strings.HasPrefix(*ref.Reference, "Practitioner/") {
+		return ""
+	}
+	return strings.TrimPrefix(*ref.Reference, "Practitioner/")
+}
+
+// targetPatientID picks the patient compartment a new resource is written to:
+// patients write to their own or to the active patient they care for,
+// practitioners name the patient in subject.
+func targetPatientID(user domain.Identity, subject *models.Reference) (string, error) {
+	if user.IsPractitioner() {
+		if id := patientIDFromReference(subject); id != "" {
+			return id, nil
+		}
+	}
+	if id := user.CompartmentID(); id != "" {
+		return id, nil
+	}
+	if user.IsPractitioner() {
+		return "", fmt.Errorf("%w: subject must reference a Patient", domain.ErrInvalidInput)
+	}
+	return "", domain.ErrAccessDenied
+}
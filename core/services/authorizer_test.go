@@ -0,0 +1,968 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testPractitionerID = "practitioner-123"
+)
+
+func createTestPractitionerIdentity(practitionerID string, scopes []string) domain.Identity {
+	return domain.Identity{
+		UserID:         testUserID,
+		PractitionerID: practitionerID,
+		Scopes:         scopes,
+	}
+}
+
+func createTestCareRelationship(access ...domain.CareAccess) *domain.CareRelationship {
+	return &domain.CareRelationship{
+		ID:             "care-123",
+		PatientID:      testPatientID,
+		PractitionerID: testPractitionerID,
+		Access:         access,
+	}
+}
+
+func TestPolicyAuthorizer_Authorize(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read", "patient/*.write"})
+	readOnlyPatient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+	practitionerReader := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.read"})
+	practitionerWriter := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.read", "user/*.write"})
+	tmp := createTestIdentity("", "", []string{"docs:observation:" + testObsID + ":read", "files:file:" + testFileID + ":read"})
+
+	obsRef := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID}
+	otherObsRef := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: "other-patient"}
+	docRef := domain.ResourceRef{Type: "DocumentReference", ID: testDocID, PatientID: testPatientID}
+	careRef := domain.ResourceRef{Type: "CareRelationship", PatientID: testPatientID}
+
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		action        domain.Action
+		resource      domain.ResourceRef
+		setupMocks    func(*ports.MockCareRelationshipRepository)
+		expected      bool
+		expectedErr   error
+		expectedError error
+	}{
+		{
+			name:     "patient reads own compartment",
+			user:     readOnlyPatient,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			expected: true,
+		},
+		{
+			name:        "patient without write scope cannot write",
+			user:        readOnlyPatient,
+			action:      domain.ActionUpdate,
+			resource:    obsRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:        "patient scope does not cover other patients",
+			user:        patient,
+			action:      domain.ActionRead,
+			resource:    otherObsRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "patient reads own record without scope",
+			user:     createTestIdentity(testPatientID, testUserID, []string{}),
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Patient", ID: testPatientID, PatientID: testPatientID},
+			expected: true,
+		},
+		{
+			name:     "patient shares own resource",
+			user:     readOnlyPatient,
+			action:   domain.ActionShare,
+			resource: docRef,
+			expected: true,
+		},
+		{
+			name:        "patient cannot share another patient's resource",
+			user:        patient,
+			action:      domain.ActionShare,
+			resource:    otherObsRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "per-resource scope grants read",
+			user:     tmp,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			expected: true,
+		},
+		{
+			name:     "per-resource scope grants file read",
+			user:     tmp,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "File", ID: testFileID},
+			expected: true,
+		},
+		{
+			name:        "per-resource scope does not grant write",
+			user:        tmp,
+			action:      domain.ActionUpdate,
+			resource:    obsRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:        "tmp token without scope for resource",
+			user:        tmp,
+			action:      domain.ActionRead,
+			resource:    docRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "practitioner with read relationship reads",
+			user:     practitionerReader,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessRead), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "practitioner with write relationship reads",
+			user:     practitionerReader,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessWrite), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "practitioner with read relationship cannot write",
+			user:     practitionerWriter,
+			action:   domain.ActionUpdate,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessRead), nil)
+			},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "practitioner without relationship",
+			user:     practitionerReader,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(nil, nil)
+			},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:        "practitioner without user scope",
+			user:        createTestPractitionerIdentity(testPractitionerID, []string{"patient/*.read"}),
+			action:      domain.ActionRead,
+			resource:    obsRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:        "practitioner cannot share",
+			user:        practitionerWriter,
+			action:      domain.ActionShare,
+			resource:    obsRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:        "practitioner cannot manage care relationships",
+			user:        practitionerWriter,
+			action:      domain.ActionUpdate,
+			resource:    careRef,
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "patient manages own care relationships",
+			user:     patient,
+			action:   domain.ActionUpdate,
+			resource: careRef,
+			expected: true,
+		},
+		{
+			name:     "anyone signed in reads directory",
+			user:     readOnlyPatient,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Practitioner", ID: testPractitionerID, OwnerID: testPractitionerID},
+			expected: true,
+		},
+		{
+			name:     "practitioner updates own directory entry",
+			user:     practitionerWriter,
+			action:   domain.ActionUpdate,
+			resource: domain.ResourceRef{Type: "Practitioner", ID: testPractitionerID, OwnerID: testPractitionerID},
+			expected: true,
+		},
+		{
+			name:        "practitioner cannot update another directory entry",
+			user:        practitionerWriter,
+			action:      domain.ActionUpdate,
+			resource:    domain.ResourceRef{Type: "PractitionerRole", ID: "role-1", OwnerID: "other-practitioner"},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "anyone signed in reads shared organization",
+			user:     readOnlyPatient,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Organization", ID: "org-1"},
+			expected: true,
+		},
+		{
+			name:        "patient cannot change shared organization",
+			user:        patient,
+			action:      domain.ActionUpdate,
+			resource:    domain.ResourceRef{Type: "Organization", ID: "org-1"},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "administrator curates shared location",
+			user:     domain.Identity{UserID: "admin-1", SystemClient: true, Scopes: []string{"system/Location.cud"}},
+			action:   domain.ActionUpdate,
+			resource: domain.ResourceRef{Type: "Location", ID: "loc-1"},
+			expected: true,
+		},
+		{
+			name:     "patient updates private organization",
+			user:     patient,
+			action:   domain.ActionUpdate,
+			resource: domain.ResourceRef{Type: "Organization", ID: "org-1", PatientID: testPatientID},
+			expected: true,
+		},
+		{
+			name:        "private organization of another patient",
+			user:        patient,
+			action:      domain.ActionRead,
+			resource:    domain.ResourceRef{Type: "Organization", ID: "org-1", PatientID: "other-patient"},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "anyone signed in reads questionnaire",
+			user:     readOnlyPatient,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Questionnaire", ID: "q-1"},
+			expected: true,
+		},
+		{
+			name:        "patient cannot change questionnaire",
+			user:        patient,
+			action:      domain.ActionUpdate,
+			resource:    domain.ResourceRef{Type: "Questionnaire", ID: "q-1"},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "administrator publishes questionnaire",
+			user:     domain.Identity{UserID: "admin-1", SystemClient: true, Scopes: []string{"system/Questionnaire.cud"}},
+			action:   domain.ActionUpdate,
+			resource: domain.ResourceRef{Type: "Questionnaire", ID: "q-1"},
+			expected: true,
+		},
+		{
+			name:        "resource outside compartment",
+			user:        patient,
+			action:      domain.ActionRead,
+			resource:    domain.ResourceRef{Type: "Observation", ID: testObsID},
+			expectedErr: domain.ErrAccessDenied,
+		},
+		{
+			name:     "repository error",
+			user:     practitionerReader,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockCareRelationshipRepository(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+			breakGlassRepo := ports.NewMockBreakGlassRepository(ctrl)
+			breakGlassRepo.EXPECT().
+				FindActive(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			authz := NewPolicyAuthorizer(repo, ports.NewMockDelegationRepository(ctrl), breakGlassRepo, ports.NewMockBreakGlassAuditor(ctrl))
+
+			decision, err := authz.Authorize(context.Background(), tt.user, tt.action, tt.resource)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision.Allowed)
+			assert.NotEmpty(t, decision.Reason)
+			assert.Equal(t, tt.expectedErr, decision.Err)
+		})
+	}
+}
+
+func TestPolicyAuthorizer_AuthorizeType(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         domain.Identity
+		action       domain.Action
+		resourceType string
+		expected     bool
+		expectedErr  error
+	}{
+		{
+			name:         "patient with write scope",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"}),
+			action:       domain.ActionUpdate,
+			resourceType: "Observation",
+			expected:     true,
+		},
+		{
+			name:         "patient without scope",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"}),
+			action:       domain.ActionUpdate,
+			resourceType: "DocumentReference",
+			expectedErr:  domain.ErrAccessDenied,
+		},
+		{
+			name:         "practitioner with user scope",
+			user:         createTestPractitionerIdentity(testPractitionerID, []string{"user/*.read"}),
+			action:       domain.ActionRead,
+			resourceType: "Observation",
+			expected:     true,
+		},
+		{
+			name:         "practitioner cannot share",
+			user:         createTestPractitionerIdentity(testPractitionerID, []string{"user/*.read"}),
+			action:       domain.ActionShare,
+			resourceType: "Patient",
+			expectedErr:  domain.ErrAccessDenied,
+		},
+		{
+			name:         "patient creates directory entry",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"}),
+			action:       domain.ActionUpdate,
+			resourceType: "Practitioner",
+			expectedErr:  domain.ErrAccessDenied,
+		},
+		{
+			name:         "patient creates private organization",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"}),
+			action:       domain.ActionCreate,
+			resourceType: "Organization",
+			expected:     true,
+		},
+		{
+			name:         "administrator creates shared location",
+			user:         domain.Identity{UserID: "admin-1", SystemClient: true, Scopes: []string{"system/Location.c"}},
+			action:       domain.ActionCreate,
+			resourceType: "Location",
+			expected:     true,
+		},
+		{
+			name:         "practitioner cannot create organization",
+			user:         createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"}),
+			action:       domain.ActionCreate,
+			resourceType: "Organization",
+			expectedErr:  domain.ErrAccessDenied,
+		},
+		{
+			name:         "patient cannot create questionnaire",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"}),
+			action:       domain.ActionCreate,
+			resourceType: "Questionnaire",
+			expectedErr:  domain.ErrAccessDenied,
+		},
+		{
+			name:         "tmp token",
+			user:         createTestIdentity("", "", []string{"docs:observation:" + testObsID + ":read"}),
+			action:       domain.ActionRead,
+			resourceType: "Observation",
+			expectedErr:  domain.ErrTmpTokenForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+
+			decision := authz.AuthorizeType(tt.user, tt.action, tt.resourceType)
+
+			assert.Equal(t, tt.expected, decision.Allowed)
+			assert.NotEmpty(t, decision.Reason)
+			assert.Equal(t, tt.expectedErr, decision.Err)
+		})
+	}
+}
+
+func TestResourceScopeName(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		service      string
+		resource     string
+	}{
+		{resourceType: "Observation", service: "docs", resource: "observation"},
+		{resourceType: "DocumentReference", service: "docs", resource: "document_reference"},
+		{resourceType: "File", service: "files", resource: "file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			service, resource := resourceScopeName(tt.resourceType)
+			assert.Equal(t, tt.service, service)
+			assert.Equal(t, tt.resource, resource)
+		})
+	}
+}
+
+func TestTargetPatientID(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		subject       *models.Reference
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "patient writes to own compartment",
+			user:     createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"}),
+			subject:  &models.Reference{Reference: strPtr("Patient/other-patient")},
+			expected: testPatientID,
+		},
+		{
+			name:     "practitioner writes to subject",
+			user:     createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"}),
+			subject:  &models.Reference{Reference: strPtr("Patient/" + testPatientID)},
+			expected: testPatientID,
+		},
+		{
+			name:          "practitioner without patient subject",
+			user:          createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"}),
+			subject:       &models.Reference{Reference: strPtr("Group/g-1")},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:     "caregiver writes to active patient",
+			user:     createTestCaregiverIdentity(testPatientID, []string{"patient/*.write"}),
+			subject:  &models.Reference{Reference: strPtr("Patient/other-patient")},
+			expected: testPatientID,
+		},
+		{
+			name:          "tmp token",
+			user:          createTestIdentity("", "", nil),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := targetPatientID(tt.user, tt.subject)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		raw      string
+		ok       bool
+		expected domain.Scope
+	}{
+		{
+			raw:      "patient/*.read",
+			ok:       true,
+			expected: domain.Scope{Context: domain.ScopeContextPatient, ResourceType: "*", Permissions: domain.PermissionRead | domain.PermissionSearch},
+		},
+		{
+			raw:      "user/Observation.write",
+			ok:       true,
+			expected: domain.Scope{Context: domain.ScopeContextUser, ResourceType: "Observation", Permissions: domain.PermissionCreate | domain.PermissionUpdate | domain.PermissionDelete},
+		},
+		{
+			raw: "patient/*.cruds",
+			ok:  true,
+			expected: domain.Scope{
+				Context:      domain.ScopeContextPatient,
+				ResourceType: "*",
+				Permissions:  domain.PermissionCreate | domain.PermissionRead | domain.PermissionUpdate | domain.PermissionDelete | domain.PermissionSearch,
+			},
+		},
+		{
+			raw: "patient/Observation.rs?category=laboratory",
+			ok:  true,
+			expected: domain.Scope{
+				Context:      domain.ScopeContextPatient,
+				ResourceType: "Observation",
+				Permissions:  domain.PermissionRead | domain.PermissionSearch,
+				Params:       url.Values{"category": {"laboratory"}},
+			},
+		},
+		{
+			raw:      "system/DocumentReference.cu",
+			ok:       true,
+			expected: domain.Scope{Context: domain.ScopeContextSystem, ResourceType: "DocumentReference", Permissions: domain.PermissionCreate | domain.PermissionUpdate},
+		},
+		{raw: "patient/Observation.sr"},
+		{raw: "patient/Observation.rr"},
+		{raw: "patient/Observation.x"},
+		{raw: "patient/Observation."},
+		{raw: "launch/patient"},
+		{raw: "openid"},
+		{raw: "docs:observation:obs-1:read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			scope, ok := domain.ParseScope(tt.raw)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, scope)
+			}
+		})
+	}
+}
+
+func TestPolicyAuthorizer_ScopeRestrictions(t *testing.T) {
+	labReader := createTestIdentity(testPatientID, testUserID, []string{"patient/Observation.rs?category=laboratory,imaging"})
+	lab := url.Values{"category": {"laboratory"}}
+	vitals := url.Values{"category": {"vital-signs"}}
+
+	tests := []struct {
+		name                 string
+		user                 domain.Identity
+		action               domain.Action
+		resource             domain.ResourceRef
+		expected             bool
+		expectedRestrictions []url.Values
+	}{
+		{
+			name:     "matching category is readable",
+			user:     labReader,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID, SearchParams: lab},
+			expected: true,
+		},
+		{
+			name:     "other category is not readable",
+			user:     labReader,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID, SearchParams: vitals},
+		},
+		{
+			name:                 "search carries restrictions",
+			user:                 labReader,
+			action:               domain.ActionSearch,
+			resource:             domain.ResourceRef{Type: "Observation", PatientID: testPatientID},
+			expected:             true,
+			expectedRestrictions: []url.Values{{"category": {"laboratory,imaging"}}},
+		},
+		{
+			name: "unrestricted scope lifts restrictions",
+			user: createTestIdentity(testPatientID, testUserID, []string{
+				"patient/Observation.rs?category=laboratory",
+				"patient/*.rs",
+			}),
+			action:   domain.ActionSearch,
+			resource: domain.ResourceRef{Type: "Observation", PatientID: testPatientID},
+			expected: true,
+		},
+		{
+			name:     "scope for another resource type",
+			user:     labReader,
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "DocumentReference", ID: testDocID, PatientID: testPatientID},
+		},
+		{
+			name:     "read scope does not grant update",
+			user:     labReader,
+			action:   domain.ActionUpdate,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID, SearchParams: lab},
+		},
+		{
+			name:     "system client reads any compartment",
+			user:     domain.Identity{UserID: "backend", SystemClient: true, Scopes: []string{"system/Observation.rs"}},
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: "other-patient"},
+			expected: true,
+		},
+		{
+			name:     "system scope ignored without the client-credentials grant",
+			user:     domain.Identity{UserID: "backend", Scopes: []string{"system/Observation.rs"}},
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: "other-patient"},
+		},
+		{
+			name:     "system scope ignored for patients",
+			user:     createTestIdentity(testPatientID, testUserID, []string{"system/*.cruds"}),
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+
+			decision, err := authz.Authorize(context.Background(), tt.user, tt.action, tt.resource)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision.Allowed)
+			assert.Equal(t, tt.expectedRestrictions, decision.Restrictions)
+			if !tt.expected {
+				assert.Equal(t, domain.ErrAccessDenied, decision.Err)
+			}
+		})
+	}
+}
+
+func TestPolicyAuthorizer_Delegation(t *testing.T) {
+	caregiver := createTestCaregiverIdentity(testPatientID, []string{"patient/*.cruds"})
+	obsRef := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID}
+
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		action        domain.Action
+		resource      domain.ResourceRef
+		setupMocks    func(*ports.MockDelegationRepository)
+		expected      bool
+		expectedError error
+	}{
+		{
+			name:     "read delegation allows read",
+			user:     caregiver,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "read delegation does not allow update",
+			user:     caregiver,
+			action:   domain.ActionUpdate,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+		},
+		{
+			name:     "write delegation allows updating the patient record",
+			user:     caregiver,
+			action:   domain.ActionUpdate,
+			resource: domain.ResourceRef{Type: "Patient", ID: testPatientID, PatientID: testPatientID},
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessWrite), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "no delegation",
+			user:     caregiver,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(nil, nil)
+			},
+		},
+		{
+			name:     "resource outside the active patient",
+			user:     createTestCaregiverIdentity("other-patient", []string{"patient/*.cruds"}),
+			action:   domain.ActionRead,
+			resource: obsRef,
+		},
+		{
+			name:     "no active patient selected",
+			user:     createTestCaregiverIdentity("", []string{"patient/*.cruds"}),
+			action:   domain.ActionRead,
+			resource: obsRef,
+		},
+		{
+			name:     "write delegation allows sharing",
+			user:     caregiver,
+			action:   domain.ActionShare,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessWrite), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "read delegation cannot share",
+			user:     caregiver,
+			action:   domain.ActionShare,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+		},
+		{
+			name:     "scope still applies",
+			user:     createTestCaregiverIdentity(testPatientID, []string{"patient/DocumentReference.rs"}),
+			action:   domain.ActionRead,
+			resource: obsRef,
+		},
+		{
+			name: "patient with own record acts for a child",
+			user: domain.Identity{
+				UserID:          testCaregiverID,
+				PatientID:       "caregiver-patient",
+				ActivePatientID: testPatientID,
+				Scopes:          []string{"patient/*.rs"},
+			},
+			action:   domain.ActionSearch,
+			resource: domain.ResourceRef{Type: "Observation", PatientID: testPatientID},
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "repository error",
+			user:     caregiver,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockDelegationRepository(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), repo, ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+
+			decision, err := authz.Authorize(context.Background(), tt.user, tt.action, tt.resource)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision.Allowed)
+			if !tt.expected {
+				assert.Equal(t, domain.ErrAccessDenied, decision.Err)
+			}
+		})
+	}
+}
+
+func TestPolicyAuthorizer_AuthorizeStanding(t *testing.T) {
+	obsRef := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID}
+	practitioner := domain.Principal{UserID: testUserID, PractitionerID: testPractitionerID}
+	caregiver := domain.Principal{UserID: testCaregiverID}
+
+	tests := []struct {
+		name          string
+		owner         domain.Principal
+		action        domain.Action
+		resource      domain.ResourceRef
+		setupMocks    func(*ports.MockCareRelationshipRepository, *ports.MockDelegationRepository)
+		expected      bool
+		expectedError error
+	}{
+		{
+			name:     "patient reads own compartment",
+			owner:    domain.Principal{UserID: testUserID, PatientID: testPatientID},
+			action:   domain.ActionRead,
+			resource: obsRef,
+			expected: true,
+		},
+		{
+			name:     "care relationship in force",
+			owner:    practitioner,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(care *ports.MockCareRelationshipRepository, _ *ports.MockDelegationRepository) {
+				care.EXPECT().Find(gomock.Any(), testPatientID, testPractitionerID).Return(createTestCareRelationship(domain.CareAccessRead), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "care relationship revoked",
+			owner:    practitioner,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(care *ports.MockCareRelationshipRepository, delegations *ports.MockDelegationRepository) {
+				care.EXPECT().Find(gomock.Any(), testPatientID, testPractitionerID).Return(nil, nil)
+				delegations.EXPECT().Find(gomock.Any(), testPatientID, testUserID).Return(nil, nil)
+			},
+		},
+		{
+			name:     "delegation in force",
+			owner:    caregiver,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(_ *ports.MockCareRelationshipRepository, delegations *ports.MockDelegationRepository) {
+				delegations.EXPECT().Find(gomock.Any(), testPatientID, testCaregiverID).Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "delegation does not grant the action",
+			owner:    caregiver,
+			action:   domain.ActionUpdate,
+			resource: obsRef,
+			setupMocks: func(_ *ports.MockCareRelationshipRepository, delegations *ports.MockDelegationRepository) {
+				delegations.EXPECT().Find(gomock.Any(), testPatientID, testCaregiverID).Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+		},
+		{
+			name:     "resource outside a compartment",
+			owner:    domain.Principal{UserID: testUserID, PatientID: testPatientID},
+			action:   domain.ActionRead,
+			resource: domain.ResourceRef{Type: "Observation", ID: testObsID},
+		},
+		{
+			name:     "share is left to the patient",
+			owner:    caregiver,
+			action:   domain.ActionShare,
+			resource: obsRef,
+		},
+		{
+			name:     "repository error",
+			owner:    practitioner,
+			action:   domain.ActionRead,
+			resource: obsRef,
+			setupMocks: func(care *ports.MockCareRelationshipRepository, _ *ports.MockDelegationRepository) {
+				care.EXPECT().Find(gomock.Any(), testPatientID, testPractitionerID).Return(nil, errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			care := ports.NewMockCareRelationshipRepository(ctrl)
+			delegations := ports.NewMockDelegationRepository(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(care, delegations)
+			}
+
+			authz := NewPolicyAuthorizer(care, delegations, ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+
+			decision, err := authz.AuthorizeStanding(context.Background(), tt.owner, tt.action, tt.resource)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision.Allowed)
+			if !tt.expected {
+				assert.Equal(t, domain.ErrAccessDenied, decision.Err)
+			}
+		})
+	}
+}
+
+func TestLogDecision(t *testing.T) {
+	user := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs"})
+	res := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID}
+
+	tests := []struct {
+		name     string
+		decision domain.Decision
+		expected string
+	}{
+		{
+			name:     "allowed decision",
+			decision: domain.Allow("compartment owner"),
+			expected: `authz: allow subject=Patient/patient-123 action=read resource=Observation/obs-123 patient=patient-123 reason="compartment owner"`,
+		},
+		{
+			name:     "denied decision",
+			decision: domain.Deny(domain.ErrAccessDenied, "not the patient of this compartment"),
+			expected: `authz: deny subject=Patient/patient-123 action=read resource=Observation/obs-123 patient=patient-123 reason="not the patient of this compartment"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			defer log.SetOutput(os.Stderr)
+
+			logDecision(user, domain.ActionRead, res, tt.decision)
+
+			assert.Contains(t, buf.String(), tt.expected)
+		})
+	}
+}
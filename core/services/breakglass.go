@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type BreakGlassService struct {
+	repo    ports.BreakGlassRepository
+	auditor ports.BreakGlassAuditor
+	authz   ports.Authorizer
+}
+
+func NewBreakGlassService(repo ports.BreakGlassRepository, auditor ports.BreakGlassAuditor, authz ports.Authorizer) *BreakGlassService {
+	return &BreakGlassService{
+		repo:    repo,
+		auditor: auditor,
+		authz:   authz,
+	}
+}
+
+// Request declares emergency access to a patient the practitioner has no care
+// relationship with. The grant allows reads for at most BreakGlassMaxDuration
+// and stays in the review queue until a reviewer signs it off.
+func (s *BreakGlassService) Request(ctx context.Context, req domain.BreakGlassRequest) (*domain.BreakGlassGrant, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, breakGlassResourceType); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if req.PatientID == "" {
+		return nil, domain.ErrPatientIDRequired
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, domain.ErrBreakGlassReasonRequired
+	}
+
+	duration := req.Duration
+	if duration == 0 {
+		duration = domain.BreakGlassMaxDuration
+	}
+	if duration < 0 || duration > domain.BreakGlassMaxDuration {
+		return nil, fmt.Errorf("%w: break-glass access is limited to %s", domain.ErrInvalidInput, domain.BreakGlassMaxDuration)
+	}
+
+	now := time.Now()
+	grant := &domain.BreakGlassGrant{
+		ID:             uuid.New().String(),
+		PatientID:      req.PatientID,
+		PractitionerID: user.PractitionerID,
+		Reason:         reason,
+		CreatedAt:      now.Unix(),
+		ExpiresAt:      now.Add(duration).Unix(),
+	}
+
+	created, err := s.repo.Create(ctx, grant)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	if err := s.auditor.RecordGrant(ctx, created); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+// ListForReview returns the grants nobody has reviewed yet, oldest first.
+func (s *BreakGlassService) ListForReview(ctx context.Context, limit, offset int) (*domain.ListResponse[domain.BreakGlassGrant], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, breakGlassResourceType); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	items, total, err := s.repo.ListUnreviewed(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[domain.BreakGlassGrant]{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+func (s *BreakGlassService) Review(ctx context.Context, review domain.BreakGlassReview) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, breakGlassResourceType); !decision.Allowed {
+		return decision.Err
+	}
+
+	grant, err := s.repo.GetByID(ctx, review.GrantID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if grant == nil {
+		return domain.ErrBreakGlassNotFound
+	}
+	if grant.Reviewed() {
+		return fmt.Errorf("%w: break-glass grant is already reviewed", domain.ErrInvalidInput)
+	}
+	if user.IsPractitioner() && user.PractitionerID == grant.PractitionerID {
+		return fmt.Errorf("%w: cannot review your own break-glass access", domain.ErrAccessDenied)
+	}
+
+	if err := s.repo.MarkReviewed(ctx, grant.ID, subjectOf(user), strings.TrimSpace(review.Note), time.Now().Unix()); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// BreakGlassAuditor writes the audit trail of emergency access: a
+// high-severity AuditEvent for the security team and a notification for the
+// patient, for the grant itself and for every access made under it.
+type BreakGlassAuditor struct {
+	auditRepo        ports.AuditEventRepository
+	notificationRepo ports.NotificationRepository
+}
+
+func NewBreakGlassAuditor(auditRepo ports.AuditEventRepository, notificationRepo ports.NotificationRepository) *BreakGlassAuditor {
+	return &BreakGlassAuditor{
+		auditRepo:        auditRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (a *BreakGlassAuditor) RecordGrant(ctx context.Context, grant *domain.BreakGlassGrant) error {
+	event := newBreakGlassEvent(grant, models.AuditEventActionE)
+	event.Entity = []models.AuditEventEntity{{
+		What:        &models.Reference{Reference: ptr.To("Patient/" + grant.PatientID)},
+		Description: ptr.To("emergency access declared until " + time.Unix(grant.ExpiresAt, 0).UTC().Format(time.RFC3339)),
+	}}
+
+	message := fmt.Sprintf("Practitioner/%s declared emergency access to your records: %s", grant.PractitionerID, grant.Reason)
+	return a.record(ctx, grant, event, message, "Patient/"+grant.PatientID)
+}
+
+func (a *BreakGlassAuditor) RecordAccess(ctx context.Context, grant *domain.BreakGlassGrant, action domain.Action, res domain.ResourceRef) error {
+	event := newBreakGlassEvent(grant, models.AuditEventActionR)
+
+	reference := res.Type
+	entity := models.AuditEventEntity{}
+	if res.ID != "" {
+		reference = res.Type + "/" + res.ID
+		entity.What = &models.Reference{Reference: ptr.To(reference)}
+	} else {
+		entity.Description = ptr.To(fmt.Sprintf("%s %s?%s", action, res.Type, res.SearchParams.Encode()))
+	}
+	event.Entity = []models.AuditEventEntity{entity}
+
+	message := fmt.Sprintf("Practitioner/%s accessed %s under emergency access", grant.PractitionerID, reference)
+	return a.record(ctx, grant, event, message, reference)
+}
+
+func (a *BreakGlassAuditor) record(ctx context.Context, grant *domain.BreakGlassGrant, event *models.AuditEvent, message, reference string) error {
+	if err := a.auditRepo.Create(ctx, event); err != nil {
+		return err
+	}
+
+	return a.notificationRepo.Create(ctx, &domain.Notification{
+		ID:        uuid.New().String(),
+		PatientID: grant.PatientID,
+		Type:      domain.NotificationTypeBreakGlass,
+		Message:   message,
+		Reference: reference,
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+func newBreakGlassEvent(grant *domain.BreakGlassGrant, action models.AuditEventAction) *models.AuditEvent {
+	return &models.AuditEvent{
+		ResourceType: "AuditEvent",
+		Id:           ptr.To(uuid.New().String()),
+		Type: &models.CodeableConcept{Coding: []models.Coding{{
+			System:  ptr.To("http://dicom.nema.org/resources/ontology/DCM"),
+			Code:    ptr.To("110113"),
+			Display: ptr.To("Security Alert"),
+		}}},
+		Action:   ptr.To(string(action)),
+		Severity: ptr.To(string(models.AuditEventSeverityAlert)),
+		Recorded: time.Now().UTC().Format(time.RFC3339),
+		Authorization: []models.CodeableConcept{{
+			Coding: []models.Coding{{
+				System:  ptr.To("http://terminology.hl7.org/CodeSystem/v3-ActReason"),
+				Code:    ptr.To("BTG"),
+				Display: ptr.To("break the glass"),
+			}},
+			Text: ptr.To(grant.Reason),
+		}},
+		Patient: &models.Reference{Reference: ptr.To("Patient/" + grant.PatientID)},
+		Agent: []models.AuditEventAgent{{
+			Who:       &models.Reference{Reference: ptr.To("Practitioner/" + grant.PractitionerID)},
+			Requestor: ptr.To(true),
+			Policy:    []string{"urn:uuid:" + grant.ID},
+		}},
+		Source: &models.AuditEventSource{
+			Observer: &models.Reference{Display: ptr.To("codex-documents")},
+		},
+	}
+}
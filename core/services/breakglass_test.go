@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testGrantID    = "grant-123"
+	testReviewerID = "reviewer-123"
+)
+
+func createTestBreakGlassGrant() *domain.BreakGlassGrant {
+	now := time.Now().Unix()
+	return &domain.BreakGlassGrant{
+		ID:             testGrantID,
+		PatientID:      testPatientID,
+		PractitionerID: testPractitionerID,
+		Reason:         "unconscious patient in ER",
+		CreatedAt:      now,
+		ExpiresAt:      now + int64(time.Hour/time.Second),
+	}
+}
+
+func TestBreakGlassService_Request(t *testing.T) {
+	emergencyClinician := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.rs", domain.ScopeBreakGlass})
+
+	tests := []struct {
+		name           string
+		user           domain.Identity
+		req            domain.BreakGlassRequest
+		setupMocks     func(*ports.MockBreakGlassRepository, *ports.MockBreakGlassAuditor)
+		expectedError  error
+		validateResult func(*testing.T, *domain.BreakGlassGrant)
+	}{
+		{
+			name: "success path - default duration is the maximum",
+			user: emergencyClinician,
+			req:  domain.BreakGlassRequest{PatientID: testPatientID, Reason: "  unconscious patient in ER "},
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, g *domain.BreakGlassGrant) (*domain.BreakGlassGrant, error) {
+						return g, nil
+					})
+				auditor.EXPECT().RecordGrant(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			validateResult: func(t *testing.T, g *domain.BreakGlassGrant) {
+				assert.Equal(t, testPatientID, g.PatientID)
+				assert.Equal(t, testPractitionerID, g.PractitionerID)
+				assert.Equal(t, "unconscious patient in ER", g.Reason)
+				assert.Equal(t, int64(domain.BreakGlassMaxDuration/time.Second), g.ExpiresAt-g.CreatedAt)
+				assert.False(t, g.Reviewed())
+			},
+		},
+		{
+			name: "success path - shorter duration",
+			user: emergencyClinician,
+			req:  domain.BreakGlassRequest{PatientID: testPatientID, Reason: "ER", Duration: 15 * time.Minute},
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, g *domain.BreakGlassGrant) (*domain.BreakGlassGrant, error) {
+						return g, nil
+					})
+				auditor.EXPECT().RecordGrant(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			validateResult: func(t *testing.T, g *domain.BreakGlassGrant) {
+				assert.Equal(t, int64(15*60), g.ExpiresAt-g.CreatedAt)
+			},
+		},
+		{
+			name:          "error - reason is required",
+			user:          emergencyClinician,
+			req:           domain.BreakGlassRequest{PatientID: testPatientID, Reason: "   "},
+			expectedError: domain.ErrBreakGlassReasonRequired,
+		},
+		{
+			name:          "error - duration above the maximum",
+			user:          emergencyClinician,
+			req:           domain.BreakGlassRequest{PatientID: testPatientID, Reason: "ER", Duration: 2 * time.Hour},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - patient is required",
+			user:          emergencyClinician,
+			req:           domain.BreakGlassRequest{Reason: "ER"},
+			expectedError: domain.ErrPatientIDRequired,
+		},
+		{
+			name:          "error - practitioner without break-glass scope",
+			user:          createTestPractitionerIdentity(testPractitionerID, []string{"user/*.rs"}),
+			req:           domain.BreakGlassRequest{PatientID: testPatientID, Reason: "ER"},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - patients cannot break the glass",
+			user:          createTestIdentity(testPatientID, testUserID, []string{domain.ScopeBreakGlass}),
+			req:           domain.BreakGlassRequest{PatientID: "other-patient", Reason: "ER"},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - grant audit fails",
+			user: emergencyClinician,
+			req:  domain.BreakGlassRequest{PatientID: testPatientID, Reason: "ER"},
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, g *domain.BreakGlassGrant) (*domain.BreakGlassGrant, error) {
+						return g, nil
+					})
+				auditor.EXPECT().RecordGrant(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockBreakGlassRepository(ctrl)
+			auditor := ports.NewMockBreakGlassAuditor(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo, auditor)
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), repo, auditor)
+			service := NewBreakGlassService(repo, auditor, authz)
+
+			result, err := service.Request(identity.WithCtx(context.Background(), tt.user), tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			tt.validateResult(t, result)
+		})
+	}
+}
+
+func TestBreakGlassService_Review(t *testing.T) {
+	reviewer := domain.Identity{UserID: testReviewerID, Scopes: []string{domain.ScopeBreakGlassReview}}
+
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		setupMocks    func(*ports.MockBreakGlassRepository)
+		expectedError error
+	}{
+		{
+			name: "success path",
+			user: reviewer,
+			setupMocks: func(repo *ports.MockBreakGlassRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), testGrantID).Return(createTestBreakGlassGrant(), nil)
+				repo.EXPECT().
+					MarkReviewed(gomock.Any(), testGrantID, "User/"+testReviewerID, "justified", gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "error - grant not found",
+			user: reviewer,
+			setupMocks: func(repo *ports.MockBreakGlassRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), testGrantID).Return(nil, nil)
+			},
+			expectedError: domain.ErrBreakGlassNotFound,
+		},
+		{
+			name: "error - already reviewed",
+			user: reviewer,
+			setupMocks: func(repo *ports.MockBreakGlassRepository) {
+				grant := createTestBreakGlassGrant()
+				grant.ReviewedAt = time.Now().Unix()
+				repo.EXPECT().GetByID(gomock.Any(), testGrantID).Return(grant, nil)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - practitioner cannot review own access",
+			user: createTestPractitionerIdentity(testPractitionerID, []string{domain.ScopeBreakGlassReview}),
+			setupMocks: func(repo *ports.MockBreakGlassRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), testGrantID).Return(createTestBreakGlassGrant(), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - no review scope",
+			user:          createTestPractitionerIdentity("other-practitioner", []string{domain.ScopeBreakGlass}),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockBreakGlassRepository(ctrl)
+			auditor := ports.NewMockBreakGlassAuditor(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), repo, auditor)
+			service := NewBreakGlassService(repo, auditor, authz)
+
+			err := service.Review(identity.WithCtx(context.Background(), tt.user), domain.BreakGlassReview{GrantID: testGrantID, Note: " justified "})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicyAuthorizer_BreakGlass(t *testing.T) {
+	clinician := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.cruds"})
+	obsRef := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID}
+
+	tests := []struct {
+		name          string
+		action        domain.Action
+		setupMocks    func(*ports.MockBreakGlassRepository, *ports.MockBreakGlassAuditor)
+		expected      bool
+		expectedError error
+	}{
+		{
+			name:   "active grant allows audited read",
+			action: domain.ActionRead,
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				grant := createTestBreakGlassGrant()
+				repo.EXPECT().FindActive(gomock.Any(), testPatientID, testPractitionerID, gomock.Any()).Return(grant, nil)
+				auditor.EXPECT().RecordAccess(gomock.Any(), grant, domain.ActionRead, obsRef).Return(nil)
+			},
+			expected: true,
+		},
+		{
+			name:   "active grant allows audited search",
+			action: domain.ActionSearch,
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				repo.EXPECT().FindActive(gomock.Any(), testPatientID, testPractitionerID, gomock.Any()).Return(createTestBreakGlassGrant(), nil)
+				auditor.EXPECT().RecordAccess(gomock.Any(), gomock.Any(), domain.ActionSearch, gomock.Any()).Return(nil)
+			},
+			expected: true,
+		},
+		{
+			name:   "no active grant",
+			action: domain.ActionRead,
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				repo.EXPECT().FindActive(gomock.Any(), testPatientID, testPractitionerID, gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:     "grant does not cover writes",
+			action:   domain.ActionUpdate,
+			expected: false,
+		},
+		{
+			name:   "failed audit denies access",
+			action: domain.ActionRead,
+			setupMocks: func(repo *ports.MockBreakGlassRepository, auditor *ports.MockBreakGlassAuditor) {
+				repo.EXPECT().FindActive(gomock.Any(), testPatientID, testPractitionerID, gomock.Any()).Return(createTestBreakGlassGrant(), nil)
+				auditor.EXPECT().RecordAccess(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+			careRepo.EXPECT().Find(gomock.Any(), testPatientID, testPractitionerID).Return(nil, nil)
+			repo := ports.NewMockBreakGlassRepository(ctrl)
+			auditor := ports.NewMockBreakGlassAuditor(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo, auditor)
+			}
+
+			authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), repo, auditor)
+			ref := obsRef
+			if tt.action == domain.ActionSearch {
+				ref = domain.ResourceRef{Type: "Observation", PatientID: testPatientID}
+			}
+
+			decision, err := authz.Authorize(context.Background(), clinician, tt.action, ref)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision.Allowed)
+		})
+	}
+}
+
+func TestBreakGlassAuditor_RecordAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auditRepo := ports.NewMockAuditEventRepository(ctrl)
+	notificationRepo := ports.NewMockNotificationRepository(ctrl)
+	grant := createTestBreakGlassGrant()
+
+	auditRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event *models.AuditEvent) error {
+			require.NoError(t, event.Validate())
+			assert.Equal(t, string(models.AuditEventSeverityAlert), *event.Severity)
+			assert.Equal(t, string(models.AuditEventActionR), *event.Action)
+			assert.Equal(t, "BTG", *event.Authorization[0].Coding[0].Code)
+			assert.Equal(t, grant.Reason, *event.Authorization[0].Text)
+			assert.Equal(t, "Practitioner/"+testPractitionerID, *event.Agent[0].Who.Reference)
+			assert.Equal(t, "Patient/"+testPatientID, *event.Patient.Reference)
+			assert.Equal(t, "Observation/"+testObsID, *event.Entity[0].What.Reference)
+			return nil
+		})
+	notificationRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, n *domain.Notification) error {
+			assert.Equal(t, testPatientID, n.PatientID)
+			assert.Equal(t, domain.NotificationTypeBreakGlass, n.Type)
+			assert.Equal(t, "Observation/"+testObsID, n.Reference)
+			return nil
+		})
+
+	auditor := NewBreakGlassAuditor(auditRepo, notificationRepo)
+	res := domain.ResourceRef{Type: "Observation", ID: testObsID, PatientID: testPatientID}
+
+	require.NoError(t, auditor.RecordAccess(context.Background(), grant, domain.ActionRead, res))
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+)
+
+type CareRelationshipService struct {
+	repo             ports.CareRelationshipRepository
+	practitionerRepo ports.PractitionerRepository
+	authz            ports.Authorizer
+}
+
+func NewCareRelationshipService(
+	repo ports.CareRelationshipRepository,
+	practitionerRepo ports.PractitionerRepository,
+	authz ports.Authorizer,
+) *CareRelationshipService {
+	return &CareRelationshipService{
+		repo:             repo,
+		practitionerRepo: practitionerRepo,
+		authz:            authz,
+	}
+}
+
+// Grant lets a patient give a practitioner read or write access to their
+// compartment. Granting again replaces the previous access levels.
+func (s *CareRelationshipService) Grant(ctx context.Context, req domain.GrantCareRequest) (*domain.CareRelationship, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "CareRelationship"); !decision.Allowed {
+		return nil, decision.Err
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "CareRelationship", PatientID: req.PatientID}); err != nil {
+		return nil, err
+	}
+
+	if req.PractitionerID == "" {
+		return nil, domain.ErrPractitionerIDRequired
+	}
+
+	if len(req.Access) == 0 {
+		return nil, fmt.Errorf("%w: at least one access level is required", domain.ErrInvalidInput)
+	}
+	for _, a := range req.Access {
+		if a != domain.CareAccessRead && a != domain.CareAccessWrite {
+			return nil, fmt.Errorf("%w: unknown access level %q", domain.ErrInvalidInput, a)
+		}
+	}
+
+	practitioner, err := s.practitionerRepo.GetByID(ctx, req.PractitionerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if practitioner == nil {
+		return nil, domain.ErrPractitionerNotFound
+	}
+
+	rel := &domain.CareRelationship{
+		ID:             uuid.New().String(),
+		PatientID:      req.PatientID,
+		PractitionerID: req.PractitionerID,
+		Access:         req.Access,
+		CreatedAt:      time.Now().Unix(),
+	}
+
+	saved, err := s.repo.Upsert(ctx, rel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return saved, nil
+}
+
+func (s *CareRelationshipService) List(ctx context.Context, patientID string) ([]domain.CareRelationship, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "CareRelationship"); !decision.Allowed {
+		return nil, decision.Err
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionSearch, domain.ResourceRef{Type: "CareRelationship", PatientID: patientID}); err != nil {
+		return nil, err
+	}
+
+	rels, err := s.repo.ListByPatient(ctx, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return rels, nil
+}
+
+func (s *CareRelationshipService) Revoke(ctx context.Context, patientID, practitionerID string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "CareRelationship"); !decision.Allowed {
+		return decision.Err
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, domain.ResourceRef{Type: "CareRelationship", PatientID: patientID}); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.Find(ctx, patientID, practitionerID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrCareRelationshipNotFound
+	}
+
+	if err := s.repo.Delete(ctx, patientID, practitionerID); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type CarePlanService struct {
+	repo      ports.CarePlanRepository
+	goalRepo  ports.GoalRepository
+	condRepo  ports.ConditionRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.CarePlanValidator
+}
+
+func NewCarePlanService(
+	repo ports.CarePlanRepository,
+	goalRepo ports.GoalRepository,
+	condRepo ports.ConditionRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.CarePlanValidator,
+) *CarePlanService {
+	return &CarePlanService{
+		repo:      repo,
+		goalRepo:  goalRepo,
+		condRepo:  condRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *CarePlanService) Create(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	if err := s.validator.Validate(plan); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "CarePlan"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, plan.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "CarePlan", PatientID: patientID, SearchParams: carePlanSearchParams(plan)}); err != nil {
+		return nil, err
+	}
+
+	if plan.Id != nil && *plan.Id != "" {
+		return nil, fmt.Errorf("%w: care plan ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	plan.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	plan.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := s.validateLinks(ctx, plan, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.CarePlan
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, plan); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, carePlanRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *CarePlanService) Get(ctx context.Context, id string) (*models.CarePlan, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrCarePlanIDRequired
+	}
+
+	plan, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if plan == nil {
+		return nil, domain.ErrCarePlanNotFound
+	}
+
+	ref := carePlanRef(plan)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (s *CarePlanService) Update(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "CarePlan"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if plan.Id == nil {
+		return nil, domain.ErrCarePlanIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *plan.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrCarePlanNotFound
+	}
+
+	ref := carePlanRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(plan); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		plan.Meta = keepSecurityLabels(plan.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the care plan to another compartment.
+	plan.Subject = existing.Subject
+
+	// A restricted scope must also cover the care plan as it will be stored.
+	ref.SearchParams = carePlanSearchParams(plan)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateLinks(ctx, plan, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.CarePlan
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, plan); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, carePlanRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a care plan and
+// returns its resulting meta.
+func (s *CarePlanService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "CarePlan"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if plan == nil {
+		return nil, domain.ErrCarePlanNotFound
+	}
+
+	ref := carePlanRef(plan)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	plan.Meta = changeSecurityLabels(plan.Meta, add, remove)
+	var updated *models.CarePlan
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, plan); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, carePlanRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *CarePlanService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "CarePlan"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrCarePlanNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, carePlanRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, carePlanRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *CarePlanService) List(ctx context.Context, search domain.CarePlanSearch, limit, offset int) (*domain.ListResponse[models.CarePlan], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "CarePlan"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "CarePlan", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = carePlanRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.CarePlan]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// validateLinks checks that the goals of a plan are goals and the issues it
+// addresses are conditions of the same patient.
+func (s *CarePlanService) validateLinks(ctx context.Context, plan *models.CarePlan, patientID string) error {
+	for _, ref := range plan.Goal {
+		if ref.Reference == nil {
+			continue
+		}
+
+		goalID, ok := strings.CutPrefix(*ref.Reference, "Goal/")
+		if !ok || goalID == "" {
+			return domain.ErrInvalidPlanRef
+		}
+
+		goal, err := s.goalRepo.GetByID(ctx, goalID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if goal == nil {
+			return domain.ErrPlanRefNotFound
+		}
+
+		if patientIDFromReference(goal.Subject) != patientID {
+			return domain.ErrAccessDenied
+		}
+	}
+
+	var addresses []models.Reference
+	for _, issue := range plan.Addresses {
+		if issue.Reference != nil {
+			addresses = append(addresses, *issue.Reference)
+		}
+	}
+	return validateConditionRefs(ctx, s.condRepo, addresses, patientID)
+}
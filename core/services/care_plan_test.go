@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func createTestCarePlan(patientID string, goals ...string) *models.CarePlan {
+	plan := &models.CarePlan{
+		ResourceType: "CarePlan",
+		Status:       "active",
+		Intent:       "plan",
+		Subject:      &models.Reference{Reference: strPtr("Patient/" + patientID)},
+		Addresses: []models.CodeableReference{{
+			Reference: &models.Reference{Reference: strPtr("Condition/" + testCondID)},
+		}},
+	}
+	for _, ref := range goals {
+		plan.Goal = append(plan.Goal, models.Reference{Reference: strPtr(ref)})
+	}
+	return plan
+}
+
+func TestCarePlanService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/CarePlan.c"})
+
+	tests := []struct {
+		name          string
+		plan          *models.CarePlan
+		setupMocks    func(goalRepo *ports.MockGoalRepository, condRepo *ports.MockConditionRepository)
+		expectedError error
+	}{
+		{
+			name: "success path",
+			plan: createTestCarePlan(testPatientID, "Goal/"+testGoalID),
+			setupMocks: func(goalRepo *ports.MockGoalRepository, condRepo *ports.MockConditionRepository) {
+				goalRepo.EXPECT().GetByID(gomock.Any(), testGoalID).Return(createTestGoal(testGoalID, testPatientID, "2024-12-31"), nil)
+				condRepo.EXPECT().GetByID(gomock.Any(), testCondID).Return(createTestCondition(testCondID, testPatientID), nil)
+			},
+		},
+		{
+			name: "error - goal of another patient",
+			plan: createTestCarePlan(testPatientID, "Goal/"+testGoalID),
+			setupMocks: func(goalRepo *ports.MockGoalRepository, condRepo *ports.MockConditionRepository) {
+				goalRepo.EXPECT().GetByID(gomock.Any(), testGoalID).Return(createTestGoal(testGoalID, "other-patient", "2024-12-31"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - goal not found",
+			plan: createTestCarePlan(testPatientID, "Goal/"+testGoalID),
+			setupMocks: func(goalRepo *ports.MockGoalRepository, condRepo *ports.MockConditionRepository) {
+				goalRepo.EXPECT().GetByID(gomock.Any(), testGoalID).Return(nil, nil)
+			},
+			expectedError: domain.ErrPlanRefNotFound,
+		},
+		{
+			name:          "error - goal is not a Goal reference",
+			plan:          createTestCarePlan(testPatientID, "Observation/"+testObsID),
+			setupMocks:    func(*ports.MockGoalRepository, *ports.MockConditionRepository) {},
+			expectedError: domain.ErrInvalidPlanRef,
+		},
+		{
+			name: "error - invalid intent",
+			plan: func() *models.CarePlan {
+				plan := createTestCarePlan(testPatientID)
+				plan.Intent = "wish"
+				return plan
+			}(),
+			setupMocks:    func(*ports.MockGoalRepository, *ports.MockConditionRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockCarePlanRepository(ctrl)
+			goalRepo := ports.NewMockGoalRepository(ctrl)
+			condRepo := ports.NewMockConditionRepository(ctrl)
+			tt.setupMocks(goalRepo, condRepo)
+
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, plan *models.CarePlan) (*models.CarePlan, error) {
+						return plan, nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewCarePlanService(repo, goalRepo, condRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewCarePlanValidator())
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.plan)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func TestCareRelationshipService_Grant(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            domain.GrantCareRequest
+		setupMocks     func(*ports.MockCareRelationshipRepository, *ports.MockPractitionerRepository)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *domain.CareRelationship, error)
+	}{
+		{
+			name: "success path - patient grants read",
+			req: domain.GrantCareRequest{
+				PatientID:      testPatientID,
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(repo *ports.MockCareRelationshipRepository, practitionerRepo *ports.MockPractitionerRepository) {
+				practitionerRepo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(&models.Practitioner{Id: strPtr(testPractitionerID)}, nil)
+				repo.EXPECT().
+					Upsert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, rel *domain.CareRelationship) (*domain.CareRelationship, error) {
+						return rel, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			validateResult: func(t *testing.T, rel *domain.CareRelationship, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, rel)
+				assert.NotEmpty(t, rel.ID)
+				assert.Equal(t, testPatientID, rel.PatientID)
+				assert.Equal(t, testPractitionerID, rel.PractitionerID)
+				assert.Equal(t, []domain.CareAccess{domain.CareAccessRead}, rel.Access)
+			},
+		},
+		{
+			name: "error - other patient",
+			req: domain.GrantCareRequest{
+				PatientID:      "other-patient",
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockCareRelationshipRepository, *ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - practitioner cannot grant",
+			req: domain.GrantCareRequest{
+				PatientID:      testPatientID,
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockCareRelationshipRepository, *ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - missing practitioner ID",
+			req: domain.GrantCareRequest{
+				PatientID: testPatientID,
+				Access:    []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockCareRelationshipRepository, *ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrPractitionerIDRequired,
+		},
+		{
+			name: "error - unknown access level",
+			req: domain.GrantCareRequest{
+				PatientID:      testPatientID,
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{"admin"},
+			},
+			setupMocks: func(*ports.MockCareRelationshipRepository, *ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - practitioner not found",
+			req: domain.GrantCareRequest{
+				PatientID:      testPatientID,
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{domain.CareAccessWrite},
+			},
+			setupMocks: func(repo *ports.MockCareRelationshipRepository, practitionerRepo *ports.MockPractitionerRepository) {
+				practitionerRepo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(nil, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrPractitionerNotFound,
+		},
+		{
+			name: "error - tmp token",
+			req: domain.GrantCareRequest{
+				PatientID:      testPatientID,
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockCareRelationshipRepository, *ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity("", "", []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrTmpTokenForbidden,
+		},
+		{
+			name: "error - repository error",
+			req: domain.GrantCareRequest{
+				PatientID:      testPatientID,
+				PractitionerID: testPractitionerID,
+				Access:         []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(repo *ports.MockCareRelationshipRepository, practitionerRepo *ports.MockPractitionerRepository) {
+				practitionerRepo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(&models.Practitioner{Id: strPtr(testPractitionerID)}, nil)
+				repo.EXPECT().
+					Upsert(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockCareRelationshipRepository(ctrl)
+			practitionerRepo := ports.NewMockPractitionerRepository(ctrl)
+
+			tt.setupMocks(repo, practitionerRepo)
+
+			service := NewCareRelationshipService(repo, practitionerRepo, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)))
+
+			result, err := service.Grant(tt.setupContext(), tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
+
+func TestCareRelationshipService_Revoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		patientID      string
+		practitionerID string
+		setupMocks     func(*ports.MockCareRelationshipRepository)
+		setupContext   func() context.Context
+		expectedError  error
+	}{
+		{
+			name:           "success path",
+			patientID:      testPatientID,
+			practitionerID: testPractitionerID,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessRead), nil)
+				repo.EXPECT().
+					Delete(gomock.Any(), testPatientID, testPractitionerID).
+					Return(nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+		},
+		{
+			name:           "error - relationship not found",
+			patientID:      testPatientID,
+			practitionerID: testPractitionerID,
+			setupMocks: func(repo *ports.MockCareRelationshipRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(nil, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrCareRelationshipNotFound,
+		},
+		{
+			name:           "error - other patient",
+			patientID:      "other-patient",
+			practitionerID: testPractitionerID,
+			setupMocks:     func(*ports.MockCareRelationshipRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:           "error - no identity",
+			patientID:      testPatientID,
+			practitionerID: testPractitionerID,
+			setupMocks:     func(*ports.MockCareRelationshipRepository) {},
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockCareRelationshipRepository(ctrl)
+			tt.setupMocks(repo)
+
+			service := NewCareRelationshipService(repo, ports.NewMockPractitionerRepository(ctrl), NewPolicyAuthorizer(repo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)))
+
+			err := service.Revoke(tt.setupContext(), tt.patientID, tt.practitionerID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
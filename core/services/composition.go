@@ -0,0 +1,573 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const documentBundleContentType = "application/fhir+json"
+
+type CompositionService struct {
+	repo      ports.CompositionRepository
+	obsRepo   ports.ObservationRepository
+	condRepo  ports.ConditionRepository
+	docRepo   ports.DocumentRepository
+	patients  ports.PatientService
+	documents ports.DocumentService
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.CompositionValidator
+	publicURL string
+}
+
+func NewCompositionService(
+	links LinkOptions,
+	repo ports.CompositionRepository,
+	obsRepo ports.ObservationRepository,
+	condRepo ports.ConditionRepository,
+	docRepo ports.DocumentRepository,
+	patients ports.PatientService,
+	documents ports.DocumentService,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.CompositionValidator,
+) *CompositionService {
+	return &CompositionService{
+		repo:      repo,
+		obsRepo:   obsRepo,
+		condRepo:  condRepo,
+		docRepo:   docRepo,
+		patients:  patients,
+		documents: documents,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+		publicURL: links.baseURL(),
+	}
+}
+
+func (s *CompositionService) Create(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	if err := s.validator.Validate(comp); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Composition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, compositionSubject(comp))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Composition", PatientID: patientID, SearchParams: compositionSearchParams(comp)}); err != nil {
+		return nil, err
+	}
+
+	if comp.Id != nil && *comp.Id != "" {
+		return nil, fmt.Errorf("%w: composition ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	comp.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	comp.Subject = []models.Reference{{
+		Reference: &patientRef,
+	}}
+
+	if err := s.validateEntries(ctx, comp, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.Composition
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, comp); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, compositionRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *CompositionService) Get(ctx context.Context, id string) (*models.Composition, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrCompositionIDRequired
+	}
+
+	comp, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if comp == nil {
+		return nil, domain.ErrCompositionNotFound
+	}
+
+	ref := compositionRef(comp)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+func (s *CompositionService) Update(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Composition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if comp.Id == nil {
+		return nil, domain.ErrCompositionIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *comp.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrCompositionNotFound
+	}
+
+	ref := compositionRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(comp); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		comp.Meta = keepSecurityLabels(comp.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the composition to another compartment.
+	comp.Subject = existing.Subject
+
+	// A restricted scope must also cover the composition as it will be
+	// stored.
+	ref.SearchParams = compositionSearchParams(comp)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateEntries(ctx, comp, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Composition
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, comp); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, compositionRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a composition and
+// returns its resulting meta.
+func (s *CompositionService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Composition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	comp, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if comp == nil {
+		return nil, domain.ErrCompositionNotFound
+	}
+
+	ref := compositionRef(comp)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	comp.Meta = changeSecurityLabels(comp.Meta, add, remove)
+	var updated *models.Composition
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, comp); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, compositionRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *CompositionService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Composition"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrCompositionNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, compositionRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, compositionRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *CompositionService) List(ctx context.Context, search domain.CompositionSearch, limit, offset int) (*domain.ListResponse[models.Composition], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Composition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Composition", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = compositionRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.Composition]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// Document assembles a FHIR Document from a composition: a document Bundle
+// with the composition, its patient and every resource its sections
+// reference. The caller must be allowed to read all of them; a withheld entry
+// fails the operation rather than producing an incomplete document. With
+// persist set the bundle is also stored as a new DocumentReference of the
+// patient.
+func (s *CompositionService) Document(ctx context.Context, id string, persist bool) (*domain.GeneratedDocument, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	comp, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patientID := patientIDFromReference(compositionSubject(comp))
+	patient, err := s.patients.Get(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := newDocumentBundle()
+	if err := appendDocumentEntry(bundle, resourceURL(s.publicURL, "Composition", *comp.Id), comp); err != nil {
+		return nil, err
+	}
+	if err := appendDocumentEntry(bundle, resourceURL(s.publicURL, "Patient", patientID), patient); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range sectionEntries(comp.Section) {
+		if entry.Reference == nil || seen[*entry.Reference] {
+			continue
+		}
+		seen[*entry.Reference] = true
+
+		resource, ref, err := s.loadEntry(ctx, *entry.Reference)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+			return nil, err
+		}
+		if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+			return nil, err
+		}
+
+		if doc, ok := resource.(*models.DocumentReference); ok {
+			resource = withResolvedAttachments(doc)
+		}
+		if err := appendDocumentEntry(bundle, resourceURL(s.publicURL, ref.Type, ref.ID), resource); err != nil {
+			return nil, err
+		}
+	}
+
+	generated := &domain.GeneratedDocument{Bundle: bundle}
+	if !persist {
+		return generated, nil
+	}
+
+	doc, err := documentFromBundle(comp, bundle)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.documents.CreateDocument(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	generated.DocumentReference = result.Document
+
+	return generated, nil
+}
+
+// validateEntries checks that the section entries of a composition, at any
+// depth, reference observations, conditions and documents of the same
+// patient.
+func (s *CompositionService) validateEntries(ctx context.Context, comp *models.Composition, patientID string) error {
+	for _, entry := range sectionEntries(comp.Section) {
+		if entry.Reference == nil {
+			continue
+		}
+
+		_, ref, err := s.loadEntry(ctx, *entry.Reference)
+		if err != nil {
+			return err
+		}
+		if ref.PatientID != patientID {
+			return domain.ErrAccessDenied
+		}
+	}
+	return nil
+}
+
+// loadEntry loads the resource a section entry references and describes it
+// for authorization and consent checks.
+func (s *CompositionService) loadEntry(ctx context.Context, reference string) (any, domain.ResourceRef, error) {
+	resourceType, id, ok := strings.Cut(reference, "/")
+	if !ok || id == "" {
+		return nil, domain.ResourceRef{}, domain.ErrInvalidSectionRef
+	}
+
+	var (
+		resource any
+		ref      domain.ResourceRef
+		err      error
+	)
+	switch resourceType {
+	case "Observation":
+		var obs *models.Observation
+		if obs, err = s.obsRepo.GetByID(ctx, id); err == nil && obs != nil {
+			resource, ref = obs, observationRef(obs)
+		}
+	case "Condition":
+		var cond *models.Condition
+		if cond, err = s.condRepo.GetByID(ctx, id); err == nil && cond != nil {
+			resource, ref = cond, conditionRef(cond)
+		}
+	case "DocumentReference":
+		var doc *models.DocumentReference
+		if doc, err = s.docRepo.GetByID(ctx, id); err == nil && doc != nil {
+			resource, ref = doc, documentRef(doc)
+		}
+	default:
+		return nil, domain.ResourceRef{}, domain.ErrInvalidSectionRef
+	}
+
+	if err != nil {
+		return nil, domain.ResourceRef{}, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if resource == nil {
+		return nil, domain.ResourceRef{}, domain.ErrSectionEntryNotFound
+	}
+	return resource, ref, nil
+}
+
+// newDocumentBundle returns an empty document Bundle with a fresh identity.
+func newDocumentBundle() *models.Bundle {
+	id := uuid.New().String()
+	return &models.Bundle{
+		ResourceType: "Bundle",
+		Id:           &id,
+		Identifier: &models.Identifier{
+			System: ptr.To("urn:ietf:rfc:3986"),
+			Value:  ptr.To("urn:uuid:" + id),
+		},
+		Type:      "document",
+		Timestamp: ptr.To(time.Now().UTC().Format(time.RFC3339)),
+	}
+}
+
+func appendDocumentEntry(bundle *models.Bundle, fullURL string, resource any) error {
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	bundle.Entry = append(bundle.Entry, models.BundleEntry{
+		FullUrl:  &fullURL,
+		Resource: raw,
+	})
+	return nil
+}
+
+// LinkOptions configure the links services put in the documents and bundles
+// they build.
+type LinkOptions struct {
+	// PublicURL is the absolute URL the API is reached at.
+	PublicURL string
+}
+
+func (o LinkOptions) baseURL() string {
+	return strings.TrimSuffix(o.PublicURL, "/")
+}
+
+// resourceURL returns the absolute URL of a resource served by this API.
+func resourceURL(publicURL, resourceType, id string) string {
+	return fmt.Sprintf("%s/api/v1/%s/%s", publicURL, resourceType, id)
+}
+
+// compositionSubject returns the patient a composition is about. Compositions
+// of this service have a single subject.
+func compositionSubject(comp *models.Composition) *models.Reference {
+	if len(comp.Subject) == 0 {
+		return nil
+	}
+	return &comp.Subject[0]
+}
+
+// sectionEntries returns the entries of the sections and all of their nested
+// sections.
+func sectionEntries(sections []models.CompositionSection) []models.Reference {
+	var entries []models.Reference
+	for _, section := range sections {
+		entries = append(entries, section.Entry...)
+		entries = append(entries, sectionEntries(section.Section)...)
+	}
+	return entries
+}
+
+// withResolvedAttachments returns a copy of a document with only the
+// attachments a reader of the document can resolve, inline or by URL.
+// Attachments still awaiting their upload are left out.
+func withResolvedAttachments(doc *models.DocumentReference) *models.DocumentReference {
+	resolved := *doc
+	resolved.Content = nil
+	for _, content := range doc.Content {
+		if content.Attachment == nil || (content.Attachment.Data == nil && content.Attachment.Url == nil) {
+			continue
+		}
+		resolved.Content = append(resolved.Content, content)
+	}
+	return &resolved
+}
+
+// documentFromBundle describes a generated document bundle as a
+// DocumentReference with the bundle inline.
+func documentFromBundle(comp *models.Composition, bundle *models.Bundle) (*models.DocumentReference, error) {
+	raw, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &models.DocumentReference{
+		ResourceType: "DocumentReference",
+		Status:       "current",
+		Type:         comp.Type,
+		Category:     comp.Category,
+		Subject:      compositionSubject(comp),
+		Date:         bundle.Timestamp,
+		Description:  comp.Title,
+		Content: []models.DocumentReferenceContent{{
+			Attachment: &models.Attachment{
+				ContentType: ptr.To(documentBundleContentType),
+				Data:        ptr.To(base64.StdEncoding.EncodeToString(raw)),
+				Size:        ptr.To(int64(len(raw))),
+				Title:       comp.Title,
+				Creation:    bundle.Timestamp,
+			},
+		}},
+	}, nil
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testCompositionID = "comp-123"
+)
+
+func createTestComposition(id, patientID string, entries ...string) *models.Composition {
+	comp := &models.Composition{
+		ResourceType: "Composition",
+		Status:       "final",
+		Type: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://loinc.org", "11503-0"),
+		}},
+		Subject: []models.Reference{{Reference: strPtr("Patient/" + patientID)}},
+		Date:    "2024-06-01T10:00:00Z",
+		Author:  []models.Reference{{Reference: strPtr("Patient/" + patientID)}},
+		Title:   strPtr("Medical records"),
+	}
+	if id != "" {
+		comp.Id = strPtr(id)
+	}
+	section := models.CompositionSection{Title: strPtr("Results")}
+	for _, ref := range entries {
+		section.Entry = append(section.Entry, models.Reference{Reference: strPtr(ref)})
+	}
+	if len(entries) == 0 {
+		section.EmptyReason = &models.CodeableConcept{Text: strPtr("nil known")}
+	}
+	comp.Section = []models.CompositionSection{section}
+	return comp
+}
+
+type compositionMocks struct {
+	repo      *ports.MockCompositionRepository
+	obsRepo   *ports.MockObservationRepository
+	condRepo  *ports.MockConditionRepository
+	docRepo   *ports.MockDocumentRepository
+	patients  *ports.MockPatientService
+	documents *ports.MockDocumentService
+}
+
+func newTestCompositionService(ctrl *gomock.Controller, m compositionMocks) *CompositionService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewCompositionService(LinkOptions{PublicURL: testPublicURL + "/"}, m.repo, m.obsRepo, m.condRepo, m.docRepo, m.patients, m.documents, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewCompositionValidator())
+}
+
+func newCompositionMocks(ctrl *gomock.Controller) compositionMocks {
+	return compositionMocks{
+		repo:      ports.NewMockCompositionRepository(ctrl),
+		obsRepo:   ports.NewMockObservationRepository(ctrl),
+		condRepo:  ports.NewMockConditionRepository(ctrl),
+		docRepo:   ports.NewMockDocumentRepository(ctrl),
+		patients:  ports.NewMockPatientService(ctrl),
+		documents: ports.NewMockDocumentService(ctrl),
+	}
+}
+
+func TestCompositionService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Composition.c"})
+
+	tests := []struct {
+		name          string
+		comp          *models.Composition
+		setupMocks    func(compositionMocks)
+		expectedError error
+	}{
+		{
+			name: "success path - entries in nested sections",
+			comp: func() *models.Composition {
+				comp := createTestComposition("", testPatientID, "Observation/"+testObsID)
+				comp.Section[0].Section = []models.CompositionSection{{
+					Title: strPtr("Problems"),
+					Entry: []models.Reference{{Reference: strPtr("Condition/" + testCondID)}},
+				}}
+				return comp
+			}(),
+			setupMocks: func(m compositionMocks) {
+				m.obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, testPatientID), nil)
+				m.condRepo.EXPECT().GetByID(gomock.Any(), testCondID).Return(createTestCondition(testCondID, testPatientID), nil)
+				m.repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, comp *models.Composition) (*models.Composition, error) {
+						return comp, nil
+					})
+			},
+		},
+		{
+			name: "error - entry of another patient",
+			comp: createTestComposition("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(m compositionMocks) {
+				m.docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - entry not found",
+			comp: createTestComposition("", testPatientID, "Observation/"+testObsID),
+			setupMocks: func(m compositionMocks) {
+				m.obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(nil, nil)
+			},
+			expectedError: domain.ErrSectionEntryNotFound,
+		},
+		{
+			name:          "error - unsupported entry type",
+			comp:          createTestComposition("", testPatientID, "Encounter/"+testEncounterID),
+			setupMocks:    func(compositionMocks) {},
+			expectedError: domain.ErrInvalidSectionRef,
+		},
+		{
+			name: "error - missing title",
+			comp: func() *models.Composition {
+				comp := createTestComposition("", testPatientID)
+				comp.Title = nil
+				return comp
+			}(),
+			setupMocks:    func(compositionMocks) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - empty section without a reason",
+			comp: func() *models.Composition {
+				comp := createTestComposition("", testPatientID)
+				comp.Section[0].EmptyReason = nil
+				return comp
+			}(),
+			setupMocks:    func(compositionMocks) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newCompositionMocks(ctrl)
+			tt.setupMocks(m)
+
+			service := newTestCompositionService(ctrl, m)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.comp)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Subject, 1)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject[0].Reference)
+		})
+	}
+}
+
+func TestCompositionService_Document(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs", "patient/DocumentReference.c"})
+
+	tests := []struct {
+		name          string
+		persist       bool
+		obsPatientID  string
+		expectedError error
+	}{
+		{
+			name:         "success path",
+			obsPatientID: testPatientID,
+		},
+		{
+			name:         "success path - persisted as a document",
+			persist:      true,
+			obsPatientID: testPatientID,
+		},
+		{
+			name:          "error - entry the user may not read",
+			obsPatientID:  "other-patient",
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uploaded := createTestDocument(testDocID, testPatientID)
+			uploaded.Content = append(uploaded.Content, models.DocumentReferenceContent{
+				Attachment: &models.Attachment{ContentType: strPtr(testContentType), Url: strPtr("https://files.example.com/scan.pdf")},
+			})
+
+			m := newCompositionMocks(ctrl)
+			m.repo.EXPECT().
+				GetByID(gomock.Any(), testCompositionID).
+				Return(createTestComposition(testCompositionID, testPatientID, "Observation/"+testObsID, "DocumentReference/"+testDocID, "Observation/"+testObsID), nil)
+			m.patients.EXPECT().Get(gomock.Any(), testPatientID).Return(&models.Patient{ResourceType: "Patient", Id: strPtr(testPatientID)}, nil)
+			m.obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, tt.obsPatientID), nil)
+			if tt.expectedError == nil {
+				m.docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(uploaded, nil)
+			}
+			if tt.persist {
+				m.documents.EXPECT().
+					CreateDocument(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, doc *models.DocumentReference) (*domain.CreateDocumentResult, error) {
+						assert.Equal(t, "Patient/"+testPatientID, *doc.Subject.Reference)
+						assert.Equal(t, "Medical records", *doc.Description)
+						require.Len(t, doc.Content, 1)
+						assert.Equal(t, "application/fhir+json", *doc.Content[0].Attachment.ContentType)
+
+						raw, err := base64.StdEncoding.DecodeString(*doc.Content[0].Attachment.Data)
+						require.NoError(t, err)
+						var bundle models.Bundle
+						require.NoError(t, json.Unmarshal(raw, &bundle))
+						assert.Equal(t, "document", bundle.Type)
+
+						doc.Id = strPtr("generated-doc")
+						return &domain.CreateDocumentResult{Document: doc}, nil
+					})
+			}
+
+			service := newTestCompositionService(ctrl, m)
+			result, err := service.Document(identity.WithCtx(context.Background(), patient), testCompositionID, tt.persist)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+
+			bundle := result.Bundle
+			assert.Equal(t, "document", bundle.Type)
+			assert.Equal(t, "urn:uuid:"+*bundle.Id, *bundle.Identifier.Value)
+			require.Len(t, bundle.Entry, 4)
+			assert.Equal(t, testPublicURL+"/api/v1/Composition/"+testCompositionID, *bundle.Entry[0].FullUrl)
+			assert.Equal(t, testPublicURL+"/api/v1/Patient/"+testPatientID, *bundle.Entry[1].FullUrl)
+			assert.Equal(t, testPublicURL+"/api/v1/Observation/"+testObsID, *bundle.Entry[2].FullUrl)
+			assert.Equal(t, testPublicURL+"/api/v1/DocumentReference/"+testDocID, *bundle.Entry[3].FullUrl)
+
+			var doc models.DocumentReference
+			require.NoError(t, json.Unmarshal(bundle.Entry[3].Resource, &doc))
+			require.Len(t, doc.Content, 1, "attachments awaiting upload are left out")
+			assert.Equal(t, "https://files.example.com/scan.pdf", *doc.Content[0].Attachment.Url)
+
+			if tt.persist {
+				require.NotNil(t, result.DocumentReference)
+				assert.Equal(t, "generated-doc", *result.DocumentReference.Id)
+			} else {
+				assert.Nil(t, result.DocumentReference)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type ConditionService struct {
+	repo      ports.ConditionRepository
+	obsRepo   ports.ObservationRepository
+	docRepo   ports.DocumentRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.ConditionValidator
+}
+
+func NewConditionService(
+	repo ports.ConditionRepository,
+	obsRepo ports.ObservationRepository,
+	docRepo ports.DocumentRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.ConditionValidator,
+) *ConditionService {
+	return &ConditionService{
+		repo:      repo,
+		obsRepo:   obsRepo,
+		docRepo:   docRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *ConditionService) Create(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	if err := s.validator.Validate(cond); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Condition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, cond.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Condition", PatientID: patientID, SearchParams: conditionSearchParams(cond)}); err != nil {
+		return nil, err
+	}
+
+	if cond.Id != nil && *cond.Id != "" {
+		return nil, fmt.Errorf("%w: condition ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	cond.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	cond.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := s.validateEvidence(ctx, cond.Evidence, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.Condition
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, cond); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, conditionRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *ConditionService) Get(ctx context.Context, id string) (*models.Condition, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrConditionIDRequired
+	}
+
+	cond, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if cond == nil {
+		return nil, domain.ErrConditionNotFound
+	}
+
+	ref := conditionRef(cond)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return cond, nil
+}
+
+func (s *ConditionService) Update(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Condition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if cond.Id == nil {
+		return nil, domain.ErrConditionIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *cond.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrConditionNotFound
+	}
+
+	ref := conditionRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(cond); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		cond.Meta = keepSecurityLabels(cond.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the condition to another compartment.
+	cond.Subject = existing.Subject
+
+	// A restricted scope must also cover the condition as it will be stored.
+	ref.SearchParams = conditionSearchParams(cond)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateEvidence(ctx, cond.Evidence, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Condition
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, cond); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, conditionRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a condition and
+// returns its resulting meta.
+func (s *ConditionService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Condition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	cond, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if cond == nil {
+		return nil, domain.ErrConditionNotFound
+	}
+
+	ref := conditionRef(cond)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	cond.Meta = changeSecurityLabels(cond.Meta, add, remove)
+	var updated *models.Condition
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, cond); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, conditionRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *ConditionService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Condition"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrConditionNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, conditionRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, conditionRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *ConditionService) List(ctx context.Context, search domain.ConditionSearch, limit, offset int) (*domain.ListResponse[models.Condition], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Condition"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Condition", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = conditionRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.Condition]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// validateEvidence checks that evidence references point to documents and
+// observations of the same patient.
+func (s *ConditionService) validateEvidence(ctx context.Context, evidence []models.CodeableReference, patientID string) error {
+	for _, e := range evidence {
+		if e.Reference == nil || e.Reference.Reference == nil {
+			continue
+		}
+
+		resourceType, id, _ := strings.Cut(*e.Reference.Reference, "/")
+		if id == "" {
+			return domain.ErrInvalidEvidenceRef
+		}
+
+		var subject *models.Reference
+		switch resourceType {
+		case "DocumentReference":
+			doc, err := s.docRepo.GetByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+			}
+			if doc == nil {
+				return domain.ErrEvidenceNotFound
+			}
+			subject = doc.Subject
+		case "Observation":
+			obs, err := s.obsRepo.GetByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+			}
+			if obs == nil {
+				return domain.ErrEvidenceNotFound
+			}
+			subject = obs.Subject
+		default:
+			return domain.ErrInvalidEvidenceRef
+		}
+
+		if patientIDFromReference(subject) != patientID {
+			return domain.ErrAccessDenied
+		}
+	}
+
+	return nil
+}
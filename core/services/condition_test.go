@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testCondID = "cond-123"
+)
+
+func createTestCondition(id, patientID string) *models.Condition {
+	patientRef := "Patient/" + patientID
+	cond := &models.Condition{
+		ResourceType: "Condition",
+		ClinicalStatus: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://terminology.hl7.org/CodeSystem/condition-clinical", "active"),
+		}},
+		Code: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://snomed.info/sct", "44054006"),
+		}},
+		Subject: &models.Reference{Reference: &patientRef},
+	}
+	if id != "" {
+		cond.Id = strPtr(id)
+	}
+	return cond
+}
+
+func createTestConditionWithEvidence(id, patientID string, refs ...string) *models.Condition {
+	cond := createTestCondition(id, patientID)
+	for _, ref := range refs {
+		cond.Evidence = append(cond.Evidence, models.CodeableReference{
+			Reference: &models.Reference{Reference: strPtr(ref)},
+		})
+	}
+	return cond
+}
+
+func newTestConditionService(ctrl *gomock.Controller, repo ports.ConditionRepository, obsRepo ports.ObservationRepository, docRepo ports.DocumentRepository, careRepo ports.CareRelationshipRepository) *ConditionService {
+	authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewConditionService(repo, obsRepo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewConditionValidator())
+}
+
+func TestConditionService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.c"})
+
+	tests := []struct {
+		name          string
+		cond          *models.Condition
+		user          domain.Identity
+		setupMocks    func(*ports.MockConditionRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name: "success path",
+			cond: createTestCondition("", ""),
+			user: patient,
+			setupMocks: func(repo *ports.MockConditionRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+						assert.Equal(t, "Patient/"+testPatientID, *cond.Subject.Reference)
+						assert.NotEmpty(t, *cond.Id)
+						return cond, nil
+					})
+			},
+		},
+		{
+			name: "success path - evidence of the same patient",
+			cond: createTestConditionWithEvidence("", "", "DocumentReference/"+testDocID, "Observation/"+testObsID),
+			user: patient,
+			setupMocks: func(repo *ports.MockConditionRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, testPatientID), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(createTestCondition(testCondID, testPatientID), nil)
+			},
+		},
+		{
+			name: "error - evidence of another patient",
+			cond: createTestConditionWithEvidence("", "", "DocumentReference/"+testDocID),
+			user: patient,
+			setupMocks: func(repo *ports.MockConditionRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - evidence not found",
+			cond: createTestConditionWithEvidence("", "", "Observation/"+testObsID),
+			user: patient,
+			setupMocks: func(repo *ports.MockConditionRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(nil, nil)
+			},
+			expectedError: domain.ErrEvidenceNotFound,
+		},
+		{
+			name:          "error - evidence of unsupported type",
+			cond:          createTestConditionWithEvidence("", "", "Patient/"+testPatientID),
+			user:          patient,
+			setupMocks:    func(*ports.MockConditionRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidEvidenceRef,
+		},
+		{
+			name: "error - unknown clinical status",
+			cond: func() *models.Condition {
+				cond := createTestCondition("", "")
+				cond.ClinicalStatus.Coding[0].Code = strPtr("cured")
+				return cond
+			}(),
+			user:          patient,
+			setupMocks:    func(*ports.MockConditionRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - id provided",
+			cond:          createTestCondition(testCondID, ""),
+			user:          patient,
+			setupMocks:    func(*ports.MockConditionRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - no create scope",
+			cond:          createTestCondition("", ""),
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.rs"}),
+			setupMocks:    func(*ports.MockConditionRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - repository failure",
+			cond: createTestCondition("", ""),
+			user: patient,
+			setupMocks: func(repo *ports.MockConditionRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConditionRepository(ctrl)
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, obsRepo, docRepo)
+
+			service := newTestConditionService(ctrl, repo, obsRepo, docRepo, ports.NewMockCareRelationshipRepository(ctrl))
+			result, err := service.Create(identity.WithCtx(context.Background(), tt.user), tt.cond)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result)
+		})
+	}
+}
+
+func TestConditionService_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		stored        *models.Condition
+		setupCare     func(*ports.MockCareRelationshipRepository)
+		expectedError error
+	}{
+		{
+			name:   "success path - own condition",
+			user:   createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.rs"}),
+			stored: createTestCondition(testCondID, testPatientID),
+		},
+		{
+			name:   "success path - treating practitioner",
+			user:   createTestPractitionerIdentity(testPractitionerID, []string{"user/Condition.rs"}),
+			stored: createTestCondition(testCondID, testPatientID),
+			setupCare: func(careRepo *ports.MockCareRelationshipRepository) {
+				careRepo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessRead), nil)
+			},
+		},
+		{
+			name:          "error - another patient's condition",
+			user:          createTestIdentity("other-patient", "other-user", []string{"patient/Condition.rs"}),
+			stored:        createTestCondition(testCondID, testPatientID),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - not found",
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.rs"}),
+			expectedError: domain.ErrConditionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConditionRepository(ctrl)
+			careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), testCondID).Return(tt.stored, nil)
+			if tt.setupCare != nil {
+				tt.setupCare(careRepo)
+			}
+
+			service := newTestConditionService(ctrl, repo, ports.NewMockObservationRepository(ctrl), ports.NewMockDocumentRepository(ctrl), careRepo)
+			result, err := service.Get(identity.WithCtx(context.Background(), tt.user), testCondID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCondID, *result.Id)
+		})
+	}
+}
+
+func TestConditionService_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		cond          *models.Condition
+		stored        *models.Condition
+		expectUpdate  bool
+		expectedError error
+	}{
+		{
+			name: "success path - subject stays with the compartment",
+			cond: func() *models.Condition {
+				cond := createTestCondition(testCondID, "other-patient")
+				cond.ClinicalStatus.Coding[0].Code = strPtr("resolved")
+				return cond
+			}(),
+			stored:       createTestCondition(testCondID, testPatientID),
+			expectUpdate: true,
+		},
+		{
+			name:          "error - not found",
+			cond:          createTestCondition(testCondID, testPatientID),
+			expectedError: domain.ErrConditionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConditionRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), testCondID).Return(tt.stored, nil)
+			if tt.expectUpdate {
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cond *models.Condition) (*models.Condition, error) {
+						return cond, nil
+					})
+			}
+
+			service := newTestConditionService(ctrl, repo, ports.NewMockObservationRepository(ctrl), ports.NewMockDocumentRepository(ctrl), ports.NewMockCareRelationshipRepository(ctrl))
+			id := createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.cruds"})
+			result, err := service.Update(identity.WithCtx(context.Background(), id), tt.cond)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+			assert.Equal(t, "resolved", *result.ClinicalStatus.Coding[0].Code)
+		})
+	}
+}
+
+func TestConditionService_List(t *testing.T) {
+	search := domain.ConditionSearch{
+		PatientID:      testPatientID,
+		ClinicalStatus: "active",
+		OnsetDate:      []domain.DateFilter{{Prefix: "ge", Start: "2020", End: "2021"}},
+	}
+
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		expectSearch  bool
+		expectedError error
+	}{
+		{
+			name:         "success path",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.rs"}),
+			expectSearch: true,
+		},
+		{
+			name:          "error - another patient",
+			user:          createTestIdentity("other-patient", "other-user", []string{"patient/Condition.rs"}),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConditionRepository(ctrl)
+			if tt.expectSearch {
+				repo.EXPECT().
+					Search(gomock.Any(), search, gomock.Nil(), 10, 0).
+					Return([]models.Condition{*createTestCondition(testCondID, testPatientID)}, int64(1), nil)
+			}
+
+			service := newTestConditionService(ctrl, repo, ports.NewMockObservationRepository(ctrl), ports.NewMockDocumentRepository(ctrl), ports.NewMockCareRelationshipRepository(ctrl))
+			result, err := service.List(identity.WithCtx(context.Background(), tt.user), search, 10, 0)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Items, 1)
+			assert.Equal(t, int64(1), result.Total)
+		})
+	}
+}
+
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type ConsentService struct {
+	repo      ports.ConsentRepository
+	authz     ports.Authorizer
+	validator *validator.ConsentValidator
+}
+
+func NewConsentService(repo ports.ConsentRepository, authz ports.Authorizer, v *validator.ConsentValidator) *ConsentService {
+	return &ConsentService{
+		repo:      repo,
+		authz:     authz,
+		validator: v,
+	}
+}
+
+func (s *ConsentService) Create(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	if err := s.validator.Validate(consent); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Consent"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	ref := domain.ResourceRef{Type: "Consent", PatientID: patientIDFromReference(consent.Subject)}
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, ref); err != nil {
+		return nil, err
+	}
+
+	if consent.Id != nil && *consent.Id != "" {
+		return nil, fmt.Errorf("%w: consent ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	consent.Id = &id
+
+	created, err := s.repo.Create(ctx, consent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *ConsentService) Get(ctx context.Context, id string) (*models.Consent, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionRead, "Consent"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	consent, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if consent == nil {
+		return nil, domain.ErrConsentNotFound
+	}
+
+	ref := domain.ResourceRef{Type: "Consent", ID: id, PatientID: patientIDFromReference(consent.Subject)}
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// Update replaces a consent. The subject cannot change: a consent always
+// stays in the compartment of the patient who gave it.
+func (s *ConsentService) Update(ctx context.Context, consent *models.Consent) (*models.Consent, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Consent"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if consent.Id == nil || *consent.Id == "" {
+		return nil, fmt.Errorf("%w: consent ID is required", domain.ErrInvalidInput)
+	}
+
+	existing, err := s.repo.GetByID(ctx, *consent.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrConsentNotFound
+	}
+
+	patientID := patientIDFromReference(existing.Subject)
+	ref := domain.ResourceRef{Type: "Consent", ID: *consent.Id, PatientID: patientID}
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(consent); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if patientIDFromReference(consent.Subject) != patientID {
+		return nil, fmt.Errorf("%w: consent subject cannot be changed", domain.ErrInvalidInput)
+	}
+
+	updated, err := s.repo.Update(ctx, consent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+func (s *ConsentService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Consent"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrConsentNotFound
+	}
+
+	ref := domain.ResourceRef{Type: "Consent", ID: id, PatientID: patientIDFromReference(existing.Subject)}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, ref); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *ConsentService) List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Consent], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Consent"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if patientID == "" {
+		patientID = user.CompartmentID()
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionSearch, domain.ResourceRef{Type: "Consent", PatientID: patientID}); err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, patientID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.Consent]{
+		Items: items,
+		Total: total,
+	}, nil
+}
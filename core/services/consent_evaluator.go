@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// researchPurposes are denied unless a consent of the patient permits them.
+// Every other purpose is permitted unless a consent denies it.
+var researchPurposes = map[string]bool{
+	domain.PurposeResearch:         true,
+	domain.PurposeClinicalResearch: true,
+}
+
+// PolicyConsentEvaluator evaluates the active Consent resources of a patient.
+//
+// A consent states its decision for what its top-level provisions describe,
+// or for everything when it has none. Nested provisions are exceptions and
+// carry the opposite decision of their parent. A provision describes a
+// release when every element it sets matches: the period, actor, action,
+// purpose of use, resource type, security labels, document type, codes
+// (matched against category, code and type) and data references.
+//
+// A deny from any consent wins over permits.
+//
+// Resources carrying a restricted security label are withheld from anyone but
+// the patient unless the release is explicitly allowed for every such label:
+// by a granting SMART scope whose _security restriction names it, for the
+// requester's own access, or by a permitting consent provision that lists it.
+type PolicyConsentEvaluator struct {
+	repo ports.ConsentRepository
+}
+
+func NewPolicyConsentEvaluator(repo ports.ConsentRepository) *PolicyConsentEvaluator {
+	return &PolicyConsentEvaluator{
+		repo: repo,
+	}
+}
+
+func (e *PolicyConsentEvaluator) Withheld(ctx context.Context, user domain.Identity, action domain.Action, resources []domain.ResourceRef) ([]domain.ConsentDenial, error) {
+	req := consentRequest(user, action)
+	now := time.Now()
+
+	consentsByPatient := make(map[string][]models.Consent)
+	var denials []domain.ConsentDenial
+	for _, res := range resources {
+		if res.PatientID == "" || consentExempt(user, action, res.PatientID) {
+			continue
+		}
+
+		consents, loaded := consentsByPatient[res.PatientID]
+		if !loaded {
+			var err error
+			consents, err = e.repo.ListActive(ctx, res.PatientID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+			}
+			consentsByPatient[res.PatientID] = consents
+		}
+
+		reason, denied, released := decideConsents(consents, res, req, now)
+		if !denied {
+			reason, denied = restrictedByLabel(user, action, req, res, released)
+		}
+		if denied {
+			denials = append(denials, domain.ConsentDenial{
+				Reference: resourceReference(res),
+				Reason:    reason,
+			})
+		}
+	}
+
+	return denials, nil
+}
+
+// checkConsent turns a consent denial of a single resource into an error.
+func checkConsent(ctx context.Context, consent ports.ConsentEvaluator, user domain.Identity, action domain.Action, res domain.ResourceRef) error {
+	denials, err := consent.Withheld(ctx, user, action, []domain.ResourceRef{res})
+	if err != nil {
+		return err
+	}
+	if len(denials) > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrConsentDenied, denials[0].Reason)
+	}
+	return nil
+}
+
+// withholdByConsent drops the items consents withhold from the user and
+// reports them. refs[i] describes items[i].
+func withholdByConsent[T any](ctx context.Context, consent ports.ConsentEvaluator, user domain.Identity, action domain.Action, items []T, refs []domain.ResourceRef) ([]T, []domain.ConsentDenial, error) {
+	denials, err := consent.Withheld(ctx, user, action, refs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(denials) == 0 {
+		return items, nil, nil
+	}
+
+	withheld := make(map[string]bool, len(denials))
+	for _, d := range denials {
+		withheld[d.Reference] = true
+	}
+
+	kept := make([]T, 0, len(items)-len(denials))
+	for i := range items {
+		if !withheld[resourceReference(refs[i])] {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept, denials, nil
+}
+
+// consentExempt reports whether the patient, or a caregiver acting for them,
+// reads their own compartment. Consents restrict releases to others.
+func consentExempt(user domain.Identity, action domain.Action, patientID string) bool {
+	if action == domain.ActionShare {
+		return false
+	}
+	if user.PatientID == patientID {
+		return true
+	}
+	return !user.IsPractitioner() && user.ActivePatientID == patientID
+}
+
+// consentRequest describes the release the user asks for. Shares and reads
+// through a share token disclose data to a third party at the patient's
+// request; everything else is access by the requester.
+func consentRequest(user domain.Identity, action domain.Action) domain.ConsentRequest {
+	req := domain.ConsentRequest{
+		Action:  domain.ConsentActionAccess,
+		Purpose: user.PurposeOfUse,
+	}
+
+	switch {
+	case action == domain.ActionShare || user.IsTmpToken():
+		req.Action = domain.ConsentActionDisclose
+		if req.Purpose == "" {
+			req.Purpose = domain.PurposePatientRequest
+		}
+	case user.IsPractitioner():
+		req.Actor = "Practitioner/" + user.PractitionerID
+		if req.Purpose == "" {
+			req.Purpose = domain.PurposeTreatment
+		}
+	default:
+		if user.UserID != "" {
+			req.Actor = "User/" + user.UserID
+		}
+		if req.Purpose == "" {
+			req.Purpose = domain.PurposeOperations
+		}
+	}
+
+	return req
+}
+
+// decideConsents returns whether the consents withhold res from req, and why.
+// When they do not, released lists the security labels the permitting
+// provisions name explicitly.
+func decideConsents(consents []models.Consent, res domain.ResourceRef, req domain.ConsentRequest, now time.Time) (reason string, denied bool, released []models.Coding) {
+	permitted := false
+	for i := range consents {
+		consent := &consents[i]
+		if consent.Period != nil && !periodCovers(consent.Period, now) {
+			continue
+		}
+
+		decision, labels, applies := consentDecision(consent, res, req, now)
+		if !applies {
+			continue
+		}
+		if decision == string(models.ConsentProvisionTypeDeny) {
+			return "denied by " + resourceReferenceOf("Consent", consent.Id), true, nil
+		}
+		permitted = true
+		released = append(released, labels...)
+	}
+
+	if !permitted && researchPurposes[req.Purpose] {
+		return "research use requires the patient's consent", true, nil
+	}
+	return "", false, released
+}
+
+// consentDecision returns the decision a consent makes for the release, the
+// security labels named by the provisions that led to it, and whether the
+// consent applies to the release at all.
+func consentDecision(consent *models.Consent, res domain.ResourceRef, req domain.ConsentRequest, now time.Time) (string, []models.Coding, bool) {
+	base := string(models.ConsentProvisionTypePermit)
+	if consent.Decision != nil && *consent.Decision != "" {
+		base = *consent.Decision
+	}
+
+	if len(consent.Provision) == 0 {
+		return base, nil, true
+	}
+	for i := range consent.Provision {
+		p := &consent.Provision[i]
+		if provisionMatches(p, res, req, now) {
+			decision, labels := applyExceptions(p.Provision, base, res, req, now)
+			return decision, append(labels, p.SecurityLabel...), true
+		}
+	}
+	return "", nil, false
+}
+
+// applyExceptions walks nested provisions: a matching one flips the decision
+// of its parent and may carry exceptions of its own.
+func applyExceptions(provisions []models.ConsentProvision, decision string, res domain.ResourceRef, req domain.ConsentRequest, now time.Time) (string, []models.Coding) {
+	for i := range provisions {
+		p := &provisions[i]
+		if provisionMatches(p, res, req, now) {
+			decision, labels := applyExceptions(p.Provision, oppositeDecision(decision), res, req, now)
+			return decision, append(labels, p.SecurityLabel...)
+		}
+	}
+	return decision, nil
+}
+
+// restrictedByLabel withholds a resource whose restricted security labels are
+// not all released explicitly. The reason does not name the label, as that
+// alone would disclose the sensitive category.
+func restrictedByLabel(user domain.Identity, action domain.Action, req domain.ConsentRequest, res domain.ResourceRef, released []models.Coding) (string, bool) {
+	labels := domain.RestrictedSecurityLabels(res.SearchParams["_security"])
+	if len(labels) == 0 {
+		return "", false
+	}
+
+	// Scopes cover the requester's own access; disclosure to third parties
+	// needs the patient's consent.
+	var scopes []domain.Scope
+	if req.Action == domain.ConsentActionAccess {
+		scopes = user.GrantingScopes(res.Type, action.Permission(), domain.ScopeContextPatient, domain.ScopeContextUser, domain.ScopeContextSystem)
+	}
+
+	for _, label := range labels {
+		system, code, ok := strings.Cut(label, "|")
+		if !ok {
+			system, code = "", label
+		}
+		if !labelReleased(scopes, released, system, code) {
+			return "restricted by security label", true
+		}
+	}
+	return "", false
+}
+
+func labelReleased(scopes []domain.Scope, released []models.Coding, system, code string) bool {
+	for _, scope := range scopes {
+		if scope.NamesSecurityLabel(system, code) {
+			return true
+		}
+	}
+
+	tokens := []string{code}
+	if system != "" {
+		tokens = append(tokens, system+"|"+code)
+	}
+	return codingsMatchTokens(released, tokens)
+}
+
+func oppositeDecision(decision string) string {
+	if decision == string(models.ConsentProvisionTypeDeny) {
+		return string(models.ConsentProvisionTypePermit)
+	}
+	return string(models.ConsentProvisionTypeDeny)
+}
+
+func provisionMatches(p *models.ConsentProvision, res domain.ResourceRef, req domain.ConsentRequest, now time.Time) bool {
+	if p.Period != nil && !periodCovers(p.Period, now) {
+		return false
+	}
+	if len(p.Actor) > 0 && !actorMatches(p.Actor, req.Actor) {
+		return false
+	}
+	if len(p.Action) > 0 && !conceptsContainCode(p.Action, req.Action) {
+		return false
+	}
+	if len(p.Purpose) > 0 && !codingsContainCode(p.Purpose, req.Purpose) {
+		return false
+	}
+	if len(p.ResourceType) > 0 && !codingsContainCode(p.ResourceType, res.Type) {
+		return false
+	}
+	if len(p.SecurityLabel) > 0 && !codingsMatchTokens(p.SecurityLabel, res.SearchParams["_security"]) {
+		return false
+	}
+	if len(p.DocumentType) > 0 && !codingsMatchTokens(p.DocumentType, res.SearchParams["type"]) {
+		return false
+	}
+	if len(p.Code) > 0 {
+		var tokens []string
+		for _, name := range []string{"category", "code", "type"} {
+			tokens = append(tokens, res.SearchParams[name]...)
+		}
+		matched := false
+		for i := range p.Code {
+			if codingsMatchTokens(p.Code[i].Coding, tokens) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(p.Data) > 0 && !dataMatches(p.Data, resourceReference(res)) {
+		return false
+	}
+	return true
+}
+
+func actorMatches(actors []models.ConsentProvisionActor, actor string) bool {
+	for _, a := range actors {
+		if a.Reference != nil && a.Reference.Reference != nil && *a.Reference.Reference == actor {
+			return true
+		}
+	}
+	return false
+}
+
+func dataMatches(data []models.ConsentProvisionData, reference string) bool {
+	for _, d := range data {
+		if d.Reference != nil && d.Reference.Reference != nil && *d.Reference.Reference == reference {
+			return true
+		}
+	}
+	return false
+}
+
+func conceptsContainCode(concepts []models.CodeableConcept, code string) bool {
+	for i := range concepts {
+		if codingsContainCode(concepts[i].Coding, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func codingsContainCode(codings []models.Coding, code string) bool {
+	for _, coding := range codings {
+		if coding.Code != nil && *coding.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// codingsMatchTokens reports whether any coding is among the "code" and
+// "system|code" token values of a resource. Codings with a system must match
+// the system too.
+func codingsMatchTokens(codings []models.Coding, tokens []string) bool {
+	for _, coding := range codings {
+		if coding.Code == nil {
+			continue
+		}
+		want := *coding.Code
+		if coding.System != nil && *coding.System != "" {
+			want = *coding.System + "|" + *coding.Code
+		}
+		if slices.Contains(tokens, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func periodCovers(period *models.Period, now time.Time) bool {
+	if period.Start != nil {
+		if start, ok := parseFHIRDateTime(*period.Start); ok && now.Before(start) {
+			return false
+		}
+	}
+	if period.End != nil {
+		if end, ok := parseFHIRDateTime(*period.End); ok && !now.Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFHIRDateTime parses the dateTime forms FHIR allows, from a full
+// timestamp down to a year.
+func parseFHIRDateTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func resourceReference(res domain.ResourceRef) string {
+	if res.ID == "" {
+		return res.Type
+	}
+	return res.Type + "/" + res.ID
+}
+
+func resourceReferenceOf(resourceType string, id *string) string {
+	if id == nil {
+		return resourceType
+	}
+	return resourceType + "/" + *id
+}
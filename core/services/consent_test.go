@@ -0,0 +1,481 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testConsentID = "consent-123"
+)
+
+// permitAllConsents is a consent evaluator that never withholds anything, for
+// tests that are not about consent.
+func permitAllConsents(ctrl *gomock.Controller) *ports.MockConsentEvaluator {
+	consent := ports.NewMockConsentEvaluator(ctrl)
+	consent.EXPECT().
+		Withheld(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	return consent
+}
+
+func createTestConsent(decision models.ConsentProvisionType, provisions ...models.ConsentProvision) models.Consent {
+	return models.Consent{
+		ResourceType: "Consent",
+		Id:           strPtr(testConsentID),
+		Status:       string(models.ConsentStateActive),
+		Subject:      &models.Reference{Reference: strPtr("Patient/" + testPatientID)},
+		Decision:     strPtr(string(decision)),
+		Provision:    provisions,
+	}
+}
+
+func coding(system, code string) models.Coding {
+	return models.Coding{System: strPtr(system), Code: strPtr(code)}
+}
+
+func TestPolicyConsentEvaluator_Withheld(t *testing.T) {
+	practitioner := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.rs"})
+	researcher := domain.Identity{UserID: "research-app", PurposeOfUse: domain.PurposeResearch}
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs"})
+
+	labObs := domain.ResourceRef{
+		Type:      "Observation",
+		ID:        testObsID,
+		PatientID: testPatientID,
+		SearchParams: map[string][]string{
+			"category": {"laboratory", "http://terminology.hl7.org/CodeSystem/observation-category|laboratory"},
+		},
+	}
+	psyDoc := domain.ResourceRef{
+		Type:      "DocumentReference",
+		ID:        testDocID,
+		PatientID: testPatientID,
+		SearchParams: map[string][]string{
+			"_security": {"PSY", "http://terminology.hl7.org/CodeSystem/v3-ActCode|PSY"},
+		},
+	}
+
+	neverSharePSY := createTestConsent(models.ConsentProvisionTypeDeny, models.ConsentProvision{
+		Action:        []models.CodeableConcept{{Coding: []models.Coding{coding(domain.ConsentActionSystem, domain.ConsentActionDisclose)}}},
+		SecurityLabel: []models.Coding{coding("http://terminology.hl7.org/CodeSystem/v3-ActCode", "PSY")},
+	})
+	researchOnLabs := createTestConsent(models.ConsentProvisionTypePermit, models.ConsentProvision{
+		Purpose: []models.Coding{coding(domain.PurposeOfUseSystem, domain.PurposeResearch)},
+		Code: []models.CodeableConcept{{Coding: []models.Coding{
+			coding("http://terminology.hl7.org/CodeSystem/observation-category", "laboratory"),
+		}}},
+	})
+	treatPSY := createTestConsent(models.ConsentProvisionTypePermit, models.ConsentProvision{
+		Purpose:       []models.Coding{coding(domain.PurposeOfUseSystem, domain.PurposeTreatment)},
+		SecurityLabel: []models.Coding{coding(domain.ActCodeSystem, domain.SensitivityPSY)},
+	})
+	denyAllButLabs := createTestConsent(models.ConsentProvisionTypeDeny, models.ConsentProvision{
+		ResourceType: []models.Coding{{Code: strPtr("Observation")}},
+		Provision: []models.ConsentProvision{{
+			Code: []models.CodeableConcept{{Coding: []models.Coding{{Code: strPtr("laboratory")}}}},
+		}},
+	})
+	denyPractitioner := createTestConsent(models.ConsentProvisionTypeDeny, models.ConsentProvision{
+		Actor: []models.ConsentProvisionActor{{Reference: &models.Reference{Reference: strPtr("Practitioner/" + testPractitionerID)}}},
+	})
+	expired := createTestConsent(models.ConsentProvisionTypeDeny)
+	expired.Period = &models.Period{End: strPtr("2020-01-01")}
+
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		action        domain.Action
+		resources     []domain.ResourceRef
+		consents      []models.Consent
+		repoErr       error
+		expectLoad    bool
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:       "no consents permit treatment",
+			user:       practitioner,
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{labObs},
+			expectLoad: true,
+		},
+		{
+			name:       "restricted label needs explicit release",
+			user:       practitioner,
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{labObs, psyDoc},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "scope naming the label releases it",
+			user:       createTestPractitionerIdentity(testPractitionerID, []string{"user/Observation.rs", "user/DocumentReference.rs?_security=PSY"}),
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{labObs, psyDoc},
+			expectLoad: true,
+		},
+		{
+			name:       "scope naming another label does not release it",
+			user:       createTestPractitionerIdentity(testPractitionerID, []string{"user/DocumentReference.rs?_security=HIV"}),
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{psyDoc},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "consent naming the label releases it",
+			user:       practitioner,
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{psyDoc},
+			consents:   []models.Consent{treatPSY},
+			expectLoad: true,
+		},
+		{
+			name:       "blanket permit does not release labels",
+			user:       practitioner,
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{psyDoc},
+			consents:   []models.Consent{createTestConsent(models.ConsentProvisionTypePermit)},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "scopes do not release labels to share recipients",
+			user:       createTestIdentity("", "", []string{"docs:document_reference:" + testDocID + ":read", "patient/DocumentReference.rs?_security=PSY"}),
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{psyDoc},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "deny on security label blocks sharing",
+			user:       patient,
+			action:     domain.ActionShare,
+			resources:  []domain.ResourceRef{labObs, psyDoc},
+			consents:   []models.Consent{neverSharePSY},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "disclosure deny does not block treatment access",
+			user:       createTestPractitionerIdentity(testPractitionerID, []string{"user/DocumentReference.rs?_security=PSY"}),
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{psyDoc},
+			consents:   []models.Consent{neverSharePSY},
+			expectLoad: true,
+		},
+		{
+			name:       "share recipients are held to disclosure denials",
+			user:       createTestIdentity("", "", []string{"docs:document_reference:" + testDocID + ":read"}),
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{psyDoc},
+			consents:   []models.Consent{neverSharePSY},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "research is denied without consent",
+			user:       researcher,
+			action:     domain.ActionSearch,
+			resources:  []domain.ResourceRef{labObs},
+			expectLoad: true,
+			expected:   []string{"Observation/" + testObsID},
+		},
+		{
+			name:       "research consent covers lab observations only",
+			user:       researcher,
+			action:     domain.ActionSearch,
+			resources:  []domain.ResourceRef{labObs, psyDoc},
+			consents:   []models.Consent{researchOnLabs},
+			expectLoad: true,
+			expected:   []string{"DocumentReference/" + testDocID},
+		},
+		{
+			name:       "nested provision is an exception",
+			user:       practitioner,
+			action:     domain.ActionSearch,
+			resources:  []domain.ResourceRef{labObs, {Type: "Observation", ID: "obs-vitals", PatientID: testPatientID}},
+			consents:   []models.Consent{denyAllButLabs},
+			expectLoad: true,
+			expected:   []string{"Observation/obs-vitals"},
+		},
+		{
+			name:       "deny wins over permit",
+			user:       researcher,
+			action:     domain.ActionSearch,
+			resources:  []domain.ResourceRef{labObs},
+			consents:   []models.Consent{researchOnLabs, createTestConsent(models.ConsentProvisionTypeDeny)},
+			expectLoad: true,
+			expected:   []string{"Observation/" + testObsID},
+		},
+		{
+			name:       "actor specific deny",
+			user:       practitioner,
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{labObs},
+			consents:   []models.Consent{denyPractitioner},
+			expectLoad: true,
+			expected:   []string{"Observation/" + testObsID},
+		},
+		{
+			name:       "expired consent is ignored",
+			user:       practitioner,
+			action:     domain.ActionRead,
+			resources:  []domain.ResourceRef{labObs},
+			consents:   []models.Consent{expired},
+			expectLoad: true,
+		},
+		{
+			name:      "patient reads own data regardless of consents",
+			user:      patient,
+			action:    domain.ActionRead,
+			resources: []domain.ResourceRef{psyDoc},
+		},
+		{
+			name:          "repository error",
+			user:          practitioner,
+			action:        domain.ActionRead,
+			resources:     []domain.ResourceRef{labObs},
+			repoErr:       errors.New("database error"),
+			expectLoad:    true,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConsentRepository(ctrl)
+			if tt.expectLoad {
+				repo.EXPECT().ListActive(gomock.Any(), testPatientID).Return(tt.consents, tt.repoErr)
+			}
+
+			evaluator := NewPolicyConsentEvaluator(repo)
+			denials, err := evaluator.Withheld(context.Background(), tt.user, tt.action, tt.resources)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var refs []string
+			for _, d := range denials {
+				refs = append(refs, d.Reference)
+				assert.NotEmpty(t, d.Reason)
+			}
+			assert.Equal(t, tt.expected, refs)
+		})
+	}
+}
+
+func TestShareService_Share_Consent(t *testing.T) {
+	psyDoc := createTestDocumentWithoutFiles(testDocID, testPatientID)
+	psyDoc.Meta = &models.Meta{Security: []models.Coding{coding("http://terminology.hl7.org/CodeSystem/v3-ActCode", "PSY")}}
+	neverSharePSY := createTestConsent(models.ConsentProvisionTypeDeny, models.ConsentProvision{
+		SecurityLabel: []models.Coding{{Code: strPtr("PSY")}},
+	})
+
+	tests := []struct {
+		name          string
+		resourceIDs   []string
+		documents     []models.DocumentReference
+		expectToken   bool
+		expectedError error
+	}{
+		{
+			name:        "withheld resources are left out and reported",
+			resourceIDs: []string{"Observation/" + testObsID, "DocumentReference/" + testDocID},
+			documents:   []models.DocumentReference{*psyDoc},
+			expectToken: true,
+		},
+		{
+			name:          "error - everything withheld",
+			resourceIDs:   []string{"DocumentReference/" + testDocID},
+			documents:     []models.DocumentReference{*psyDoc},
+			expectedError: domain.ErrNoResourcesToShare,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			client := ports.NewMockTmpAccessClient(ctrl)
+			consentRepo := ports.NewMockConsentRepository(ctrl)
+
+			var observations []models.Observation
+			if tt.expectToken {
+				observations = []models.Observation{*createTestObservation(testObsID, testPatientID)}
+			}
+			obsRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return(observations, nil)
+			docRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return(tt.documents, nil)
+			consentRepo.EXPECT().ListActive(gomock.Any(), testPatientID).Return([]models.Consent{neverSharePSY}, nil).AnyTimes()
+			if tt.expectToken {
+				client.EXPECT().
+					GenerateTmpToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req domain.GenerateTmpTokenRequest) (*domain.GenerateTmpTokenResponse, error) {
+						assert.Equal(t, "docs:observation:"+testObsID+":read", req.Payload["scopes"])
+						return &domain.GenerateTmpTokenResponse{TmpToken: "tmp-token"}, nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, ports.NewMockSHLRepository(ctrl), client, ports.NewMockFileProvider(ctrl), authz, NewPolicyConsentEvaluator(consentRepo), discardOutbox(ctrl))
+
+			id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs"})
+			resp, err := service.Share(identity.WithCtx(context.Background(), id), domain.ShareRequest{ResourceIDs: tt.resourceIDs})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "tmp-token", resp.Token)
+			require.Len(t, resp.Withheld, 1)
+			assert.Equal(t, "DocumentReference/"+testDocID, resp.Withheld[0].Reference)
+		})
+	}
+}
+
+func TestObservationService_Get_Consent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	obsRepo := ports.NewMockObservationRepository(ctrl)
+	careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+	consentRepo := ports.NewMockConsentRepository(ctrl)
+
+	obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, testPatientID), nil)
+	careRepo.EXPECT().
+		Find(gomock.Any(), testPatientID, testPractitionerID).
+		Return(createTestCareRelationship(domain.CareAccessRead), nil)
+	consentRepo.EXPECT().
+		ListActive(gomock.Any(), testPatientID).
+		Return([]models.Consent{createTestConsent(models.ConsentProvisionTypeDeny)}, nil)
+
+	authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	service := NewObservationService(obsRepo, ports.NewMockDocumentRepository(ctrl), ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), authz, NewPolicyConsentEvaluator(consentRepo), discardOutbox(ctrl), validator.NewObservationValidator())
+
+	id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.rs"})
+	result, err := service.Get(identity.WithCtx(context.Background(), id), testObsID)
+
+	assert.ErrorIs(t, err, domain.ErrConsentDenied)
+	assert.Nil(t, result)
+}
+
+func TestObservationService_List_Consent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	obsRepo := ports.NewMockObservationRepository(ctrl)
+	careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+	consentRepo := ports.NewMockConsentRepository(ctrl)
+
+	obsRepo.EXPECT().
+		Search(gomock.Any(), domain.ObservationSearch{PatientID: testPatientID}, gomock.Nil(), 10, 0).
+		Return([]models.Observation{*createTestObservation(testObsID, testPatientID), *createTestObservation("obs-456", testPatientID)}, int64(2), nil)
+	careRepo.EXPECT().
+		Find(gomock.Any(), testPatientID, testPractitionerID).
+		Return(createTestCareRelationship(domain.CareAccessRead), nil)
+	consentRepo.EXPECT().
+		ListActive(gomock.Any(), testPatientID).
+		Return([]models.Consent{createTestConsent(models.ConsentProvisionTypeDeny)}, nil).
+		AnyTimes()
+
+	authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	service := NewObservationService(obsRepo, ports.NewMockDocumentRepository(ctrl), ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), authz, NewPolicyConsentEvaluator(consentRepo), discardOutbox(ctrl), validator.NewObservationValidator())
+
+	id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.rs"})
+	result, err := service.List(identity.WithCtx(context.Background(), id), domain.ObservationSearch{PatientID: testPatientID}, 10, 0)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Items)
+	assert.Len(t, result.Withheld, 2)
+	// The total still counts the withheld matches, so the page is short.
+	assert.Equal(t, int64(2), result.Total)
+}
+
+func TestConsentService_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		consent       models.Consent
+		user          domain.Identity
+		expectUpdate  bool
+		expectedError error
+	}{
+		{
+			name:         "success path",
+			consent:      createTestConsent(models.ConsentProvisionTypePermit),
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			expectUpdate: true,
+		},
+		{
+			name: "error - subject cannot change",
+			consent: func() models.Consent {
+				c := createTestConsent(models.ConsentProvisionTypePermit)
+				c.Subject = &models.Reference{Reference: strPtr("Patient/other-patient")}
+				return c
+			}(),
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - practitioners cannot manage consents",
+			consent:       createTestConsent(models.ConsentProvisionTypePermit),
+			user:          createTestPractitionerIdentity(testPractitionerID, []string{"user/*.cruds"}),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConsentRepository(ctrl)
+			existing := createTestConsent(models.ConsentProvisionTypeDeny)
+			repo.EXPECT().GetByID(gomock.Any(), testConsentID).Return(&existing, nil).AnyTimes()
+			if tt.expectUpdate {
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c *models.Consent) (*models.Consent, error) {
+						return c, nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewConsentService(repo, authz, validator.NewConsentValidator())
+
+			consent := tt.consent
+			result, err := service.Update(identity.WithCtx(context.Background(), tt.user), &consent)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, string(models.ConsentProvisionTypePermit), *result.Decision)
+		})
+	}
+}
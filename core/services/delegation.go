@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+)
+
+type DelegationService struct {
+	repo              ports.DelegationRepository
+	relatedPersonRepo ports.RelatedPersonRepository
+	authz             ports.Authorizer
+}
+
+func NewDelegationService(
+	repo ports.DelegationRepository,
+	relatedPersonRepo ports.RelatedPersonRepository,
+	authz ports.Authorizer,
+) *DelegationService {
+	return &DelegationService{
+		repo:              repo,
+		relatedPersonRepo: relatedPersonRepo,
+		authz:             authz,
+	}
+}
+
+// Grant lets a patient give another user read or write access to their
+// compartment. The user is described by a RelatedPerson of the patient.
+// Granting again replaces the previous access levels.
+func (s *DelegationService) Grant(ctx context.Context, req domain.GrantDelegationRequest) (*domain.Delegation, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Delegation"); !decision.Allowed {
+		return nil, decision.Err
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Delegation", PatientID: req.PatientID}); err != nil {
+		return nil, err
+	}
+
+	if req.UserID == "" {
+		return nil, domain.ErrUserIDRequired
+	}
+	if req.UserID == user.UserID {
+		return nil, fmt.Errorf("%w: cannot delegate access to yourself", domain.ErrInvalidInput)
+	}
+
+	if len(req.Access) == 0 {
+		return nil, fmt.Errorf("%w: at least one access level is required", domain.ErrInvalidInput)
+	}
+	for _, a := range req.Access {
+		if a != domain.CareAccessRead && a != domain.CareAccessWrite {
+			return nil, fmt.Errorf("%w: unknown access level %q", domain.ErrInvalidInput, a)
+		}
+	}
+
+	if req.RelatedPersonID == "" {
+		return nil, fmt.Errorf("%w: related person ID is required", domain.ErrInvalidInput)
+	}
+	person, err := s.relatedPersonRepo.GetByID(ctx, req.RelatedPersonID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if person == nil || patientIDFromReference(person.Patient) != req.PatientID {
+		return nil, domain.ErrRelatedPersonNotFound
+	}
+
+	d := &domain.Delegation{
+		ID:              uuid.New().String(),
+		PatientID:       req.PatientID,
+		UserID:          req.UserID,
+		RelatedPersonID: req.RelatedPersonID,
+		Access:          req.Access,
+		CreatedAt:       time.Now().Unix(),
+	}
+
+	saved, err := s.repo.Upsert(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return saved, nil
+}
+
+func (s *DelegationService) List(ctx context.Context, patientID string) ([]domain.Delegation, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Delegation"); !decision.Allowed {
+		return nil, decision.Err
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionSearch, domain.ResourceRef{Type: "Delegation", PatientID: patientID}); err != nil {
+		return nil, err
+	}
+
+	delegations, err := s.repo.ListByPatient(ctx, patientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return delegations, nil
+}
+
+// ListMine returns the compartments the calling user may act on as a
+// caregiver, so clients can offer an active patient selection.
+func (s *DelegationService) ListMine(ctx context.Context) ([]domain.Delegation, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if user.IsTmpToken() {
+		return nil, domain.ErrTmpTokenForbidden
+	}
+
+	delegations, err := s.repo.ListByUser(ctx, user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return delegations, nil
+}
+
+func (s *DelegationService) Revoke(ctx context.Context, patientID, userID string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Delegation"); !decision.Allowed {
+		return decision.Err
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, domain.ResourceRef{Type: "Delegation", PatientID: patientID}); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.Find(ctx, patientID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrDelegationNotFound
+	}
+
+	if err := s.repo.Delete(ctx, patientID, userID); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testCaregiverID     = "caregiver-123"
+	testRelatedPersonID = "related-person-123"
+)
+
+func createTestDelegation(access ...domain.CareAccess) *domain.Delegation {
+	return &domain.Delegation{
+		ID:              "delegation-123",
+		PatientID:       testPatientID,
+		UserID:          testCaregiverID,
+		RelatedPersonID: testRelatedPersonID,
+		Access:          access,
+	}
+}
+
+func createTestCaregiverIdentity(activePatientID string, scopes []string) domain.Identity {
+	return domain.Identity{
+		UserID:          testCaregiverID,
+		ActivePatientID: activePatientID,
+		Scopes:          scopes,
+	}
+}
+
+func createTestRelatedPerson(id, patientID string) *models.RelatedPerson {
+	return &models.RelatedPerson{
+		ResourceType: "RelatedPerson",
+		Id:           strPtr(id),
+		Patient:      &models.Reference{Reference: strPtr("Patient/" + patientID)},
+		Name:         []models.HumanName{{Family: strPtr("Doe")}},
+	}
+}
+
+func TestDelegationService_Grant(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            domain.GrantDelegationRequest
+		setupMocks     func(*ports.MockDelegationRepository, *ports.MockRelatedPersonRepository)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *domain.Delegation, error)
+	}{
+		{
+			name: "success path - patient delegates write",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				UserID:          testCaregiverID,
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{domain.CareAccessWrite},
+			},
+			setupMocks: func(repo *ports.MockDelegationRepository, personRepo *ports.MockRelatedPersonRepository) {
+				personRepo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(createTestRelatedPerson(testRelatedPersonID, testPatientID), nil)
+				repo.EXPECT().
+					Upsert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, d *domain.Delegation) (*domain.Delegation, error) {
+						return d, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			validateResult: func(t *testing.T, d *domain.Delegation, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, d)
+				assert.NotEmpty(t, d.ID)
+				assert.Equal(t, testPatientID, d.PatientID)
+				assert.Equal(t, testCaregiverID, d.UserID)
+				assert.Equal(t, testRelatedPersonID, d.RelatedPersonID)
+				assert.Equal(t, []domain.CareAccess{domain.CareAccessWrite}, d.Access)
+			},
+		},
+		{
+			name: "error - related person of another patient",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				UserID:          testCaregiverID,
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(repo *ports.MockDelegationRepository, personRepo *ports.MockRelatedPersonRepository) {
+				personRepo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(createTestRelatedPerson(testRelatedPersonID, "other-patient"), nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrRelatedPersonNotFound,
+		},
+		{
+			name: "error - delegate cannot delegate further",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				UserID:          "someone-else",
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockDelegationRepository, *ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestCaregiverIdentity(testPatientID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - missing user ID",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockDelegationRepository, *ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrUserIDRequired,
+		},
+		{
+			name: "error - delegate to self",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				UserID:          testUserID,
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(*ports.MockDelegationRepository, *ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - unknown access level",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				UserID:          testCaregiverID,
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{"admin"},
+			},
+			setupMocks: func(*ports.MockDelegationRepository, *ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - repository error",
+			req: domain.GrantDelegationRequest{
+				PatientID:       testPatientID,
+				UserID:          testCaregiverID,
+				RelatedPersonID: testRelatedPersonID,
+				Access:          []domain.CareAccess{domain.CareAccessRead},
+			},
+			setupMocks: func(repo *ports.MockDelegationRepository, personRepo *ports.MockRelatedPersonRepository) {
+				personRepo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(createTestRelatedPerson(testRelatedPersonID, testPatientID), nil)
+				repo.EXPECT().
+					Upsert(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockDelegationRepository(ctrl)
+			personRepo := ports.NewMockRelatedPersonRepository(ctrl)
+
+			tt.setupMocks(repo, personRepo)
+
+			service := NewDelegationService(repo, personRepo, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)))
+
+			result, err := service.Grant(tt.setupContext(), tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
+
+func TestDelegationService_ListMine(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*ports.MockDelegationRepository)
+		setupContext  func() context.Context
+		expectedError error
+		expectedLen   int
+	}{
+		{
+			name: "success path",
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					ListByUser(gomock.Any(), testCaregiverID).
+					Return([]domain.Delegation{*createTestDelegation(domain.CareAccessRead)}, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestCaregiverIdentity("", []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedLen: 1,
+		},
+		{
+			name:       "error - tmp token",
+			setupMocks: func(*ports.MockDelegationRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity("", "", nil)
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrTmpTokenForbidden,
+		},
+		{
+			name:       "error - no identity",
+			setupMocks: func(*ports.MockDelegationRepository) {},
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockDelegationRepository(ctrl)
+			tt.setupMocks(repo)
+
+			service := NewDelegationService(repo, ports.NewMockRelatedPersonRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), repo, ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)))
+
+			result, err := service.ListMine(tt.setupContext())
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result, tt.expectedLen)
+		})
+	}
+}
+
+func TestDelegationService_Revoke(t *testing.T) {
+	tests := []struct {
+		name          string
+		patientID     string
+		userID        string
+		setupMocks    func(*ports.MockDelegationRepository)
+		setupContext  func() context.Context
+		expectedError error
+	}{
+		{
+			name:      "success path",
+			patientID: testPatientID,
+			userID:    testCaregiverID,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessRead), nil)
+				repo.EXPECT().
+					Delete(gomock.Any(), testPatientID, testCaregiverID).
+					Return(nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+		},
+		{
+			name:      "error - delegation not found",
+			patientID: testPatientID,
+			userID:    testCaregiverID,
+			setupMocks: func(repo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(nil, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrDelegationNotFound,
+		},
+		{
+			name:       "error - other patient",
+			patientID:  "other-patient",
+			userID:     testCaregiverID,
+			setupMocks: func(*ports.MockDelegationRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockDelegationRepository(ctrl)
+			tt.setupMocks(repo)
+
+			service := NewDelegationService(repo, ports.NewMockRelatedPersonRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)))
+
+			err := service.Revoke(tt.setupContext(), tt.patientID, tt.userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type DiagnosticReportService struct {
+	repo      ports.DiagnosticReportRepository
+	obsRepo   ports.ObservationRepository
+	docRepo   ports.DocumentRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.DiagnosticReportValidator
+}
+
+func NewDiagnosticReportService(
+	repo ports.DiagnosticReportRepository,
+	obsRepo ports.ObservationRepository,
+	docRepo ports.DocumentRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.DiagnosticReportValidator,
+) *DiagnosticReportService {
+	return &DiagnosticReportService{
+		repo:      repo,
+		obsRepo:   obsRepo,
+		docRepo:   docRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *DiagnosticReportService) Create(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	if err := s.validator.Validate(report); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "DiagnosticReport"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, report.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "DiagnosticReport", PatientID: patientID, SearchParams: diagnosticReportSearchParams(report)}); err != nil {
+		return nil, err
+	}
+
+	if report.Id != nil && *report.Id != "" {
+		return nil, fmt.Errorf("%w: diagnostic report ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	report.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	report.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := s.validateLinks(ctx, report, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.DiagnosticReport
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, report); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, diagnosticReportRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *DiagnosticReportService) Get(ctx context.Context, id string) (*models.DiagnosticReport, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrDiagnosticReportIDRequired
+	}
+
+	report, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if report == nil {
+		return nil, domain.ErrDiagnosticReportNotFound
+	}
+
+	ref := diagnosticReportRef(report)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *DiagnosticReportService) Update(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "DiagnosticReport"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if report.Id == nil {
+		return nil, domain.ErrDiagnosticReportIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *report.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrDiagnosticReportNotFound
+	}
+
+	ref := diagnosticReportRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(report); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		report.Meta = keepSecurityLabels(report.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the diagnostic report to another compartment.
+	report.Subject = existing.Subject
+
+	// A restricted scope must also cover the diagnostic report as it will
+	// be stored.
+	ref.SearchParams = diagnosticReportSearchParams(report)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateLinks(ctx, report, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.DiagnosticReport
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, report); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, diagnosticReportRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a diagnostic
+// report and returns its resulting meta.
+func (s *DiagnosticReportService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "DiagnosticReport"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	report, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if report == nil {
+		return nil, domain.ErrDiagnosticReportNotFound
+	}
+
+	ref := diagnosticReportRef(report)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	report.Meta = changeSecurityLabels(report.Meta, add, remove)
+	var updated *models.DiagnosticReport
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, report); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, diagnosticReportRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *DiagnosticReportService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "DiagnosticReport"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrDiagnosticReportNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, diagnosticReportRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, diagnosticReportRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *DiagnosticReportService) List(ctx context.Context, search domain.DiagnosticReportSearch, limit, offset int) (*domain.ListResponse[models.DiagnosticReport], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "DiagnosticReport"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "DiagnosticReport", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = diagnosticReportRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.DiagnosticReport]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// validateLinks checks that the results of a report are observations and its
+// source documents are documents of the same patient. The model carries no
+// extensions, so source documents are linked as supporting information.
+func (s *DiagnosticReportService) validateLinks(ctx context.Context, report *models.DiagnosticReport, patientID string) error {
+	for _, result := range report.Result {
+		if result.Reference == nil {
+			continue
+		}
+
+		obsID, ok := strings.CutPrefix(*result.Reference, "Observation/")
+		if !ok || obsID == "" {
+			return domain.ErrInvalidResultRef
+		}
+
+		obs, err := s.obsRepo.GetByID(ctx, obsID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if obs == nil {
+			return domain.ErrResultNotFound
+		}
+
+		if patientIDFromReference(obs.Subject) != patientID {
+			return domain.ErrAccessDenied
+		}
+	}
+
+	return validateDerivedFrom(ctx, s.docRepo, diagnosticReportDocuments(report), patientID)
+}
+
+// diagnosticReportDocuments returns the documents linked to a report as
+// supporting information.
+func diagnosticReportDocuments(report *models.DiagnosticReport) []models.Reference {
+	var refs []models.Reference
+	for _, info := range report.SupportingInfo {
+		if info.Reference != nil {
+			refs = append(refs, *info.Reference)
+		}
+	}
+	return documentLinks(refs)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testReportID = "report-123"
+)
+
+func createTestDiagnosticReport(id, patientID string, results []string, sourceDocs ...string) *models.DiagnosticReport {
+	patientRef := "Patient/" + patientID
+	report := &models.DiagnosticReport{
+		ResourceType: "DiagnosticReport",
+		Status:       "final",
+		Code: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://loinc.org", "58410-2"),
+		}},
+		Subject:           &models.Reference{Reference: &patientRef},
+		EffectiveDateTime: strPtr("2024-03-01"),
+	}
+	if id != "" {
+		report.Id = strPtr(id)
+	}
+	for _, ref := range results {
+		report.Result = append(report.Result, models.Reference{Reference: strPtr(ref)})
+	}
+	for _, ref := range sourceDocs {
+		report.SupportingInfo = append(report.SupportingInfo, models.DiagnosticReportSupportingInfo{
+			Type:      &models.CodeableConcept{Text: strPtr("source document")},
+			Reference: &models.Reference{Reference: strPtr(ref)},
+		})
+	}
+	return report
+}
+
+func newTestDiagnosticReportService(ctrl *gomock.Controller, repo ports.DiagnosticReportRepository, obsRepo ports.ObservationRepository, docRepo ports.DocumentRepository) *DiagnosticReportService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewDiagnosticReportService(repo, obsRepo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewDiagnosticReportValidator())
+}
+
+func TestDiagnosticReportService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/DiagnosticReport.c"})
+
+	tests := []struct {
+		name          string
+		report        *models.DiagnosticReport
+		setupMocks    func(*ports.MockDiagnosticReportRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name:   "success path - results and source document",
+			report: createTestDiagnosticReport("", testPatientID, []string{"Observation/" + testObsID}, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockDiagnosticReportRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, testPatientID), nil)
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, report *models.DiagnosticReport) (*models.DiagnosticReport, error) {
+						return report, nil
+					})
+			},
+		},
+		{
+			name:   "error - result of another patient",
+			report: createTestDiagnosticReport("", testPatientID, []string{"Observation/" + testObsID}),
+			setupMocks: func(repo *ports.MockDiagnosticReportRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:   "error - result not found",
+			report: createTestDiagnosticReport("", testPatientID, []string{"Observation/" + testObsID}),
+			setupMocks: func(repo *ports.MockDiagnosticReportRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(nil, nil)
+			},
+			expectedError: domain.ErrResultNotFound,
+		},
+		{
+			name:          "error - result is not an observation",
+			report:        createTestDiagnosticReport("", testPatientID, []string{"DocumentReference/" + testDocID}),
+			setupMocks:    func(*ports.MockDiagnosticReportRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidResultRef,
+		},
+		{
+			name:   "error - source document of another patient",
+			report: createTestDiagnosticReport("", testPatientID, nil, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockDiagnosticReportRepository, obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - missing code",
+			report: func() *models.DiagnosticReport {
+				report := createTestDiagnosticReport("", testPatientID, nil)
+				report.Code = nil
+				return report
+			}(),
+			setupMocks:    func(*ports.MockDiagnosticReportRepository, *ports.MockObservationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockDiagnosticReportRepository(ctrl)
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, obsRepo, docRepo)
+
+			service := newTestDiagnosticReportService(ctrl, repo, obsRepo, docRepo)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.report)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
+
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// directoryOwner returns the patient a directory entry is private to, or ""
+// for shared entries.
+func directoryOwner(meta *models.Meta) string {
+	if meta == nil {
+		return ""
+	}
+	for _, tag := range meta.Tag {
+		if tag.System != nil && *tag.System == domain.DirectoryOwnerSystem && tag.Code != nil {
+			return *tag.Code
+		}
+	}
+	return ""
+}
+
+// setDirectoryOwner returns meta with the owner tag replaced by one for
+// patientID, or removed when patientID is empty.
+func setDirectoryOwner(meta *models.Meta, patientID string) *models.Meta {
+	changed := models.Meta{}
+	if meta != nil {
+		changed = *meta
+	}
+
+	tags := make([]models.Coding, 0, len(changed.Tag)+1)
+	for _, tag := range changed.Tag {
+		if tag.System == nil || *tag.System != domain.DirectoryOwnerSystem {
+			tags = append(tags, tag)
+		}
+	}
+	if patientID != "" {
+		system, code := domain.DirectoryOwnerSystem, patientID
+		tags = append(tags, models.Coding{System: &system, Code: &code})
+	}
+	changed.Tag = tags
+
+	if meta == nil && len(tags) == 0 {
+		return nil
+	}
+	return &changed
+}
+
+// validateOrganizations checks that the Organization references among refs
+// point to shared directory entries or to private entries of the patient.
+// Other references are left alone.
+func validateOrganizations(ctx context.Context, repo ports.OrganizationRepository, refs []models.Reference, patientID string) error {
+	for _, id := range referencedIDs(refs, "Organization") {
+		org, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if org == nil {
+			return fmt.Errorf("%w: Organization/%s", domain.ErrDirectoryRefNotFound, id)
+		}
+		if err := mayReference(directoryOwner(org.Meta), patientID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLocations checks that the Location references among refs point to
+// shared directory entries or to private entries of the patient. Other
+// references are left alone.
+func validateLocations(ctx context.Context, repo ports.LocationRepository, refs []models.Reference, patientID string) error {
+	for _, id := range referencedIDs(refs, "Location") {
+		loc, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if loc == nil {
+			return fmt.Errorf("%w: Location/%s", domain.ErrDirectoryRefNotFound, id)
+		}
+		if err := mayReference(directoryOwner(loc.Meta), patientID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mayReference checks that a resource of the patient may point at a
+// directory entry with the given owner.
+func mayReference(ownerID, patientID string) error {
+	if ownerID != "" && ownerID != patientID {
+		return fmt.Errorf("%w: directory entry is private to another patient", domain.ErrAccessDenied)
+	}
+	return nil
+}
+
+// directoryRefs returns refs with the optional reference single appended.
+func directoryRefs(single *models.Reference, refs ...models.Reference) []models.Reference {
+	if single == nil {
+		return refs
+	}
+	return append([]models.Reference{*single}, refs...)
+}
@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 	"github.com/gruzdev-dev/codex-documents/core/ports"
@@ -16,18 +15,33 @@ import (
 
 type DocumentService struct {
 	repo         ports.DocumentRepository
+	encRepo      ports.EncounterRepository
+	orgRepo      ports.OrganizationRepository
 	fileProvider ports.FileProvider
+	authz        ports.Authorizer
+	consent      ports.ConsentEvaluator
+	outbox       *EventOutbox
 	validator    *validator.DocumentValidator
 }
 
 func NewDocumentService(
 	repo ports.DocumentRepository,
+	encRepo ports.EncounterRepository,
+	orgRepo ports.OrganizationRepository,
 	fileProvider ports.FileProvider,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
 	v *validator.DocumentValidator,
 ) *DocumentService {
 	return &DocumentService{
 		repo:         repo,
+		encRepo:      encRepo,
+		orgRepo:      orgRepo,
 		fileProvider: fileProvider,
+		authz:        authz,
+		consent:      consent,
+		outbox:       outbox,
 		validator:    v,
 	}
 }
@@ -41,11 +55,17 @@ func (s *DocumentService) CreateDocument(ctx context.Context, doc *models.Docume
 		return nil, domain.ErrAccessDenied
 	}
 
-	if user.IsTmpToken() {
-		return nil, domain.ErrTmpTokenForbidden
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "DocumentReference"); !decision.Allowed {
+		return nil, decision.Err
 	}
-	if !user.HasScope("patient/*.write") || user.PatientID == "" {
-		return nil, domain.ErrAccessDenied
+
+	patientID, err := targetPatientID(user, doc.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "DocumentReference", PatientID: patientID, SearchParams: documentSearchParams(doc)}); err != nil {
+		return nil, err
 	}
 
 	if doc.Id != nil && *doc.Id != "" {
@@ -54,11 +74,21 @@ func (s *DocumentService) CreateDocument(ctx context.Context, doc *models.Docume
 	id := uuid.New().String()
 	doc.Id = &id
 
-	patientRef := fmt.Sprintf("Patient/%s", user.PatientID)
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
 	doc.Subject = &models.Reference{
 		Reference: &patientRef,
 	}
 
+	// Context may also reference appointments and episodes of care, which
+	// are not stored here.
+	if err := validateEncounters(ctx, s.encRepo, encounterLinks(doc.Context), patientID); err != nil {
+		return nil, err
+	}
+
+	if err := validateOrganizations(ctx, s.orgRepo, directoryRefs(doc.Custodian, doc.Author...), patientID); err != nil {
+		return nil, err
+	}
+
 	uploadUrls := make(map[string]string)
 
 	if len(doc.Content) > 0 {
@@ -77,7 +107,7 @@ func (s *DocumentService) CreateDocument(ctx context.Context, doc *models.Docume
 			}
 
 			presignedUrls, err := s.fileProvider.GetPresignedUrls(ctx, domain.GetPresignedUrlsRequest{
-				UserId:      user.PatientID,
+				UserId:      patientID,
 				ContentType: *attachment.ContentType,
 				Size:        *attachment.Size,
 			})
@@ -92,7 +122,14 @@ func (s *DocumentService) CreateDocument(ctx context.Context, doc *models.Docume
 		}
 	}
 
-	created, err := s.repo.Create(ctx, doc)
+	var created *models.DocumentReference
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, doc); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, documentRef(created), created)}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
@@ -121,15 +158,68 @@ func (s *DocumentService) GetDocument(ctx context.Context, id string) (*models.D
 		return nil, domain.ErrDocumentNotFound
 	}
 
-	if !user.IsTmpToken() && user.HasScope("patient/*.read") && s.isOwner(user, doc) {
-		return doc, nil
+	ref := domain.ResourceRef{
+		Type:         "DocumentReference",
+		ID:           id,
+		PatientID:    patientIDFromReference(doc.Subject),
+		SearchParams: documentSearchParams(doc),
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// UpdateDocumentSecurityLabels adds and removes security labels of a document
+// reference and returns its resulting meta.
+func (s *DocumentService) UpdateDocumentSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "DocumentReference"); !decision.Allowed {
+		return nil, decision.Err
 	}
 
-	if user.HasResourceScope("docs", "document_reference", id, "read") {
-		return doc, nil
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
 	}
 
-	return nil, domain.ErrAccessDenied
+	doc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if doc == nil {
+		return nil, domain.ErrDocumentNotFound
+	}
+
+	ref := documentRef(doc)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	doc.Meta = changeSecurityLabels(doc.Meta, add, remove)
+	var updated *models.DocumentReference
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, doc); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, documentRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
 }
 
 func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
@@ -138,11 +228,8 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
 		return domain.ErrAccessDenied
 	}
 
-	if user.IsTmpToken() {
-		return domain.ErrTmpTokenForbidden
-	}
-	if !user.HasScope("patient/*.write") || user.PatientID == "" {
-		return domain.ErrAccessDenied
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "DocumentReference"); !decision.Allowed {
+		return decision.Err
 	}
 
 	existing, err := s.repo.GetByID(ctx, id)
@@ -153,8 +240,14 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
 		return domain.ErrDocumentNotFound
 	}
 
-	if !s.isOwner(user, existing) {
-		return domain.ErrAccessDenied
+	ref := domain.ResourceRef{
+		Type:         "DocumentReference",
+		ID:           id,
+		PatientID:    patientIDFromReference(existing.Subject),
+		SearchParams: documentSearchParams(existing),
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, ref); err != nil {
+		return err
 	}
 
 	if existing.Content != nil {
@@ -166,47 +259,51 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, id string) error {
 		}
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, ref, existing)}, nil
+	})
+	if err != nil {
 		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
 
 	return nil
 }
 
-func (s *DocumentService) ListDocuments(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.DocumentReference], error) {
+func (s *DocumentService) ListDocuments(ctx context.Context, search domain.DocumentSearch, limit, offset int) (*domain.ListResponse[models.DocumentReference], error) {
 	user, ok := identity.FromCtx(ctx)
 	if !ok {
 		return nil, domain.ErrAccessDenied
 	}
 
-	if user.IsTmpToken() {
-		return nil, domain.ErrTmpTokenForbidden
-	}
-	if !user.HasScope("patient/*.read") || user.PatientID == "" {
-		return nil, domain.ErrAccessDenied
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "DocumentReference"); !decision.Allowed {
+		return nil, decision.Err
 	}
 
-	if user.PatientID != patientID {
-		return nil, domain.ErrAccessDenied
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "DocumentReference", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
 	}
 
-	items, total, err := s.repo.Search(ctx, patientID, limit, offset)
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
 
-	return &domain.ListResponse[models.DocumentReference]{
-		Items: items,
-		Total: total,
-	}, nil
-}
-
-func (s *DocumentService) isOwner(user domain.Identity, doc *models.DocumentReference) bool {
-	if user.PatientID == "" {
-		return false
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = documentRef(&items[i])
 	}
-	if doc.Subject == nil || doc.Subject.Reference == nil {
-		return false
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
 	}
-	return strings.HasSuffix(*doc.Subject.Reference, user.PatientID)
+
+	return &domain.ListResponse[models.DocumentReference]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
 }
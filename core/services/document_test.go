@@ -415,7 +415,7 @@ func TestDocumentService_CreateDocument(t *testing.T) {
 
 			tt.setupMocks(repo, provider)
 
-			service := NewDocumentService(repo, provider, validator)
+			service := NewDocumentService(repo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), provider, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			result, err := service.CreateDocument(ctx, tt.doc)
@@ -580,7 +580,7 @@ func TestDocumentService_GetDocument(t *testing.T) {
 
 			tt.setupMocks(repo)
 
-			service := NewDocumentService(repo, provider, validator)
+			service := NewDocumentService(repo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), provider, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			result, err := service.GetDocument(ctx, tt.docID)
@@ -766,7 +766,7 @@ func TestDocumentService_DeleteDocument(t *testing.T) {
 
 			tt.setupMocks(repo, provider)
 
-			service := NewDocumentService(repo, provider, validator)
+			service := NewDocumentService(repo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), provider, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			err := service.DeleteDocument(ctx, tt.docID)
@@ -806,7 +806,7 @@ func TestDocumentService_ListDocuments(t *testing.T) {
 					*createTestDocument("doc-2", testPatientID),
 				}
 				repo.EXPECT().
-					Search(gomock.Any(), testPatientID, 10, 0).
+					Search(gomock.Any(), domain.DocumentSearch{PatientID: testPatientID}, gomock.Nil(), 10, 0).
 					Return(docs, int64(2), nil)
 			},
 			setupContext: func() context.Context {
@@ -895,7 +895,7 @@ func TestDocumentService_ListDocuments(t *testing.T) {
 			offset:    0,
 			setupMocks: func(repo *ports.MockDocumentRepository) {
 				repo.EXPECT().
-					Search(gomock.Any(), testPatientID, 10, 0).
+					Search(gomock.Any(), domain.DocumentSearch{PatientID: testPatientID}, gomock.Nil(), 10, 0).
 					Return(nil, int64(0), errors.New("database error"))
 			},
 			setupContext: func() context.Context {
@@ -922,10 +922,10 @@ func TestDocumentService_ListDocuments(t *testing.T) {
 
 			tt.setupMocks(repo)
 
-			service := NewDocumentService(repo, provider, validator)
+			service := NewDocumentService(repo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), provider, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
-			result, err := service.ListDocuments(ctx, tt.patientID, tt.limit, tt.offset)
+			result, err := service.ListDocuments(ctx, domain.DocumentSearch{PatientID: tt.patientID}, tt.limit, tt.offset)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -939,3 +939,62 @@ func TestDocumentService_ListDocuments(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentService_GetDocument_PractitionerAccess(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupCare     func(*ports.MockCareRelationshipRepository)
+		expectedError error
+	}{
+		{
+			name: "success path - practitioner with care relationship",
+			setupCare: func(careRepo *ports.MockCareRelationshipRepository) {
+				careRepo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessRead), nil)
+			},
+		},
+		{
+			name: "error - practitioner without care relationship",
+			setupCare: func(careRepo *ports.MockCareRelationshipRepository) {
+				careRepo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(nil, nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockDocumentRepository(ctrl)
+			careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+			breakGlassRepo := ports.NewMockBreakGlassRepository(ctrl)
+
+			repo.EXPECT().
+				GetByID(gomock.Any(), testDocID).
+				Return(createTestDocument(testDocID, testPatientID), nil)
+			tt.setupCare(careRepo)
+			breakGlassRepo.EXPECT().
+				FindActive(gomock.Any(), testPatientID, testPractitionerID, gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			service := NewDocumentService(repo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), ports.NewMockFileProvider(ctrl), NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), breakGlassRepo, ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewDocumentValidator())
+
+			id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.read"})
+			result, err := service.GetDocument(identity.WithCtx(context.Background(), id), testDocID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testDocID, *result.Id)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type EncounterService struct {
+	repo      ports.EncounterRepository
+	orgRepo   ports.OrganizationRepository
+	locRepo   ports.LocationRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.EncounterValidator
+}
+
+func NewEncounterService(
+	repo ports.EncounterRepository,
+	orgRepo ports.OrganizationRepository,
+	locRepo ports.LocationRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.EncounterValidator,
+) *EncounterService {
+	return &EncounterService{
+		repo:      repo,
+		orgRepo:   orgRepo,
+		locRepo:   locRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *EncounterService) Create(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	if err := s.validator.Validate(enc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Encounter"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, enc.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Encounter", PatientID: patientID, SearchParams: encounterSearchParams(enc)}); err != nil {
+		return nil, err
+	}
+
+	if enc.Id != nil && *enc.Id != "" {
+		return nil, fmt.Errorf("%w: encounter ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	enc.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	enc.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := s.validateDirectoryRefs(ctx, enc, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.Encounter
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, enc); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, encounterRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *EncounterService) Get(ctx context.Context, id string) (*models.Encounter, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrEncounterIDRequired
+	}
+
+	enc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if enc == nil {
+		return nil, domain.ErrEncounterNotFound
+	}
+
+	ref := encounterRef(enc)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return enc, nil
+}
+
+func (s *EncounterService) Update(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Encounter"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if enc.Id == nil {
+		return nil, domain.ErrEncounterIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *enc.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrEncounterNotFound
+	}
+
+	ref := encounterRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(enc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		enc.Meta = keepSecurityLabels(enc.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the encounter to another compartment.
+	enc.Subject = existing.Subject
+
+	// A restricted scope must also cover the encounter as it will be stored.
+	ref.SearchParams = encounterSearchParams(enc)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateDirectoryRefs(ctx, enc, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Encounter
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, enc); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, encounterRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of an encounter and
+// returns its resulting meta.
+func (s *EncounterService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Encounter"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	enc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if enc == nil {
+		return nil, domain.ErrEncounterNotFound
+	}
+
+	ref := encounterRef(enc)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	enc.Meta = changeSecurityLabels(enc.Meta, add, remove)
+	if err := s.validateDirectoryRefs(ctx, enc, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Encounter
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, enc); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, encounterRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *EncounterService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Encounter"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrEncounterNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, encounterRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, encounterRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *EncounterService) List(ctx context.Context, search domain.EncounterSearch, limit, offset int) (*domain.ListResponse[models.Encounter], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Encounter"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Encounter", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = encounterRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.Encounter]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// validateDirectoryRefs checks the service provider and locations of an
+// encounter against the organization and location directory.
+func (s *EncounterService) validateDirectoryRefs(ctx context.Context, enc *models.Encounter, patientID string) error {
+	if err := validateOrganizations(ctx, s.orgRepo, directoryRefs(enc.ServiceProvider), patientID); err != nil {
+		return err
+	}
+
+	var locations []models.Reference
+	for _, loc := range enc.Location {
+		if loc.Location != nil {
+			locations = append(locations, *loc.Location)
+		}
+	}
+	return validateLocations(ctx, s.locRepo, locations, patientID)
+}
+
+// validateEncounters checks that encounter references point to encounters of
+// the same patient.
+func validateEncounters(ctx context.Context, encRepo ports.EncounterRepository, refs []models.Reference, patientID string) error {
+	for _, ref := range refs {
+		if ref.Reference == nil {
+			continue
+		}
+
+		encID, ok := strings.CutPrefix(*ref.Reference, "Encounter/")
+		if !ok || encID == "" {
+			return domain.ErrInvalidEncounterRef
+		}
+
+		enc, err := encRepo.GetByID(ctx, encID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if enc == nil {
+			return domain.ErrEncounterRefNotFound
+		}
+
+		if patientIDFromReference(enc.Subject) != patientID {
+			return domain.ErrAccessDenied
+		}
+	}
+
+	return nil
+}
+
+// encounterLinks picks the Encounter references out of refs.
+func encounterLinks(refs []models.Reference) []models.Reference {
+	var links []models.Reference
+	for _, ref := range refs {
+		if ref.Reference != nil && strings.HasPrefix(*ref.Reference, "Encounter/") {
+			links = append(links, ref)
+		}
+	}
+	return links
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testEncounterID = "enc-123"
+)
+
+func createTestEncounter(id, patientID string) *models.Encounter {
+	patientRef := "Patient/" + patientID
+	enc := &models.Encounter{
+		ResourceType: "Encounter",
+		Status:       "completed",
+		Class: []models.CodeableConcept{{Coding: []models.Coding{
+			coding("http://terminology.hl7.org/CodeSystem/v3-ActCode", "IMP"),
+		}}},
+		Subject:      &models.Reference{Reference: &patientRef},
+		ActualPeriod: &models.Period{Start: strPtr("2024-05-02"), End: strPtr("2024-05-09")},
+	}
+	if id != "" {
+		enc.Id = strPtr(id)
+	}
+	return enc
+}
+
+func newTestEncounterService(ctrl *gomock.Controller, repo ports.EncounterRepository) *EncounterService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewEncounterService(repo, ports.NewMockOrganizationRepository(ctrl), ports.NewMockLocationRepository(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewEncounterValidator())
+}
+
+func TestEncounterService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Encounter.c"})
+
+	tests := []struct {
+		name          string
+		enc           *models.Encounter
+		setupMocks    func(*ports.MockEncounterRepository)
+		expectedError error
+	}{
+		{
+			name: "success path",
+			enc:  createTestEncounter("", testPatientID),
+			setupMocks: func(repo *ports.MockEncounterRepository) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, enc *models.Encounter) (*models.Encounter, error) {
+						return enc, nil
+					})
+			},
+		},
+		{
+			name: "error - invalid status",
+			enc: func() *models.Encounter {
+				enc := createTestEncounter("", testPatientID)
+				enc.Status = "finished"
+				return enc
+			}(),
+			setupMocks:    func(*ports.MockEncounterRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - period ends before it starts",
+			enc: func() *models.Encounter {
+				enc := createTestEncounter("", testPatientID)
+				enc.ActualPeriod.End = strPtr("2024-05-01")
+				return enc
+			}(),
+			setupMocks:    func(*ports.MockEncounterRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockEncounterRepository(ctrl)
+			tt.setupMocks(repo)
+
+			service := newTestEncounterService(ctrl, repo)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.enc)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
+
+func TestObservationService_Create_Encounter(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Observation.c"})
+
+	tests := []struct {
+		name          string
+		encounter     string
+		setupMocks    func(*ports.MockObservationRepository, *ports.MockEncounterRepository)
+		expectedError error
+	}{
+		{
+			name:      "success path",
+			encounter: "Encounter/" + testEncounterID,
+			setupMocks: func(repo *ports.MockObservationRepository, encRepo *ports.MockEncounterRepository) {
+				encRepo.EXPECT().GetByID(gomock.Any(), testEncounterID).Return(createTestEncounter(testEncounterID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			},
+		},
+		{
+			name:      "error - encounter of another patient",
+			encounter: "Encounter/" + testEncounterID,
+			setupMocks: func(repo *ports.MockObservationRepository, encRepo *ports.MockEncounterRepository) {
+				encRepo.EXPECT().GetByID(gomock.Any(), testEncounterID).Return(createTestEncounter(testEncounterID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:      "error - encounter not found",
+			encounter: "Encounter/" + testEncounterID,
+			setupMocks: func(repo *ports.MockObservationRepository, encRepo *ports.MockEncounterRepository) {
+				encRepo.EXPECT().GetByID(gomock.Any(), testEncounterID).Return(nil, nil)
+			},
+			expectedError: domain.ErrEncounterRefNotFound,
+		},
+		{
+			name:          "error - not an encounter reference",
+			encounter:     "Condition/" + testCondID,
+			setupMocks:    func(*ports.MockObservationRepository, *ports.MockEncounterRepository) {},
+			expectedError: domain.ErrInvalidEncounterRef,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockObservationRepository(ctrl)
+			encRepo := ports.NewMockEncounterRepository(ctrl)
+			tt.setupMocks(repo, encRepo)
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewObservationService(repo, ports.NewMockDocumentRepository(ctrl), encRepo, ports.NewMockOrganizationRepository(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewObservationValidator())
+
+			obs := createTestObservation("", testPatientID)
+			obs.Id = nil
+			obs.Code = &models.CodeableConcept{Text: strPtr("Hemoglobin")}
+			obs.Encounter = &models.Reference{Reference: strPtr(tt.encounter)}
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), obs)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.encounter, *result.Encounter.Reference)
+		})
+	}
+}
+
+func TestDocumentService_CreateDocument_Encounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	encRepo := ports.NewMockEncounterRepository(ctrl)
+	encRepo.EXPECT().GetByID(gomock.Any(), testEncounterID).Return(createTestEncounter(testEncounterID, "other-patient"), nil)
+
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	service := NewDocumentService(ports.NewMockDocumentRepository(ctrl), encRepo, ports.NewMockOrganizationRepository(ctrl), ports.NewMockFileProvider(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewDocumentValidator())
+
+	doc := createTestDocumentWithoutFiles("", testPatientID)
+	doc.Id = nil
+	doc.Context = []models.Reference{
+		{Reference: strPtr("Appointment/appt-1")},
+		{Reference: strPtr("Encounter/" + testEncounterID)},
+	}
+
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+	result, err := service.CreateDocument(identity.WithCtx(context.Background(), patient), doc)
+
+	assert.ErrorIs(t, err, domain.ErrAccessDenied)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+)
+
+// eventInteractions maps the domain events that subscription topics trigger
+// on to their interactions.
+var eventInteractions = map[domain.EventType]domain.Interaction{
+	domain.EventResourceCreated: domain.InteractionCreate,
+	domain.EventResourceUpdated: domain.InteractionUpdate,
+	domain.EventResourceDeleted: domain.InteractionDelete,
+}
+
+// eventRefs describe the resource in the data of a domain event for the
+// resource types whose search parameters subscriptions filter by.
+var eventRefs = map[string]func(data json.RawMessage) (domain.ResourceRef, error){
+	"Observation":           decodeEventRef(observationRef),
+	"DocumentReference":     decodeEventRef(documentRef),
+	"Condition":             decodeEventRef(conditionRef),
+	"MedicationStatement":   decodeEventRef(medicationStatementRef),
+	"MedicationRequest":     decodeEventRef(medicationRequestRef),
+	"AllergyIntolerance":    decodeEventRef(allergyIntoleranceRef),
+	"Immunization":          decodeEventRef(immunizationRef),
+	"DiagnosticReport":      decodeEventRef(diagnosticReportRef),
+	"Encounter":             decodeEventRef(encounterRef),
+	"Procedure":             decodeEventRef(procedureRef),
+	"FamilyMemberHistory":   decodeEventRef(familyMemberHistoryRef),
+	"Appointment":           decodeEventRef(appointmentRef),
+	"Goal":                  decodeEventRef(goalRef),
+	"CarePlan":              decodeEventRef(carePlanRef),
+	"Composition":           decodeEventRef(compositionRef),
+	"QuestionnaireResponse": decodeEventRef(questionnaireResponseRef),
+}
+
+func decodeEventRef[T any](ref func(*T) domain.ResourceRef) func(data json.RawMessage) (domain.ResourceRef, error) {
+	return func(data json.RawMessage) (domain.ResourceRef, error) {
+		var resource T
+		if err := json.Unmarshal(data, &resource); err != nil {
+			return domain.ResourceRef{}, err
+		}
+		return ref(&resource), nil
+	}
+}
+
+// resourceEvent describes the resource write a domain event announces for
+// subscriptions. It reports false for events that are not about a write.
+func resourceEvent(event domain.DomainEvent) (domain.ResourceEvent, bool, error) {
+	interaction, ok := eventInteractions[event.Type]
+	if !ok {
+		return domain.ResourceEvent{}, false, nil
+	}
+
+	ref := domain.ResourceRef{Type: event.ResourceType, ID: event.ResourceID, PatientID: event.PatientID}
+	var status struct {
+		Status string `json:"status"`
+	}
+	if len(event.Data) > 0 {
+		if err := json.Unmarshal(event.Data, &status); err != nil {
+			return domain.ResourceEvent{}, false, fmt.Errorf("decoding %s: %w", event.Key(), err)
+		}
+		if decode, ok := eventRefs[event.ResourceType]; ok {
+			decoded, err := decode(event.Data)
+			if err != nil {
+				return domain.ResourceEvent{}, false, fmt.Errorf("decoding %s: %w", event.Key(), err)
+			}
+			ref.SearchParams = decoded.SearchParams
+		}
+	}
+
+	return domain.ResourceEvent{
+		Interaction: interaction,
+		Ref:         ref,
+		Status:      status.Status,
+		Resource:    event.Data,
+		OccurredAt:  event.OccurredAt,
+	}, true, nil
+}
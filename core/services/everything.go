@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// compartmentType is a resource type of the patient compartment that
+// Patient/$everything collects through the service of the type.
+type compartmentType struct {
+	resourceType string
+	list         func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[domain.TypedResource], error)
+}
+
+func compartmentOf[T any](
+	resourceType string,
+	list func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[T], error),
+	ref func(*T) domain.ResourceRef,
+) compartmentType {
+	return compartmentType{
+		resourceType: resourceType,
+		list: func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[domain.TypedResource], error) {
+			res, err := list(ctx, patientID, limit, offset)
+			if err != nil {
+				return nil, err
+			}
+			typed := &domain.ListResponse[domain.TypedResource]{
+				Items:    make([]domain.TypedResource, 0, len(res.Items)),
+				Total:    res.Total,
+				Withheld: res.Withheld,
+			}
+			for i := range res.Items {
+				item := &res.Items[i]
+				typed.Items = append(typed.Items, domain.TypedResource{Type: resourceType, ID: ref(item).ID, Resource: *item})
+			}
+			return typed, nil
+		},
+	}
+}
+
+type EverythingService struct {
+	patients ports.PatientService
+	types    []compartmentType
+}
+
+func NewEverythingService(
+	patients ports.PatientService,
+	observations ports.ObservationService,
+	documents ports.DocumentService,
+	conditions ports.ConditionService,
+	statements ports.MedicationStatementService,
+	requests ports.MedicationRequestService,
+	allergies ports.AllergyIntoleranceService,
+	immunizations ports.ImmunizationService,
+	reports ports.DiagnosticReportService,
+	procedures ports.ProcedureService,
+	histories ports.FamilyMemberHistoryService,
+	appointments ports.AppointmentService,
+	encounters ports.EncounterService,
+	compositions ports.CompositionService,
+	goals ports.GoalService,
+	carePlans ports.CarePlanService,
+) *EverythingService {
+	return &EverythingService{
+		patients: patients,
+		types: []compartmentType{
+			compartmentOf("Observation", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Observation], error) {
+				return observations.List(ctx, domain.ObservationSearch{PatientID: patientID}, limit, offset)
+			}, observationRef),
+			compartmentOf("DocumentReference", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.DocumentReference], error) {
+				return documents.ListDocuments(ctx, domain.DocumentSearch{PatientID: patientID}, limit, offset)
+			}, documentRef),
+			compartmentOf("Condition", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Condition], error) {
+				return conditions.List(ctx, domain.ConditionSearch{PatientID: patientID}, limit, offset)
+			}, conditionRef),
+			compartmentOf("MedicationStatement", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.MedicationStatement], error) {
+				return statements.List(ctx, domain.MedicationSearch{PatientID: patientID}, limit, offset)
+			}, medicationStatementRef),
+			compartmentOf("MedicationRequest", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.MedicationRequest], error) {
+				return requests.List(ctx, domain.MedicationSearch{PatientID: patientID}, limit, offset)
+			}, medicationRequestRef),
+			compartmentOf("AllergyIntolerance", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.AllergyIntolerance], error) {
+				return allergies.List(ctx, domain.AllergySearch{PatientID: patientID}, limit, offset)
+			}, allergyIntoleranceRef),
+			compartmentOf("Immunization", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Immunization], error) {
+				return immunizations.List(ctx, domain.ImmunizationSearch{PatientID: patientID}, limit, offset)
+			}, immunizationRef),
+			compartmentOf("DiagnosticReport", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.DiagnosticReport], error) {
+				return reports.List(ctx, domain.DiagnosticReportSearch{PatientID: patientID}, limit, offset)
+			}, diagnosticReportRef),
+			compartmentOf("Procedure", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Procedure], error) {
+				return procedures.List(ctx, domain.ProcedureSearch{PatientID: patientID}, limit, offset)
+			}, procedureRef),
+			compartmentOf("FamilyMemberHistory", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.FamilyMemberHistory], error) {
+				return histories.List(ctx, domain.FamilyMemberHistorySearch{PatientID: patientID}, limit, offset)
+			}, familyMemberHistoryRef),
+			compartmentOf("Appointment", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Appointment], error) {
+				return appointments.List(ctx, domain.AppointmentSearch{PatientID: patientID}, limit, offset)
+			}, appointmentRef),
+			compartmentOf("Encounter", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Encounter], error) {
+				return encounters.List(ctx, domain.EncounterSearch{PatientID: patientID}, limit, offset)
+			}, encounterRef),
+			compartmentOf("Composition", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Composition], error) {
+				return compositions.List(ctx, domain.CompositionSearch{PatientID: patientID}, limit, offset)
+			}, compositionRef),
+			compartmentOf("Goal", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Goal], error) {
+				return goals.List(ctx, domain.GoalSearch{PatientID: patientID}, limit, offset)
+			}, goalRef),
+			compartmentOf("CarePlan", func(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.CarePlan], error) {
+				return carePlans.List(ctx, domain.CarePlanSearch{PatientID: patientID}, limit, offset)
+			}, carePlanRef),
+		},
+	}
+}
+
+// Everything returns the patient and the resources of their compartment the
+// user may search. limit and offset page each resource type separately.
+// Types the user has no scope to search are left out rather than failing the
+// whole request.
+func (s *EverythingService) Everything(ctx context.Context, patientID string, limit, offset int) (*domain.PatientEverything, error) {
+	patient, err := s.patients.Get(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PatientEverything{Patient: patient}
+	for _, t := range s.types {
+		res, err := t.list(ctx, patientID, limit, offset)
+		if errors.Is(err, domain.ErrAccessDenied) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Resources = append(result.Resources, res.Items...)
+		result.Total += res.Total
+		result.Withheld = append(result.Withheld, res.Withheld...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func TestEverythingService_Everything(t *testing.T) {
+	tests := []struct {
+		name          string
+		patientErr    error
+		obsErr        error
+		expectedTypes []string
+		expectedTotal int64
+		expectedError error
+	}{
+		{
+			name:          "success path",
+			expectedTypes: []string{"Observation", "Condition", "Immunization"},
+			expectedTotal: 3,
+		},
+		{
+			name:          "success path - types without search scope are left out",
+			obsErr:        domain.ErrAccessDenied,
+			expectedTypes: []string{"Condition", "Immunization"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "error - patient not accessible",
+			patientErr:    domain.ErrAccessDenied,
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - list failure",
+			obsErr:        domain.ErrInternal,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patients := ports.NewMockPatientService(ctrl)
+			observations := ports.NewMockObservationService(ctrl)
+			documents := ports.NewMockDocumentService(ctrl)
+			conditions := ports.NewMockConditionService(ctrl)
+			statements := ports.NewMockMedicationStatementService(ctrl)
+			requests := ports.NewMockMedicationRequestService(ctrl)
+			allergies := ports.NewMockAllergyIntoleranceService(ctrl)
+			immunizations := ports.NewMockImmunizationService(ctrl)
+			reports := ports.NewMockDiagnosticReportService(ctrl)
+			procedures := ports.NewMockProcedureService(ctrl)
+			histories := ports.NewMockFamilyMemberHistoryService(ctrl)
+			appointments := ports.NewMockAppointmentService(ctrl)
+			encounters := ports.NewMockEncounterService(ctrl)
+			compositions := ports.NewMockCompositionService(ctrl)
+			goals := ports.NewMockGoalService(ctrl)
+			carePlans := ports.NewMockCarePlanService(ctrl)
+
+			if tt.patientErr != nil {
+				patients.EXPECT().Get(gomock.Any(), testPatientID).Return(nil, tt.patientErr)
+			} else {
+				patients.EXPECT().Get(gomock.Any(), testPatientID).Return(&models.Patient{ResourceType: "Patient", Id: strPtr(testPatientID)}, nil)
+				obsList := observations.EXPECT().List(gomock.Any(), domain.ObservationSearch{PatientID: testPatientID}, 10, 0)
+				if tt.obsErr != nil {
+					obsList.Return(nil, tt.obsErr)
+				} else {
+					obsList.Return(&domain.ListResponse[models.Observation]{Items: []models.Observation{*createTestObservation(testObsID, testPatientID)}, Total: 1}, nil)
+				}
+			}
+			if tt.patientErr == nil && (tt.obsErr == nil || tt.obsErr == domain.ErrAccessDenied) {
+				documents.EXPECT().ListDocuments(gomock.Any(), domain.DocumentSearch{PatientID: testPatientID}, 10, 0).Return(nil, domain.ErrAccessDenied)
+				conditions.EXPECT().
+					List(gomock.Any(), domain.ConditionSearch{PatientID: testPatientID}, 10, 0).
+					Return(&domain.ListResponse[models.Condition]{Items: []models.Condition{*createTestCondition(testCondID, testPatientID)}, Total: 1}, nil)
+				statements.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.MedicationStatement]{}, nil)
+				requests.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.MedicationRequest]{}, nil)
+				allergies.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.AllergyIntolerance]{}, nil)
+				immunizations.EXPECT().
+					List(gomock.Any(), domain.ImmunizationSearch{PatientID: testPatientID}, 10, 0).
+					Return(&domain.ListResponse[models.Immunization]{Items: []models.Immunization{*createTestImmunization(testImmunizationID, testPatientID)}, Total: 1}, nil)
+				reports.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.DiagnosticReport]{}, nil)
+				procedures.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.Procedure]{}, nil)
+				histories.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.FamilyMemberHistory]{}, nil)
+				appointments.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.Appointment]{}, nil)
+				encounters.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.Encounter]{}, nil)
+				compositions.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.Composition]{}, nil)
+				goals.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(&domain.ListResponse[models.Goal]{}, nil)
+				carePlans.EXPECT().List(gomock.Any(), gomock.Any(), 10, 0).Return(nil, domain.ErrAccessDenied)
+			}
+
+			service := NewEverythingService(patients, observations, documents, conditions, statements, requests, allergies, immunizations, reports, procedures, histories, appointments, encounters, compositions, goals, carePlans)
+			result, err := service.Everything(context.Background(), testPatientID, 10, 0)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testPatientID, *result.Patient.Id)
+			var types []string
+			for _, res := range result.Resources {
+				types = append(types, res.Type)
+			}
+			assert.Equal(t, tt.expectedTypes, types)
+			assert.Equal(t, tt.expectedTotal, result.Total)
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type FamilyMemberHistoryService struct {
+	repo      ports.FamilyMemberHistoryRepository
+	docRepo   ports.DocumentRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.FamilyMemberHistoryValidator
+}
+
+func NewFamilyMemberHistoryService(
+	repo ports.FamilyMemberHistoryRepository,
+	docRepo ports.DocumentRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.FamilyMemberHistoryValidator,
+) *FamilyMemberHistoryService {
+	return &FamilyMemberHistoryService{
+		repo:      repo,
+		docRepo:   docRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *FamilyMemberHistoryService) Create(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	if err := s.validator.Validate(fmh); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "FamilyMemberHistory"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, fmh.Patient)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "FamilyMemberHistory", PatientID: patientID, SearchParams: familyMemberHistorySearchParams(fmh)}); err != nil {
+		return nil, err
+	}
+
+	if fmh.Id != nil && *fmh.Id != "" {
+		return nil, fmt.Errorf("%w: family member history ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	fmh.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	fmh.Patient = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	// Documents are linked as reasons for the history and must belong to the
+	// same patient.
+	if err := validateDerivedFrom(ctx, s.docRepo, familyMemberHistoryDocuments(fmh), patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.FamilyMemberHistory
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, fmh); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, familyMemberHistoryRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *FamilyMemberHistoryService) Get(ctx context.Context, id string) (*models.FamilyMemberHistory, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrFamilyMemberHistoryIDRequired
+	}
+
+	fmh, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if fmh == nil {
+		return nil, domain.ErrFamilyMemberHistoryNotFound
+	}
+
+	ref := familyMemberHistoryRef(fmh)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return fmh, nil
+}
+
+func (s *FamilyMemberHistoryService) Update(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "FamilyMemberHistory"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if fmh.Id == nil {
+		return nil, domain.ErrFamilyMemberHistoryIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *fmh.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrFamilyMemberHistoryNotFound
+	}
+
+	ref := familyMemberHistoryRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(fmh); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		fmh.Meta = keepSecurityLabels(fmh.Meta, existing.Meta)
+	}
+
+	// The patient cannot move the family member history to another compartment.
+	fmh.Patient = existing.Patient
+
+	// A restricted scope must also cover the family member history as it will
+	// be stored.
+	ref.SearchParams = familyMemberHistorySearchParams(fmh)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if derivedFromChanged(familyMemberHistoryDocuments(existing), familyMemberHistoryDocuments(fmh)) {
+		if err := validateDerivedFrom(ctx, s.docRepo, familyMemberHistoryDocuments(fmh), ref.PatientID); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated *models.FamilyMemberHistory
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, fmh); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, familyMemberHistoryRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a family member
+// history and returns its resulting meta.
+func (s *FamilyMemberHistoryService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "FamilyMemberHistory"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	fmh, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if fmh == nil {
+		return nil, domain.ErrFamilyMemberHistoryNotFound
+	}
+
+	ref := familyMemberHistoryRef(fmh)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	fmh.Meta = changeSecurityLabels(fmh.Meta, add, remove)
+	var updated *models.FamilyMemberHistory
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, fmh); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, familyMemberHistoryRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *FamilyMemberHistoryService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "FamilyMemberHistory"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrFamilyMemberHistoryNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, familyMemberHistoryRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, familyMemberHistoryRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *FamilyMemberHistoryService) List(ctx context.Context, search domain.FamilyMemberHistorySearch, limit, offset int) (*domain.ListResponse[models.FamilyMemberHistory], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "FamilyMemberHistory"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "FamilyMemberHistory", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = familyMemberHistoryRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.FamilyMemberHistory]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// familyMemberHistoryDocuments returns the documents linked to a family
+// member history as reasons, e.g. a relative's discharge letter.
+func familyMemberHistoryDocuments(fmh *models.FamilyMemberHistory) []models.Reference {
+	var refs []models.Reference
+	for _, reason := range fmh.Reason {
+		if reason.Reference != nil {
+			refs = append(refs, *reason.Reference)
+		}
+	}
+	return documentLinks(refs)
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testFamilyMemberHistoryID = "fmh-123"
+)
+
+func createTestFamilyMemberHistory(id, patientID string, reasons ...string) *models.FamilyMemberHistory {
+	patientRef := "Patient/" + patientID
+	fmh := &models.FamilyMemberHistory{
+		ResourceType: "FamilyMemberHistory",
+		Status:       "completed",
+		Patient:      &models.Reference{Reference: &patientRef},
+		Relationship: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://terminology.hl7.org/CodeSystem/v3-RoleCode", "FTH"),
+		}},
+		Condition: []models.FamilyMemberHistoryCondition{{
+			Code: &models.CodeableConcept{Coding: []models.Coding{coding("http://snomed.info/sct", "22298006")}},
+		}},
+	}
+	if id != "" {
+		fmh.Id = strPtr(id)
+	}
+	for _, ref := range reasons {
+		fmh.Reason = append(fmh.Reason, models.CodeableReference{Reference: &models.Reference{Reference: strPtr(ref)}})
+	}
+	return fmh
+}
+
+func newTestFamilyMemberHistoryService(ctrl *gomock.Controller, repo ports.FamilyMemberHistoryRepository, docRepo ports.DocumentRepository) *FamilyMemberHistoryService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewFamilyMemberHistoryService(repo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewFamilyMemberHistoryValidator())
+}
+
+func TestFamilyMemberHistoryService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/FamilyMemberHistory.c"})
+
+	tests := []struct {
+		name          string
+		fmh           *models.FamilyMemberHistory
+		setupMocks    func(*ports.MockFamilyMemberHistoryRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name: "success path - document linked as reason",
+			fmh:  createTestFamilyMemberHistory("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockFamilyMemberHistoryRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fmh *models.FamilyMemberHistory) (*models.FamilyMemberHistory, error) {
+						return fmh, nil
+					})
+			},
+		},
+		{
+			name: "error - document of another patient",
+			fmh:  createTestFamilyMemberHistory("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockFamilyMemberHistoryRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - missing relationship",
+			fmh: func() *models.FamilyMemberHistory {
+				fmh := createTestFamilyMemberHistory("", testPatientID)
+				fmh.Relationship = nil
+				return fmh
+			}(),
+			setupMocks:    func(*ports.MockFamilyMemberHistoryRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - both age and born date",
+			fmh: func() *models.FamilyMemberHistory {
+				fmh := createTestFamilyMemberHistory("", testPatientID)
+				fmh.AgeString = strPtr("about 60")
+				fmh.BornDate = strPtr("1950")
+				return fmh
+			}(),
+			setupMocks:    func(*ports.MockFamilyMemberHistoryRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockFamilyMemberHistoryRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, docRepo)
+
+			service := newTestFamilyMemberHistoryService(ctrl, repo, docRepo)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.fmh)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Patient.Reference)
+		})
+	}
+}
+
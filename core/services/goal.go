@@ -0,0 +1,594 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type GoalService struct {
+	repo         ports.GoalRepository
+	condRepo     ports.ConditionRepository
+	observations ports.ObservationService
+	authz        ports.Authorizer
+	consent      ports.ConsentEvaluator
+	outbox       *EventOutbox
+	validator    *validator.GoalValidator
+}
+
+func NewGoalService(
+	repo ports.GoalRepository,
+	condRepo ports.ConditionRepository,
+	observations ports.ObservationService,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.GoalValidator,
+) *GoalService {
+	return &GoalService{
+		repo:         repo,
+		condRepo:     condRepo,
+		observations: observations,
+		authz:        authz,
+		consent:      consent,
+		outbox:       outbox,
+		validator:    v,
+	}
+}
+
+func (s *GoalService) Create(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	if err := s.validator.Validate(goal); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Goal"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, goal.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Goal", PatientID: patientID, SearchParams: goalSearchParams(goal)}); err != nil {
+		return nil, err
+	}
+
+	if goal.Id != nil && *goal.Id != "" {
+		return nil, fmt.Errorf("%w: goal ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	goal.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	goal.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := validateConditionRefs(ctx, s.condRepo, goal.Addresses, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.Goal
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, goal); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, goalRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *GoalService) Get(ctx context.Context, id string) (*models.Goal, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrGoalIDRequired
+	}
+
+	goal, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if goal == nil {
+		return nil, domain.ErrGoalNotFound
+	}
+
+	ref := goalRef(goal)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (s *GoalService) Update(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Goal"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if goal.Id == nil {
+		return nil, domain.ErrGoalIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *goal.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrGoalNotFound
+	}
+
+	ref := goalRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(goal); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		goal.Meta = keepSecurityLabels(goal.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the goal to another compartment.
+	goal.Subject = existing.Subject
+
+	// A restricted scope must also cover the goal as it will be stored.
+	ref.SearchParams = goalSearchParams(goal)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := validateConditionRefs(ctx, s.condRepo, goal.Addresses, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Goal
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, goal); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, goalRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a goal and returns
+// its resulting meta.
+func (s *GoalService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Goal"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	goal, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if goal == nil {
+		return nil, domain.ErrGoalNotFound
+	}
+
+	ref := goalRef(goal)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	goal.Meta = changeSecurityLabels(goal.Meta, add, remove)
+	var updated *models.Goal
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, goal); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, goalRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *GoalService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Goal"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrGoalNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, goalRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, goalRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *GoalService) List(ctx context.Context, search domain.GoalSearch, limit, offset int) (*domain.ListResponse[models.Goal], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Goal"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Goal", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = goalRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.Goal]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// goalProgressLimit bounds the observations read for each goal target.
+const goalProgressLimit = 100
+
+// Progress evaluates the measurable targets of a goal against the
+// observations of its subject. An observation counts for a target when it
+// has a code of the target measure and was made between the goal start and
+// the target due date. Whether a target is met is judged by the most recent
+// observation; whether it is improving or worsening by comparing that
+// observation to the one before. The goal itself is left unchanged.
+func (s *GoalService) Progress(ctx context.Context, id string) (*domain.GoalProgress, error) {
+	goal, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	progress := &domain.GoalProgress{Goal: goal}
+	for _, target := range goal.Target {
+		if !isMeasurableTarget(target) {
+			continue
+		}
+
+		tp, err := s.targetProgress(ctx, goal, target, now)
+		if err != nil {
+			return nil, err
+		}
+		progress.Targets = append(progress.Targets, *tp)
+	}
+	progress.AchievementStatus = goalAchievement(progress.Targets)
+
+	return progress, nil
+}
+
+func (s *GoalService) targetProgress(ctx context.Context, goal *models.Goal, target models.GoalTarget, now time.Time) (*domain.TargetProgress, error) {
+	var codes []string
+	for _, c := range target.Measure.Coding {
+		if isSystemCode(c) {
+			codes = append(codes, *c.System+"|"+*c.Code)
+		}
+	}
+
+	search := domain.ObservationSearch{
+		PatientID: patientIDFromReference(goal.Subject),
+		Code:      strings.Join(codes, ","),
+		Newest:    true,
+	}
+	if goal.StartDate != nil {
+		start, err := domain.ParseDateFilter("ge" + *goal.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: startDate: %v", domain.ErrInvalidInput, err)
+		}
+		search.Date = append(search.Date, start)
+	}
+	if target.DueDate != nil {
+		due, err := domain.ParseDateFilter("le" + *target.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: dueDate: %v", domain.ErrInvalidInput, err)
+		}
+		search.Date = append(search.Date, due)
+	}
+
+	res, err := s.observations.List(ctx, search, goalProgressLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []models.Observation
+	for _, obs := range res.Items {
+		if obs.Status == "entered-in-error" || obs.Status == "cancelled" {
+			continue
+		}
+		matched = append(matched, obs)
+	}
+
+	overdue := false
+	if target.DueDate != nil {
+		if due, ok := parseFHIRDateTime(*target.DueDate); ok {
+			overdue = now.After(due)
+		}
+	}
+
+	tp := &domain.TargetProgress{Measure: target.Measure, Observations: len(matched)}
+	if len(matched) == 0 {
+		tp.AchievementStatus = "in-progress"
+		if overdue {
+			tp.AchievementStatus = "not-achieved"
+		}
+		return tp, nil
+	}
+	tp.Latest = &matched[0]
+
+	met, distance, numeric := evaluateTarget(target, &matched[0])
+	var prevMet, prevNumeric bool
+	var prevDistance float64
+	if len(matched) > 1 {
+		prevMet, prevDistance, prevNumeric = evaluateTarget(target, &matched[1])
+	}
+
+	switch {
+	case met && prevMet:
+		tp.AchievementStatus = "sustaining"
+	case met:
+		tp.AchievementStatus = "achieved"
+	case overdue:
+		tp.AchievementStatus = "not-achieved"
+	case numeric && prevNumeric && distance < prevDistance:
+		tp.AchievementStatus = "improving"
+	case numeric && prevNumeric && distance > prevDistance:
+		tp.AchievementStatus = "worsening"
+	case numeric && prevNumeric:
+		tp.AchievementStatus = "no-change"
+	default:
+		tp.AchievementStatus = "in-progress"
+	}
+
+	return tp, nil
+}
+
+// goalAchievement combines the achievement of the targets of a goal. A goal
+// is achieved when every target is, and not achieved when any target is not.
+func goalAchievement(targets []domain.TargetProgress) string {
+	if len(targets) == 0 {
+		return "in-progress"
+	}
+	if len(targets) == 1 {
+		return targets[0].AchievementStatus
+	}
+
+	achieved, sustaining := true, true
+	for _, target := range targets {
+		switch target.AchievementStatus {
+		case "not-achieved":
+			return "not-achieved"
+		case "sustaining":
+		case "achieved":
+			sustaining = false
+		default:
+			achieved = false
+		}
+	}
+
+	switch {
+	case achieved && sustaining:
+		return "sustaining"
+	case achieved:
+		return "achieved"
+	}
+	return "in-progress"
+}
+
+// isMeasurableTarget reports whether a target has a measure coded with a
+// system and a detail observations can be compared with. A code without its
+// system could match observations of an unrelated code system.
+func isMeasurableTarget(target models.GoalTarget) bool {
+	if target.Measure == nil || !slices.ContainsFunc(target.Measure.Coding, isSystemCode) {
+		return false
+	}
+	return target.DetailQuantity != nil || target.DetailRange != nil || target.DetailInteger != nil ||
+		target.DetailBoolean != nil || target.DetailString != nil || target.DetailCodeableConcept != nil
+}
+
+func isSystemCode(c models.Coding) bool {
+	return c.System != nil && *c.System != "" && c.Code != nil && *c.Code != ""
+}
+
+// evaluateTarget reports whether an observation meets a target. For numeric
+// targets it also returns how far the value is from the target, so that
+// successive observations can be compared; numeric is false when the value
+// cannot be compared. Quantities in different units are not comparable.
+func evaluateTarget(target models.GoalTarget, obs *models.Observation) (met bool, distance float64, numeric bool) {
+	switch {
+	case target.DetailQuantity != nil:
+		value, ok := observationNumber(obs, target.DetailQuantity.Code)
+		if !ok || target.DetailQuantity.Value == nil {
+			return false, 0, false
+		}
+		want := *target.DetailQuantity.Value
+		comparator := ""
+		if target.DetailQuantity.Comparator != nil {
+			comparator = *target.DetailQuantity.Comparator
+		}
+		switch comparator {
+		case "<":
+			met = value < want
+		case "<=":
+			met = value <= want
+		case ">":
+			met = value > want
+		case ">=":
+			met = value >= want
+		default:
+			met = value == want
+		}
+		if met {
+			return true, 0, true
+		}
+		return false, math.Abs(value - want), true
+
+	case target.DetailRange != nil:
+		var unit *string
+		if target.DetailRange.Low != nil {
+			unit = target.DetailRange.Low.Code
+		} else {
+			unit = target.DetailRange.High.Code
+		}
+		value, ok := observationNumber(obs, unit)
+		if !ok {
+			return false, 0, false
+		}
+		if low := target.DetailRange.Low; low != nil && low.Value != nil && value < *low.Value {
+			return false, *low.Value - value, true
+		}
+		if high := target.DetailRange.High; high != nil && high.Value != nil && value > *high.Value {
+			return false, value - *high.Value, true
+		}
+		return true, 0, true
+
+	case target.DetailInteger != nil:
+		value, ok := observationNumber(obs, nil)
+		if !ok {
+			return false, 0, false
+		}
+		want := float64(*target.DetailInteger)
+		return value == want, math.Abs(value - want), true
+
+	case target.DetailBoolean != nil:
+		return obs.ValueBoolean != nil && *obs.ValueBoolean == *target.DetailBoolean, 0, false
+
+	case target.DetailString != nil:
+		return obs.ValueString != nil && *obs.ValueString == *target.DetailString, 0, false
+
+	case target.DetailCodeableConcept != nil:
+		return obs.ValueCodeableConcept != nil &&
+			codingsMatchTokens(target.DetailCodeableConcept.Coding, codingValues(obs.ValueCodeableConcept.Coding)), 0, false
+	}
+	return false, 0, false
+}
+
+// observationNumber returns the numeric value of an observation. When unit
+// is given, a quantity in another coded unit has no comparable value.
+func observationNumber(obs *models.Observation, unit *string) (float64, bool) {
+	switch {
+	case obs.ValueQuantity != nil && obs.ValueQuantity.Value != nil:
+		if unit != nil && obs.ValueQuantity.Code != nil && *unit != *obs.ValueQuantity.Code {
+			return 0, false
+		}
+		return *obs.ValueQuantity.Value, true
+	case obs.ValueInteger != nil:
+		return float64(*obs.ValueInteger), true
+	}
+	return 0, false
+}
+
+// validateConditionRefs checks that references to the issues a goal or care
+// plan addresses are conditions of the same patient.
+func validateConditionRefs(ctx context.Context, condRepo ports.ConditionRepository, refs []models.Reference, patientID string) error {
+	for _, ref := range refs {
+		if ref.Reference == nil {
+			continue
+		}
+
+		condID, ok := strings.CutPrefix(*ref.Reference, "Condition/")
+		if !ok || condID == "" {
+			return domain.ErrInvalidPlanRef
+		}
+
+		cond, err := condRepo.GetByID(ctx, condID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if cond == nil {
+			return domain.ErrPlanRefNotFound
+		}
+
+		if patientIDFromReference(cond.Subject) != patientID {
+			return domain.ErrAccessDenied
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testGoalID = "goal-123"
+	testHbA1c  = "4548-4"
+)
+
+func createTestGoal(id, patientID, dueDate string) *models.Goal {
+	goal := &models.Goal{
+		ResourceType:    "Goal",
+		LifecycleStatus: "active",
+		Description:     &models.CodeableConcept{Text: strPtr("HbA1c below 7%")},
+		Subject:         &models.Reference{Reference: strPtr("Patient/" + patientID)},
+		StartDate:       strPtr("2024-01-01"),
+		Target: []models.GoalTarget{{
+			Measure: &models.CodeableConcept{Coding: []models.Coding{coding("http://loinc.org", testHbA1c)}},
+			DetailQuantity: &models.Quantity{
+				Value:      ptr.To(7.0),
+				Comparator: strPtr("<"),
+				Code:       strPtr("%"),
+			},
+			DueDate: strPtr(dueDate),
+		}},
+	}
+	if id != "" {
+		goal.Id = strPtr(id)
+	}
+	return goal
+}
+
+func createTestHbA1c(id, effective string, value float64) models.Observation {
+	obs := createTestObservation(id, testPatientID)
+	obs.Code = &models.CodeableConcept{Coding: []models.Coding{coding("http://loinc.org", testHbA1c)}}
+	obs.EffectiveDateTime = strPtr(effective)
+	obs.ValueQuantity = &models.Quantity{Value: ptr.To(value), Code: strPtr("%")}
+	return *obs
+}
+
+func newTestGoalService(ctrl *gomock.Controller, repo ports.GoalRepository, condRepo ports.ConditionRepository, observations ports.ObservationService) *GoalService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewGoalService(repo, condRepo, observations, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewGoalValidator())
+}
+
+func TestGoalService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Goal.c"})
+
+	tests := []struct {
+		name          string
+		addresses     string
+		condPatientID string
+		measure       *models.CodeableConcept
+		expectedError error
+	}{
+		{
+			name:          "success path - addresses a condition",
+			addresses:     "Condition/" + testCondID,
+			condPatientID: testPatientID,
+		},
+		{
+			name:          "error - condition of another patient",
+			addresses:     "Condition/" + testCondID,
+			condPatientID: "other-patient",
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - addresses an observation",
+			addresses:     "Observation/" + testObsID,
+			expectedError: domain.ErrInvalidPlanRef,
+		},
+		{
+			name:          "error - measure coded without a system",
+			addresses:     "Condition/" + testCondID,
+			measure:       &models.CodeableConcept{Coding: []models.Coding{{Code: strPtr(testHbA1c)}}},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockGoalRepository(ctrl)
+			condRepo := ports.NewMockConditionRepository(ctrl)
+
+			if tt.condPatientID != "" {
+				condRepo.EXPECT().GetByID(gomock.Any(), testCondID).Return(createTestCondition(testCondID, tt.condPatientID), nil)
+			}
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, goal *models.Goal) (*models.Goal, error) {
+						return goal, nil
+					})
+			}
+
+			goal := createTestGoal("", testPatientID, "2024-12-31")
+			goal.Addresses = []models.Reference{{Reference: strPtr(tt.addresses)}}
+			if tt.measure != nil {
+				goal.Target[0].Measure = tt.measure
+			}
+
+			service := newTestGoalService(ctrl, repo, condRepo, ports.NewMockObservationService(ctrl))
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), goal)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result.Id)
+		})
+	}
+}
+
+func TestGoalService_Progress(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Goal.r", "patient/Observation.rs"})
+
+	tests := []struct {
+		name           string
+		dueDate        string
+		observations   []models.Observation
+		expectedStatus string
+		expectedLatest string
+	}{
+		{
+			name:    "achieved",
+			dueDate: "2099-12-31",
+			observations: []models.Observation{
+				createTestHbA1c("obs-2", "2024-06-01", 6.8),
+				createTestHbA1c("obs-1", "2024-03-01", 7.8),
+			},
+			expectedStatus: "achieved",
+			expectedLatest: "obs-2",
+		},
+		{
+			name:    "sustaining",
+			dueDate: "2099-12-31",
+			observations: []models.Observation{
+				createTestHbA1c("obs-2", "2024-06-01", 6.5),
+				createTestHbA1c("obs-1", "2024-03-01", 6.9),
+			},
+			expectedStatus: "sustaining",
+			expectedLatest: "obs-2",
+		},
+		{
+			name:    "improving",
+			dueDate: "2099-12-31",
+			observations: []models.Observation{
+				createTestHbA1c("obs-2", "2024-06-01", 7.4),
+				createTestHbA1c("obs-1", "2024-03-01", 8.1),
+			},
+			expectedStatus: "improving",
+			expectedLatest: "obs-2",
+		},
+		{
+			name:    "worsening",
+			dueDate: "2099-12-31",
+			observations: []models.Observation{
+				createTestHbA1c("obs-2", "2024-06-01", 8.1),
+				createTestHbA1c("obs-1", "2024-03-01", 7.4),
+			},
+			expectedStatus: "worsening",
+			expectedLatest: "obs-2",
+		},
+		{
+			name:    "not achieved by the due date",
+			dueDate: "2024-12-31",
+			observations: []models.Observation{
+				createTestHbA1c("obs-1", "2024-11-01", 7.4),
+			},
+			expectedStatus: "not-achieved",
+			expectedLatest: "obs-1",
+		},
+		{
+			name:           "no observations yet",
+			dueDate:        "2099-12-31",
+			expectedStatus: "in-progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockGoalRepository(ctrl)
+			observations := ports.NewMockObservationService(ctrl)
+
+			repo.EXPECT().GetByID(gomock.Any(), testGoalID).Return(createTestGoal(testGoalID, testPatientID, tt.dueDate), nil)
+
+			start, err := domain.ParseDateFilter("ge2024-01-01")
+			require.NoError(t, err)
+			due, err := domain.ParseDateFilter("le" + tt.dueDate)
+			require.NoError(t, err)
+			observations.EXPECT().
+				List(gomock.Any(), domain.ObservationSearch{
+					PatientID: testPatientID,
+					Code:      "http://loinc.org|" + testHbA1c,
+					Date:      []domain.DateFilter{start, due},
+					Newest:    true,
+				}, goalProgressLimit, 0).
+				Return(&domain.ListResponse[models.Observation]{Items: tt.observations, Total: int64(len(tt.observations))}, nil)
+
+			service := newTestGoalService(ctrl, repo, ports.NewMockConditionRepository(ctrl), observations)
+			result, err := service.Progress(identity.WithCtx(context.Background(), patient), testGoalID)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, result.AchievementStatus)
+			require.Len(t, result.Targets, 1)
+			assert.Equal(t, len(tt.observations), result.Targets[0].Observations)
+			if tt.expectedLatest == "" {
+				assert.Nil(t, result.Targets[0].Latest)
+			} else {
+				require.NotNil(t, result.Targets[0].Latest)
+				assert.Equal(t, tt.expectedLatest, *result.Targets[0].Latest.Id)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type ImmunizationService struct {
+	repo      ports.ImmunizationRepository
+	docRepo   ports.DocumentRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.ImmunizationValidator
+}
+
+func NewImmunizationService(
+	repo ports.ImmunizationRepository,
+	docRepo ports.DocumentRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.ImmunizationValidator,
+) *ImmunizationService {
+	return &ImmunizationService{
+		repo:      repo,
+		docRepo:   docRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *ImmunizationService) Create(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	if err := s.validator.Validate(imm); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Immunization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, imm.Patient)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Immunization", PatientID: patientID, SearchParams: immunizationSearchParams(imm)}); err != nil {
+		return nil, err
+	}
+
+	if imm.Id != nil && *imm.Id != "" {
+		return nil, fmt.Errorf("%w: immunization ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	imm.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	imm.Patient = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	// Certificates are linked as supporting information and must belong to
+	// the same patient.
+	if err := validateDerivedFrom(ctx, s.docRepo, documentLinks(imm.SupportingInformation), patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.Immunization
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, imm); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, immunizationRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *ImmunizationService) Get(ctx context.Context, id string) (*models.Immunization, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrImmunizationIDRequired
+	}
+
+	imm, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if imm == nil {
+		return nil, domain.ErrImmunizationNotFound
+	}
+
+	ref := immunizationRef(imm)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return imm, nil
+}
+
+func (s *ImmunizationService) Update(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Immunization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if imm.Id == nil {
+		return nil, domain.ErrImmunizationIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *imm.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrImmunizationNotFound
+	}
+
+	ref := immunizationRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(imm); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		imm.Meta = keepSecurityLabels(imm.Meta, existing.Meta)
+	}
+
+	// The patient cannot move the immunization to another compartment.
+	imm.Patient = existing.Patient
+
+	// A restricted scope must also cover the immunization as it will be stored.
+	ref.SearchParams = immunizationSearchParams(imm)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if derivedFromChanged(documentLinks(existing.SupportingInformation), documentLinks(imm.SupportingInformation)) {
+		if err := validateDerivedFrom(ctx, s.docRepo, documentLinks(imm.SupportingInformation), ref.PatientID); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated *models.Immunization
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, imm); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, immunizationRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of an immunization and
+// returns its resulting meta.
+func (s *ImmunizationService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Immunization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	imm, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if imm == nil {
+		return nil, domain.ErrImmunizationNotFound
+	}
+
+	ref := immunizationRef(imm)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	imm.Meta = changeSecurityLabels(imm.Meta, add, remove)
+	var updated *models.Immunization
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, imm); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, immunizationRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *ImmunizationService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Immunization"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrImmunizationNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, immunizationRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, immunizationRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *ImmunizationService) List(ctx context.Context, search domain.ImmunizationSearch, limit, offset int) (*domain.ListResponse[models.Immunization], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Immunization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Immunization", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = immunizationRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.Immunization]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testImmunizationID = "imm-123"
+)
+
+func createTestImmunization(id, patientID string, supportingInformation ...string) *models.Immunization {
+	patientRef := "Patient/" + patientID
+	imm := &models.Immunization{
+		ResourceType: "Immunization",
+		Status:       "completed",
+		VaccineCode: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://hl7.org/fhir/sid/cvx", "208"),
+		}},
+		Patient:            &models.Reference{Reference: &patientRef},
+		OccurrenceDateTime: strPtr("2021-06-01"),
+	}
+	if id != "" {
+		imm.Id = strPtr(id)
+	}
+	for _, ref := range supportingInformation {
+		imm.SupportingInformation = append(imm.SupportingInformation, models.Reference{Reference: strPtr(ref)})
+	}
+	return imm
+}
+
+func newTestImmunizationService(ctrl *gomock.Controller, repo ports.ImmunizationRepository, docRepo ports.DocumentRepository) *ImmunizationService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewImmunizationService(repo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewImmunizationValidator())
+}
+
+func TestImmunizationService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Immunization.c"})
+
+	tests := []struct {
+		name          string
+		imm           *models.Immunization
+		setupMocks    func(*ports.MockImmunizationRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name: "success path - certificate linked as supporting information",
+			imm:  createTestImmunization("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockImmunizationRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, imm *models.Immunization) (*models.Immunization, error) {
+						return imm, nil
+					})
+			},
+		},
+		{
+			name: "error - certificate of another patient",
+			imm:  createTestImmunization("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockImmunizationRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - certificate not found",
+			imm:  createTestImmunization("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockImmunizationRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(nil, nil)
+			},
+			expectedError: domain.ErrDerivedFromDocNotFound,
+		},
+		{
+			name: "error - missing occurrence",
+			imm: func() *models.Immunization {
+				imm := createTestImmunization("", testPatientID)
+				imm.OccurrenceDateTime = nil
+				return imm
+			}(),
+			setupMocks:    func(*ports.MockImmunizationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - missing vaccine code",
+			imm: func() *models.Immunization {
+				imm := createTestImmunization("", testPatientID)
+				imm.VaccineCode = nil
+				return imm
+			}(),
+			setupMocks:    func(*ports.MockImmunizationRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockImmunizationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, docRepo)
+
+			service := newTestImmunizationService(ctrl, repo, docRepo)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.imm)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Patient.Reference)
+		})
+	}
+}
+
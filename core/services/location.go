@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type LocationService struct {
+	repo      ports.LocationRepository
+	authz     ports.Authorizer
+	validator *validator.LocationValidator
+}
+
+func NewLocationService(repo ports.LocationRepository, authz ports.Authorizer, v *validator.LocationValidator) *LocationService {
+	return &LocationService{
+		repo:      repo,
+		authz:     authz,
+		validator: v,
+	}
+}
+
+// Create adds a location to the directory. Administrators add shared
+// entries; patients add private entries to their own compartment.
+func (s *LocationService) Create(ctx context.Context, loc *models.Location) (*models.Location, error) {
+	if err := s.validator.Validate(loc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Location"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	ownerID := user.CompartmentID()
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Location", PatientID: ownerID}); err != nil {
+		return nil, err
+	}
+
+	if loc.Id != nil && *loc.Id != "" {
+		return nil, fmt.Errorf("%w: location ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	loc.Id = &id
+	loc.Meta = setDirectoryOwner(loc.Meta, ownerID)
+
+	created, err := s.repo.Create(ctx, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *LocationService) Get(ctx context.Context, id string) (*models.Location, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrLocationIDRequired
+	}
+
+	loc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if loc == nil {
+		return nil, domain.ErrLocationNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, locationRef(loc)); err != nil {
+		return nil, err
+	}
+
+	return loc, nil
+}
+
+func (s *LocationService) Update(ctx context.Context, loc *models.Location) (*models.Location, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Location"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if loc.Id == nil || *loc.Id == "" {
+		return nil, domain.ErrLocationIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *loc.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrLocationNotFound
+	}
+
+	ref := locationRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(loc); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	// An entry cannot move between the shared directory and a compartment.
+	loc.Meta = setDirectoryOwner(loc.Meta, ref.PatientID)
+
+	updated, err := s.repo.Update(ctx, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+func (s *LocationService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Location"); !decision.Allowed {
+		return decision.Err
+	}
+
+	if id == "" {
+		return domain.ErrLocationIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrLocationNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, locationRef(existing)); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// List searches the shared directory and the private entries of the caller's
+// compartment.
+func (s *LocationService) List(ctx context.Context, search domain.DirectorySearch, limit, offset int) (*domain.ListResponse[models.Location], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Location"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	search.PatientID = user.CompartmentID()
+
+	items, total, err := s.repo.Search(ctx, search, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.Location]{
+		Items: items,
+		Total: total,
+	}, nil
+}
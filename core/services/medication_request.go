@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type MedicationRequestService struct {
+	repo      ports.MedicationRequestRepository
+	docRepo   ports.DocumentRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
+	validator *validator.MedicationRequestValidator
+}
+
+func NewMedicationRequestService(
+	repo ports.MedicationRequestRepository,
+	docRepo ports.DocumentRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.MedicationRequestValidator,
+) *MedicationRequestService {
+	return &MedicationRequestService{
+		repo:      repo,
+		docRepo:   docRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
+		validator: v,
+	}
+}
+
+func (s *MedicationRequestService) Create(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	if err := s.validator.Validate(mr); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "MedicationRequest"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, mr.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "MedicationRequest", PatientID: patientID, SearchParams: medicationRequestSearchParams(mr)}); err != nil {
+		return nil, err
+	}
+
+	if mr.Id != nil && *mr.Id != "" {
+		return nil, fmt.Errorf("%w: medication request ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	mr.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	mr.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	// MedicationRequest has no derivedFrom; the prescription document is
+	// linked as supporting information instead.
+	if err := validateDerivedFrom(ctx, s.docRepo, documentLinks(mr.SupportingInformation), patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.MedicationRequest
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, mr); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, medicationRequestRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *MedicationRequestService) Get(ctx context.Context, id string) (*models.MedicationRequest, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrMedicationRequestIDRequired
+	}
+
+	mr, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if mr == nil {
+		return nil, domain.ErrMedicationRequestNotFound
+	}
+
+	ref := medicationRequestRef(mr)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return mr, nil
+}
+
+func (s *MedicationRequestService) Update(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "MedicationRequest"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if mr.Id == nil {
+		return nil, domain.ErrMedicationRequestIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *mr.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrMedicationRequestNotFound
+	}
+
+	ref := medicationRequestRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(mr); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		mr.Meta = keepSecurityLabels(mr.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the medication request to another compartment.
+	mr.Subject = existing.Subject
+
+	// A restricted scope must also cover the medication request as it will be
+	// stored.
+	ref.SearchParams = medicationRequestSearchParams(mr)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if derivedFromChanged(documentLinks(existing.SupportingInformation), documentLinks(mr.SupportingInformation)) {
+		if err := validateDerivedFrom(ctx, s.docRepo, documentLinks(mr.SupportingInformation), ref.PatientID); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated *models.MedicationRequest
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, mr); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, medicationRequestRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a medication request
+// and returns its resulting meta.
+func (s *MedicationRequestService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "MedicationRequest"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	mr, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if mr == nil {
+		return nil, domain.ErrMedicationRequestNotFound
+	}
+
+	ref := medicationRequestRef(mr)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	mr.Meta = changeSecurityLabels(mr.Meta, add, remove)
+	var updated *models.MedicationRequest
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, mr); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, medicationRequestRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *MedicationRequestService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "MedicationRequest"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrMedicationRequestNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, medicationRequestRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, medicationRequestRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *MedicationRequestService) List(ctx context.Context, search domain.MedicationSearch, limit, offset int) (*domain.ListResponse[models.MedicationRequest], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "MedicationRequest"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "MedicationRequest", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = medicationRequestRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.MedicationRequest]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// documentLinks picks the DocumentReference references out of refs.
+func documentLinks(refs []models.Reference) []models.Reference {
+	var links []models.Reference
+	for _, ref := range refs {
+		if ref.Reference != nil && strings.HasPrefix(*ref.Reference, "DocumentReference/") {
+			links = append(links, ref)
+		}
+	}
+	return links
+}
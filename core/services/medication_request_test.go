@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testMedRequestID = "mr-123"
+)
+
+func createTestMedicationRequest(id, patientID string, supportingInformation ...string) *models.MedicationRequest {
+	patientRef := "Patient/" + patientID
+	mr := &models.MedicationRequest{
+		ResourceType: "MedicationRequest",
+		Status:       "active",
+		Intent:       "order",
+		Medication: &models.CodeableReference{Concept: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://www.nlm.nih.gov/research/umls/rxnorm", "197361"),
+		}}},
+		Subject:    &models.Reference{Reference: &patientRef},
+		AuthoredOn: strPtr("2024-03-01"),
+	}
+	if id != "" {
+		mr.Id = strPtr(id)
+	}
+	for _, ref := range supportingInformation {
+		mr.SupportingInformation = append(mr.SupportingInformation, models.Reference{Reference: strPtr(ref)})
+	}
+	return mr
+}
+
+func newTestMedicationRequestService(ctrl *gomock.Controller, repo ports.MedicationRequestRepository, docRepo ports.DocumentRepository) *MedicationRequestService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewMedicationRequestService(repo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewMedicationRequestValidator())
+}
+
+func TestMedicationRequestService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/MedicationRequest.c"})
+
+	tests := []struct {
+		name          string
+		mr            *models.MedicationRequest
+		setupMocks    func(*ports.MockMedicationRequestRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name: "success path - prescription linked as supporting information",
+			mr:   createTestMedicationRequest("", "", "DocumentReference/"+testDocID, "Observation/"+testObsID),
+			setupMocks: func(repo *ports.MockMedicationRequestRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, mr *models.MedicationRequest) (*models.MedicationRequest, error) {
+						return mr, nil
+					})
+			},
+		},
+		{
+			name: "error - prescription of another patient",
+			mr:   createTestMedicationRequest("", "", "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockMedicationRequestRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - unknown intent",
+			mr: func() *models.MedicationRequest {
+				mr := createTestMedicationRequest("", "")
+				mr.Intent = "wish"
+				return mr
+			}(),
+			setupMocks:    func(*ports.MockMedicationRequestRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockMedicationRequestRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, docRepo)
+
+			service := newTestMedicationRequestService(ctrl, repo, docRepo)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.mr)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
+
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testMedStatementID = "ms-123"
+)
+
+func createTestMedicationStatement(id, patientID string, derivedFrom ...string) *models.MedicationStatement {
+	patientRef := "Patient/" + patientID
+	ms := &models.MedicationStatement{
+		ResourceType: "MedicationStatement",
+		Status:       "recorded",
+		Medication: &models.CodeableReference{Concept: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://www.nlm.nih.gov/research/umls/rxnorm", "197361"),
+		}}},
+		Subject:           &models.Reference{Reference: &patientRef},
+		EffectiveDateTime: strPtr("2024-03-01"),
+	}
+	if id != "" {
+		ms.Id = strPtr(id)
+	}
+	for _, ref := range derivedFrom {
+		ms.DerivedFrom = append(ms.DerivedFrom, models.Reference{Reference: strPtr(ref)})
+	}
+	return ms
+}
+
+func newTestMedicationStatementService(ctrl *gomock.Controller, repo ports.MedicationStatementRepository, docRepo ports.DocumentRepository) *MedicationStatementService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewMedicationStatementService(repo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewMedicationStatementValidator())
+}
+
+func TestMedicationStatementService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/MedicationStatement.c"})
+
+	tests := []struct {
+		name          string
+		ms            *models.MedicationStatement
+		user          domain.Identity
+		setupMocks    func(*ports.MockMedicationStatementRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name: "success path - derived from the prescription",
+			ms:   createTestMedicationStatement("", "", "DocumentReference/"+testDocID),
+			user: patient,
+			setupMocks: func(repo *ports.MockMedicationStatementRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+						assert.Equal(t, "Patient/"+testPatientID, *ms.Subject.Reference)
+						assert.NotEmpty(t, *ms.Id)
+						return ms, nil
+					})
+			},
+		},
+		{
+			name: "error - prescription of another patient",
+			ms:   createTestMedicationStatement("", "", "DocumentReference/"+testDocID),
+			user: patient,
+			setupMocks: func(repo *ports.MockMedicationStatementRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - prescription not found",
+			ms:   createTestMedicationStatement("", "", "DocumentReference/"+testDocID),
+			user: patient,
+			setupMocks: func(repo *ports.MockMedicationStatementRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(nil, nil)
+			},
+			expectedError: domain.ErrDerivedFromDocNotFound,
+		},
+		{
+			name:          "error - derived from a non-document",
+			ms:            createTestMedicationStatement("", "", "Observation/"+testObsID),
+			user:          patient,
+			setupMocks:    func(*ports.MockMedicationStatementRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidDerivedFromRef,
+		},
+		{
+			name: "error - missing medication",
+			ms: func() *models.MedicationStatement {
+				ms := createTestMedicationStatement("", "")
+				ms.Medication = nil
+				return ms
+			}(),
+			user:          patient,
+			setupMocks:    func(*ports.MockMedicationStatementRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - unknown status",
+			ms: func() *models.MedicationStatement {
+				ms := createTestMedicationStatement("", "")
+				ms.Status = "active"
+				return ms
+			}(),
+			user:          patient,
+			setupMocks:    func(*ports.MockMedicationStatementRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:          "error - no create scope",
+			ms:            createTestMedicationStatement("", ""),
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/MedicationStatement.rs"}),
+			setupMocks:    func(*ports.MockMedicationStatementRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - repository failure",
+			ms:   createTestMedicationStatement("", ""),
+			user: patient,
+			setupMocks: func(repo *ports.MockMedicationStatementRepository, docRepo *ports.MockDocumentRepository) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockMedicationStatementRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, docRepo)
+
+			service := newTestMedicationStatementService(ctrl, repo, docRepo)
+			result, err := service.Create(identity.WithCtx(context.Background(), tt.user), tt.ms)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result)
+		})
+	}
+}
+
+func TestMedicationStatementService_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		ms            *models.MedicationStatement
+		stored        *models.MedicationStatement
+		setupDocs     func(*ports.MockDocumentRepository)
+		expectUpdate  bool
+		expectedError error
+	}{
+		{
+			name:         "success path - unchanged derivedFrom is not revalidated",
+			ms:           createTestMedicationStatement(testMedStatementID, "other-patient", "DocumentReference/"+testDocID),
+			stored:       createTestMedicationStatement(testMedStatementID, testPatientID, "DocumentReference/"+testDocID),
+			expectUpdate: true,
+		},
+		{
+			name:   "error - new prescription of another patient",
+			ms:     createTestMedicationStatement(testMedStatementID, testPatientID, "DocumentReference/other-doc"),
+			stored: createTestMedicationStatement(testMedStatementID, testPatientID),
+			setupDocs: func(docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), "other-doc").Return(createTestDocument("other-doc", "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - not found",
+			ms:            createTestMedicationStatement(testMedStatementID, testPatientID),
+			expectedError: domain.ErrMedicationStatementNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockMedicationStatementRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), testMedStatementID).Return(tt.stored, nil)
+			if tt.setupDocs != nil {
+				tt.setupDocs(docRepo)
+			}
+			if tt.expectUpdate {
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, ms *models.MedicationStatement) (*models.MedicationStatement, error) {
+						return ms, nil
+					})
+			}
+
+			service := newTestMedicationStatementService(ctrl, repo, docRepo)
+			id := createTestIdentity(testPatientID, testUserID, []string{"patient/MedicationStatement.cruds"})
+			result, err := service.Update(identity.WithCtx(context.Background(), id), tt.ms)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
+
+func TestMedicationStatementService_List(t *testing.T) {
+	search := domain.MedicationSearch{
+		PatientID: testPatientID,
+		Status:    "recorded",
+		Effective: []domain.DateFilter{{Prefix: "ge", Start: "2024", End: "2025"}},
+	}
+
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		expectSearch  bool
+		expectedError error
+	}{
+		{
+			name:         "success path",
+			user:         createTestIdentity(testPatientID, testUserID, []string{"patient/MedicationStatement.rs"}),
+			expectSearch: true,
+		},
+		{
+			name:          "error - another patient",
+			user:          createTestIdentity("other-patient", "other-user", []string{"patient/MedicationStatement.rs"}),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockMedicationStatementRepository(ctrl)
+			if tt.expectSearch {
+				repo.EXPECT().
+					Search(gomock.Any(), search, gomock.Nil(), 10, 0).
+					Return([]models.MedicationStatement{*createTestMedicationStatement(testMedStatementID, testPatientID)}, int64(1), nil)
+			}
+
+			service := newTestMedicationStatementService(ctrl, repo, ports.NewMockDocumentRepository(ctrl))
+			result, err := service.List(identity.WithCtx(context.Background(), tt.user), search, 10, 0)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Items, 1)
+			assert.Equal(t, int64(1), result.Total)
+		})
+	}
+}
+
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+)
+
+type NotificationService struct {
+	repo  ports.NotificationRepository
+	authz ports.Authorizer
+}
+
+func NewNotificationService(repo ports.NotificationRepository, authz ports.Authorizer) *NotificationService {
+	return &NotificationService{
+		repo:  repo,
+		authz: authz,
+	}
+}
+
+// List returns the calling patient's notifications, newest first.
+func (s *NotificationService) List(ctx context.Context, limit, offset int) (*domain.ListResponse[domain.Notification], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	ref := domain.ResourceRef{Type: "Notification", PatientID: user.PatientID}
+	if err := authorize(ctx, s.authz, user, domain.ActionSearch, ref); err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.ListByPatient(ctx, user.PatientID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[domain.Notification]{
+		Items: items,
+		Total: total,
+	}, nil
+}
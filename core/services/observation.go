@@ -17,17 +17,32 @@ import (
 type ObservationService struct {
 	repo      ports.ObservationRepository
 	docRepo   ports.DocumentRepository
+	encRepo   ports.EncounterRepository
+	orgRepo   ports.OrganizationRepository
+	authz     ports.Authorizer
+	consent   ports.ConsentEvaluator
+	outbox    *EventOutbox
 	validator *validator.ObservationValidator
 }
 
 func NewObservationService(
 	repo ports.ObservationRepository,
 	docRepo ports.DocumentRepository,
+	encRepo ports.EncounterRepository,
+	orgRepo ports.OrganizationRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
 	v *validator.ObservationValidator,
 ) *ObservationService {
 	return &ObservationService{
 		repo:      repo,
 		docRepo:   docRepo,
+		encRepo:   encRepo,
+		orgRepo:   orgRepo,
+		authz:     authz,
+		consent:   consent,
+		outbox:    outbox,
 		validator: v,
 	}
 }
@@ -42,8 +57,17 @@ func (s *ObservationService) Create(ctx context.Context, obs *models.Observation
 		return nil, domain.ErrAccessDenied
 	}
 
-	if user.IsTmpToken() || !user.HasScope("patient/*.write") || user.PatientID == "" {
-		return nil, domain.ErrAccessDenied
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Observation"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, obs.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Observation", PatientID: patientID, SearchParams: observationSearchParams(obs)}); err != nil {
+		return nil, err
 	}
 
 	if obs.Id != nil && *obs.Id != "" {
@@ -53,16 +77,31 @@ func (s *ObservationService) Create(ctx context.Context, obs *models.Observation
 	id := uuid.New().String()
 	obs.Id = &id
 
-	patientRef := fmt.Sprintf("Patient/%s", user.PatientID)
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
 	obs.Subject = &models.Reference{
 		Reference: &patientRef,
 	}
 
-	if err := s.validateDerivedFrom(ctx, obs.DerivedFrom, user.PatientID); err != nil {
+	if err := validateDerivedFrom(ctx, s.docRepo, obs.DerivedFrom, patientID); err != nil {
+		return nil, err
+	}
+
+	if err := validateEncounters(ctx, s.encRepo, observationEncounters(obs), patientID); err != nil {
 		return nil, err
 	}
 
-	created, err := s.repo.Create(ctx, obs)
+	if err := validateOrganizations(ctx, s.orgRepo, obs.Performer, patientID); err != nil {
+		return nil, err
+	}
+
+	var created *models.Observation
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, obs); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, observationRef(created), created)}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
@@ -88,15 +127,20 @@ func (s *ObservationService) Get(ctx context.Context, id string) (*models.Observ
 		return nil, domain.ErrObservationNotFound
 	}
 
-	if !user.IsTmpToken() && user.HasScope("patient/*.read") && s.isOwner(user, obs) {
-		return obs, nil
+	ref := domain.ResourceRef{
+		Type:         "Observation",
+		ID:           id,
+		PatientID:    patientIDFromReference(obs.Subject),
+		SearchParams: observationSearchParams(obs),
 	}
-
-	if user.HasResourceScope("docs", "observation", id, "read") {
-		return obs, nil
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
 	}
 
-	return nil, domain.ErrAccessDenied
+	return obs, nil
 }
 
 func (s *ObservationService) Update(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
@@ -105,11 +149,8 @@ func (s *ObservationService) Update(ctx context.Context, obs *models.Observation
 		return nil, domain.ErrAccessDenied
 	}
 
-	if user.IsTmpToken() {
-		return nil, domain.ErrTmpTokenForbidden
-	}
-	if !user.HasScope("patient/*.write") || user.PatientID == "" {
-		return nil, domain.ErrAccessDenied
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Observation"); !decision.Allowed {
+		return nil, decision.Err
 	}
 
 	if obs.Id == nil {
@@ -124,21 +165,60 @@ func (s *ObservationService) Update(ctx context.Context, obs *models.Observation
 		return nil, domain.ErrObservationNotFound
 	}
 
-	if !s.isOwner(user, existing) {
-		return nil, domain.ErrAccessDenied
+	patientID := patientIDFromReference(existing.Subject)
+	ref := domain.ResourceRef{
+		Type:         "Observation",
+		ID:           *obs.Id,
+		PatientID:    patientID,
+		SearchParams: observationSearchParams(existing),
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
 	}
 
 	if err := s.validator.Validate(obs); err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
 	}
 
+	if mayLabel(user, patientID) != nil {
+		obs.Meta = keepSecurityLabels(obs.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the observation to another compartment.
+	obs.Subject = existing.Subject
+
+	// A restricted scope must also cover the observation as it will be stored.
+	ref.SearchParams = observationSearchParams(obs)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
 	if derivedFromChanged(existing.DerivedFrom, obs.DerivedFrom) {
-		if err := s.validateDerivedFrom(ctx, obs.DerivedFrom, user.PatientID); err != nil {
+		if err := validateDerivedFrom(ctx, s.docRepo, obs.DerivedFrom, patientID); err != nil {
+			return nil, err
+		}
+	}
+
+	if derivedFromChanged(observationEncounters(existing), observationEncounters(obs)) {
+		if err := validateEncounters(ctx, s.encRepo, observationEncounters(obs), patientID); err != nil {
+			return nil, err
+		}
+	}
+
+	if derivedFromChanged(existing.Performer, obs.Performer) {
+		if err := validateOrganizations(ctx, s.orgRepo, obs.Performer, patientID); err != nil {
 			return nil, err
 		}
 	}
 
-	updated, err := s.repo.Update(ctx, obs)
+	var updated *models.Observation
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, obs); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, observationRef(updated), updated)}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
@@ -146,19 +226,64 @@ func (s *ObservationService) Update(ctx context.Context, obs *models.Observation
 	return updated, nil
 }
 
-func (s *ObservationService) Delete(ctx context.Context, id string) error {
+// UpdateSecurityLabels adds and removes security labels of an observation and
+// returns its resulting meta.
+func (s *ObservationService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
 	user, ok := identity.FromCtx(ctx)
 	if !ok {
-		return domain.ErrAccessDenied
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Observation"); !decision.Allowed {
+		return nil, decision.Err
 	}
 
-	if user.IsTmpToken() {
-		return domain.ErrTmpTokenForbidden
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
 	}
-	if !user.HasScope("patient/*.write") || user.PatientID == "" {
+
+	obs, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if obs == nil {
+		return nil, domain.ErrObservationNotFound
+	}
+
+	ref := observationRef(obs)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	obs.Meta = changeSecurityLabels(obs.Meta, add, remove)
+	var updated *models.Observation
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, obs); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, observationRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *ObservationService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
 		return domain.ErrAccessDenied
 	}
 
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Observation"); !decision.Allowed {
+		return decision.Err
+	}
+
 	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
@@ -167,56 +292,68 @@ func (s *ObservationService) Delete(ctx context.Context, id string) error {
 		return domain.ErrObservationNotFound
 	}
 
-	if !s.isOwner(user, existing) {
-		return domain.ErrAccessDenied
+	ref := domain.ResourceRef{
+		Type:         "Observation",
+		ID:           id,
+		PatientID:    patientIDFromReference(existing.Subject),
+		SearchParams: observationSearchParams(existing),
+	}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, ref); err != nil {
+		return err
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, ref, existing)}, nil
+	})
+	if err != nil {
 		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
 
 	return nil
 }
 
-func (s *ObservationService) List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.Observation], error) {
+func (s *ObservationService) List(ctx context.Context, search domain.ObservationSearch, limit, offset int) (*domain.ListResponse[models.Observation], error) {
 	user, ok := identity.FromCtx(ctx)
 	if !ok {
 		return nil, domain.ErrAccessDenied
 	}
 
-	if user.IsTmpToken() {
-		return nil, domain.ErrTmpTokenForbidden
-	}
-	if !user.HasScope("patient/*.read") || user.PatientID == "" {
-		return nil, domain.ErrAccessDenied
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Observation"); !decision.Allowed {
+		return nil, decision.Err
 	}
 
-	if user.PatientID != patientID {
-		return nil, domain.ErrAccessDenied
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "Observation", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
 	}
 
-	items, total, err := s.repo.Search(ctx, patientID, limit, offset)
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
 
-	return &domain.ListResponse[models.Observation]{
-		Items: items,
-		Total: total,
-	}, nil
-}
-
-func (s *ObservationService) isOwner(user domain.Identity, obs *models.Observation) bool {
-	if user.PatientID == "" {
-		return false
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = observationRef(&items[i])
 	}
-	if obs.Subject == nil || obs.Subject.Reference == nil {
-		return false
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
 	}
-	return strings.HasSuffix(*obs.Subject.Reference, user.PatientID)
+
+	return &domain.ListResponse[models.Observation]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
 }
 
-func (s *ObservationService) validateDerivedFrom(ctx context.Context, derivedFrom []models.Reference, patientID string) error {
+// validateDerivedFrom checks that derivedFrom references point to documents
+// of the same patient.
+func validateDerivedFrom(ctx context.Context, docRepo ports.DocumentRepository, derivedFrom []models.Reference, patientID string) error {
 	if len(derivedFrom) == 0 {
 		return nil
 	}
@@ -236,7 +373,7 @@ func (s *ObservationService) validateDerivedFrom(ctx context.Context, derivedFro
 			return domain.ErrInvalidDerivedFromRef
 		}
 
-		doc, err := s.docRepo.GetByID(ctx, docID)
+		doc, err := docRepo.GetByID(ctx, docID)
 		if err != nil {
 			return fmt.Errorf("%w: %v", domain.ErrInternal, err)
 		}
@@ -248,8 +385,7 @@ func (s *ObservationService) validateDerivedFrom(ctx context.Context, derivedFro
 			return domain.ErrDerivedFromDocNotFound
 		}
 
-		expectedPatientRef := fmt.Sprintf("Patient/%s", patientID)
-		if *doc.Subject.Reference != expectedPatientRef {
+		if patientIDFromReference(doc.Subject) != patientID {
 			return domain.ErrAccessDenied
 		}
 	}
@@ -257,6 +393,15 @@ func (s *ObservationService) validateDerivedFrom(ctx context.Context, derivedFro
 	return nil
 }
 
+// observationEncounters returns the encounter of an observation as a list of
+// references.
+func observationEncounters(obs *models.Observation) []models.Reference {
+	if obs.Encounter == nil {
+		return nil
+	}
+	return []models.Reference{*obs.Encounter}
+}
+
 func derivedFromChanged(old, new []models.Reference) bool {
 	if len(old) != len(new) {
 		return true
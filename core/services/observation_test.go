@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"net/url"
 	"testing"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
@@ -168,11 +169,11 @@ func TestObservationService_Create(t *testing.T) {
 				id := createTestIdentity("", "", []string{})
 				return identity.WithCtx(context.Background(), id)
 			},
-			expectedError: domain.ErrAccessDenied,
+			expectedError: domain.ErrTmpTokenForbidden,
 			validateResult: func(t *testing.T, obs *models.Observation, err error) {
 				assert.Error(t, err)
 				assert.Nil(t, obs)
-				assert.Equal(t, domain.ErrAccessDenied, err)
+				assert.Equal(t, domain.ErrTmpTokenForbidden, err)
 			},
 		},
 		{
@@ -292,7 +293,7 @@ func TestObservationService_Create(t *testing.T) {
 
 			tt.setupMocks(obsRepo, docRepo)
 
-			service := NewObservationService(obsRepo, docRepo, validator)
+			service := NewObservationService(obsRepo, docRepo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			result, err := service.Create(ctx, tt.obs)
@@ -457,7 +458,7 @@ func TestObservationService_Get(t *testing.T) {
 
 			tt.setupMocks(obsRepo)
 
-			service := NewObservationService(obsRepo, docRepo, validator)
+			service := NewObservationService(obsRepo, docRepo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			result, err := service.Get(ctx, tt.obsID)
@@ -536,6 +537,58 @@ func TestObservationService_Update(t *testing.T) {
 				require.NotNil(t, obs)
 			},
 		},
+		{
+			name: "success path - subject stays with the compartment",
+			obs:  createTestObservation(testObsID, "other-patient"),
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				obsRepo.EXPECT().
+					GetByID(gomock.Any(), testObsID).
+					Return(createTestObservation(testObsID, testPatientID), nil)
+				obsRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, obs *models.Observation, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, obs.Subject)
+				assert.Equal(t, "Patient/"+testPatientID, *obs.Subject.Reference)
+			},
+		},
+		{
+			name: "success path - missing subject keeps the stored one",
+			obs: &models.Observation{
+				ResourceType: "Observation",
+				Id:           strPtr(testObsID),
+				Status:       "final",
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository) {
+				obsRepo.EXPECT().
+					GetByID(gomock.Any(), testObsID).
+					Return(createTestObservation(testObsID, testPatientID), nil)
+				obsRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, obs *models.Observation, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, obs.Subject)
+				assert.Equal(t, "Patient/"+testPatientID, *obs.Subject.Reference)
+			},
+		},
 		{
 			name: "error - no ID",
 			obs: &models.Observation{
@@ -754,7 +807,7 @@ func TestObservationService_Update(t *testing.T) {
 
 			tt.setupMocks(obsRepo, docRepo)
 
-			service := NewObservationService(obsRepo, docRepo, validator)
+			service := NewObservationService(obsRepo, docRepo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			result, err := service.Update(ctx, tt.obs)
@@ -772,6 +825,157 @@ func TestObservationService_Update(t *testing.T) {
 	}
 }
 
+func TestObservationService_UpdateSecurityLabels(t *testing.T) {
+	psy := coding(domain.ActCodeSystem, domain.SensitivityPSY)
+
+	labelled := func() *models.Observation {
+		obs := createTestObservation(testObsID, testPatientID)
+		obs.Meta = &models.Meta{Security: []models.Coding{psy}}
+		return obs
+	}
+
+	tests := []struct {
+		name           string
+		add            []models.Coding
+		remove         []models.Coding
+		setupMocks     func(*ports.MockObservationRepository, *ports.MockCareRelationshipRepository)
+		user           domain.Identity
+		expectedError  error
+		expectedLabels []string
+	}{
+		{
+			name: "patient adds a label",
+			add:  []models.Coding{psy},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, careRepo *ports.MockCareRelationshipRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(createTestObservation(testObsID, testPatientID), nil)
+				obsRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			},
+			user:           createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			expectedLabels: []string{domain.SensitivityPSY},
+		},
+		{
+			name: "adding an existing label keeps one",
+			add:  []models.Coding{psy},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, careRepo *ports.MockCareRelationshipRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(labelled(), nil)
+				obsRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			},
+			user:           createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			expectedLabels: []string{domain.SensitivityPSY},
+		},
+		{
+			name:   "patient removes a label by code",
+			remove: []models.Coding{{Code: strPtr(domain.SensitivityPSY)}},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, careRepo *ports.MockCareRelationshipRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(labelled(), nil)
+				obsRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			},
+			user: createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+		},
+		{
+			name:   "error - practitioner cannot change labels",
+			remove: []models.Coding{psy},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, careRepo *ports.MockCareRelationshipRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(labelled(), nil)
+				careRepo.EXPECT().
+					Find(gomock.Any(), testPatientID, testPractitionerID).
+					Return(createTestCareRelationship(domain.CareAccessWrite), nil)
+			},
+			user:          createTestPractitionerIdentity(testPractitionerID, []string{"user/*.cruds"}),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - label without code",
+			add:           []models.Coding{{System: strPtr(domain.ActCodeSystem)}},
+			setupMocks:    func(obsRepo *ports.MockObservationRepository, careRepo *ports.MockCareRelationshipRepository) {},
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - observation not found",
+			add:  []models.Coding{psy},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, careRepo *ports.MockCareRelationshipRepository) {
+				obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(nil, nil)
+			},
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			expectedError: domain.ErrObservationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+			tt.setupMocks(obsRepo, careRepo)
+
+			authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewObservationService(obsRepo, ports.NewMockDocumentRepository(ctrl), ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewObservationValidator())
+
+			meta, err := service.UpdateSecurityLabels(identity.WithCtx(context.Background(), tt.user), testObsID, tt.add, tt.remove)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, meta)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, meta)
+			var codes []string
+			for _, label := range meta.Security {
+				codes = append(codes, *label.Code)
+			}
+			assert.Equal(t, tt.expectedLabels, codes)
+		})
+	}
+}
+
+func TestObservationService_Update_KeepsSecurityLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	obsRepo := ports.NewMockObservationRepository(ctrl)
+	careRepo := ports.NewMockCareRelationshipRepository(ctrl)
+
+	existing := createTestObservation(testObsID, testPatientID)
+	existing.Meta = &models.Meta{Security: []models.Coding{coding(domain.ConfidentialitySystem, domain.ConfidentialityRestricted)}}
+	obsRepo.EXPECT().GetByID(gomock.Any(), testObsID).Return(existing, nil)
+	careRepo.EXPECT().
+		Find(gomock.Any(), testPatientID, testPractitionerID).
+		Return(createTestCareRelationship(domain.CareAccessWrite), nil).
+		Times(2)
+	obsRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+			return obs, nil
+		})
+
+	authz := NewPolicyAuthorizer(careRepo, ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	service := NewObservationService(obsRepo, ports.NewMockDocumentRepository(ctrl), ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewObservationValidator())
+
+	update := createTestObservation(testObsID, testPatientID)
+	id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.cruds"})
+	result, err := service.Update(identity.WithCtx(context.Background(), id), update)
+
+	require.NoError(t, err)
+	require.NotNil(t, result.Meta)
+	assert.Equal(t, existing.Meta.Security, result.Meta.Security)
+}
+
 func TestObservationService_Delete(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -915,7 +1119,7 @@ func TestObservationService_Delete(t *testing.T) {
 
 			tt.setupMocks(obsRepo)
 
-			service := NewObservationService(obsRepo, docRepo, validator)
+			service := NewObservationService(obsRepo, docRepo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
 			err := service.Delete(ctx, tt.obsID)
@@ -955,7 +1159,7 @@ func TestObservationService_List(t *testing.T) {
 					*createTestObservation("obs-2", testPatientID),
 				}
 				repo.EXPECT().
-					Search(gomock.Any(), testPatientID, 10, 0).
+					Search(gomock.Any(), domain.ObservationSearch{PatientID: testPatientID}, gomock.Nil(), 10, 0).
 					Return(obs, int64(2), nil)
 			},
 			setupContext: func() context.Context {
@@ -970,6 +1174,29 @@ func TestObservationService_List(t *testing.T) {
 				assert.Equal(t, int64(2), result.Total)
 			},
 		},
+		{
+			name:      "success path - restricted scope",
+			patientID: testPatientID,
+			limit:     10,
+			offset:    0,
+			setupMocks: func(repo *ports.MockObservationRepository) {
+				restrictions := []url.Values{{"category": {"laboratory"}}}
+				repo.EXPECT().
+					Search(gomock.Any(), domain.ObservationSearch{PatientID: testPatientID}, restrictions, 10, 0).
+					Return([]models.Observation{*createTestObservation("obs-1", testPatientID)}, int64(1), nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/Observation.rs?category=laboratory"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, result *domain.ListResponse[models.Observation], err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Len(t, result.Items, 1)
+				assert.Equal(t, int64(1), result.Total)
+			},
+		},
 		{
 			name:      "error - no identity",
 			patientID: testPatientID,
@@ -1027,7 +1254,7 @@ func TestObservationService_List(t *testing.T) {
 			offset:    0,
 			setupMocks: func(repo *ports.MockObservationRepository) {
 				repo.EXPECT().
-					Search(gomock.Any(), testPatientID, 10, 0).
+					Search(gomock.Any(), domain.ObservationSearch{PatientID: testPatientID}, gomock.Nil(), 10, 0).
 					Return(nil, int64(0), errors.New("database error"))
 			},
 			setupContext: func() context.Context {
@@ -1054,10 +1281,10 @@ func TestObservationService_List(t *testing.T) {
 
 			tt.setupMocks(obsRepo)
 
-			service := NewObservationService(obsRepo, docRepo, validator)
+			service := NewObservationService(obsRepo, docRepo, ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl), validator)
 
 			ctx := tt.setupContext()
-			result, err := service.List(ctx, tt.patientID, tt.limit, tt.offset)
+			result, err := service.List(ctx, domain.ObservationSearch{PatientID: tt.patientID}, tt.limit, tt.offset)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
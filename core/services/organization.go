@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type OrganizationService struct {
+	repo      ports.OrganizationRepository
+	authz     ports.Authorizer
+	validator *validator.OrganizationValidator
+}
+
+func NewOrganizationService(repo ports.OrganizationRepository, authz ports.Authorizer, v *validator.OrganizationValidator) *OrganizationService {
+	return &OrganizationService{
+		repo:      repo,
+		authz:     authz,
+		validator: v,
+	}
+}
+
+// Create adds an organization to the directory. Administrators add shared
+// entries; patients add private entries to their own compartment.
+func (s *OrganizationService) Create(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	if err := s.validator.Validate(org); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Organization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	ownerID := user.CompartmentID()
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Organization", PatientID: ownerID}); err != nil {
+		return nil, err
+	}
+
+	if org.Id != nil && *org.Id != "" {
+		return nil, fmt.Errorf("%w: organization ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	org.Id = &id
+	org.Meta = setDirectoryOwner(org.Meta, ownerID)
+
+	created, err := s.repo.Create(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *OrganizationService) Get(ctx context.Context, id string) (*models.Organization, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrOrganizationIDRequired
+	}
+
+	org, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if org == nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, organizationRef(org)); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+func (s *OrganizationService) Update(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Organization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if org.Id == nil || *org.Id == "" {
+		return nil, domain.ErrOrganizationIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *org.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	ref := organizationRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(org); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	// An entry cannot move between the shared directory and a compartment.
+	org.Meta = setDirectoryOwner(org.Meta, ref.PatientID)
+
+	updated, err := s.repo.Update(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+func (s *OrganizationService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Organization"); !decision.Allowed {
+		return decision.Err
+	}
+
+	if id == "" {
+		return domain.ErrOrganizationIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrOrganizationNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, organizationRef(existing)); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// List searches the shared directory and the private entries of the caller's
+// compartment.
+func (s *OrganizationService) List(ctx context.Context, search domain.DirectorySearch, limit, offset int) (*domain.ListResponse[models.Organization], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Organization"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	search.PatientID = user.CompartmentID()
+
+	items, total, err := s.repo.Search(ctx, search, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.Organization]{
+		Items: items,
+		Total: total,
+	}, nil
+}
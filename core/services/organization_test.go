@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testOrgID      = "org-123"
+	testLocationID = "loc-123"
+)
+
+func createTestOrganization(id, ownerID string) *models.Organization {
+	org := &models.Organization{
+		ResourceType: "Organization",
+		Name:         strPtr("Invitro"),
+		Contact: []models.ExtendedContactDetail{{
+			Address: &models.Address{City: strPtr("Moscow")},
+		}},
+	}
+	if id != "" {
+		org.Id = strPtr(id)
+	}
+	org.Meta = setDirectoryOwner(nil, ownerID)
+	return org
+}
+
+func createTestLocation(id, ownerID string) *models.Location {
+	loc := &models.Location{
+		ResourceType: "Location",
+		Name:         strPtr("Invitro, Tverskaya 1"),
+		Address:      &models.Address{City: strPtr("Moscow")},
+	}
+	if id != "" {
+		loc.Id = strPtr(id)
+	}
+	loc.Meta = setDirectoryOwner(nil, ownerID)
+	return loc
+}
+
+func newTestOrganizationService(ctrl *gomock.Controller, repo ports.OrganizationRepository) *OrganizationService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewOrganizationService(repo, authz, validator.NewOrganizationValidator())
+}
+
+func TestOrganizationService_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		org           *models.Organization
+		expectedOwner string
+		expectedError error
+	}{
+		{
+			name:          "success path - patient adds a private entry",
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/Organization.c"}),
+			org:           createTestOrganization("", ""),
+			expectedOwner: testPatientID,
+		},
+		{
+			name: "success path - administrator adds a shared entry",
+			user: domain.Identity{UserID: "admin-1", SystemClient: true, Scopes: []string{"system/Organization.c"}},
+			org:  createTestOrganization("", "someone-else"),
+		},
+		{
+			name:          "error - practitioner",
+			user:          createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"}),
+			org:           createTestOrganization("", ""),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - missing name",
+			user: createTestIdentity(testPatientID, testUserID, []string{"patient/Organization.c"}),
+			org: func() *models.Organization {
+				org := createTestOrganization("", "")
+				org.Name = nil
+				return org
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockOrganizationRepository(ctrl)
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+						return org, nil
+					})
+			}
+
+			service := newTestOrganizationService(ctrl, repo)
+			result, err := service.Create(identity.WithCtx(context.Background(), tt.user), tt.org)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, *result.Id)
+			assert.Equal(t, tt.expectedOwner, directoryOwner(result.Meta))
+		})
+	}
+}
+
+func TestOrganizationService_Update(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Organization.u"})
+
+	tests := []struct {
+		name          string
+		stored        *models.Organization
+		expectedError error
+	}{
+		{
+			name:   "success path - own private entry keeps its owner",
+			stored: createTestOrganization(testOrgID, testPatientID),
+		},
+		{
+			name:          "error - shared entry",
+			stored:        createTestOrganization(testOrgID, ""),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - private entry of another patient",
+			stored:        createTestOrganization(testOrgID, "other-patient"),
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockOrganizationRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), testOrgID).Return(tt.stored, nil)
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, org *models.Organization) (*models.Organization, error) {
+						return org, nil
+					})
+			}
+
+			// The body tries to move the entry into the shared directory.
+			org := createTestOrganization(testOrgID, "")
+			org.Name = strPtr("Invitro Lab")
+
+			service := newTestOrganizationService(ctrl, repo)
+			result, err := service.Update(identity.WithCtx(context.Background(), patient), org)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testPatientID, directoryOwner(result.Meta))
+			assert.Equal(t, "Invitro Lab", *result.Name)
+		})
+	}
+}
+
+func TestOrganizationService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := ports.NewMockOrganizationRepository(ctrl)
+	repo.EXPECT().
+		Search(gomock.Any(), domain.DirectorySearch{PatientID: testPatientID, Name: "inv"}, 10, 0).
+		Return([]models.Organization{*createTestOrganization(testOrgID, "")}, int64(1), nil)
+
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+	service := newTestOrganizationService(ctrl, repo)
+	result, err := service.List(identity.WithCtx(context.Background(), patient), domain.DirectorySearch{PatientID: "other-patient", Name: "inv"}, 10, 0)
+
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, int64(1), result.Total)
+}
+
+func TestObservationService_Create_Performer(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Observation.c"})
+
+	tests := []struct {
+		name          string
+		stored        *models.Organization
+		expectedError error
+	}{
+		{
+			name:   "success path - shared organization",
+			stored: createTestOrganization(testOrgID, ""),
+		},
+		{
+			name:   "success path - own private organization",
+			stored: createTestOrganization(testOrgID, testPatientID),
+		},
+		{
+			name:          "error - private organization of another patient",
+			stored:        createTestOrganization(testOrgID, "other-patient"),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - organization not found",
+			expectedError: domain.ErrDirectoryRefNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockObservationRepository(ctrl)
+			orgRepo := ports.NewMockOrganizationRepository(ctrl)
+			orgRepo.EXPECT().GetByID(gomock.Any(), testOrgID).Return(tt.stored, nil)
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						return obs, nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewObservationService(repo, ports.NewMockDocumentRepository(ctrl), ports.NewMockEncounterRepository(ctrl), orgRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewObservationValidator())
+
+			obs := createTestObservation("", testPatientID)
+			obs.Id = nil
+			obs.Code = &models.CodeableConcept{Text: strPtr("Hemoglobin")}
+			obs.Performer = []models.Reference{
+				{Reference: strPtr("Practitioner/" + testPractitionerID)},
+				{Reference: strPtr("Organization/" + testOrgID)},
+			}
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), obs)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEncounterService_Create_Location(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := ports.NewMockEncounterRepository(ctrl)
+	orgRepo := ports.NewMockOrganizationRepository(ctrl)
+	locRepo := ports.NewMockLocationRepository(ctrl)
+	orgRepo.EXPECT().GetByID(gomock.Any(), testOrgID).Return(createTestOrganization(testOrgID, ""), nil)
+	locRepo.EXPECT().GetByID(gomock.Any(), testLocationID).Return(createTestLocation(testLocationID, "other-patient"), nil)
+
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	service := NewEncounterService(repo, orgRepo, locRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewEncounterValidator())
+
+	enc := createTestEncounter("", testPatientID)
+	enc.ServiceProvider = &models.Reference{Reference: strPtr("Organization/" + testOrgID)}
+	enc.Location = []models.EncounterLocation{{Location: &models.Reference{Reference: strPtr("Location/" + testLocationID)}}}
+
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Encounter.c"})
+	result, err := service.Create(identity.WithCtx(context.Background(), patient), enc)
+
+	assert.ErrorIs(t, err, domain.ErrAccessDenied)
+	assert.Nil(t, result)
+}
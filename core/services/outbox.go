@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	"github.com/google/uuid"
+)
+
+// EventOutbox stores changes together with the domain events announcing
+// them, so an event is published if and only if its change was stored.
+type EventOutbox struct {
+	tx   ports.Transactor
+	repo ports.OutboxRepository
+}
+
+func NewEventOutbox(tx ports.Transactor, repo ports.OutboxRepository) *EventOutbox {
+	return &EventOutbox{tx: tx, repo: repo}
+}
+
+// Write runs write and appends the events it returns to the outbox in one
+// transaction. write may be run again if the transaction conflicts with
+// another one.
+func (o *EventOutbox) Write(ctx context.Context, write func(ctx context.Context) ([]domain.DomainEvent, error)) error {
+	return o.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		events, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		return o.repo.Append(ctx, events...)
+	})
+}
+
+// Announce appends events and then runs then in one transaction, so the
+// events are dropped if then fails. It is for changes made outside the
+// database, which cannot be undone once the transaction fails: then should
+// make them last and skip them when it is run again after a conflict.
+func (o *EventOutbox) Announce(ctx context.Context, events []domain.DomainEvent, then func(ctx context.Context) error) error {
+	return o.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := o.repo.Append(ctx, events...); err != nil {
+			return err
+		}
+		return then(ctx)
+	})
+}
+
+// domainEvent describes a change of the resource ref points to. data is
+// encoded as the event data unless it is nil.
+func domainEvent(eventType domain.EventType, ref domain.ResourceRef, data any) domain.DomainEvent {
+	event := domain.DomainEvent{
+		ID:           uuid.New().String(),
+		Type:         eventType,
+		ResourceType: ref.Type,
+		ResourceID:   ref.ID,
+		PatientID:    ref.PatientID,
+		OccurredAt:   time.Now(),
+	}
+	if data != nil {
+		event.Data, _ = json.Marshal(data)
+	}
+	return event
+}
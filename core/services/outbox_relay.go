@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	"github.com/google/uuid"
+)
+
+const (
+	relayLease               = "outbox-relay"
+	defaultRelayInterval     = time.Second
+	defaultRelayLeaseTTL     = 30 * time.Second
+	relayBatchSize           = 100
+	relayBatchesPerTick      = 10
+	relayPublishTimeoutMax   = 30 * time.Second
+	defaultRelayMaxAttempts  = 10
+	defaultRelayRetryBackoff = 5 * time.Second
+	maxRelayRetryBackoff     = 10 * time.Minute
+)
+
+// RelayOptions configure the OutboxRelay; zero values take the defaults.
+type RelayOptions struct {
+	// Interval is how often pending events are published; LeaseTTL is as
+	// for ReminderOptions.
+	Interval time.Duration
+	LeaseTTL time.Duration
+	// MaxAttempts is how many times an event is tried before it is moved to
+	// the dead letters. RetryBackoff is the wait after the first failure,
+	// doubled after each further one.
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// OutboxRelay hands the domain events in the outbox to the service's
+// EventHandler and then publishes them. Like the
+// ReminderScheduler it runs on every replica and a lease lets one of them
+// publish at a time. An event leaves the outbox only after the publisher
+// accepted it, so an event may be published more than once but never lost;
+// the handler sees it once, as it is marked handled in the transaction the
+// handler writes in.
+// When an event fails, it and the later events of its resource wait for a
+// retry, with a backoff doubling after each failure, while the other
+// resources go on; once the attempts run out the event is moved to the dead
+// letters so its resource is not held forever.
+type OutboxRelay struct {
+	tx           ports.Transactor
+	outbox       ports.OutboxRepository
+	publisher    ports.EventPublisher
+	handler      ports.EventHandler
+	leases       ports.LeaseRepository
+	interval     time.Duration
+	leaseTTL     time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+	holder       string
+	now          func() time.Time
+}
+
+func NewOutboxRelay(
+	opts RelayOptions,
+	tx ports.Transactor,
+	outbox ports.OutboxRepository,
+	publisher ports.EventPublisher,
+	handler ports.EventHandler,
+	leases ports.LeaseRepository,
+) *OutboxRelay {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+	leaseTTL := opts.LeaseTTL
+	if leaseTTL <= interval {
+		leaseTTL = max(defaultRelayLeaseTTL, 2*interval)
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRelayMaxAttempts
+	}
+	retryBackoff := opts.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRelayRetryBackoff
+	}
+
+	hostname, _ := os.Hostname()
+	return &OutboxRelay{
+		tx:           tx,
+		outbox:       outbox,
+		publisher:    publisher,
+		handler:      handler,
+		leases:       leases,
+		interval:     interval,
+		leaseTTL:     leaseTTL,
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		holder:       hostname + "-" + uuid.New().String(),
+		now:          time.Now,
+	}
+}
+
+// Run publishes pending events every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.tick(ctx); err != nil {
+			log.Printf("Outbox relay: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			r.releaseLease()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *OutboxRelay) releaseLease() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.leases.Release(ctx, relayLease, r.holder); err != nil {
+		log.Printf("Outbox relay: releasing lease: %v", err)
+	}
+}
+
+// tick publishes pending events if this replica holds the lease. It pages
+// through the outbox by resource key, so every resource gets its turn, and
+// stops after a few pages so the lease is renewed before it runs out. A page
+// that ends within the events of a resource leaves the rest of them to the
+// next tick.
+func (r *OutboxRelay) tick(ctx context.Context) error {
+	acquired, err := r.leases.Acquire(ctx, relayLease, r.holder, r.leaseTTL)
+	if err != nil {
+		return fmt.Errorf("acquiring lease: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+
+	afterKey := ""
+	for range relayBatchesPerTick {
+		pending, err := r.outbox.ListPending(ctx, afterKey, relayBatchSize)
+		if err != nil {
+			return fmt.Errorf("listing pending events: %w", err)
+		}
+
+		if err := r.publishBatch(ctx, pending); err != nil {
+			return err
+		}
+		if len(pending) < relayBatchSize {
+			return nil
+		}
+		afterKey = pending[len(pending)-1].Key()
+	}
+	return nil
+}
+
+// publishBatch publishes a page of pending events in order. A resource
+// whose event is held for a retry or fails now is skipped for the rest of the
+// page; only storage errors stop the tick.
+func (r *OutboxRelay) publishBatch(ctx context.Context, pending []domain.DomainEvent) error {
+	now := r.now()
+	held := make(map[string]bool)
+	for _, event := range pending {
+		key := event.Key()
+		if held[key] {
+			continue
+		}
+		if event.NextAttemptAt.After(now) {
+			held[key] = true
+			continue
+		}
+
+		if err := r.deliver(ctx, event); err != nil {
+			log.Printf("Outbox relay: %s of %s: %v", event.Type, key, err)
+			held[key] = true
+			if err := r.retry(ctx, event, err, now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkPublished(ctx, event.ID); err != nil {
+			return fmt.Errorf("marking event %s published: %w", event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deliver hands an event to the handler, unless it was handled by an earlier
+// attempt, and publishes it.
+func (r *OutboxRelay) deliver(ctx context.Context, event domain.DomainEvent) error {
+	if !event.Handled {
+		err := r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := r.handler.HandleEvent(ctx, event); err != nil {
+				return err
+			}
+			return r.outbox.MarkHandled(ctx, event.ID)
+		})
+		if err != nil {
+			return fmt.Errorf("handling: %w", err)
+		}
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, min(r.leaseTTL/2, relayPublishTimeoutMax))
+	defer cancel()
+	if err := r.publisher.Publish(publishCtx, event); err != nil {
+		return fmt.Errorf("publishing: %w", err)
+	}
+	return nil
+}
+
+// retry schedules another attempt of a failed event or, once the attempts
+// are used up, moves it to the dead letters, which frees its resource.
+func (r *OutboxRelay) retry(ctx context.Context, event domain.DomainEvent, cause error, now time.Time) error {
+	if event.Attempts+1 >= r.maxAttempts {
+		log.Printf("Outbox relay: giving up on event %s of %s after %d attempts", event.ID, event.Key(), event.Attempts+1)
+		if err := r.outbox.DeadLetter(ctx, event, cause.Error()); err != nil {
+			return fmt.Errorf("dead-lettering event %s: %w", event.ID, err)
+		}
+		return nil
+	}
+
+	if err := r.outbox.Retry(ctx, event.ID, now.Add(r.backoff(event.Attempts+1))); err != nil {
+		return fmt.Errorf("rescheduling event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// backoff returns the wait before the next attempt after the given number of
+// failed ones.
+func (r *OutboxRelay) backoff(failures int) time.Duration {
+	wait := r.retryBackoff
+	for i := 1; i < failures && wait < maxRelayRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRelayRetryBackoff)
+}
@@ -0,0 +1,467 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// inTransaction returns a Transactor that runs every unit of work directly.
+func inTransaction(ctrl *gomock.Controller) *ports.MockTransactor {
+	tx := ports.NewMockTransactor(ctrl)
+	tx.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	return tx
+}
+
+func discardOutbox(ctrl *gomock.Controller) *EventOutbox {
+	repo := ports.NewMockOutboxRepository(ctrl)
+	repo.EXPECT().Append(gomock.Any(), gomock.Any()).AnyTimes()
+	return NewEventOutbox(inTransaction(ctrl), repo)
+}
+
+func TestObservationService_Create_Outbox(t *testing.T) {
+	user := createTestIdentity(testPatientID, testUserID, []string{"patient/Observation.c"})
+
+	tests := []struct {
+		name          string
+		appendErr     error
+		expectedError error
+	}{
+		{
+			name: "success path - created event appended",
+		},
+		{
+			name:          "error - outbox write fails the create",
+			appendErr:     errors.New("write conflict"),
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			outboxRepo := ports.NewMockOutboxRepository(ctrl)
+
+			obsRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+					return obs, nil
+				})
+			outboxRepo.EXPECT().
+				Append(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, events ...domain.DomainEvent) error {
+					require.Len(t, events, 1)
+					assert.Equal(t, domain.EventResourceCreated, events[0].Type)
+					assert.Equal(t, "Observation", events[0].ResourceType)
+					assert.NotEmpty(t, events[0].ResourceID)
+					assert.Equal(t, testPatientID, events[0].PatientID)
+					assert.NotEmpty(t, events[0].Data)
+					return tt.appendErr
+				})
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewObservationService(obsRepo, ports.NewMockDocumentRepository(ctrl), ports.NewMockEncounterRepository(ctrl), ports.NewMockOrganizationRepository(ctrl), authz, permitAllConsents(ctrl), NewEventOutbox(inTransaction(ctrl), outboxRepo), validator.NewObservationValidator())
+
+			obs := createTestObservation("", testPatientID)
+			obs.Id = nil
+			result, err := service.Create(identity.WithCtx(context.Background(), user), obs)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result)
+		})
+	}
+}
+
+func TestConditionService_Delete_Outbox(t *testing.T) {
+	user := createTestIdentity(testPatientID, testUserID, []string{"patient/Condition.d"})
+
+	tests := []struct {
+		name          string
+		appendErr     error
+		expectedError error
+	}{
+		{
+			name: "success path - deleted event carries the last state",
+		},
+		{
+			name:          "error - outbox write fails the delete",
+			appendErr:     errors.New("write conflict"),
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockConditionRepository(ctrl)
+			outboxRepo := ports.NewMockOutboxRepository(ctrl)
+
+			repo.EXPECT().GetByID(gomock.Any(), "cond-1").Return(createTestCondition("cond-1", testPatientID), nil)
+			repo.EXPECT().Delete(gomock.Any(), "cond-1").Return(nil)
+			outboxRepo.EXPECT().
+				Append(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, events ...domain.DomainEvent) error {
+					require.Len(t, events, 1)
+					assert.Equal(t, domain.EventResourceDeleted, events[0].Type)
+					assert.Equal(t, "Condition/cond-1", events[0].Key())
+					assert.Equal(t, testPatientID, events[0].PatientID)
+					assert.Contains(t, string(events[0].Data), "44054006")
+					return tt.appendErr
+				})
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewConditionService(repo, ports.NewMockObservationRepository(ctrl), ports.NewMockDocumentRepository(ctrl), authz, permitAllConsents(ctrl), NewEventOutbox(inTransaction(ctrl), outboxRepo), validator.NewConditionValidator())
+
+			err := service.Delete(identity.WithCtx(context.Background(), user), "cond-1")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestShareService_Share_Outbox(t *testing.T) {
+	tests := []struct {
+		name          string
+		appendErr     error
+		tokenErr      error
+		expectedToken bool
+		expectedError error
+	}{
+		{
+			name:          "success path - token minted after the shared event",
+			expectedToken: true,
+		},
+		{
+			name:          "error - no token minted when the event is not written",
+			appendErr:     errors.New("write conflict"),
+			expectedError: domain.ErrInternal,
+		},
+		{
+			name:          "error - token service failure drops the event",
+			tokenErr:      errors.New("auth service unavailable"),
+			expectedToken: true,
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			client := ports.NewMockTmpAccessClient(ctrl)
+			outboxRepo := ports.NewMockOutboxRepository(ctrl)
+			tx := ports.NewMockTransactor(ctrl)
+
+			obsRepo.EXPECT().
+				GetByIDs(gomock.Any(), []string{testObsID}).
+				Return([]models.Observation{*createTestObservation(testObsID, testPatientID)}, nil)
+			docRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Len(0)).Return([]models.DocumentReference{}, nil)
+
+			appended := false
+			tx.EXPECT().
+				WithinTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					err := fn(ctx)
+					if err != nil {
+						appended = false
+					}
+					return err
+				})
+			outboxRepo.EXPECT().
+				Append(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, events ...domain.DomainEvent) error {
+					require.Len(t, events, 1)
+					assert.Equal(t, domain.EventResourceShared, events[0].Type)
+					assert.Equal(t, "Observation/"+testObsID, events[0].Key())
+					assert.JSONEq(t, `{"via":"token"}`, string(events[0].Data))
+					appended = tt.appendErr == nil
+					return tt.appendErr
+				})
+			if tt.expectedToken {
+				client.EXPECT().
+					GenerateTmpToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req domain.GenerateTmpTokenRequest) (*domain.GenerateTmpTokenResponse, error) {
+						assert.True(t, appended, "token minted before the event was written")
+						if tt.tokenErr != nil {
+							return nil, tt.tokenErr
+						}
+						return &domain.GenerateTmpTokenResponse{TmpToken: "tmp-token"}, nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, ports.NewMockSHLRepository(ctrl), client, ports.NewMockFileProvider(ctrl), authz, permitAllConsents(ctrl), NewEventOutbox(tx, outboxRepo))
+
+			id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs"})
+			resp, err := service.Share(identity.WithCtx(context.Background(), id), domain.ShareRequest{ResourceIDs: []string{"Observation/" + testObsID}})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.False(t, appended)
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, appended)
+			assert.Equal(t, "tmp-token", resp.Token)
+		})
+	}
+}
+
+func TestEventOutbox_Write_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := ports.NewMockOutboxRepository(ctrl)
+	outbox := NewEventOutbox(inTransaction(ctrl), repo)
+
+	writeErr := errors.New("duplicate key")
+	err := outbox.Write(context.Background(), func(ctx context.Context) ([]domain.DomainEvent, error) {
+		return nil, writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+}
+
+func TestOutboxRelay_Tick(t *testing.T) {
+	now := time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)
+	first := domain.DomainEvent{ID: "e-1", Type: domain.EventResourceCreated, ResourceType: "Observation", ResourceID: testObsID, Sequence: 1}
+	second := domain.DomainEvent{ID: "e-2", Type: domain.EventResourceUpdated, ResourceType: "Observation", ResourceID: testObsID, Sequence: 2}
+	third := domain.DomainEvent{ID: "e-3", Type: domain.EventResourceDeleted, ResourceType: "DocumentReference", ResourceID: testDocID, Sequence: 1}
+
+	tests := []struct {
+		name               string
+		acquired           bool
+		firstAttempts      int
+		firstHeldUntil     time.Time
+		firstHandled       bool
+		failing            string
+		failingHandler     string
+		expectedHandled    []string
+		expectedPublished  []string
+		expectedRetry      time.Time
+		expectedDeadLetter string
+	}{
+		{
+			name:              "handles and publishes every pending event in order",
+			acquired:          true,
+			expectedHandled:   []string{"e-1", "e-2", "e-3"},
+			expectedPublished: []string{"e-1", "e-2", "e-3"},
+		},
+		{
+			name:              "event handled by an earlier attempt is only published",
+			acquired:          true,
+			firstHandled:      true,
+			expectedHandled:   []string{"e-2", "e-3"},
+			expectedPublished: []string{"e-1", "e-2", "e-3"},
+		},
+		{
+			name:              "handler failure holds the resource unpublished",
+			acquired:          true,
+			failingHandler:    "e-1",
+			expectedHandled:   []string{"e-3"},
+			expectedPublished: []string{"e-3"},
+			expectedRetry:     now.Add(5 * time.Second),
+		},
+		{
+			name:              "failure holds only the resource of the event",
+			acquired:          true,
+			failing:           "e-1",
+			expectedHandled:   []string{"e-1", "e-3"},
+			expectedPublished: []string{"e-3"},
+			expectedRetry:     now.Add(5 * time.Second),
+		},
+		{
+			name:              "backoff doubles with each failure",
+			acquired:          true,
+			firstAttempts:     2,
+			failing:           "e-1",
+			expectedHandled:   []string{"e-1", "e-3"},
+			expectedPublished: []string{"e-3"},
+			expectedRetry:     now.Add(20 * time.Second),
+		},
+		{
+			name:               "attempts used up - event dead-lettered",
+			acquired:           true,
+			firstAttempts:      9,
+			failing:            "e-1",
+			expectedHandled:    []string{"e-1", "e-3"},
+			expectedPublished:  []string{"e-3"},
+			expectedDeadLetter: "e-1",
+		},
+		{
+			name:              "event waiting for its retry holds its resource",
+			acquired:          true,
+			firstHeldUntil:    now.Add(time.Minute),
+			expectedHandled:   []string{"e-3"},
+			expectedPublished: []string{"e-3"},
+		},
+		{
+			name: "lease held by another replica",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			outbox := ports.NewMockOutboxRepository(ctrl)
+			publisher := ports.NewMockEventPublisher(ctrl)
+			handler := ports.NewMockEventHandler(ctrl)
+			leases := ports.NewMockLeaseRepository(ctrl)
+
+			relay := NewOutboxRelay(RelayOptions{}, inTransaction(ctrl), outbox, publisher, handler, leases)
+			relay.now = func() time.Time { return now }
+
+			head := first
+			head.Attempts = tt.firstAttempts
+			head.NextAttemptAt = tt.firstHeldUntil
+			head.Handled = tt.firstHandled
+
+			leases.EXPECT().Acquire(gomock.Any(), relayLease, relay.holder, relay.leaseTTL).Return(tt.acquired, nil)
+			if tt.acquired {
+				outbox.EXPECT().ListPending(gomock.Any(), "", relayBatchSize).Return([]domain.DomainEvent{head, second, third}, nil)
+			}
+
+			publisher.EXPECT().
+				Publish(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, event domain.DomainEvent) error {
+					if event.ID == tt.failing {
+						return errors.New("nats: timeout")
+					}
+					return nil
+				}).
+				AnyTimes()
+
+			handler.EXPECT().
+				HandleEvent(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, event domain.DomainEvent) error {
+					if event.ID == tt.failingHandler {
+						return errors.New("write conflict")
+					}
+					return nil
+				}).
+				AnyTimes()
+
+			var handled []string
+			outbox.EXPECT().
+				MarkHandled(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, id string) error {
+					handled = append(handled, id)
+					return nil
+				}).
+				AnyTimes()
+
+			var published []string
+			outbox.EXPECT().
+				MarkPublished(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, id string) error {
+					published = append(published, id)
+					return nil
+				}).
+				AnyTimes()
+			if !tt.expectedRetry.IsZero() {
+				outbox.EXPECT().Retry(gomock.Any(), "e-1", tt.expectedRetry).Return(nil)
+			}
+			if tt.expectedDeadLetter != "" {
+				outbox.EXPECT().
+					DeadLetter(gomock.Any(), gomock.Any(), "publishing: nats: timeout").
+					DoAndReturn(func(ctx context.Context, event domain.DomainEvent, reason string) error {
+						assert.Equal(t, tt.expectedDeadLetter, event.ID)
+						return nil
+					})
+			}
+
+			require.NoError(t, relay.tick(context.Background()))
+			assert.Equal(t, tt.expectedHandled, handled)
+			assert.Equal(t, tt.expectedPublished, published)
+		})
+	}
+}
+
+func TestQuestionnaireResponseService_Delete_Outbox(t *testing.T) {
+	user := createTestIdentity(testPatientID, testUserID, []string{"patient/QuestionnaireResponse.d"})
+
+	tests := []struct {
+		name          string
+		appendErr     error
+		expectedError error
+	}{
+		{
+			name: "success path - deleted event carries the last state",
+		},
+		{
+			name:          "error - outbox write fails the delete",
+			appendErr:     errors.New("write conflict"),
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockQuestionnaireResponseRepository(ctrl)
+			outboxRepo := ports.NewMockOutboxRepository(ctrl)
+
+			repo.EXPECT().GetByID(gomock.Any(), testResponseID).Return(createTestResponse(testResponseID, "completed", smokerAnswer(false)), nil)
+			repo.EXPECT().Delete(gomock.Any(), testResponseID).Return(nil)
+			outboxRepo.EXPECT().
+				Append(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, events ...domain.DomainEvent) error {
+					require.Len(t, events, 1)
+					assert.Equal(t, domain.EventResourceDeleted, events[0].Type)
+					assert.Equal(t, "QuestionnaireResponse/"+testResponseID, events[0].Key())
+					assert.Equal(t, testPatientID, events[0].PatientID)
+					assert.Contains(t, string(events[0].Data), testQuestionnaireURL)
+					return tt.appendErr
+				})
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewQuestionnaireResponseService(repo, ports.NewMockQuestionnaireRepository(ctrl), ports.NewMockObservationRepository(ctrl), ports.NewMockObservationService(ctrl), authz, permitAllConsents(ctrl), NewEventOutbox(inTransaction(ctrl), outboxRepo), validator.NewQuestionnaireResponseValidator())
+
+			err := service.Delete(identity.WithCtx(context.Background(), user), testResponseID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
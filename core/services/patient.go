@@ -15,12 +15,14 @@ import (
 
 type PatientService struct {
 	repo      ports.PatientRepository
+	authz     ports.Authorizer
 	validator *validator.PatientValidator
 }
 
-func NewPatientService(repo ports.PatientRepository, v *validator.PatientValidator) *PatientService {
+func NewPatientService(repo ports.PatientRepository, authz ports.Authorizer, v *validator.PatientValidator) *PatientService {
 	return &PatientService{
 		repo:      repo,
+		authz:     authz,
 		validator: v,
 	}
 }
@@ -53,8 +55,9 @@ func (s *PatientService) Get(ctx context.Context, id string) (*models.Patient, e
 		return nil, domain.ErrAccessDenied
 	}
 
-	if !user.IsPatient(id) && !user.HasScope("patient/*.read") {
-		return nil, domain.ErrAccessDenied
+	ref := domain.ResourceRef{Type: "Patient", ID: id, PatientID: id}
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
 	}
 
 	patient, err := s.repo.GetByID(ctx, id)
@@ -79,8 +82,9 @@ func (s *PatientService) Update(ctx context.Context, patient *models.Patient) (*
 		return nil, domain.ErrPatientIDRequired
 	}
 
-	if !user.IsPatient(*patient.Id) && !user.HasScope("patient/*.write") {
-		return nil, domain.ErrAccessDenied
+	ref := domain.ResourceRef{Type: "Patient", ID: *patient.Id, PatientID: *patient.Id}
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
 	}
 
 	if err := s.validator.Validate(patient); err != nil {
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type PractitionerService struct {
+	repo      ports.PractitionerRepository
+	authz     ports.Authorizer
+	validator *validator.PractitionerValidator
+}
+
+func NewPractitionerService(repo ports.PractitionerRepository, authz ports.Authorizer, v *validator.PractitionerValidator) *PractitionerService {
+	return &PractitionerService{
+		repo:      repo,
+		authz:     authz,
+		validator: v,
+	}
+}
+
+// Create registers the practitioner profile of the calling clinician. The
+// resource ID is taken from the practitioner identity of the token.
+func (s *PractitionerService) Create(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	if err := s.validator.Validate(practitioner); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Practitioner"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if practitioner.Id != nil && *practitioner.Id != "" {
+		return nil, fmt.Errorf("%w: practitioner ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	existing, err := s.repo.GetByID(ctx, user.PractitionerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: practitioner profile already exists", domain.ErrInvalidInput)
+	}
+
+	id := user.PractitionerID
+	practitioner.Id = &id
+
+	created, err := s.repo.Create(ctx, practitioner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *PractitionerService) Get(ctx context.Context, id string) (*models.Practitioner, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionRead, "Practitioner"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if id == "" {
+		return nil, domain.ErrPractitionerIDRequired
+	}
+
+	practitioner, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if practitioner == nil {
+		return nil, domain.ErrPractitionerNotFound
+	}
+
+	return practitioner, nil
+}
+
+func (s *PractitionerService) Update(ctx context.Context, practitioner *models.Practitioner) (*models.Practitioner, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if practitioner.Id == nil || *practitioner.Id == "" {
+		return nil, domain.ErrPractitionerIDRequired
+	}
+
+	ref := domain.ResourceRef{Type: "Practitioner", ID: *practitioner.Id, OwnerID: *practitioner.Id}
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(practitioner); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	existing, err := s.repo.GetByID(ctx, *practitioner.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrPractitionerNotFound
+	}
+
+	updated, err := s.repo.Update(ctx, practitioner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
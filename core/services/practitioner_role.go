@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type PractitionerRoleService struct {
+	repo             ports.PractitionerRoleRepository
+	practitionerRepo ports.PractitionerRepository
+	authz            ports.Authorizer
+	validator        *validator.PractitionerRoleValidator
+}
+
+func NewPractitionerRoleService(
+	repo ports.PractitionerRoleRepository,
+	practitionerRepo ports.PractitionerRepository,
+	authz ports.Authorizer,
+	v *validator.PractitionerRoleValidator,
+) *PractitionerRoleService {
+	return &PractitionerRoleService{
+		repo:             repo,
+		practitionerRepo: practitionerRepo,
+		authz:            authz,
+		validator:        v,
+	}
+}
+
+func (s *PractitionerRoleService) Create(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error) {
+	if err := s.validator.Validate(role); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "PractitionerRole"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if role.Id != nil && *role.Id != "" {
+		return nil, fmt.Errorf("%w: practitioner role ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	practitioner, err := s.practitionerRepo.GetByID(ctx, user.PractitionerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if practitioner == nil {
+		return nil, domain.ErrPractitionerNotFound
+	}
+
+	id := uuid.New().String()
+	role.Id = &id
+
+	practitionerRef := fmt.Sprintf("Practitioner/%s", user.PractitionerID)
+	role.Practitioner = &models.Reference{
+		Reference: &practitionerRef,
+	}
+
+	created, err := s.repo.Create(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *PractitionerRoleService) Get(ctx context.Context, id string) (*models.PractitionerRole, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionRead, "PractitionerRole"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	role, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if role == nil {
+		return nil, domain.ErrPractitionerRoleNotFound
+	}
+
+	return role, nil
+}
+
+func (s *PractitionerRoleService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "PractitionerRole"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrPractitionerRoleNotFound
+	}
+
+	ref := domain.ResourceRef{Type: "PractitionerRole", ID: id, OwnerID: practitionerIDFromReference(existing.Practitioner)}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, ref); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *PractitionerRoleService) List(ctx context.Context, practitionerID string, limit, offset int) (*domain.ListResponse[models.PractitionerRole], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "PractitionerRole"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	items, total, err := s.repo.Search(ctx, practitionerID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.PractitionerRole]{
+		Items: items,
+		Total: total,
+	}, nil
+}
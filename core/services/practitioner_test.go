@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func createTestPractitioner(id string) *models.Practitioner {
+	practitioner := &models.Practitioner{
+		ResourceType: "Practitioner",
+		Name: []models.HumanName{
+			{Family: strPtr("House")},
+		},
+	}
+	if id != "" {
+		practitioner.Id = strPtr(id)
+	}
+	return practitioner
+}
+
+func TestPractitionerService_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		practitioner   *models.Practitioner
+		setupMocks     func(*ports.MockPractitionerRepository)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *models.Practitioner, error)
+	}{
+		{
+			name:         "success path - ID taken from token",
+			practitioner: createTestPractitioner(""),
+			setupMocks: func(repo *ports.MockPractitionerRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(nil, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p *models.Practitioner) (*models.Practitioner, error) {
+						return p, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			validateResult: func(t *testing.T, p *models.Practitioner, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				assert.Equal(t, testPractitionerID, *p.Id)
+			},
+		},
+		{
+			name:         "error - patient token",
+			practitioner: createTestPractitioner(""),
+			setupMocks:   func(*ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:         "error - missing name",
+			practitioner: &models.Practitioner{ResourceType: "Practitioner"},
+			setupMocks:   func(*ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:         "error - profile already exists",
+			practitioner: createTestPractitioner(""),
+			setupMocks: func(repo *ports.MockPractitionerRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(createTestPractitioner(testPractitionerID), nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:         "error - repository error",
+			practitioner: createTestPractitioner(""),
+			setupMocks: func(repo *ports.MockPractitionerRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(nil, errors.New("database error"))
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockPractitionerRepository(ctrl)
+			tt.setupMocks(repo)
+
+			service := NewPractitionerService(repo, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), validator.NewPractitionerValidator())
+
+			result, err := service.Create(tt.setupContext(), tt.practitioner)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
+
+func TestPractitionerService_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		practitioner  *models.Practitioner
+		setupMocks    func(*ports.MockPractitionerRepository)
+		setupContext  func() context.Context
+		expectedError error
+	}{
+		{
+			name:         "success path - own profile",
+			practitioner: createTestPractitioner(testPractitionerID),
+			setupMocks: func(repo *ports.MockPractitionerRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(createTestPractitioner(testPractitionerID), nil)
+				repo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p *models.Practitioner) (*models.Practitioner, error) {
+						return p, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+		},
+		{
+			name:         "error - other practitioner",
+			practitioner: createTestPractitioner("other-practitioner"),
+			setupMocks:   func(*ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:         "error - missing ID",
+			practitioner: createTestPractitioner(""),
+			setupMocks:   func(*ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrPractitionerIDRequired,
+		},
+		{
+			name:         "error - not found",
+			practitioner: createTestPractitioner(testPractitionerID),
+			setupMocks: func(repo *ports.MockPractitionerRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(nil, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrPractitionerNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockPractitionerRepository(ctrl)
+			tt.setupMocks(repo)
+
+			service := NewPractitionerService(repo, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), validator.NewPractitionerValidator())
+
+			_, err := service.Update(tt.setupContext(), tt.practitioner)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPractitionerRoleService_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           *models.PractitionerRole
+		setupMocks     func(*ports.MockPractitionerRoleRepository, *ports.MockPractitionerRepository)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *models.PractitionerRole, error)
+	}{
+		{
+			name: "success path - practitioner reference forced to self",
+			role: &models.PractitionerRole{
+				ResourceType: "PractitionerRole",
+				Code:         []models.CodeableConcept{{Text: strPtr("Cardiologist")}},
+				Practitioner: &models.Reference{Reference: strPtr("Practitioner/someone-else")},
+			},
+			setupMocks: func(repo *ports.MockPractitionerRoleRepository, practitionerRepo *ports.MockPractitionerRepository) {
+				practitionerRepo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(createTestPractitioner(testPractitionerID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, role *models.PractitionerRole) (*models.PractitionerRole, error) {
+						return role, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			validateResult: func(t *testing.T, role *models.PractitionerRole, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, role.Id)
+				assert.Equal(t, "Practitioner/"+testPractitionerID, *role.Practitioner.Reference)
+			},
+		},
+		{
+			name: "error - no practitioner profile",
+			role: &models.PractitionerRole{
+				ResourceType: "PractitionerRole",
+				Code:         []models.CodeableConcept{{Text: strPtr("Cardiologist")}},
+			},
+			setupMocks: func(repo *ports.MockPractitionerRoleRepository, practitionerRepo *ports.MockPractitionerRepository) {
+				practitionerRepo.EXPECT().
+					GetByID(gomock.Any(), testPractitionerID).
+					Return(nil, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrPractitionerNotFound,
+		},
+		{
+			name:       "error - missing code and specialty",
+			role:       &models.PractitionerRole{ResourceType: "PractitionerRole"},
+			setupMocks: func(*ports.MockPractitionerRoleRepository, *ports.MockPractitionerRepository) {},
+			setupContext: func() context.Context {
+				id := createTestPractitionerIdentity(testPractitionerID, []string{"user/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockPractitionerRoleRepository(ctrl)
+			practitionerRepo := ports.NewMockPractitionerRepository(ctrl)
+			tt.setupMocks(repo, practitionerRepo)
+
+			service := NewPractitionerRoleService(repo, practitionerRepo, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), validator.NewPractitionerRoleValidator())
+
+			result, err := service.Create(tt.setupContext(), tt.role)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testProcedureID = "proc-123"
+)
+
+func createTestProcedure(id, patientID string, reports ...string) *models.Procedure {
+	patientRef := "Patient/" + patientID
+	proc := &models.Procedure{
+		ResourceType: "Procedure",
+		Status:       "completed",
+		Code: &models.CodeableConcept{Coding: []models.Coding{
+			coding("http://snomed.info/sct", "80146002"),
+		}},
+		Subject:            &models.Reference{Reference: &patientRef},
+		OccurrenceDateTime: strPtr("2023-03-14"),
+	}
+	if id != "" {
+		proc.Id = strPtr(id)
+	}
+	for _, ref := range reports {
+		proc.Report = append(proc.Report, models.Reference{Reference: strPtr(ref)})
+	}
+	return proc
+}
+
+func newTestProcedureService(ctrl *gomock.Controller, repo ports.ProcedureRepository, docRepo ports.DocumentRepository) *ProcedureService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewProcedureService(repo, docRepo, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewProcedureValidator())
+}
+
+func TestProcedureService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Procedure.c"})
+
+	tests := []struct {
+		name          string
+		proc          *models.Procedure
+		setupMocks    func(*ports.MockProcedureRepository, *ports.MockDocumentRepository)
+		expectedError error
+	}{
+		{
+			name: "success path - operation report linked",
+			proc: createTestProcedure("", testPatientID, "DocumentReference/"+testDocID, "DiagnosticReport/report-1"),
+			setupMocks: func(repo *ports.MockProcedureRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, testPatientID), nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, proc *models.Procedure) (*models.Procedure, error) {
+						return proc, nil
+					})
+			},
+		},
+		{
+			name: "error - operation report of another patient",
+			proc: createTestProcedure("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockProcedureRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(createTestDocument(testDocID, "other-patient"), nil)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - operation report not found",
+			proc: createTestProcedure("", testPatientID, "DocumentReference/"+testDocID),
+			setupMocks: func(repo *ports.MockProcedureRepository, docRepo *ports.MockDocumentRepository) {
+				docRepo.EXPECT().GetByID(gomock.Any(), testDocID).Return(nil, nil)
+			},
+			expectedError: domain.ErrDerivedFromDocNotFound,
+		},
+		{
+			name: "error - missing code",
+			proc: func() *models.Procedure {
+				proc := createTestProcedure("", testPatientID)
+				proc.Code = nil
+				return proc
+			}(),
+			setupMocks:    func(*ports.MockProcedureRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - invalid status",
+			proc: func() *models.Procedure {
+				proc := createTestProcedure("", testPatientID)
+				proc.Status = "done"
+				return proc
+			}(),
+			setupMocks:    func(*ports.MockProcedureRepository, *ports.MockDocumentRepository) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockProcedureRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			tt.setupMocks(repo, docRepo)
+
+			service := newTestProcedureService(ctrl, repo, docRepo)
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.proc)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
+
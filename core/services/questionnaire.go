@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type QuestionnaireService struct {
+	repo      ports.QuestionnaireRepository
+	authz     ports.Authorizer
+	validator *validator.QuestionnaireValidator
+}
+
+func NewQuestionnaireService(repo ports.QuestionnaireRepository, authz ports.Authorizer, v *validator.QuestionnaireValidator) *QuestionnaireService {
+	return &QuestionnaireService{
+		repo:      repo,
+		authz:     authz,
+		validator: v,
+	}
+}
+
+// Create publishes a questionnaire. Only administrators may publish.
+func (s *QuestionnaireService) Create(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	if err := s.validator.Validate(q); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Questionnaire"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Questionnaire"}); err != nil {
+		return nil, err
+	}
+
+	if q.Id != nil && *q.Id != "" {
+		return nil, fmt.Errorf("%w: questionnaire ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	q.Id = &id
+
+	created, err := s.repo.Create(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *QuestionnaireService) Get(ctx context.Context, id string) (*models.Questionnaire, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrQuestionnaireIDRequired
+	}
+
+	q, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if q == nil {
+		return nil, domain.ErrQuestionnaireNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, questionnaireRef(q)); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (s *QuestionnaireService) Update(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Questionnaire"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if q.Id == nil || *q.Id == "" {
+		return nil, domain.ErrQuestionnaireIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *q.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrQuestionnaireNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, questionnaireRef(existing)); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(q); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	updated, err := s.repo.Update(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+func (s *QuestionnaireService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "Questionnaire"); !decision.Allowed {
+		return decision.Err
+	}
+
+	if id == "" {
+		return domain.ErrQuestionnaireIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrQuestionnaireNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, questionnaireRef(existing)); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *QuestionnaireService) List(ctx context.Context, search domain.QuestionnaireSearch, limit, offset int) (*domain.ListResponse[models.Questionnaire], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Questionnaire"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.Questionnaire]{
+		Items: items,
+		Total: total,
+	}, nil
+}
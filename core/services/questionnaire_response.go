@@ -0,0 +1,682 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// questionnaireResponseSystem identifies extracted observations by the
+// response and item they were extracted from.
+const questionnaireResponseSystem = "urn:codex:questionnaire-response"
+
+type QuestionnaireResponseService struct {
+	repo           ports.QuestionnaireResponseRepository
+	questionnaires ports.QuestionnaireRepository
+	obsRepo        ports.ObservationRepository
+	observations   ports.ObservationService
+	authz          ports.Authorizer
+	consent        ports.ConsentEvaluator
+	outbox         *EventOutbox
+	validator      *validator.QuestionnaireResponseValidator
+}
+
+func NewQuestionnaireResponseService(
+	repo ports.QuestionnaireResponseRepository,
+	questionnaires ports.QuestionnaireRepository,
+	obsRepo ports.ObservationRepository,
+	observations ports.ObservationService,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
+	v *validator.QuestionnaireResponseValidator,
+) *QuestionnaireResponseService {
+	return &QuestionnaireResponseService{
+		repo:           repo,
+		questionnaires: questionnaires,
+		obsRepo:        obsRepo,
+		observations:   observations,
+		authz:          authz,
+		consent:        consent,
+		outbox:         outbox,
+		validator:      v,
+	}
+}
+
+func (s *QuestionnaireResponseService) Create(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	if err := s.validator.Validate(resp); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "QuestionnaireResponse"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	patientID, err := targetPatientID(user, resp.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "QuestionnaireResponse", PatientID: patientID, SearchParams: questionnaireResponseSearchParams(resp)}); err != nil {
+		return nil, err
+	}
+
+	if resp.Id != nil && *resp.Id != "" {
+		return nil, fmt.Errorf("%w: questionnaire response ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	resp.Id = &id
+
+	patientRef := fmt.Sprintf("Patient/%s", patientID)
+	resp.Subject = &models.Reference{
+		Reference: &patientRef,
+	}
+
+	if err := s.validateAnswers(ctx, resp); err != nil {
+		return nil, err
+	}
+
+	var created *models.QuestionnaireResponse
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.repo.Create(ctx, resp); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceCreated, questionnaireResponseRef(created), created)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *QuestionnaireResponseService) Get(ctx context.Context, id string) (*models.QuestionnaireResponse, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrQuestionnaireResponseIDRequired
+	}
+
+	resp, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if resp == nil {
+		return nil, domain.ErrQuestionnaireResponseNotFound
+	}
+
+	ref := questionnaireResponseRef(resp)
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+	if err := checkConsent(ctx, s.consent, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *QuestionnaireResponseService) Update(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "QuestionnaireResponse"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if resp.Id == nil {
+		return nil, domain.ErrQuestionnaireResponseIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *resp.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrQuestionnaireResponseNotFound
+	}
+
+	ref := questionnaireResponseRef(existing)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(resp); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if mayLabel(user, ref.PatientID) != nil {
+		resp.Meta = keepSecurityLabels(resp.Meta, existing.Meta)
+	}
+
+	// The subject cannot move the response to another compartment.
+	resp.Subject = existing.Subject
+
+	// A restricted scope must also cover the response as it will be stored.
+	ref.SearchParams = questionnaireResponseSearchParams(resp)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateAnswers(ctx, resp); err != nil {
+		return nil, err
+	}
+
+	var updated *models.QuestionnaireResponse
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, resp); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, questionnaireResponseRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+// UpdateSecurityLabels adds and removes security labels of a questionnaire
+// response and returns its resulting meta.
+func (s *QuestionnaireResponseService) UpdateSecurityLabels(ctx context.Context, id string, add, remove []models.Coding) (*models.Meta, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "QuestionnaireResponse"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := validateSecurityLabels(add); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if resp == nil {
+		return nil, domain.ErrQuestionnaireResponseNotFound
+	}
+
+	ref := questionnaireResponseRef(resp)
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, ref); err != nil {
+		return nil, err
+	}
+	if err := mayLabel(user, ref.PatientID); err != nil {
+		return nil, err
+	}
+
+	resp.Meta = changeSecurityLabels(resp.Meta, add, remove)
+	var updated *models.QuestionnaireResponse
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if updated, err = s.repo.Update(ctx, resp); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceUpdated, questionnaireResponseRef(updated), updated)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated.Meta, nil
+}
+
+func (s *QuestionnaireResponseService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "QuestionnaireResponse"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrQuestionnaireResponseNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, questionnaireResponseRef(existing)); err != nil {
+		return err
+	}
+
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		return []domain.DomainEvent{domainEvent(domain.EventResourceDeleted, questionnaireResponseRef(existing), existing)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *QuestionnaireResponseService) List(ctx context.Context, search domain.QuestionnaireResponseSearch, limit, offset int) (*domain.ListResponse[models.QuestionnaireResponse], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "QuestionnaireResponse"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	restrictions, err := authorizeSearch(ctx, s.authz, user, domain.ResourceRef{Type: "QuestionnaireResponse", PatientID: search.PatientID})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, search, restrictions, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	refs := make([]domain.ResourceRef, len(items))
+	for i := range items {
+		refs[i] = questionnaireResponseRef(&items[i])
+	}
+	items, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionSearch, items, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ListResponse[models.QuestionnaireResponse]{
+		Items:    items,
+		Total:    total,
+		Withheld: withheld,
+	}, nil
+}
+
+// Extract turns the answers to questionnaire items marked for observation
+// extraction into Observations of the response subject. The observations are
+// created through the observation service, so the caller needs permission to
+// create them. Each observation carries an identifier of the answer it records
+// and is only created if no observation of the patient has it yet, so
+// extracting a response again, e.g. after a failure partway through, completes
+// the extraction instead of duplicating it.
+func (s *QuestionnaireResponseService) Extract(ctx context.Context, id string) ([]models.Observation, error) {
+	resp, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status != "completed" && resp.Status != "amended" {
+		return nil, fmt.Errorf("%w: only completed responses can be extracted", domain.ErrInvalidInput)
+	}
+
+	q, err := s.resolveQuestionnaire(ctx, resp.Questionnaire)
+	if err != nil {
+		return nil, err
+	}
+
+	patientID := patientIDFromReference(resp.Subject)
+	items := questionnaireItems(q.Item)
+	var extracted []models.Observation
+	for _, answered := range responseItems(resp.Item) {
+		item, ok := items[answered.LinkId]
+		if !ok || !isObservationExtract(item) {
+			continue
+		}
+
+		for i, answer := range answered.Answer {
+			obs := extractObservation(resp, item, answer, i)
+			existing, err := s.extractedObservation(ctx, patientID, obs)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				extracted = append(extracted, *existing)
+				continue
+			}
+			created, err := s.observations.Create(ctx, obs)
+			if err != nil {
+				return nil, err
+			}
+			extracted = append(extracted, *created)
+		}
+	}
+
+	return extracted, nil
+}
+
+// extractedObservation returns the observation an earlier extraction created
+// for the answer obs records, found by its identifier, or nil if there is none.
+func (s *QuestionnaireResponseService) extractedObservation(ctx context.Context, patientID string, obs *models.Observation) (*models.Observation, error) {
+	identifier := obs.Identifier[0]
+	search := domain.ObservationSearch{
+		PatientID:  patientID,
+		Identifier: *identifier.System + "|" + *identifier.Value,
+	}
+	found, _, err := s.obsRepo.Search(ctx, search, nil, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	return &found[0], nil
+}
+
+// resolveQuestionnaire loads the questionnaire a response answers, given
+// either as a local reference or as a canonical URL with optional version.
+func (s *QuestionnaireResponseService) resolveQuestionnaire(ctx context.Context, canonical string) (*models.Questionnaire, error) {
+	var (
+		q   *models.Questionnaire
+		err error
+	)
+	if id, ok := strings.CutPrefix(canonical, "Questionnaire/"); ok {
+		q, err = s.questionnaires.GetByID(ctx, id)
+	} else {
+		url, version, _ := strings.Cut(canonical, "|")
+		q, err = s.questionnaires.GetByURL(ctx, url, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if q == nil {
+		return nil, domain.ErrQuestionnaireRefNotFound
+	}
+	return q, nil
+}
+
+// validateAnswers checks the answers of a response against the items of the
+// questionnaire it answers. Required items must be answered once the
+// response is completed, unless they are only conditionally enabled; the
+// conditions themselves are not evaluated.
+func (s *QuestionnaireResponseService) validateAnswers(ctx context.Context, resp *models.QuestionnaireResponse) error {
+	q, err := s.resolveQuestionnaire(ctx, resp.Questionnaire)
+	if err != nil {
+		return err
+	}
+
+	items := questionnaireItems(q.Item)
+	answered := make(map[string]bool)
+	for _, ri := range responseItems(resp.Item) {
+		item, ok := items[ri.LinkId]
+		if !ok {
+			return fmt.Errorf("%w: unknown item %q", domain.ErrInvalidAnswers, ri.LinkId)
+		}
+		if len(ri.Answer) == 0 {
+			continue
+		}
+		if item.Type == "group" || item.Type == "display" {
+			return fmt.Errorf("%w: %s item %q cannot be answered", domain.ErrInvalidAnswers, item.Type, ri.LinkId)
+		}
+		if len(ri.Answer) > 1 && (item.Repeats == nil || !*item.Repeats) {
+			return fmt.Errorf("%w: item %q does not repeat", domain.ErrInvalidAnswers, ri.LinkId)
+		}
+		for _, answer := range ri.Answer {
+			if err := validateAnswer(item, answer); err != nil {
+				return fmt.Errorf("%w: item %q: %v", domain.ErrInvalidAnswers, ri.LinkId, err)
+			}
+		}
+		answered[ri.LinkId] = true
+	}
+
+	if resp.Status != "completed" && resp.Status != "amended" {
+		return nil
+	}
+	for linkID, item := range items {
+		if item.Required != nil && *item.Required && len(item.EnableWhen) == 0 && item.Type != "group" && !answered[linkID] {
+			return fmt.Errorf("%w: required item %q is not answered", domain.ErrInvalidAnswers, linkID)
+		}
+	}
+
+	return nil
+}
+
+// validateAnswer checks that an answer has the value type of its item and,
+// unless the item also allows free answers, is one of the item options.
+func validateAnswer(item models.QuestionnaireItem, answer models.QuestionnaireResponseItemAnswer) error {
+	valueType := answerValueType(answer)
+	if valueType == "" {
+		return fmt.Errorf("answer has no value")
+	}
+
+	constraint := "optionsOnly"
+	if item.AnswerConstraint != nil {
+		constraint = *item.AnswerConstraint
+	}
+
+	if len(item.AnswerOption) > 0 && isAnswerOption(item.AnswerOption, answer) {
+		return nil
+	}
+	if len(item.AnswerOption) > 0 && constraint == "optionsOnly" {
+		return fmt.Errorf("answer is not one of the options")
+	}
+	if constraint == "optionsOrString" && len(item.AnswerOption) > 0 && valueType == "string" {
+		return nil
+	}
+
+	if !itemValueTypes[item.Type][valueType] {
+		return fmt.Errorf("%s answer to a %s item", valueType, item.Type)
+	}
+	return nil
+}
+
+// itemValueTypes lists the answer value types each item type accepts.
+var itemValueTypes = map[string]map[string]bool{
+	"boolean":    {"boolean": true},
+	"decimal":    {"decimal": true},
+	"integer":    {"integer": true},
+	"date":       {"date": true},
+	"dateTime":   {"dateTime": true},
+	"time":       {"time": true},
+	"string":     {"string": true},
+	"text":       {"string": true},
+	"url":        {"uri": true},
+	"coding":     {"coding": true},
+	"attachment": {"attachment": true},
+	"reference":  {"reference": true},
+	"quantity":   {"quantity": true},
+}
+
+func answerValueType(answer models.QuestionnaireResponseItemAnswer) string {
+	switch {
+	case answer.ValueBoolean != nil:
+		return "boolean"
+	case answer.ValueDecimal != nil:
+		return "decimal"
+	case answer.ValueInteger != nil:
+		return "integer"
+	case answer.ValueDate != nil:
+		return "date"
+	case answer.ValueDateTime != nil:
+		return "dateTime"
+	case answer.ValueTime != nil:
+		return "time"
+	case answer.ValueString != nil:
+		return "string"
+	case answer.ValueUri != nil:
+		return "uri"
+	case answer.ValueAttachment != nil:
+		return "attachment"
+	case answer.ValueCoding != nil:
+		return "coding"
+	case answer.ValueQuantity != nil:
+		return "quantity"
+	case answer.ValueReference != nil:
+		return "reference"
+	}
+	return ""
+}
+
+// isAnswerOption reports whether an answer matches one of the options.
+// Codings match by system and code, references by reference.
+func isAnswerOption(options []models.QuestionnaireItemAnswerOption, answer models.QuestionnaireResponseItemAnswer) bool {
+	for _, option := range options {
+		switch {
+		case answer.ValueCoding != nil && option.ValueCoding != nil:
+			if equalPtr(answer.ValueCoding.System, option.ValueCoding.System) && equalPtr(answer.ValueCoding.Code, option.ValueCoding.Code) {
+				return true
+			}
+		case answer.ValueString != nil && option.ValueString != nil:
+			if *answer.ValueString == *option.ValueString {
+				return true
+			}
+		case answer.ValueInteger != nil && option.ValueInteger != nil:
+			if *answer.ValueInteger == *option.ValueInteger {
+				return true
+			}
+		case answer.ValueDecimal != nil && option.ValueDecimal != nil:
+			if *answer.ValueDecimal == *option.ValueDecimal {
+				return true
+			}
+		case answer.ValueDate != nil && option.ValueDate != nil:
+			if *answer.ValueDate == *option.ValueDate {
+				return true
+			}
+		case answer.ValueDateTime != nil && option.ValueDateTime != nil:
+			if *answer.ValueDateTime == *option.ValueDateTime {
+				return true
+			}
+		case answer.ValueTime != nil && option.ValueTime != nil:
+			if *answer.ValueTime == *option.ValueTime {
+				return true
+			}
+		case answer.ValueUri != nil && option.ValueUri != nil:
+			if *answer.ValueUri == *option.ValueUri {
+				return true
+			}
+		case answer.ValueReference != nil && option.ValueReference != nil:
+			if equalPtr(answer.ValueReference.Reference, option.ValueReference.Reference) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// questionnaireItems indexes the items of a questionnaire, at any depth, by
+// linkId.
+func questionnaireItems(items []models.QuestionnaireItem) map[string]models.QuestionnaireItem {
+	index := make(map[string]models.QuestionnaireItem)
+	var walk func([]models.QuestionnaireItem)
+	walk = func(items []models.QuestionnaireItem) {
+		for _, item := range items {
+			index[item.LinkId] = item
+			walk(item.Item)
+		}
+	}
+	walk(items)
+	return index
+}
+
+// responseItems flattens the items of a response, including items nested
+// in groups and under answers.
+func responseItems(items []models.QuestionnaireResponseItem) []models.QuestionnaireResponseItem {
+	var flat []models.QuestionnaireResponseItem
+	for _, item := range items {
+		flat = append(flat, item)
+		flat = append(flat, responseItems(item.Item)...)
+		for _, answer := range item.Answer {
+			flat = append(flat, responseItems(answer.Item)...)
+		}
+	}
+	return flat
+}
+
+// isObservationExtract reports whether answers to an item are extracted as
+// observations.
+func isObservationExtract(item models.QuestionnaireItem) bool {
+	for _, c := range item.Code {
+		if c.System != nil && *c.System == domain.ObservationExtractSystem {
+			return true
+		}
+	}
+	return false
+}
+
+// extractObservation builds the survey observation recording one answer.
+func extractObservation(resp *models.QuestionnaireResponse, item models.QuestionnaireItem, answer models.QuestionnaireResponseItemAnswer, index int) *models.Observation {
+	code := &models.CodeableConcept{Text: item.Text}
+	for _, c := range item.Code {
+		if c.System == nil || *c.System != domain.ObservationExtractSystem {
+			code.Coding = append(code.Coding, c)
+		}
+	}
+
+	system := questionnaireResponseSystem
+	categorySystem := "http://terminology.hl7.org/CodeSystem/observation-category"
+	category := "survey"
+	value := fmt.Sprintf("QuestionnaireResponse/%s#%s", *resp.Id, item.LinkId)
+	if index > 0 {
+		value = fmt.Sprintf("%s/%d", value, index)
+	}
+
+	obs := &models.Observation{
+		ResourceType: "Observation",
+		Identifier:   []models.Identifier{{System: &system, Value: &value}},
+		Status:       "final",
+		Category: []models.CodeableConcept{{Coding: []models.Coding{
+			{System: &categorySystem, Code: &category},
+		}}},
+		Code:              code,
+		Subject:           resp.Subject,
+		Encounter:         resp.Encounter,
+		EffectiveDateTime: resp.Authored,
+	}
+
+	switch {
+	case answer.ValueBoolean != nil:
+		obs.ValueBoolean = answer.ValueBoolean
+	case answer.ValueInteger != nil:
+		obs.ValueInteger = answer.ValueInteger
+	case answer.ValueDecimal != nil:
+		obs.ValueQuantity = &models.Quantity{Value: answer.ValueDecimal}
+	case answer.ValueQuantity != nil:
+		obs.ValueQuantity = answer.ValueQuantity
+	case answer.ValueString != nil:
+		obs.ValueString = answer.ValueString
+	case answer.ValueUri != nil:
+		obs.ValueString = answer.ValueUri
+	case answer.ValueCoding != nil:
+		obs.ValueCodeableConcept = &models.CodeableConcept{Coding: []models.Coding{*answer.ValueCoding}}
+	case answer.ValueDate != nil:
+		obs.ValueDateTime = answer.ValueDate
+	case answer.ValueDateTime != nil:
+		obs.ValueDateTime = answer.ValueDateTime
+	case answer.ValueTime != nil:
+		obs.ValueTime = answer.ValueTime
+	case answer.ValueAttachment != nil:
+		obs.ValueAttachment = answer.ValueAttachment
+	}
+
+	return obs
+}
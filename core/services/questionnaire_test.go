@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testQuestionnaireID  = "q-123"
+	testQuestionnaireURL = "https://forms.example.com/intake"
+	testResponseID       = "qr-123"
+)
+
+func createTestQuestionnaire(id string) *models.Questionnaire {
+	q := &models.Questionnaire{
+		ResourceType: "Questionnaire",
+		Url:          strPtr(testQuestionnaireURL),
+		Status:       "active",
+		Item: []models.QuestionnaireItem{
+			{
+				LinkId: "smoker",
+				Text:   strPtr("Do you smoke?"),
+				Type:   "boolean",
+				Code: []models.Coding{
+					coding(domain.ObservationExtractSystem, "extract"),
+					coding("http://loinc.org", "72166-2"),
+				},
+				Required: ptr.To(true),
+			},
+			{
+				LinkId: "mood",
+				Type:   "coding",
+				AnswerOption: []models.QuestionnaireItemAnswerOption{
+					{ValueCoding: &models.Coding{System: strPtr("http://snomed.info/sct"), Code: strPtr("good")}},
+					{ValueCoding: &models.Coding{System: strPtr("http://snomed.info/sct"), Code: strPtr("bad")}},
+				},
+			},
+		},
+	}
+	if id != "" {
+		q.Id = strPtr(id)
+	}
+	return q
+}
+
+func createTestResponse(id, status string, items ...models.QuestionnaireResponseItem) *models.QuestionnaireResponse {
+	resp := &models.QuestionnaireResponse{
+		ResourceType:  "QuestionnaireResponse",
+		Questionnaire: testQuestionnaireURL,
+		Status:        status,
+		Subject:       &models.Reference{Reference: strPtr("Patient/" + testPatientID)},
+		Authored:      strPtr("2024-06-01T10:00:00Z"),
+		Item:          items,
+	}
+	if id != "" {
+		resp.Id = strPtr(id)
+	}
+	return resp
+}
+
+func smokerAnswer(values ...bool) models.QuestionnaireResponseItem {
+	item := models.QuestionnaireResponseItem{LinkId: "smoker"}
+	for i := range values {
+		item.Answer = append(item.Answer, models.QuestionnaireResponseItemAnswer{ValueBoolean: &values[i]})
+	}
+	return item
+}
+
+func newTestQuestionnaireResponseService(ctrl *gomock.Controller, repo ports.QuestionnaireResponseRepository, questionnaires ports.QuestionnaireRepository, obsRepo ports.ObservationRepository, observations ports.ObservationService) *QuestionnaireResponseService {
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	return NewQuestionnaireResponseService(repo, questionnaires, obsRepo, observations, authz, permitAllConsents(ctrl), discardOutbox(ctrl), validator.NewQuestionnaireResponseValidator())
+}
+
+func TestQuestionnaireService_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		q             *models.Questionnaire
+		expectedError error
+	}{
+		{
+			name: "success path - administrator publishes",
+			user: domain.Identity{UserID: "admin-1", SystemClient: true, Scopes: []string{"system/Questionnaire.c"}},
+			q:    createTestQuestionnaire(""),
+		},
+		{
+			name:          "error - patient",
+			user:          createTestIdentity(testPatientID, testUserID, []string{"patient/*.cruds"}),
+			q:             createTestQuestionnaire(""),
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - duplicate linkId",
+			user: domain.Identity{UserID: "admin-1", SystemClient: true, Scopes: []string{"system/Questionnaire.c"}},
+			q: func() *models.Questionnaire {
+				q := createTestQuestionnaire("")
+				q.Item[1].LinkId = "smoker"
+				return q
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockQuestionnaireRepository(ctrl)
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, q *models.Questionnaire) (*models.Questionnaire, error) {
+						return q, nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewQuestionnaireService(repo, authz, validator.NewQuestionnaireValidator())
+			result, err := service.Create(identity.WithCtx(context.Background(), tt.user), tt.q)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result.Id)
+		})
+	}
+}
+
+func TestQuestionnaireResponseService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/QuestionnaireResponse.c"})
+
+	good := models.QuestionnaireResponseItem{LinkId: "mood", Answer: []models.QuestionnaireResponseItemAnswer{{
+		ValueCoding: &models.Coding{System: strPtr("http://snomed.info/sct"), Code: strPtr("good")},
+	}}}
+
+	tests := []struct {
+		name          string
+		resp          *models.QuestionnaireResponse
+		questionnaire *models.Questionnaire
+		expectedError error
+	}{
+		{
+			name:          "success path",
+			resp:          createTestResponse("", "completed", smokerAnswer(false), good),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+		},
+		{
+			name:          "success path - required item may be open while in progress",
+			resp:          createTestResponse("", "in-progress", good),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+		},
+		{
+			name:          "error - required item not answered",
+			resp:          createTestResponse("", "completed", good),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+			expectedError: domain.ErrInvalidAnswers,
+		},
+		{
+			name:          "error - unknown item",
+			resp:          createTestResponse("", "in-progress", models.QuestionnaireResponseItem{LinkId: "height"}),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+			expectedError: domain.ErrInvalidAnswers,
+		},
+		{
+			name:          "error - several answers to a non-repeating item",
+			resp:          createTestResponse("", "completed", smokerAnswer(true, false)),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+			expectedError: domain.ErrInvalidAnswers,
+		},
+		{
+			name: "error - answer of the wrong type",
+			resp: createTestResponse("", "in-progress", models.QuestionnaireResponseItem{LinkId: "smoker", Answer: []models.QuestionnaireResponseItemAnswer{{
+				ValueString: strPtr("sometimes"),
+			}}}),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+			expectedError: domain.ErrInvalidAnswers,
+		},
+		{
+			name: "error - answer not among the options",
+			resp: createTestResponse("", "in-progress", models.QuestionnaireResponseItem{LinkId: "mood", Answer: []models.QuestionnaireResponseItemAnswer{{
+				ValueCoding: &models.Coding{System: strPtr("http://snomed.info/sct"), Code: strPtr("meh")},
+			}}}),
+			questionnaire: createTestQuestionnaire(testQuestionnaireID),
+			expectedError: domain.ErrInvalidAnswers,
+		},
+		{
+			name:          "error - questionnaire not found",
+			resp:          createTestResponse("", "completed", smokerAnswer(false)),
+			expectedError: domain.ErrQuestionnaireRefNotFound,
+		},
+		{
+			name: "error - missing status",
+			resp:          createTestResponse("", "", smokerAnswer(false)),
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockQuestionnaireResponseRepository(ctrl)
+			questionnaires := ports.NewMockQuestionnaireRepository(ctrl)
+
+			if tt.resp.Status != "" {
+				questionnaires.EXPECT().GetByURL(gomock.Any(), testQuestionnaireURL, "").Return(tt.questionnaire, nil)
+			}
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, resp *models.QuestionnaireResponse) (*models.QuestionnaireResponse, error) {
+						return resp, nil
+					})
+			}
+
+			service := newTestQuestionnaireResponseService(ctrl, repo, questionnaires, ports.NewMockObservationRepository(ctrl), ports.NewMockObservationService(ctrl))
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.resp)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Patient/"+testPatientID, *result.Subject.Reference)
+		})
+	}
+}
+
+func TestQuestionnaireResponseService_Extract(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/QuestionnaireResponse.r", "patient/Observation.c"})
+
+	smokerID := "QuestionnaireResponse/" + testResponseID + "#smoker"
+	searchFor := func(value string) domain.ObservationSearch {
+		return domain.ObservationSearch{PatientID: testPatientID, Identifier: questionnaireResponseSystem + "|" + value}
+	}
+	stored := func(id, value string) []models.Observation {
+		obs := createTestObservation(id, testPatientID)
+		obs.Identifier = []models.Identifier{{System: strPtr(questionnaireResponseSystem), Value: strPtr(value)}}
+		return []models.Observation{*obs}
+	}
+	created := func(id string) func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+		return func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+			obs.Id = strPtr(id)
+			return obs, nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		status        string
+		smoker        []bool
+		setupMocks    func(*ports.MockObservationRepository, *ports.MockObservationService)
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:   "success path",
+			status: "completed",
+			smoker: []bool{true},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, observations *ports.MockObservationService) {
+				obsRepo.EXPECT().Search(gomock.Any(), searchFor(smokerID), gomock.Nil(), 1, 0).Return(nil, int64(0), nil)
+				observations.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, obs *models.Observation) (*models.Observation, error) {
+						assert.Equal(t, "final", obs.Status)
+						assert.Equal(t, "survey", *obs.Category[0].Coding[0].Code)
+						require.Len(t, obs.Code.Coding, 1, "the extraction marker is not part of the code")
+						assert.Equal(t, "72166-2", *obs.Code.Coding[0].Code)
+						assert.Equal(t, "Patient/"+testPatientID, *obs.Subject.Reference)
+						assert.Equal(t, "2024-06-01T10:00:00Z", *obs.EffectiveDateTime)
+						assert.True(t, *obs.ValueBoolean)
+						assert.Equal(t, smokerID, *obs.Identifier[0].Value)
+						return created(testObsID)(ctx, obs)
+					})
+			},
+			expectedIDs: []string{testObsID},
+		},
+		{
+			name:   "success path - extracted answer is not created again",
+			status: "completed",
+			smoker: []bool{true},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, observations *ports.MockObservationService) {
+				obsRepo.EXPECT().Search(gomock.Any(), searchFor(smokerID), gomock.Nil(), 1, 0).Return(stored(testObsID, smokerID), int64(1), nil)
+			},
+			expectedIDs: []string{testObsID},
+		},
+		{
+			name:   "success path - extracting again completes a partial extraction",
+			status: "completed",
+			smoker: []bool{true, false},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, observations *ports.MockObservationService) {
+				obsRepo.EXPECT().Search(gomock.Any(), searchFor(smokerID), gomock.Nil(), 1, 0).Return(stored(testObsID, smokerID), int64(1), nil)
+				obsRepo.EXPECT().Search(gomock.Any(), searchFor(smokerID+"/1"), gomock.Nil(), 1, 0).Return(nil, int64(0), nil)
+				observations.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(created("obs-456"))
+			},
+			expectedIDs: []string{testObsID, "obs-456"},
+		},
+		{
+			name:   "error - failure partway through",
+			status: "completed",
+			smoker: []bool{true, false},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, observations *ports.MockObservationService) {
+				obsRepo.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Nil(), 1, 0).Return(nil, int64(0), nil).Times(2)
+				gomock.InOrder(
+					observations.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(created(testObsID)),
+					observations.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInternal),
+				)
+			},
+			expectedError: domain.ErrInternal,
+		},
+		{
+			name:          "error - response in progress",
+			status:        "in-progress",
+			smoker:        []bool{true},
+			setupMocks:    func(*ports.MockObservationRepository, *ports.MockObservationService) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockQuestionnaireResponseRepository(ctrl)
+			questionnaires := ports.NewMockQuestionnaireRepository(ctrl)
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			observations := ports.NewMockObservationService(ctrl)
+
+			mood := models.QuestionnaireResponseItem{LinkId: "mood", Answer: []models.QuestionnaireResponseItemAnswer{{
+				ValueCoding: &models.Coding{System: strPtr("http://snomed.info/sct"), Code: strPtr("good")},
+			}}}
+			repo.EXPECT().
+				GetByID(gomock.Any(), testResponseID).
+				Return(createTestResponse(testResponseID, tt.status, smokerAnswer(tt.smoker...), mood), nil)
+			if tt.status == "completed" {
+				questionnaires.EXPECT().GetByURL(gomock.Any(), testQuestionnaireURL, "").Return(createTestQuestionnaire(testQuestionnaireID), nil)
+			}
+			tt.setupMocks(obsRepo, observations)
+
+			service := newTestQuestionnaireResponseService(ctrl, repo, questionnaires, obsRepo, observations)
+			result, err := service.Extract(identity.WithCtx(context.Background(), patient), testResponseID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			ids := make([]string, len(result))
+			for i := range result {
+				ids[i] = *result[i].Id
+			}
+			assert.Equal(t, tt.expectedIDs, ids, "only marked items are extracted")
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type RelatedPersonService struct {
+	repo           ports.RelatedPersonRepository
+	delegationRepo ports.DelegationRepository
+	authz          ports.Authorizer
+	validator      *validator.RelatedPersonValidator
+}
+
+func NewRelatedPersonService(
+	repo ports.RelatedPersonRepository,
+	delegationRepo ports.DelegationRepository,
+	authz ports.Authorizer,
+	v *validator.RelatedPersonValidator,
+) *RelatedPersonService {
+	return &RelatedPersonService{
+		repo:           repo,
+		delegationRepo: delegationRepo,
+		authz:          authz,
+		validator:      v,
+	}
+}
+
+func (s *RelatedPersonService) Create(ctx context.Context, person *models.RelatedPerson) (*models.RelatedPerson, error) {
+	if err := s.validator.Validate(person); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "RelatedPerson"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	ref := domain.ResourceRef{Type: "RelatedPerson", PatientID: patientIDFromReference(person.Patient)}
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, ref); err != nil {
+		return nil, err
+	}
+
+	if person.Id != nil && *person.Id != "" {
+		return nil, fmt.Errorf("%w: related person ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	person.Id = &id
+
+	created, err := s.repo.Create(ctx, person)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *RelatedPersonService) Get(ctx context.Context, id string) (*models.RelatedPerson, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionRead, "RelatedPerson"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	person, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if person == nil {
+		return nil, domain.ErrRelatedPersonNotFound
+	}
+
+	ref := domain.ResourceRef{Type: "RelatedPerson", ID: id, PatientID: patientIDFromReference(person.Patient)}
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+		return nil, err
+	}
+
+	return person, nil
+}
+
+// Delete removes the related person together with every delegation that was
+// granted through it.
+func (s *RelatedPersonService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "RelatedPerson"); !decision.Allowed {
+		return decision.Err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrRelatedPersonNotFound
+	}
+
+	ref := domain.ResourceRef{Type: "RelatedPerson", ID: id, PatientID: patientIDFromReference(existing.Patient)}
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, ref); err != nil {
+		return err
+	}
+
+	if err := s.delegationRepo.DeleteByRelatedPerson(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *RelatedPersonService) List(ctx context.Context, patientID string, limit, offset int) (*domain.ListResponse[models.RelatedPerson], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "RelatedPerson"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if patientID == "" {
+		patientID = user.CompartmentID()
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionSearch, domain.ResourceRef{Type: "RelatedPerson", PatientID: patientID}); err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.Search(ctx, patientID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.RelatedPerson]{
+		Items: items,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func TestRelatedPersonService_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		person         *models.RelatedPerson
+		setupMocks     func(*ports.MockRelatedPersonRepository)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *models.RelatedPerson, error)
+	}{
+		{
+			name:   "success path",
+			person: createTestRelatedPerson("", testPatientID),
+			setupMocks: func(repo *ports.MockRelatedPersonRepository) {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p *models.RelatedPerson) (*models.RelatedPerson, error) {
+						return p, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			validateResult: func(t *testing.T, p *models.RelatedPerson, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.Id)
+				assert.NotEmpty(t, *p.Id)
+			},
+		},
+		{
+			name:          "error - other patient",
+			person:        createTestRelatedPerson("", "other-patient"),
+			setupMocks:    func(*ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name:          "error - caregiver cannot add related persons",
+			person:        createTestRelatedPerson("", testPatientID),
+			setupMocks:    func(*ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestCaregiverIdentity(testPatientID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - missing patient",
+			person: &models.RelatedPerson{
+				ResourceType: "RelatedPerson",
+				Name:         []models.HumanName{{Family: strPtr("Doe")}},
+			},
+			setupMocks: func(*ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name:       "error - ID provided",
+			person:     createTestRelatedPerson(testRelatedPersonID, testPatientID),
+			setupMocks: func(*ports.MockRelatedPersonRepository) {},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockRelatedPersonRepository(ctrl)
+			tt.setupMocks(repo)
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewRelatedPersonService(repo, ports.NewMockDelegationRepository(ctrl), authz, validator.NewRelatedPersonValidator())
+
+			result, err := service.Create(tt.setupContext(), tt.person)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
+
+func TestRelatedPersonService_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*ports.MockRelatedPersonRepository, *ports.MockDelegationRepository)
+		setupContext  func() context.Context
+		expectedError error
+	}{
+		{
+			name: "success path - delegations are revoked",
+			setupMocks: func(repo *ports.MockRelatedPersonRepository, delegationRepo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(createTestRelatedPerson(testRelatedPersonID, testPatientID), nil)
+				delegationRepo.EXPECT().
+					DeleteByRelatedPerson(gomock.Any(), testRelatedPersonID).
+					Return(nil)
+				repo.EXPECT().
+					Delete(gomock.Any(), testRelatedPersonID).
+					Return(nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+		},
+		{
+			name: "error - not found",
+			setupMocks: func(repo *ports.MockRelatedPersonRepository, delegationRepo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(nil, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrRelatedPersonNotFound,
+		},
+		{
+			name: "error - other patient",
+			setupMocks: func(repo *ports.MockRelatedPersonRepository, delegationRepo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(createTestRelatedPerson(testRelatedPersonID, "other-patient"), nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+		},
+		{
+			name: "error - repository error",
+			setupMocks: func(repo *ports.MockRelatedPersonRepository, delegationRepo *ports.MockDelegationRepository) {
+				repo.EXPECT().
+					GetByID(gomock.Any(), testRelatedPersonID).
+					Return(nil, errors.New("database error"))
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.write"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockRelatedPersonRepository(ctrl)
+			delegationRepo := ports.NewMockDelegationRepository(ctrl)
+			tt.setupMocks(repo, delegationRepo)
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewRelatedPersonService(repo, delegationRepo, authz, validator.NewRelatedPersonValidator())
+
+			err := service.Delete(tt.setupContext(), testRelatedPersonID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,546 @@
+package services
+
+import (
+	"net/url"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// observationRef describes a stored observation for authorization and
+// consent checks.
+func observationRef(obs *models.Observation) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Observation",
+		PatientID:    patientIDFromReference(obs.Subject),
+		SearchParams: observationSearchParams(obs),
+	}
+	if obs.Id != nil {
+		ref.ID = *obs.Id
+	}
+	return ref
+}
+
+// documentRef describes a stored document reference for authorization and
+// consent checks.
+func documentRef(doc *models.DocumentReference) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "DocumentReference",
+		PatientID:    patientIDFromReference(doc.Subject),
+		SearchParams: documentSearchParams(doc),
+	}
+	if doc.Id != nil {
+		ref.ID = *doc.Id
+	}
+	return ref
+}
+
+// conditionRef describes a stored condition for authorization and consent
+// checks.
+func conditionRef(cond *models.Condition) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Condition",
+		PatientID:    patientIDFromReference(cond.Subject),
+		SearchParams: conditionSearchParams(cond),
+	}
+	if cond.Id != nil {
+		ref.ID = *cond.Id
+	}
+	return ref
+}
+
+// medicationStatementRef describes a stored medication statement for
+// authorization and consent checks.
+func medicationStatementRef(ms *models.MedicationStatement) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "MedicationStatement",
+		PatientID:    patientIDFromReference(ms.Subject),
+		SearchParams: medicationStatementSearchParams(ms),
+	}
+	if ms.Id != nil {
+		ref.ID = *ms.Id
+	}
+	return ref
+}
+
+// medicationRequestRef describes a stored medication request for
+// authorization and consent checks.
+func medicationRequestRef(mr *models.MedicationRequest) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "MedicationRequest",
+		PatientID:    patientIDFromReference(mr.Subject),
+		SearchParams: medicationRequestSearchParams(mr),
+	}
+	if mr.Id != nil {
+		ref.ID = *mr.Id
+	}
+	return ref
+}
+
+// allergyIntoleranceRef describes a stored allergy intolerance for
+// authorization and consent checks.
+func allergyIntoleranceRef(ai *models.AllergyIntolerance) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "AllergyIntolerance",
+		PatientID:    patientIDFromReference(ai.Patient),
+		SearchParams: allergyIntoleranceSearchParams(ai),
+	}
+	if ai.Id != nil {
+		ref.ID = *ai.Id
+	}
+	return ref
+}
+
+// immunizationRef describes a stored immunization for authorization and
+// consent checks.
+func immunizationRef(imm *models.Immunization) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Immunization",
+		PatientID:    patientIDFromReference(imm.Patient),
+		SearchParams: immunizationSearchParams(imm),
+	}
+	if imm.Id != nil {
+		ref.ID = *imm.Id
+	}
+	return ref
+}
+
+// diagnosticReportRef describes a stored diagnostic report for authorization
+// and consent checks.
+func diagnosticReportRef(report *models.DiagnosticReport) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "DiagnosticReport",
+		PatientID:    patientIDFromReference(report.Subject),
+		SearchParams: diagnosticReportSearchParams(report),
+	}
+	if report.Id != nil {
+		ref.ID = *report.Id
+	}
+	return ref
+}
+
+// appointmentRef describes a stored appointment for authorization and
+// consent checks.
+func appointmentRef(appt *models.Appointment) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Appointment",
+		PatientID:    patientIDFromReference(appt.Subject),
+		SearchParams: appointmentSearchParams(appt),
+	}
+	if appt.Id != nil {
+		ref.ID = *appt.Id
+	}
+	return ref
+}
+
+// procedureRef describes a stored procedure for authorization and consent
+// checks.
+func procedureRef(proc *models.Procedure) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Procedure",
+		PatientID:    patientIDFromReference(proc.Subject),
+		SearchParams: procedureSearchParams(proc),
+	}
+	if proc.Id != nil {
+		ref.ID = *proc.Id
+	}
+	return ref
+}
+
+// familyMemberHistoryRef describes a stored family member history for
+// authorization and consent checks.
+func familyMemberHistoryRef(fmh *models.FamilyMemberHistory) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "FamilyMemberHistory",
+		PatientID:    patientIDFromReference(fmh.Patient),
+		SearchParams: familyMemberHistorySearchParams(fmh),
+	}
+	if fmh.Id != nil {
+		ref.ID = *fmh.Id
+	}
+	return ref
+}
+
+// goalRef describes a stored goal for authorization and consent checks.
+func goalRef(goal *models.Goal) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Goal",
+		PatientID:    patientIDFromReference(goal.Subject),
+		SearchParams: goalSearchParams(goal),
+	}
+	if goal.Id != nil {
+		ref.ID = *goal.Id
+	}
+	return ref
+}
+
+// carePlanRef describes a stored care plan for authorization and consent
+// checks.
+func carePlanRef(plan *models.CarePlan) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "CarePlan",
+		PatientID:    patientIDFromReference(plan.Subject),
+		SearchParams: carePlanSearchParams(plan),
+	}
+	if plan.Id != nil {
+		ref.ID = *plan.Id
+	}
+	return ref
+}
+
+// compositionRef describes a stored composition for authorization and
+// consent checks.
+func compositionRef(comp *models.Composition) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Composition",
+		PatientID:    patientIDFromReference(compositionSubject(comp)),
+		SearchParams: compositionSearchParams(comp),
+	}
+	if comp.Id != nil {
+		ref.ID = *comp.Id
+	}
+	return ref
+}
+
+// encounterRef describes a stored encounter for authorization and consent
+// checks.
+func encounterRef(enc *models.Encounter) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "Encounter",
+		PatientID:    patientIDFromReference(enc.Subject),
+		SearchParams: encounterSearchParams(enc),
+	}
+	if enc.Id != nil {
+		ref.ID = *enc.Id
+	}
+	return ref
+}
+
+// questionnaireRef describes a published questionnaire for authorization
+// checks. Questionnaires are in no patient compartment.
+func questionnaireRef(q *models.Questionnaire) domain.ResourceRef {
+	ref := domain.ResourceRef{Type: "Questionnaire"}
+	if q.Id != nil {
+		ref.ID = *q.Id
+	}
+	return ref
+}
+
+// subscriptionTopicRef describes a published subscription topic for
+// authorization checks. Topics are in no patient compartment.
+func subscriptionTopicRef(topic *models.SubscriptionTopic) domain.ResourceRef {
+	ref := domain.ResourceRef{Type: "SubscriptionTopic"}
+	if topic.Id != nil {
+		ref.ID = *topic.Id
+	}
+	return ref
+}
+
+// subscriptionRef describes a stored subscription for authorization checks.
+// Subscriptions belong to the user that created them.
+func subscriptionRef(record *domain.SubscriptionRecord) domain.ResourceRef {
+	ref := domain.ResourceRef{Type: "Subscription", OwnerID: record.Owner.UserID}
+	if record.Subscription.Id != nil {
+		ref.ID = *record.Subscription.Id
+	}
+	return ref
+}
+
+// questionnaireResponseRef describes a stored questionnaire response for
+// authorization and consent checks.
+func questionnaireResponseRef(resp *models.QuestionnaireResponse) domain.ResourceRef {
+	ref := domain.ResourceRef{
+		Type:         "QuestionnaireResponse",
+		PatientID:    patientIDFromReference(resp.Subject),
+		SearchParams: questionnaireResponseSearchParams(resp),
+	}
+	if resp.Id != nil {
+		ref.ID = *resp.Id
+	}
+	return ref
+}
+
+// organizationRef describes a directory organization for authorization
+// checks. Private entries are in the compartment of their patient.
+func organizationRef(org *models.Organization) domain.ResourceRef {
+	ref := domain.ResourceRef{Type: "Organization", PatientID: directoryOwner(org.Meta)}
+	if org.Id != nil {
+		ref.ID = *org.Id
+	}
+	return ref
+}
+
+// locationRef describes a directory location for authorization checks.
+// Private entries are in the compartment of their patient.
+func locationRef(loc *models.Location) domain.ResourceRef {
+	ref := domain.ResourceRef{Type: "Location", PatientID: directoryOwner(loc.Meta)}
+	if loc.Id != nil {
+		ref.ID = *loc.Id
+	}
+	return ref
+}
+
+// observationSearchParams returns the token search parameter values of an
+// observation that SMART scopes may be restricted by.
+func observationSearchParams(obs *models.Observation) url.Values {
+	if obs == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range obs.Category {
+		params["category"] = append(params["category"], tokenValues(&obs.Category[i])...)
+	}
+	params["code"] = tokenValues(obs.Code)
+	params["_security"] = securityLabels(obs.Meta)
+	return params
+}
+
+// documentSearchParams returns the token search parameter values of a
+// document reference that SMART scopes may be restricted by.
+func documentSearchParams(doc *models.DocumentReference) url.Values {
+	if doc == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range doc.Category {
+		params["category"] = append(params["category"], tokenValues(&doc.Category[i])...)
+	}
+	params["type"] = tokenValues(doc.Type)
+	params["_security"] = securityLabels(doc.Meta)
+	return params
+}
+
+// conditionSearchParams returns the token search parameter values of a
+// condition that SMART scopes may be restricted by.
+func conditionSearchParams(cond *models.Condition) url.Values {
+	if cond == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range cond.Category {
+		params["category"] = append(params["category"], tokenValues(&cond.Category[i])...)
+	}
+	params["code"] = tokenValues(cond.Code)
+	params["clinical-status"] = tokenValues(cond.ClinicalStatus)
+	params["_security"] = securityLabels(cond.Meta)
+	return params
+}
+
+// medicationStatementSearchParams returns the token search parameter values
+// of a medication statement that SMART scopes may be restricted by.
+func medicationStatementSearchParams(ms *models.MedicationStatement) url.Values {
+	if ms == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range ms.Category {
+		params["category"] = append(params["category"], tokenValues(&ms.Category[i])...)
+	}
+	params["code"] = medicationCodeValues(ms.Medication)
+	params["_security"] = securityLabels(ms.Meta)
+	return params
+}
+
+// medicationRequestSearchParams returns the token search parameter values of
+// a medication request that SMART scopes may be restricted by.
+func medicationRequestSearchParams(mr *models.MedicationRequest) url.Values {
+	if mr == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range mr.Category {
+		params["category"] = append(params["category"], tokenValues(&mr.Category[i])...)
+	}
+	params["code"] = medicationCodeValues(mr.Medication)
+	params["_security"] = securityLabels(mr.Meta)
+	return params
+}
+
+// allergyIntoleranceSearchParams returns the token search parameter values
+// of an allergy intolerance that SMART scopes may be restricted by.
+func allergyIntoleranceSearchParams(ai *models.AllergyIntolerance) url.Values {
+	if ai == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["code"] = tokenValues(ai.Code)
+	params["clinical-status"] = tokenValues(ai.ClinicalStatus)
+	params["_security"] = securityLabels(ai.Meta)
+	return params
+}
+
+// immunizationSearchParams returns the token search parameter values of an
+// immunization that SMART scopes may be restricted by.
+func immunizationSearchParams(imm *models.Immunization) url.Values {
+	if imm == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["vaccine-code"] = tokenValues(imm.VaccineCode)
+	params["_security"] = securityLabels(imm.Meta)
+	return params
+}
+
+// diagnosticReportSearchParams returns the token search parameter values of
+// a diagnostic report that SMART scopes may be restricted by.
+func diagnosticReportSearchParams(report *models.DiagnosticReport) url.Values {
+	if report == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range report.Category {
+		params["category"] = append(params["category"], tokenValues(&report.Category[i])...)
+	}
+	params["code"] = tokenValues(report.Code)
+	params["_security"] = securityLabels(report.Meta)
+	return params
+}
+
+// appointmentSearchParams returns the token search parameter values of an
+// appointment that SMART scopes may be restricted by.
+func appointmentSearchParams(appt *models.Appointment) url.Values {
+	if appt == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["_security"] = securityLabels(appt.Meta)
+	return params
+}
+
+// procedureSearchParams returns the token search parameter values of a
+// procedure that SMART scopes may be restricted by.
+func procedureSearchParams(proc *models.Procedure) url.Values {
+	if proc == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["code"] = tokenValues(proc.Code)
+	params["_security"] = securityLabels(proc.Meta)
+	return params
+}
+
+// familyMemberHistorySearchParams returns the token search parameter values
+// of a family member history that SMART scopes may be restricted by.
+func familyMemberHistorySearchParams(fmh *models.FamilyMemberHistory) url.Values {
+	if fmh == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["relationship"] = tokenValues(fmh.Relationship)
+	params["_security"] = securityLabels(fmh.Meta)
+	return params
+}
+
+// goalSearchParams returns the token search parameter values of a goal that
+// SMART scopes may be restricted by.
+func goalSearchParams(goal *models.Goal) url.Values {
+	if goal == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range goal.Category {
+		params["category"] = append(params["category"], tokenValues(&goal.Category[i])...)
+	}
+	params["_security"] = securityLabels(goal.Meta)
+	return params
+}
+
+// carePlanSearchParams returns the token search parameter values of a care
+// plan that SMART scopes may be restricted by.
+func carePlanSearchParams(plan *models.CarePlan) url.Values {
+	if plan == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range plan.Category {
+		params["category"] = append(params["category"], tokenValues(&plan.Category[i])...)
+	}
+	params["_security"] = securityLabels(plan.Meta)
+	return params
+}
+
+// compositionSearchParams returns the token search parameter values of a
+// composition that SMART scopes may be restricted by.
+func compositionSearchParams(comp *models.Composition) url.Values {
+	if comp == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["type"] = tokenValues(comp.Type)
+	for i := range comp.Category {
+		params["category"] = append(params["category"], tokenValues(&comp.Category[i])...)
+	}
+	params["_security"] = securityLabels(comp.Meta)
+	return params
+}
+
+// encounterSearchParams returns the token search parameter values of an
+// encounter that SMART scopes may be restricted by.
+func encounterSearchParams(enc *models.Encounter) url.Values {
+	if enc == nil {
+		return nil
+	}
+	params := url.Values{}
+	for i := range enc.Class {
+		params["class"] = append(params["class"], tokenValues(&enc.Class[i])...)
+	}
+	for _, reason := range enc.Reason {
+		for _, value := range reason.Value {
+			params["reason-code"] = append(params["reason-code"], tokenValues(value.Concept)...)
+		}
+	}
+	params["_security"] = securityLabels(enc.Meta)
+	return params
+}
+
+// questionnaireResponseSearchParams returns the token search parameter values
+// of a questionnaire response that SMART scopes may be restricted by.
+func questionnaireResponseSearchParams(resp *models.QuestionnaireResponse) url.Values {
+	if resp == nil {
+		return nil
+	}
+	params := url.Values{}
+	params["_security"] = securityLabels(resp.Meta)
+	return params
+}
+
+// medicationCodeValues returns the token values of a medication given as a
+// concept. Medications given by reference have no code to match.
+func medicationCodeValues(medication *models.CodeableReference) []string {
+	if medication == nil {
+		return nil
+	}
+	return tokenValues(medication.Concept)
+}
+
+// tokenValues lists every form a token parameter may match a concept by:
+// the bare code and "system|code".
+func tokenValues(concept *models.CodeableConcept) []string {
+	if concept == nil {
+		return nil
+	}
+	return codingValues(concept.Coding)
+}
+
+// securityLabels returns the token values of the security labels in meta.
+func securityLabels(meta *models.Meta) []string {
+	if meta == nil {
+		return nil
+	}
+	return codingValues(meta.Security)
+}
+
+func codingValues(codings []models.Coding) []string {
+	var values []string
+	for _, coding := range codings {
+		if coding.Code == nil {
+			continue
+		}
+		values = append(values, *coding.Code)
+		if coding.System != nil {
+			values = append(values, *coding.System+"|"+*coding.Code)
+		}
+	}
+	return values
+}
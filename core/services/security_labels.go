@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// mayLabel checks that the user may change the security labels of a resource
+// in the patient's compartment. Labels decide who else sees the resource, so
+// only the patient, or a caregiver acting for them, may change them.
+func mayLabel(user domain.Identity, patientID string) error {
+	if user.IsPractitioner() || user.CompartmentID() != patientID {
+		return fmt.Errorf("%w: only the patient may change security labels", domain.ErrAccessDenied)
+	}
+	return nil
+}
+
+func validateSecurityLabels(labels []models.Coding) error {
+	for _, label := range labels {
+		if label.Code == nil || *label.Code == "" {
+			return fmt.Errorf("%w: security label code is required", domain.ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// changeSecurityLabels returns meta with the labels in add applied once and
+// those in remove dropped. A label to remove without a system removes the
+// code from every system.
+func changeSecurityLabels(meta *models.Meta, add, remove []models.Coding) *models.Meta {
+	changed := models.Meta{}
+	if meta != nil {
+		changed = *meta
+	}
+
+	labels := make([]models.Coding, 0, len(changed.Security)+len(add))
+	for _, label := range changed.Security {
+		if !labelListed(remove, label) {
+			labels = append(labels, label)
+		}
+	}
+	for _, label := range add {
+		if !labelListed(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	changed.Security = labels
+	return &changed
+}
+
+// keepSecurityLabels returns meta with the security labels of stored, for
+// updates by users that may not change them.
+func keepSecurityLabels(meta, stored *models.Meta) *models.Meta {
+	var labels []models.Coding
+	if stored != nil {
+		labels = stored.Security
+	}
+	if meta == nil {
+		if len(labels) == 0 {
+			return nil
+		}
+		meta = &models.Meta{}
+	}
+	meta.Security = labels
+	return meta
+}
+
+func labelListed(labels []models.Coding, label models.Coding) bool {
+	for _, l := range labels {
+		if l.Code == nil || label.Code == nil || *l.Code != *label.Code {
+			continue
+		}
+		if l.System == nil || label.System == nil || *l.System == *label.System {
+			return true
+		}
+	}
+	return false
+}
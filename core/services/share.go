@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
 	"github.com/gruzdev-dev/codex-documents/core/ports"
@@ -11,21 +15,61 @@ import (
 	models "github.com/gruzdev-dev/fhir/r5"
 )
 
+// sharedDownloadTTL bounds how long a download URL handed to a share
+// recipient stays usable.
+const sharedDownloadTTL = 5 * time.Minute
+
 type ShareService struct {
 	obsRepo         ports.ObservationRepository
 	docRepo         ports.DocumentRepository
+	types           []Shareable
+	shlRepo         ports.SHLRepository
 	tmpAccessClient ports.TmpAccessClient
+	files           ports.FileProvider
+	authz           ports.Authorizer
+	consent         ports.ConsentEvaluator
+	outbox          *EventOutbox
+	publicURL       string
+}
+
+type sharedResources struct {
+	observations []models.Observation
+	documents    []models.DocumentReference
+	others       []sharedResource
+	fileIDs      []string
+	withheld     []domain.ConsentDenial
 }
 
 func NewShareService(
+	links LinkOptions,
 	obsRepo ports.ObservationRepository,
 	docRepo ports.DocumentRepository,
+	shareables []Shareable,
+	shlRepo ports.SHLRepository,
 	tmpAccessClient ports.TmpAccessClient,
+	files ports.FileProvider,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	outbox *EventOutbox,
 ) *ShareService {
+	// The shareables arrive in no particular order; sorting them keeps
+	// shared bundles paging through the types in the same order.
+	types := slices.Clone(shareables)
+	slices.SortFunc(types, func(a, b Shareable) int {
+		return strings.Compare(a.resourceType, b.resourceType)
+	})
+
 	return &ShareService{
 		obsRepo:         obsRepo,
 		docRepo:         docRepo,
+		types:           types,
+		shlRepo:         shlRepo,
 		tmpAccessClient: tmpAccessClient,
+		files:           files,
+		authz:           authz,
+		consent:         consent,
+		outbox:          outbox,
+		publicURL:       links.baseURL(),
 	}
 }
 
@@ -35,7 +79,7 @@ func (s *ShareService) Share(ctx context.Context, req domain.ShareRequest) (*dom
 		return nil, domain.ErrAccessDenied
 	}
 
-	if !user.HasScope("patient/*.read") || user.PatientID == "" {
+	if decision := s.authz.AuthorizeType(user, domain.ActionShare, "Patient"); !decision.Allowed {
 		return nil, domain.ErrAccessDenied
 	}
 
@@ -43,7 +87,352 @@ func (s *ShareService) Share(ctx context.Context, req domain.ShareRequest) (*dom
 		return nil, domain.ErrNoResourcesToShare
 	}
 
-	obsIDs, docIDs := s.classifyResourceIDs(req.ResourceIDs)
+	resources, err := s.resolveResources(ctx, user, req.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := s.buildScopes(resources)
+
+	scopesStr := strings.Join(scopes, ",")
+
+	shared := domain.ShareEventData{Via: "token"}
+	if req.TTLSeconds > 0 {
+		shared.ExpiresAt = time.Now().Add(time.Duration(req.TTLSeconds) * time.Second).Unix()
+	}
+
+	// The token is minted once the events are written, so a token is not
+	// handed out for a share that was never announced.
+	var resp *domain.GenerateTmpTokenResponse
+	err = s.outbox.Announce(ctx, sharedEvents(resources, shared), func(ctx context.Context) error {
+		if resp != nil {
+			return nil
+		}
+		var err error
+		resp, err = s.tmpAccessClient.GenerateTmpToken(ctx, domain.GenerateTmpTokenRequest{
+			Payload: map[string]string{
+				"scopes": scopesStr,
+			},
+			TtlSeconds: req.TTLSeconds,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ShareResponse{
+		Token:       resp.TmpToken,
+		ResourceURL: "/api/v1/shared",
+		Withheld:    resources.withheld,
+	}, nil
+}
+
+func (s *ShareService) GetSharedResources(ctx context.Context) (*domain.SharedResourcesResponse, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if !user.IsTmpToken() {
+		return nil, domain.ErrAccessDenied
+	}
+
+	obsIDs, docIDs, otherIDs := s.sharedResourceIDs(user)
+
+	var observations []string
+	var documentReferences []string
+	var others []string
+
+	for _, id := range obsIDs {
+		observations = append(observations, fmt.Sprintf("/api/v1/Observation/%s", id))
+	}
+	for _, id := range docIDs {
+		documentReferences = append(documentReferences, fmt.Sprintf("/api/v1/DocumentReference/%s", id))
+	}
+	for _, t := range s.types {
+		for _, id := range otherIDs[t.resourceType] {
+			others = append(others, fmt.Sprintf("/api/v1/%s/%s", t.resourceType, id))
+		}
+	}
+
+	return &domain.SharedResourcesResponse{
+		Observations:       observations,
+		DocumentReferences: documentReferences,
+		Resources:          others,
+	}, nil
+}
+
+func (s *ShareService) GetSharedBundle(ctx context.Context, req domain.SharedBundleRequest) (*domain.SharedBundle, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if !user.IsTmpToken() {
+		return nil, domain.ErrAccessDenied
+	}
+
+	obsIDs, docIDs, otherIDs := s.sharedResourceIDs(user)
+	if len(req.Types) > 0 {
+		included := make(map[string]bool, len(req.Types))
+		for _, t := range req.Types {
+			if t != "Observation" && t != "DocumentReference" && s.shareable(t) == nil {
+				return nil, fmt.Errorf("%w: unsupported _type %q", domain.ErrInvalidInput, t)
+			}
+			included[t] = true
+		}
+		if !included["Observation"] {
+			obsIDs = nil
+		}
+		if !included["DocumentReference"] {
+			docIDs = nil
+		}
+		for resourceType := range otherIDs {
+			if !included[resourceType] {
+				delete(otherIDs, resourceType)
+			}
+		}
+	}
+
+	lists := [][]string{obsIDs, docIDs}
+	for _, t := range s.types {
+		lists = append(lists, otherIDs[t.resourceType])
+	}
+	total := 0
+	for _, ids := range lists {
+		total += len(ids)
+	}
+	pages := paginateIDs(req.Limit, req.Offset, lists...)
+	pageObsIDs, pageDocIDs := pages[0], pages[1]
+
+	observations, err := s.obsRepo.GetByIDs(ctx, pageObsIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	documents, err := s.docRepo.GetByIDs(ctx, pageDocIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	result := &domain.SharedBundle{
+		Observations:       make([]models.Observation, 0, len(observations)),
+		DocumentReferences: make([]models.DocumentReference, 0, len(documents)),
+		Total:              int64(total),
+	}
+
+	obsByID := make(map[string]models.Observation, len(observations))
+	for _, obs := range observations {
+		if obs.Id != nil {
+			obsByID[*obs.Id] = obs
+		}
+	}
+	var obsRefs []domain.ResourceRef
+	for _, id := range pageObsIDs {
+		obs, found := obsByID[id]
+		if !found {
+			continue
+		}
+		ref := observationRef(&obs)
+		if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+			continue
+		}
+		result.Observations = append(result.Observations, obs)
+		obsRefs = append(obsRefs, ref)
+	}
+
+	result.Observations, result.Withheld, err = withholdByConsent(ctx, s.consent, user, domain.ActionRead, result.Observations, obsRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	docsByID := make(map[string]models.DocumentReference, len(documents))
+	for _, doc := range documents {
+		if doc.Id != nil {
+			docsByID[*doc.Id] = doc
+		}
+	}
+	var docRefs []domain.ResourceRef
+	for _, id := range pageDocIDs {
+		doc, found := docsByID[id]
+		if !found {
+			continue
+		}
+		ref := documentRef(&doc)
+		if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+			continue
+		}
+		result.DocumentReferences = append(result.DocumentReferences, doc)
+		docRefs = append(docRefs, ref)
+	}
+
+	var withheldDocs []domain.ConsentDenial
+	result.DocumentReferences, withheldDocs, err = withholdByConsent(ctx, s.consent, user, domain.ActionRead, result.DocumentReferences, docRefs)
+	if err != nil {
+		return nil, err
+	}
+	result.Withheld = append(result.Withheld, withheldDocs...)
+	for i, doc := range result.DocumentReferences {
+		result.DocumentReferences[i] = s.resolveAttachmentURLs(ctx, user, doc)
+	}
+
+	for i, t := range s.types {
+		pageIDs := pages[i+2]
+		if len(pageIDs) == 0 {
+			continue
+		}
+
+		loaded, err := t.load(ctx, pageIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+
+		byID := make(map[string]sharedResource, len(loaded))
+		for _, res := range loaded {
+			byID[res.ref.ID] = res
+		}
+		var kept []sharedResource
+		var refs []domain.ResourceRef
+		for _, id := range pageIDs {
+			res, found := byID[id]
+			if !found {
+				continue
+			}
+			if err := authorize(ctx, s.authz, user, domain.ActionRead, res.ref); err != nil {
+				continue
+			}
+			kept = append(kept, res)
+			refs = append(refs, res.ref)
+		}
+
+		kept, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionRead, kept, refs)
+		if err != nil {
+			return nil, err
+		}
+		result.Withheld = append(result.Withheld, withheld...)
+		for _, res := range kept {
+			result.Resources = append(result.Resources, res.shared())
+		}
+	}
+
+	return result, nil
+}
+
+// sharedResourceIDs extracts the IDs granted by the per-resource read scopes
+// of a temporary token, preserving their order. IDs of the other shareable
+// types are keyed by resource type.
+func (s *ShareService) sharedResourceIDs(user domain.Identity) (obsIDs []string, docIDs []string, otherIDs map[string][]string) {
+	otherIDs = make(map[string][]string)
+	for _, scope := range user.Scopes {
+		parts := strings.Split(scope, ":")
+		if len(parts) != 4 {
+			continue
+		}
+
+		service, resource, id, action := parts[0], parts[1], parts[2], parts[3]
+		if service != "docs" || action != "read" {
+			continue
+		}
+
+		switch resource {
+		case "observation":
+			obsIDs = append(obsIDs, id)
+		case "document_reference":
+			docIDs = append(docIDs, id)
+		default:
+			for _, t := range s.types {
+				if _, name := resourceScopeName(t.resourceType); name == resource {
+					otherIDs[t.resourceType] = append(otherIDs[t.resourceType], id)
+				}
+			}
+		}
+	}
+	return obsIDs, docIDs, otherIDs
+}
+
+// sharedEvents announces the sharing of every resource in resources.
+func sharedEvents(resources *sharedResources, data domain.ShareEventData) []domain.DomainEvent {
+	events := make([]domain.DomainEvent, 0, len(resources.observations)+len(resources.documents)+len(resources.others))
+	for i := range resources.observations {
+		events = append(events, domainEvent(domain.EventResourceShared, observationRef(&resources.observations[i]), data))
+	}
+	for i := range resources.documents {
+		events = append(events, domainEvent(domain.EventResourceShared, documentRef(&resources.documents[i]), data))
+	}
+	for _, other := range resources.others {
+		events = append(events, domainEvent(domain.EventResourceShared, other.ref, data))
+	}
+	return events
+}
+
+// resolveAttachmentURLs replaces the stored download URLs with short-lived ones
+// for the files the token is allowed to read and drops the others, so the
+// recipient never receives links it cannot follow or keep using. A provider
+// that cannot sign download URLs leaves the stored one in place.
+func (s *ShareService) resolveAttachmentURLs(ctx context.Context, user domain.Identity, doc models.DocumentReference) models.DocumentReference {
+	if len(doc.Content) == 0 {
+		return doc
+	}
+
+	content := make([]models.DocumentReferenceContent, len(doc.Content))
+	copy(content, doc.Content)
+
+	for i := range content {
+		attachment := content[i].Attachment
+		if attachment == nil || attachment.Id == nil {
+			continue
+		}
+		resolved := *attachment
+		ref := domain.ResourceRef{Type: "File", ID: *attachment.Id}
+		if err := authorize(ctx, s.authz, user, domain.ActionRead, ref); err != nil {
+			resolved.Url = nil
+		} else if url, err := s.files.GetDownloadUrl(ctx, *attachment.Id, sharedDownloadTTL); err == nil {
+			resolved.Url = &url
+		} else if !errors.Is(err, domain.ErrDownloadUrlUnsupported) {
+			log.Printf("Shared bundle: signing download URL of file %s: %v", *attachment.Id, err)
+			resolved.Url = nil
+		}
+		content[i].Attachment = &resolved
+	}
+
+	doc.Content = content
+	return doc
+}
+
+// paginateIDs applies limit/offset to the concatenation of the ID lists and
+// returns the part of each list that falls on the page.
+func paginateIDs(limit, offset int, lists ...[]string) [][]string {
+	if offset < 0 {
+		offset = 0
+	}
+	count := 0
+	for _, ids := range lists {
+		count += len(ids)
+	}
+	end := offset + limit
+	if limit <= 0 {
+		end = count
+	}
+
+	pages := make([][]string, len(lists))
+	pos := 0
+	for i, ids := range lists {
+		for _, id := range ids {
+			if pos >= offset && pos < end {
+				pages[i] = append(pages[i], id)
+			}
+			pos++
+		}
+	}
+	return pages
+}
+
+// resolveResources loads the requested resources, verifies that every one of them
+// exists and may be shared by the user, and pulls in the documents referenced by
+// the other resources together with their files.
+func (s *ShareService) resolveResources(ctx context.Context, user domain.Identity, resourceIDs []string) (*sharedResources, error) {
+	obsIDs, docIDs, otherIDs := s.classifyResourceIDs(resourceIDs)
 
 	allObs, err := s.obsRepo.GetByIDs(ctx, obsIDs)
 	if err != nil {
@@ -55,6 +444,19 @@ func (s *ShareService) Share(ctx context.Context, req domain.ShareRequest) (*dom
 		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
 	}
 
+	var others []sharedResource
+	for _, t := range s.types {
+		ids := otherIDs[t.resourceType]
+		if len(ids) == 0 {
+			continue
+		}
+		loaded, err := t.load(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		others = append(others, loaded...)
+	}
+
 	foundIDs := make(map[string]bool)
 	for _, obs := range allObs {
 		if obs.Id != nil {
@@ -66,119 +468,181 @@ func (s *ShareService) Share(ctx context.Context, req domain.ShareRequest) (*dom
 			foundIDs[*doc.Id] = true
 		}
 	}
+	for _, res := range others {
+		foundIDs[res.ref.ID] = true
+	}
 
-	for _, resourceID := range req.ResourceIDs {
+	for _, resourceID := range resourceIDs {
 		id := resourceID
-		if strings.HasPrefix(id, "Observation/") {
-			id = strings.TrimPrefix(id, "Observation/")
-		} else if strings.HasPrefix(id, "DocumentReference/") {
-			id = strings.TrimPrefix(id, "DocumentReference/")
+		if _, typedID, ok := strings.Cut(resourceID, "/"); ok {
+			id = typedID
 		}
 		if !foundIDs[id] {
 			return nil, domain.ErrResourceNotOwned
 		}
 	}
 
-	expectedPatientRef := fmt.Sprintf("Patient/%s", user.PatientID)
-
 	for _, obs := range allObs {
-		if obs.Subject == nil || obs.Subject.Reference == nil {
-			return nil, domain.ErrResourceNotOwned
+		ref := domain.ResourceRef{
+			Type:         "Observation",
+			PatientID:    patientIDFromReference(obs.Subject),
+			SearchParams: observationSearchParams(&obs),
 		}
-		if *obs.Subject.Reference != expectedPatientRef {
-			return nil, domain.ErrResourceNotOwned
+		if err := s.authorizeShare(ctx, user, ref); err != nil {
+			return nil, err
 		}
 	}
 
 	for _, doc := range allDocs {
-		if doc.Subject == nil || doc.Subject.Reference == nil {
-			return nil, domain.ErrResourceNotOwned
+		ref := domain.ResourceRef{
+			Type:         "DocumentReference",
+			PatientID:    patientIDFromReference(doc.Subject),
+			SearchParams: documentSearchParams(&doc),
 		}
-		if *doc.Subject.Reference != expectedPatientRef {
-			return nil, domain.ErrResourceNotOwned
+		if err := s.authorizeShare(ctx, user, ref); err != nil {
+			return nil, err
 		}
 	}
 
-	docIDsFromObs := s.extractDocumentReferencesFromObservations(allObs)
-	if len(docIDsFromObs) > 0 {
-		additionalDocs, err := s.docRepo.GetByIDs(ctx, docIDsFromObs)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	for _, res := range others {
+		if err := s.authorizeShare(ctx, user, res.ref); err != nil {
+			return nil, err
 		}
-		allDocs = append(allDocs, additionalDocs...)
 	}
 
-	fileIDs := s.extractFileIDsFromDocuments(allDocs)
-
-	scopes := s.buildScopes(allObs, allDocs, fileIDs)
+	// Withheld resources must not pull in the documents they reference.
+	obsRefs := make([]domain.ResourceRef, len(allObs))
+	for i := range allObs {
+		obsRefs[i] = observationRef(&allObs[i])
+	}
+	allObs, withheld, err := withholdByConsent(ctx, s.consent, user, domain.ActionShare, allObs, obsRefs)
+	if err != nil {
+		return nil, err
+	}
 
-	scopesStr := strings.Join(scopes, ",")
-	resp, err := s.tmpAccessClient.GenerateTmpToken(ctx, domain.GenerateTmpTokenRequest{
-		Payload: map[string]string{
-			"scopes": scopesStr,
-		},
-		TtlSeconds: req.TTLSeconds,
-	})
+	otherRefs := make([]domain.ResourceRef, len(others))
+	for i := range others {
+		otherRefs[i] = others[i].ref
+	}
+	others, withheldOthers, err := withholdByConsent(ctx, s.consent, user, domain.ActionShare, others, otherRefs)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		return nil, err
 	}
+	withheld = append(withheld, withheldOthers...)
 
-	return &domain.ShareResponse{
-		Token:       resp.TmpToken,
-		ResourceURL: "/api/v1/shared",
-	}, nil
-}
+	// Observations linked from other resources, such as the results of a
+	// report, are shared along with them and pull in their own documents.
+	linkedObs, err := s.linkedObservations(ctx, allObs, others)
+	if err != nil {
+		return nil, err
+	}
+	linkedRefs := make([]domain.ResourceRef, len(linkedObs))
+	for i := range linkedObs {
+		linkedRefs[i] = observationRef(&linkedObs[i])
+	}
+	linkedObs, withheldLinked, err := withholdByConsent(ctx, s.consent, user, domain.ActionShare, linkedObs, linkedRefs)
+	if err != nil {
+		return nil, err
+	}
+	withheld = append(withheld, withheldLinked...)
+	allObs = append(allObs, linkedObs...)
 
-func (s *ShareService) GetSharedResources(ctx context.Context) (*domain.SharedResourcesResponse, error) {
-	user, ok := identity.FromCtx(ctx)
-	if !ok {
-		return nil, domain.ErrAccessDenied
+	referencedDocIDs := s.extractDocumentReferencesFromObservations(allObs)
+	for _, res := range others {
+		referencedDocIDs = append(referencedDocIDs, res.documentIDs...)
+	}
+	if len(referencedDocIDs) > 0 {
+		additionalDocs, err := s.docRepo.GetByIDs(ctx, referencedDocIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		allDocs = append(allDocs, additionalDocs...)
 	}
 
-	if !user.IsTmpToken() {
-		return nil, domain.ErrAccessDenied
+	docRefs := make([]domain.ResourceRef, len(allDocs))
+	for i := range allDocs {
+		docRefs[i] = documentRef(&allDocs[i])
 	}
+	allDocs, withheldDocs, err := withholdByConsent(ctx, s.consent, user, domain.ActionShare, allDocs, docRefs)
+	if err != nil {
+		return nil, err
+	}
+	withheld = append(withheld, withheldDocs...)
 
-	var observations []string
-	var documentReferences []string
+	if len(allObs) == 0 && len(allDocs) == 0 && len(others) == 0 {
+		return nil, fmt.Errorf("%w: every requested resource is withheld by consent", domain.ErrNoResourcesToShare)
+	}
 
-	for _, scope := range user.Scopes {
-		parts := strings.Split(scope, ":")
-		if len(parts) != 4 {
-			continue
-		}
+	return &sharedResources{
+		observations: allObs,
+		documents:    allDocs,
+		others:       others,
+		fileIDs:      s.extractFileIDsFromDocuments(allDocs),
+		withheld:     withheld,
+	}, nil
+}
 
-		service, resource, id, action := parts[0], parts[1], parts[2], parts[3]
-		if service != "docs" || action != "read" {
-			continue
+// linkedObservations loads the observations linked from others that are not
+// shared already.
+func (s *ShareService) linkedObservations(ctx context.Context, shared []models.Observation, others []sharedResource) ([]models.Observation, error) {
+	included := make(map[string]bool, len(shared))
+	for _, obs := range shared {
+		if obs.Id != nil {
+			included[*obs.Id] = true
 		}
+	}
 
-		switch resource {
-		case "observation":
-			observations = append(observations, fmt.Sprintf("/api/v1/Observation/%s", id))
-		case "document_reference":
-			documentReferences = append(documentReferences, fmt.Sprintf("/api/v1/DocumentReference/%s", id))
+	var ids []string
+	for _, res := range others {
+		for _, id := range res.observationIDs {
+			if !included[id] {
+				included[id] = true
+				ids = append(ids, id)
+			}
 		}
 	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
-	return &domain.SharedResourcesResponse{
-		Observations:       observations,
-		DocumentReferences: documentReferences,
-	}, nil
+	observations, err := s.obsRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	return observations, nil
 }
 
-func (s *ShareService) classifyResourceIDs(resourceIDs []string) (obsIDs []string, docIDs []string) {
+// classifyResourceIDs sorts the requested IDs by resource type. IDs without a
+// type are looked up as observations and documents.
+func (s *ShareService) classifyResourceIDs(resourceIDs []string) (obsIDs []string, docIDs []string, otherIDs map[string][]string) {
+	otherIDs = make(map[string][]string)
 	for _, id := range resourceIDs {
-		if strings.HasPrefix(id, "Observation/") {
-			obsIDs = append(obsIDs, strings.TrimPrefix(id, "Observation/"))
-		} else if strings.HasPrefix(id, "DocumentReference/") {
-			docIDs = append(docIDs, strings.TrimPrefix(id, "DocumentReference/"))
-		} else {
+		resourceType, typedID, ok := strings.Cut(id, "/")
+		switch {
+		case !ok:
 			obsIDs = append(obsIDs, id)
 			docIDs = append(docIDs, id)
+		case resourceType == "Observation":
+			obsIDs = append(obsIDs, typedID)
+		case resourceType == "DocumentReference":
+			docIDs = append(docIDs, typedID)
+		case s.shareable(resourceType) != nil:
+			otherIDs[resourceType] = append(otherIDs[resourceType], typedID)
+		default:
+			obsIDs = append(obsIDs, id)
+			docIDs = append(docIDs, id)
+		}
+	}
+	return obsIDs, docIDs, otherIDs
+}
+
+func (s *ShareService) shareable(resourceType string) *Shareable {
+	for i := range s.types {
+		if s.types[i].resourceType == resourceType {
+			return &s.types[i]
 		}
 	}
-	return obsIDs, docIDs
+	return nil
 }
 
 func (s *ShareService) extractDocumentReferencesFromObservations(observations []models.Observation) []string {
@@ -232,22 +696,29 @@ func (s *ShareService) extractFileIDsFromDocuments(documents []models.DocumentRe
 	return fileIDs
 }
 
-func (s *ShareService) buildScopes(observations []models.Observation, documents []models.DocumentReference, fileIDs []string) []string {
+func (s *ShareService) buildScopes(resources *sharedResources) []string {
 	var scopes []string
 
-	for _, obs := range observations {
+	for _, obs := range resources.observations {
 		if obs.Id != nil && *obs.Id != "" {
 			scopes = append(scopes, fmt.Sprintf("docs:observation:%s:read", *obs.Id))
 		}
 	}
 
-	for _, doc := range documents {
-		if doc.Id != nil && *doc.Id != "" {
+	seenDocs := make(map[string]bool)
+	for _, doc := range resources.documents {
+		if doc.Id != nil && *doc.Id != "" && !seenDocs[*doc.Id] {
+			seenDocs[*doc.Id] = true
 			scopes = append(scopes, fmt.Sprintf("docs:document_reference:%s:read", *doc.Id))
 		}
 	}
 
-	for _, fileID := range fileIDs {
+	for _, res := range resources.others {
+		service, name := resourceScopeName(res.ref.Type)
+		scopes = append(scopes, fmt.Sprintf("%s:%s:%s:read", service, name, res.ref.ID))
+	}
+
+	for _, fileID := range resources.fileIDs {
 		if fileID != "" {
 			scopes = append(scopes, fmt.Sprintf("files:file:%s:read", fileID))
 		}
@@ -255,3 +726,20 @@ func (s *ShareService) buildScopes(observations []models.Observation, documents
 
 	return scopes
 }
+
+// authorizeShare reports resources the user may not disclose as not owned. A
+// share is made for the compartment the user is acting in, so resources of
+// any other patient are refused even when the user could share them.
+func (s *ShareService) authorizeShare(ctx context.Context, user domain.Identity, ref domain.ResourceRef) error {
+	if ref.PatientID != user.CompartmentID() {
+		return domain.ErrResourceNotOwned
+	}
+	decision, err := s.authz.Authorize(ctx, user, domain.ActionShare, ref)
+	if err != nil {
+		return err
+	}
+	if !decision.Allowed {
+		return domain.ErrResourceNotOwned
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/gruzdev-dev/codex-documents/core/domain"
@@ -437,11 +438,12 @@ func TestShareService_Share(t *testing.T) {
 
 			obsRepo := ports.NewMockObservationRepository(ctrl)
 			docRepo := ports.NewMockDocumentRepository(ctrl)
+			shlRepo := ports.NewMockSHLRepository(ctrl)
 			client := ports.NewMockTmpAccessClient(ctrl)
 
 			tt.setupMocks(obsRepo, docRepo, client)
 
-			service := NewShareService(obsRepo, docRepo, client)
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, shlRepo, client, ports.NewMockFileProvider(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl))
 
 			ctx := tt.setupContext()
 			result, err := service.Share(ctx, tt.req)
@@ -583,9 +585,10 @@ func TestShareService_GetSharedResources(t *testing.T) {
 
 			obsRepo := ports.NewMockObservationRepository(ctrl)
 			docRepo := ports.NewMockDocumentRepository(ctrl)
+			shlRepo := ports.NewMockSHLRepository(ctrl)
 			client := ports.NewMockTmpAccessClient(ctrl)
 
-			service := NewShareService(obsRepo, docRepo, client)
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, shlRepo, client, ports.NewMockFileProvider(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl))
 
 			ctx := tt.setupContext()
 			result, err := service.GetSharedResources(ctx)
@@ -602,3 +605,451 @@ func TestShareService_GetSharedResources(t *testing.T) {
 		})
 	}
 }
+
+func TestShareService_GetSharedBundle(t *testing.T) {
+	const otherObsID = "obs-456"
+
+	tmpIdentity := func(scopes ...string) context.Context {
+		return identity.WithCtx(context.Background(), domain.Identity{Scopes: scopes})
+	}
+
+	tests := []struct {
+		name           string
+		req            domain.SharedBundleRequest
+		setupMocks     func(*ports.MockObservationRepository, *ports.MockDocumentRepository, *ports.MockFileProvider)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *domain.SharedBundle, error)
+	}{
+		{
+			name: "success path - all shared resources with file urls",
+			req:  domain.SharedBundleRequest{Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obs := createTestObservation(testObsID, testPatientID)
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testObsID}).
+					Return([]models.Observation{*obs}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				doc.Content[0].Attachment.Id = strPtr(testFileID)
+				doc.Content[0].Attachment.Url = strPtr("http://files/download/" + testFileID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+				files.EXPECT().
+					GetDownloadUrl(gomock.Any(), testFileID, sharedDownloadTTL).
+					Return("http://files/download/"+testFileID+"?signature=fresh", nil)
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity(
+					"docs:observation:"+testObsID+":read",
+					"docs:document_reference:"+testDocID+":read",
+					"files:file:"+testFileID+":read",
+				)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), res.Total)
+				require.Len(t, res.Observations, 1)
+				require.Len(t, res.DocumentReferences, 1)
+				require.NotNil(t, res.DocumentReferences[0].Content[0].Attachment.Url)
+				assert.Equal(t, "http://files/download/"+testFileID+"?signature=fresh", *res.DocumentReferences[0].Content[0].Attachment.Url)
+			},
+		},
+		{
+			name: "success path - stored file url kept when the provider cannot sign",
+			req:  domain.SharedBundleRequest{Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obsRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return([]models.Observation{}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				doc.Content[0].Attachment.Id = strPtr(testFileID)
+				doc.Content[0].Attachment.Url = strPtr("http://files/download/" + testFileID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+				files.EXPECT().
+					GetDownloadUrl(gomock.Any(), testFileID, sharedDownloadTTL).
+					Return("", domain.ErrDownloadUrlUnsupported)
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity(
+					"docs:document_reference:"+testDocID+":read",
+					"files:file:"+testFileID+":read",
+				)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.DocumentReferences, 1)
+				require.NotNil(t, res.DocumentReferences[0].Content[0].Attachment.Url)
+				assert.Equal(t, "http://files/download/"+testFileID, *res.DocumentReferences[0].Content[0].Attachment.Url)
+			},
+		},
+		{
+			name: "success path - file url stripped when signing fails",
+			req:  domain.SharedBundleRequest{Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obsRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return([]models.Observation{}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				doc.Content[0].Attachment.Id = strPtr(testFileID)
+				doc.Content[0].Attachment.Url = strPtr("http://files/download/" + testFileID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+				files.EXPECT().
+					GetDownloadUrl(gomock.Any(), testFileID, sharedDownloadTTL).
+					Return("", errors.New("files unavailable"))
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity(
+					"docs:document_reference:"+testDocID+":read",
+					"files:file:"+testFileID+":read",
+				)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.DocumentReferences, 1)
+				assert.Nil(t, res.DocumentReferences[0].Content[0].Attachment.Url)
+			},
+		},
+		{
+			name: "success path - file url stripped without file scope",
+			req:  domain.SharedBundleRequest{Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obsRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return([]models.Observation{}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				doc.Content[0].Attachment.Id = strPtr(testFileID)
+				doc.Content[0].Attachment.Url = strPtr("http://files/download/" + testFileID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity("docs:document_reference:" + testDocID + ":read")
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.DocumentReferences, 1)
+				assert.Nil(t, res.DocumentReferences[0].Content[0].Attachment.Url)
+			},
+		},
+		{
+			name: "success path - _type filter",
+			req:  domain.SharedBundleRequest{Types: []string{"DocumentReference"}, Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, ids []string) ([]models.Observation, error) {
+						require.Empty(t, ids)
+						return []models.Observation{}, nil
+					})
+				doc := createTestDocument(testDocID, testPatientID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity(
+					"docs:observation:"+testObsID+":read",
+					"docs:document_reference:"+testDocID+":read",
+				)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), res.Total)
+				assert.Empty(t, res.Observations)
+				assert.Len(t, res.DocumentReferences, 1)
+			},
+		},
+		{
+			name: "success path - paging across resource types",
+			req:  domain.SharedBundleRequest{Limit: 2, Offset: 1},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obs := createTestObservation(otherObsID, testPatientID)
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{otherObsID}).
+					Return([]models.Observation{*obs}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity(
+					"docs:observation:"+testObsID+":read",
+					"docs:observation:"+otherObsID+":read",
+					"docs:document_reference:"+testDocID+":read",
+				)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), res.Total)
+				require.Len(t, res.Observations, 1)
+				assert.Equal(t, otherObsID, *res.Observations[0].Id)
+				assert.Len(t, res.DocumentReferences, 1)
+			},
+		},
+		{
+			name: "error - unsupported _type",
+			req:  domain.SharedBundleRequest{Types: []string{"Patient"}, Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity("docs:observation:" + testObsID + ":read")
+			},
+			expectedError: domain.ErrInvalidInput,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			},
+		},
+		{
+			name: "error - not temporary token",
+			req:  domain.SharedBundleRequest{Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrAccessDenied,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				assert.Nil(t, res)
+				assert.Equal(t, domain.ErrAccessDenied, err)
+			},
+		},
+		{
+			name: "error - repository error",
+			req:  domain.SharedBundleRequest{Limit: 20},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, files *ports.MockFileProvider) {
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			setupContext: func() context.Context {
+				return tmpIdentity("docs:observation:" + testObsID + ":read")
+			},
+			expectedError: domain.ErrInternal,
+			validateResult: func(t *testing.T, res *domain.SharedBundle, err error) {
+				assert.Nil(t, res)
+				assert.ErrorIs(t, err, domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			shlRepo := ports.NewMockSHLRepository(ctrl)
+			client := ports.NewMockTmpAccessClient(ctrl)
+
+			files := ports.NewMockFileProvider(ctrl)
+
+			tt.setupMocks(obsRepo, docRepo, files)
+
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, shlRepo, client, files, NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl))
+
+			ctx := tt.setupContext()
+			result, err := service.GetSharedBundle(ctx, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
+
+func TestShareService_Shareables(t *testing.T) {
+	const resultDocID = "result-doc-123"
+
+	withFile := func(doc *models.DocumentReference) models.DocumentReference {
+		doc.Content[0].Attachment.Id = strPtr(testFileID)
+		return *doc
+	}
+
+	tests := []struct {
+		name               string
+		resourceType       string
+		id                 string
+		shareable          func(ctrl *gomock.Controller, id string) Shareable
+		linkedObservations []models.Observation
+		linkedDocuments    []models.DocumentReference
+		expectedScopes     []string
+	}{
+		{
+			name:         "Condition with its evidence",
+			resourceType: "Condition",
+			id:           testCondID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockConditionRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.Condition{*createTestConditionWithEvidence(id, testPatientID, "DocumentReference/"+testDocID)}, nil).Times(2)
+				return NewConditionShareable(repo)
+			},
+			linkedDocuments: []models.DocumentReference{withFile(createTestDocument(testDocID, testPatientID))},
+			expectedScopes:  []string{"docs:condition:" + testCondID + ":read", "docs:document_reference:" + testDocID + ":read", "files:file:" + testFileID + ":read"},
+		},
+		{
+			name:         "MedicationStatement with the documents it is derived from",
+			resourceType: "MedicationStatement",
+			id:           testMedStatementID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockMedicationStatementRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.MedicationStatement{*createTestMedicationStatement(id, testPatientID, "DocumentReference/"+testDocID)}, nil).Times(2)
+				return NewMedicationStatementShareable(repo)
+			},
+			linkedDocuments: []models.DocumentReference{withFile(createTestDocument(testDocID, testPatientID))},
+			expectedScopes:  []string{"docs:medication_statement:" + testMedStatementID + ":read", "docs:document_reference:" + testDocID + ":read", "files:file:" + testFileID + ":read"},
+		},
+		{
+			name:         "MedicationRequest with its prescription",
+			resourceType: "MedicationRequest",
+			id:           testMedRequestID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockMedicationRequestRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.MedicationRequest{*createTestMedicationRequest(id, testPatientID, "DocumentReference/"+testDocID)}, nil).Times(2)
+				return NewMedicationRequestShareable(repo)
+			},
+			linkedDocuments: []models.DocumentReference{*createTestDocument(testDocID, testPatientID)},
+			expectedScopes:  []string{"docs:medication_request:" + testMedRequestID + ":read", "docs:document_reference:" + testDocID + ":read"},
+		},
+		{
+			name:         "AllergyIntolerance alone",
+			resourceType: "AllergyIntolerance",
+			id:           testAllergyID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockAllergyIntoleranceRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.AllergyIntolerance{*createTestAllergy(id, testPatientID)}, nil).Times(2)
+				return NewAllergyIntoleranceShareable(repo)
+			},
+			expectedScopes: []string{"docs:allergy_intolerance:" + testAllergyID + ":read"},
+		},
+		{
+			name:         "Immunization with its certificate",
+			resourceType: "Immunization",
+			id:           testImmunizationID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockImmunizationRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.Immunization{*createTestImmunization(id, testPatientID, "DocumentReference/"+testDocID)}, nil).Times(2)
+				return NewImmunizationShareable(repo)
+			},
+			linkedDocuments: []models.DocumentReference{*createTestDocument(testDocID, testPatientID)},
+			expectedScopes:  []string{"docs:immunization:" + testImmunizationID + ":read", "docs:document_reference:" + testDocID + ":read"},
+		},
+		{
+			name:         "DiagnosticReport with its results and their documents",
+			resourceType: "DiagnosticReport",
+			id:           testReportID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockDiagnosticReportRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.DiagnosticReport{*createTestDiagnosticReport(id, testPatientID, []string{"Observation/" + testObsID}, "DocumentReference/"+testDocID)}, nil).Times(2)
+				return NewDiagnosticReportShareable(repo)
+			},
+			linkedObservations: []models.Observation{*createTestObservationWithDerivedFrom(testObsID, testPatientID, []string{resultDocID})},
+			linkedDocuments:    []models.DocumentReference{*createTestDocument(testDocID, testPatientID), *createTestDocument(resultDocID, testPatientID)},
+			expectedScopes: []string{
+				"docs:diagnostic_report:" + testReportID + ":read",
+				"docs:observation:" + testObsID + ":read",
+				"docs:document_reference:" + testDocID + ":read",
+				"docs:document_reference:" + resultDocID + ":read",
+			},
+		},
+		{
+			name:         "Procedure with its reports",
+			resourceType: "Procedure",
+			id:           testProcedureID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockProcedureRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.Procedure{*createTestProcedure(id, testPatientID, "DocumentReference/"+testDocID)}, nil).Times(2)
+				return NewProcedureShareable(repo)
+			},
+			linkedDocuments: []models.DocumentReference{*createTestDocument(testDocID, testPatientID)},
+			expectedScopes:  []string{"docs:procedure:" + testProcedureID + ":read", "docs:document_reference:" + testDocID + ":read"},
+		},
+		{
+			name:         "FamilyMemberHistory alone",
+			resourceType: "FamilyMemberHistory",
+			id:           testFamilyMemberHistoryID,
+			shareable: func(ctrl *gomock.Controller, id string) Shareable {
+				repo := ports.NewMockFamilyMemberHistoryRepository(ctrl)
+				repo.EXPECT().GetByIDs(gomock.Any(), []string{id}).
+					Return([]models.FamilyMemberHistory{*createTestFamilyMemberHistory(id, testPatientID)}, nil).Times(2)
+				return NewFamilyMemberHistoryShareable(repo)
+			},
+			expectedScopes: []string{"docs:family_member_history:" + testFamilyMemberHistoryID + ":read"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			client := ports.NewMockTmpAccessClient(ctrl)
+
+			obsRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Len(0)).Return([]models.Observation{}, nil).AnyTimes()
+			docRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Len(0)).Return([]models.DocumentReference{}, nil).AnyTimes()
+			if len(tt.linkedObservations) > 0 {
+				var ids []string
+				for _, obs := range tt.linkedObservations {
+					ids = append(ids, *obs.Id)
+				}
+				obsRepo.EXPECT().GetByIDs(gomock.Any(), gomock.InAnyOrder(ids)).Return(tt.linkedObservations, nil)
+			}
+			if len(tt.linkedDocuments) > 0 {
+				var ids []string
+				for _, doc := range tt.linkedDocuments {
+					ids = append(ids, *doc.Id)
+				}
+				docRepo.EXPECT().GetByIDs(gomock.Any(), gomock.InAnyOrder(ids)).Return(tt.linkedDocuments, nil)
+			}
+
+			var scopes []string
+			client.EXPECT().
+				GenerateTmpToken(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, req domain.GenerateTmpTokenRequest) (*domain.GenerateTmpTokenResponse, error) {
+					scopes = strings.Split(req.Payload["scopes"], ",")
+					return &domain.GenerateTmpTokenResponse{TmpToken: "tmp-token"}, nil
+				})
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, []Shareable{tt.shareable(ctrl, tt.id)}, ports.NewMockSHLRepository(ctrl), client, ports.NewMockFileProvider(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl))
+
+			patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs"})
+			resp, err := service.Share(identity.WithCtx(context.Background(), patient), domain.ShareRequest{ResourceIDs: []string{tt.resourceType + "/" + tt.id}})
+			require.NoError(t, err)
+			assert.Equal(t, "tmp-token", resp.Token)
+			assert.ElementsMatch(t, tt.expectedScopes, scopes)
+
+			recipient := domain.Identity{Scopes: scopes}
+			bundle, err := service.GetSharedBundle(identity.WithCtx(context.Background(), recipient), domain.SharedBundleRequest{Types: []string{tt.resourceType}, Limit: 20})
+			require.NoError(t, err)
+			require.Len(t, bundle.Resources, 1)
+			assert.Equal(t, tt.resourceType, bundle.Resources[0].Type)
+			assert.Equal(t, tt.id, bundle.Resources[0].ID)
+			assert.Equal(t, int64(1), bundle.Total)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// Shareable is a resource type, besides observations and documents, that
+// patients may share. Its resources are addressed as "Type/id" in share
+// requests. The ShareService is given one per type, so a type becomes
+// shareable by providing its Shareable.
+type Shareable struct {
+	resourceType string
+	load         func(ctx context.Context, ids []string) ([]sharedResource, error)
+}
+
+// sharedResource is a loaded resource of a shareable type together with the
+// documents and observations that are shared along with it.
+type sharedResource struct {
+	ref            domain.ResourceRef
+	resource       any
+	documentIDs    []string
+	observationIDs []string
+}
+
+func (r sharedResource) shared() domain.TypedResource {
+	return domain.TypedResource{Type: r.ref.Type, ID: r.ref.ID, Resource: r.resource}
+}
+
+// shareableOf describes a shareable type by how its resources are loaded and
+// described. linked returns the references to the documents and observations
+// that are shared along with a resource; it may be nil for types that link
+// none.
+func shareableOf[T any](
+	resourceType string,
+	getByIDs func(ctx context.Context, ids []string) ([]T, error),
+	ref func(*T) domain.ResourceRef,
+	linked func(*T) []models.Reference,
+) Shareable {
+	return Shareable{
+		resourceType: resourceType,
+		load: func(ctx context.Context, ids []string) ([]sharedResource, error) {
+			items, err := getByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			loaded := make([]sharedResource, 0, len(items))
+			for i := range items {
+				item := &items[i]
+				res := sharedResource{ref: ref(item), resource: *item}
+				if linked != nil {
+					refs := linked(item)
+					res.documentIDs = referencedIDs(refs, "DocumentReference")
+					res.observationIDs = referencedIDs(refs, "Observation")
+				}
+				loaded = append(loaded, res)
+			}
+			return loaded, nil
+		},
+	}
+}
+
+func NewConditionShareable(repo ports.ConditionRepository) Shareable {
+	return shareableOf("Condition", repo.GetByIDs, conditionRef, conditionLinks)
+}
+
+func NewMedicationStatementShareable(repo ports.MedicationStatementRepository) Shareable {
+	return shareableOf("MedicationStatement", repo.GetByIDs, medicationStatementRef, medicationStatementLinks)
+}
+
+func NewMedicationRequestShareable(repo ports.MedicationRequestRepository) Shareable {
+	return shareableOf("MedicationRequest", repo.GetByIDs, medicationRequestRef, medicationRequestLinks)
+}
+
+func NewAllergyIntoleranceShareable(repo ports.AllergyIntoleranceRepository) Shareable {
+	return shareableOf("AllergyIntolerance", repo.GetByIDs, allergyIntoleranceRef, nil)
+}
+
+func NewImmunizationShareable(repo ports.ImmunizationRepository) Shareable {
+	return shareableOf("Immunization", repo.GetByIDs, immunizationRef, immunizationLinks)
+}
+
+func NewDiagnosticReportShareable(repo ports.DiagnosticReportRepository) Shareable {
+	return shareableOf("DiagnosticReport", repo.GetByIDs, diagnosticReportRef, diagnosticReportLinks)
+}
+
+func NewProcedureShareable(repo ports.ProcedureRepository) Shareable {
+	return shareableOf("Procedure", repo.GetByIDs, procedureRef, procedureDocuments)
+}
+
+func NewFamilyMemberHistoryShareable(repo ports.FamilyMemberHistoryRepository) Shareable {
+	return shareableOf("FamilyMemberHistory", repo.GetByIDs, familyMemberHistoryRef, familyMemberHistoryDocuments)
+}
+
+// conditionLinks returns the documents a condition cites as evidence.
+func conditionLinks(cond *models.Condition) []models.Reference {
+	var refs []models.Reference
+	for _, evidence := range cond.Evidence {
+		if evidence.Reference != nil {
+			refs = append(refs, *evidence.Reference)
+		}
+	}
+	return documentLinks(refs)
+}
+
+// medicationStatementLinks returns the documents a medication statement is
+// derived from.
+func medicationStatementLinks(ms *models.MedicationStatement) []models.Reference {
+	return ms.DerivedFrom
+}
+
+// medicationRequestLinks returns the prescription documents linked to a
+// medication request.
+func medicationRequestLinks(mr *models.MedicationRequest) []models.Reference {
+	return documentLinks(mr.SupportingInformation)
+}
+
+// immunizationLinks returns the certificates linked to an immunization.
+func immunizationLinks(imm *models.Immunization) []models.Reference {
+	return documentLinks(imm.SupportingInformation)
+}
+
+// diagnosticReportLinks returns the results of a report and its source
+// documents.
+func diagnosticReportLinks(report *models.DiagnosticReport) []models.Reference {
+	return append(append([]models.Reference(nil), report.Result...), diagnosticReportDocuments(report)...)
+}
+
+// referencedIDs returns the distinct IDs of the resources of resourceType
+// among refs.
+func referencedIDs(refs []models.Reference, resourceType string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, ref := range refs {
+		if ref.Reference == nil {
+			continue
+		}
+		id, ok := strings.CutPrefix(*ref.Reference, resourceType+"/")
+		if !ok || id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/jwe"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	shlPrefix            = "shlink:/"
+	shlContentType       = "application/fhir+json"
+	shlMaxLabelLength    = 80
+	shlMaxAttempts       = 5
+	shlPasscodeSaltSize  = 16
+	shlPasscodeKeySize   = 32
+	shlPasscodeIteration = 100_000
+	shlIDEntropyBytes    = 32
+)
+
+// shlPayload is the JSON object encoded into the shlink:/ URI, as defined by the
+// SMART Health Links specification.
+type shlPayload struct {
+	URL   string `json:"url"`
+	Key   string `json:"key"`
+	Exp   int64  `json:"exp,omitempty"`
+	Flag  string `json:"flag,omitempty"`
+	Label string `json:"label,omitempty"`
+	V     int    `json:"v"`
+}
+
+func (s *ShareService) CreateSHL(ctx context.Context, req domain.SHLRequest) (*domain.SHLResponse, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionShare, "Patient"); !decision.Allowed {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if len(req.ResourceIDs) == 0 {
+		return nil, domain.ErrNoResourcesToShare
+	}
+
+	if len(req.Label) > shlMaxLabelLength {
+		return nil, fmt.Errorf("%w: label must not exceed %d characters", domain.ErrInvalidInput, shlMaxLabelLength)
+	}
+
+	if req.TTLSeconds < 0 {
+		return nil, fmt.Errorf("%w: ttl must not be negative", domain.ErrInvalidInput)
+	}
+
+	resources, err := s.resolveResources(ctx, user, req.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := s.buildCollectionBundle(resources)
+	bundleJSON, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	key, err := jwe.NewKey()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	encrypted, err := jwe.Encrypt(key, bundleJSON, shlContentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	id, err := newSHLID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	now := time.Now()
+	link := &domain.SmartHealthLink{
+		ID:        id,
+		PatientID: user.CompartmentID(),
+		Label:     req.Label,
+		Files: []domain.SHLManifestFile{
+			{ContentType: shlContentType, Embedded: encrypted},
+		},
+		CreatedAt: now.Unix(),
+	}
+
+	if req.TTLSeconds > 0 {
+		link.ExpiresAt = now.Add(time.Duration(req.TTLSeconds) * time.Second).Unix()
+	}
+
+	payload := shlPayload{
+		URL:   fmt.Sprintf("%s/api/v1/shl/%s", s.publicURL, id),
+		Key:   base64.RawURLEncoding.EncodeToString(key),
+		Exp:   link.ExpiresAt,
+		Label: req.Label,
+		V:     1,
+	}
+
+	if req.Passcode != "" {
+		salt := make([]byte, shlPasscodeSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		hash, err := hashPasscode(req.Passcode, salt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		link.PasscodeSalt = salt
+		link.PasscodeHash = hash
+		link.RemainingAttempts = shlMaxAttempts
+		payload.Flag = "P"
+	}
+
+	var created *domain.SmartHealthLink
+	err = s.outbox.Write(ctx, func(ctx context.Context) ([]domain.DomainEvent, error) {
+		var err error
+		if created, err = s.shlRepo.Create(ctx, link); err != nil {
+			return nil, err
+		}
+		shared := domain.ShareEventData{Via: "smart-health-link", LinkID: created.ID, ExpiresAt: created.ExpiresAt}
+		return sharedEvents(resources, shared), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.SHLResponse{
+		ID:        created.ID,
+		Link:      shlPrefix + base64.RawURLEncoding.EncodeToString(payloadJSON),
+		ExpiresAt: created.ExpiresAt,
+		Withheld:  resources.withheld,
+	}, nil
+}
+
+func (s *ShareService) GetSHLManifest(ctx context.Context, id string, req domain.SHLManifestRequest) (*domain.SHLManifest, error) {
+	if req.Recipient == "" {
+		return nil, fmt.Errorf("%w: recipient is required", domain.ErrInvalidInput)
+	}
+
+	link, err := s.shlRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if link == nil {
+		return nil, domain.ErrSHLNotFound
+	}
+
+	if link.ExpiresAt > 0 && time.Now().Unix() >= link.ExpiresAt {
+		return nil, domain.ErrSHLNotFound
+	}
+
+	if len(link.PasscodeHash) > 0 {
+		// The attempt is used up before the passcode is compared, so parallel
+		// guesses cannot get past the attempts the link has left. A correct
+		// passcode restores them.
+		remaining, ok, err := s.shlRepo.DecrementAttempts(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+		if !ok {
+			return nil, domain.ErrSHLNotFound
+		}
+
+		hash, err := hashPasscode(req.Passcode, link.PasscodeSalt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+
+		if subtle.ConstantTimeCompare(hash, link.PasscodeHash) != 1 {
+			return nil, fmt.Errorf("%w: %d attempts remaining", domain.ErrSHLPasscodeInvalid, remaining)
+		}
+
+		if err := s.shlRepo.ResetAttempts(ctx, id, shlMaxAttempts); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+	}
+
+	return &domain.SHLManifest{
+		Files: link.Files,
+	}, nil
+}
+
+func (s *ShareService) buildCollectionBundle(resources *sharedResources) *models.Bundle {
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Type:         "collection",
+		Timestamp:    ptr.To(time.Now().UTC().Format(time.RFC3339)),
+		Entry:        make([]models.BundleEntry, 0, len(resources.observations)+len(resources.documents)+len(resources.others)),
+	}
+
+	for i := range resources.observations {
+		s.appendBundleEntry(bundle, "Observation", resources.observations[i].Id, resources.observations[i])
+	}
+
+	for _, res := range resources.others {
+		s.appendBundleEntry(bundle, res.ref.Type, &res.ref.ID, res.resource)
+	}
+
+	seenDocs := make(map[string]bool)
+	for i := range resources.documents {
+		doc := resources.documents[i]
+		if doc.Id == nil || seenDocs[*doc.Id] {
+			continue
+		}
+		seenDocs[*doc.Id] = true
+		s.appendBundleEntry(bundle, "DocumentReference", doc.Id, doc)
+	}
+
+	return bundle
+}
+
+func (s *ShareService) appendBundleEntry(bundle *models.Bundle, resourceType string, id *string, resource any) {
+	if id == nil || *id == "" {
+		return
+	}
+
+	resourceRaw, err := json.Marshal(resource)
+	if err != nil {
+		return
+	}
+
+	bundle.Entry = append(bundle.Entry, models.BundleEntry{
+		FullUrl:  ptr.To(fmt.Sprintf("%s/api/v1/%s/%s", s.publicURL, resourceType, *id)),
+		Resource: resourceRaw,
+	})
+}
+
+func hashPasscode(passcode string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passcode, salt, shlPasscodeIteration, shlPasscodeKeySize)
+}
+
+func newSHLID() (string, error) {
+	raw := make([]byte, shlIDEntropyBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
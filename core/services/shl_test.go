@@ -0,0 +1,542 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/jwe"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testSHLID     = "shl-123"
+	testPublicURL = "https://docs.example.com"
+)
+
+func decodeSHLPayload(t *testing.T, link string) shlPayload {
+	require.True(t, strings.HasPrefix(link, shlPrefix))
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(link, shlPrefix))
+	require.NoError(t, err)
+
+	var payload shlPayload
+	require.NoError(t, json.Unmarshal(raw, &payload))
+	return payload
+}
+
+func createTestSHL(passcode string, expiresAt int64, remaining int) *domain.SmartHealthLink {
+	link := &domain.SmartHealthLink{
+		ID:        testSHLID,
+		PatientID: testPatientID,
+		Files: []domain.SHLManifestFile{
+			{ContentType: shlContentType, Embedded: "encrypted"},
+		},
+		ExpiresAt:         expiresAt,
+		RemainingAttempts: remaining,
+	}
+	if passcode != "" {
+		link.PasscodeSalt = []byte("0123456789abcdef")
+		link.PasscodeHash, _ = hashPasscode(passcode, link.PasscodeSalt)
+	}
+	return link
+}
+
+func TestShareService_CreateSHL(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            domain.SHLRequest
+		setupMocks     func(*ports.MockObservationRepository, *ports.MockDocumentRepository, *ports.MockSHLRepository)
+		setupContext   func() context.Context
+		expectedError  error
+		validateResult func(*testing.T, *domain.SHLResponse, error)
+	}{
+		{
+			name: "success path - encrypted bundle with passcode and expiry",
+			req: domain.SHLRequest{
+				ResourceIDs: []string{"Observation/" + testObsID},
+				TTLSeconds:  3600,
+				Passcode:    "1234",
+				Label:       "Lab results",
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+				obs := createTestObservationWithDerivedFrom(testObsID, testPatientID, []string{testDocID})
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testObsID}).
+					Return([]models.Observation{*obs}, nil)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), gomock.Any()).
+					Return([]models.DocumentReference{}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+				shlRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error) {
+						require.Equal(t, testPatientID, link.PatientID)
+						require.NotEmpty(t, link.PasscodeHash)
+						require.Equal(t, shlMaxAttempts, link.RemainingAttempts)
+						require.Len(t, link.Files, 1)
+						require.Greater(t, link.ExpiresAt, time.Now().Unix())
+						return link, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+
+				payload := decodeSHLPayload(t, resp.Link)
+				assert.Equal(t, testPublicURL+"/api/v1/shl/"+resp.ID, payload.URL)
+				assert.Equal(t, "P", payload.Flag)
+				assert.Equal(t, "Lab results", payload.Label)
+				assert.Equal(t, resp.ExpiresAt, payload.Exp)
+
+				key, err := base64.RawURLEncoding.DecodeString(payload.Key)
+				require.NoError(t, err)
+				assert.Len(t, key, 32)
+			},
+		},
+		{
+			name: "success path - no passcode, no expiry",
+			req: domain.SHLRequest{
+				ResourceIDs: []string{"DocumentReference/" + testDocID},
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), gomock.Any()).
+					Return([]models.Observation{}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+				shlRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error) {
+						require.Empty(t, link.PasscodeHash)
+						require.Zero(t, link.ExpiresAt)
+						return link, nil
+					})
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				require.NoError(t, err)
+				payload := decodeSHLPayload(t, resp.Link)
+				assert.Empty(t, payload.Flag)
+				assert.Zero(t, payload.Exp)
+			},
+		},
+		{
+			name: "error - resource not owned",
+			req: domain.SHLRequest{
+				ResourceIDs: []string{"Observation/" + testObsID},
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+				obs := createTestObservation(testObsID, "other-patient")
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testObsID}).
+					Return([]models.Observation{*obs}, nil)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), gomock.Any()).
+					Return([]models.DocumentReference{}, nil)
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrResourceNotOwned,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, domain.ErrResourceNotOwned)
+			},
+		},
+		{
+			name: "error - label too long",
+			req: domain.SHLRequest{
+				ResourceIDs: []string{"Observation/" + testObsID},
+				Label:       strings.Repeat("x", shlMaxLabelLength+1),
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInvalidInput,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			},
+		},
+		{
+			name: "error - no resources",
+			req:  domain.SHLRequest{},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrNoResourcesToShare,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, domain.ErrNoResourcesToShare)
+			},
+		},
+		{
+			name: "error - no identity",
+			req: domain.SHLRequest{
+				ResourceIDs: []string{"Observation/" + testObsID},
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+			},
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			expectedError: domain.ErrAccessDenied,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				assert.Nil(t, resp)
+				assert.Equal(t, domain.ErrAccessDenied, err)
+			},
+		},
+		{
+			name: "error - repository error",
+			req: domain.SHLRequest{
+				ResourceIDs: []string{"DocumentReference/" + testDocID},
+			},
+			setupMocks: func(obsRepo *ports.MockObservationRepository, docRepo *ports.MockDocumentRepository, shlRepo *ports.MockSHLRepository) {
+				obsRepo.EXPECT().
+					GetByIDs(gomock.Any(), gomock.Any()).
+					Return([]models.Observation{}, nil)
+				doc := createTestDocument(testDocID, testPatientID)
+				docRepo.EXPECT().
+					GetByIDs(gomock.Any(), []string{testDocID}).
+					Return([]models.DocumentReference{*doc}, nil)
+				shlRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			setupContext: func() context.Context {
+				id := createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"})
+				return identity.WithCtx(context.Background(), id)
+			},
+			expectedError: domain.ErrInternal,
+			validateResult: func(t *testing.T, resp *domain.SHLResponse, err error) {
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			shlRepo := ports.NewMockSHLRepository(ctrl)
+			client := ports.NewMockTmpAccessClient(ctrl)
+
+			tt.setupMocks(obsRepo, docRepo, shlRepo)
+
+			service := NewShareService(LinkOptions{PublicURL: testPublicURL}, obsRepo, docRepo, nil, shlRepo, client, ports.NewMockFileProvider(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl))
+
+			ctx := tt.setupContext()
+			result, err := service.CreateSHL(ctx, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
+
+func TestShareService_CreateSHL_BundleDecrypts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	obsRepo := ports.NewMockObservationRepository(ctrl)
+	docRepo := ports.NewMockDocumentRepository(ctrl)
+	shlRepo := ports.NewMockSHLRepository(ctrl)
+	client := ports.NewMockTmpAccessClient(ctrl)
+
+	obs := createTestObservation(testObsID, testPatientID)
+	doc := createTestDocument(testDocID, testPatientID)
+	obsRepo.EXPECT().GetByIDs(gomock.Any(), []string{testObsID}).Return([]models.Observation{*obs}, nil)
+	docRepo.EXPECT().GetByIDs(gomock.Any(), []string{testDocID}).Return([]models.DocumentReference{*doc}, nil)
+
+	var stored *domain.SmartHealthLink
+	shlRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error) {
+			stored = link
+			return link, nil
+		})
+
+	service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, shlRepo, client, ports.NewMockFileProvider(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl))
+	ctx := identity.WithCtx(context.Background(), createTestIdentity(testPatientID, testUserID, []string{"patient/*.read"}))
+
+	resp, err := service.CreateSHL(ctx, domain.SHLRequest{
+		ResourceIDs: []string{"Observation/" + testObsID, "DocumentReference/" + testDocID},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+
+	payload := decodeSHLPayload(t, resp.Link)
+	key, err := base64.RawURLEncoding.DecodeString(payload.Key)
+	require.NoError(t, err)
+
+	plaintext, err := jwe.Decrypt(key, stored.Files[0].Embedded)
+	require.NoError(t, err)
+
+	var bundle models.Bundle
+	require.NoError(t, json.Unmarshal(plaintext, &bundle))
+	assert.Equal(t, "collection", bundle.Type)
+	assert.Len(t, bundle.Entry, 2)
+}
+
+func TestShareService_CreateSHL_Delegate(t *testing.T) {
+	tests := []struct {
+		name          string
+		user          domain.Identity
+		subject       string
+		setupMocks    func(*ports.MockDelegationRepository, *ports.MockSHLRepository)
+		expectedError error
+	}{
+		{
+			name:    "link is recorded against the active patient",
+			user:    createTestCaregiverIdentity(testPatientID, []string{"patient/*.rs"}),
+			subject: testPatientID,
+			setupMocks: func(delegations *ports.MockDelegationRepository, shlRepo *ports.MockSHLRepository) {
+				delegations.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessWrite), nil)
+				shlRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, link *domain.SmartHealthLink) (*domain.SmartHealthLink, error) {
+						require.Equal(t, testPatientID, link.PatientID)
+						return link, nil
+					})
+			},
+		},
+		{
+			name:    "read delegation cannot share",
+			user:    createTestCaregiverIdentity(testPatientID, []string{"patient/*.rs"}),
+			subject: testPatientID,
+			setupMocks: func(delegations *ports.MockDelegationRepository, shlRepo *ports.MockSHLRepository) {
+				delegations.EXPECT().
+					Find(gomock.Any(), testPatientID, testCaregiverID).
+					Return(createTestDelegation(domain.CareAccessRead), nil)
+			},
+			expectedError: domain.ErrResourceNotOwned,
+		},
+		{
+			name: "own record is not shared while acting for another patient",
+			user: domain.Identity{
+				UserID:          testCaregiverID,
+				PatientID:       "caregiver-patient",
+				ActivePatientID: testPatientID,
+				Scopes:          []string{"patient/*.rs"},
+			},
+			subject:       "caregiver-patient",
+			setupMocks:    func(*ports.MockDelegationRepository, *ports.MockSHLRepository) {},
+			expectedError: domain.ErrResourceNotOwned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			shlRepo := ports.NewMockSHLRepository(ctrl)
+			delegations := ports.NewMockDelegationRepository(ctrl)
+
+			obs := createTestObservation(testObsID, tt.subject)
+			obsRepo.EXPECT().GetByIDs(gomock.Any(), []string{testObsID}).Return([]models.Observation{*obs}, nil)
+			docRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any()).Return([]models.DocumentReference{}, nil)
+			tt.setupMocks(delegations, shlRepo)
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), delegations, ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, shlRepo, ports.NewMockTmpAccessClient(ctrl), ports.NewMockFileProvider(ctrl), authz, permitAllConsents(ctrl), discardOutbox(ctrl))
+			ctx := identity.WithCtx(context.Background(), tt.user)
+
+			_, err := service.CreateSHL(ctx, domain.SHLRequest{ResourceIDs: []string{"Observation/" + testObsID}})
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestShareService_GetSHLManifest(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            domain.SHLManifestRequest
+		setupMocks     func(*ports.MockSHLRepository)
+		expectedError  error
+		validateResult func(*testing.T, *domain.SHLManifest, error)
+	}{
+		{
+			name: "success path - no passcode",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(createTestSHL("", 0, 0), nil)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				require.NoError(t, err)
+				require.Len(t, manifest.Files, 1)
+				assert.Equal(t, shlContentType, manifest.Files[0].ContentType)
+			},
+		},
+		{
+			name: "success path - correct passcode",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith", Passcode: "1234"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(createTestSHL("1234", 0, 3), nil)
+				gomock.InOrder(
+					shlRepo.EXPECT().DecrementAttempts(gomock.Any(), testSHLID).Return(2, true, nil),
+					shlRepo.EXPECT().ResetAttempts(gomock.Any(), testSHLID, shlMaxAttempts).Return(nil),
+				)
+			},
+			expectedError: nil,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				require.NoError(t, err)
+				require.Len(t, manifest.Files, 1)
+			},
+		},
+		{
+			name: "error - wrong passcode decrements attempts",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith", Passcode: "0000"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(createTestSHL("1234", 0, 3), nil)
+				shlRepo.EXPECT().DecrementAttempts(gomock.Any(), testSHLID).Return(2, true, nil)
+			},
+			expectedError: domain.ErrSHLPasscodeInvalid,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				assert.Nil(t, manifest)
+				assert.ErrorIs(t, err, domain.ErrSHLPasscodeInvalid)
+				assert.Contains(t, err.Error(), "2 attempts remaining")
+			},
+		},
+		{
+			name: "error - attempts exhausted",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith", Passcode: "1234"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(createTestSHL("1234", 0, 0), nil)
+				shlRepo.EXPECT().DecrementAttempts(gomock.Any(), testSHLID).Return(0, false, nil)
+			},
+			expectedError: domain.ErrSHLNotFound,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				assert.Nil(t, manifest)
+				assert.ErrorIs(t, err, domain.ErrSHLNotFound)
+			},
+		},
+		{
+			name: "error - parallel guesses used up the attempts",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith", Passcode: "1234"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(createTestSHL("1234", 0, 1), nil)
+				shlRepo.EXPECT().DecrementAttempts(gomock.Any(), testSHLID).Return(0, false, nil)
+			},
+			expectedError: domain.ErrSHLNotFound,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				assert.Nil(t, manifest)
+				assert.ErrorIs(t, err, domain.ErrSHLNotFound)
+			},
+		},
+		{
+			name: "error - expired",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(createTestSHL("", time.Now().Add(-time.Minute).Unix(), 0), nil)
+			},
+			expectedError: domain.ErrSHLNotFound,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				assert.Nil(t, manifest)
+				assert.ErrorIs(t, err, domain.ErrSHLNotFound)
+			},
+		},
+		{
+			name: "error - not found",
+			req:  domain.SHLManifestRequest{Recipient: "Dr. Smith"},
+			setupMocks: func(shlRepo *ports.MockSHLRepository) {
+				shlRepo.EXPECT().GetByID(gomock.Any(), testSHLID).Return(nil, nil)
+			},
+			expectedError: domain.ErrSHLNotFound,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				assert.Nil(t, manifest)
+				assert.ErrorIs(t, err, domain.ErrSHLNotFound)
+			},
+		},
+		{
+			name:          "error - recipient missing",
+			req:           domain.SHLManifestRequest{},
+			setupMocks:    func(shlRepo *ports.MockSHLRepository) {},
+			expectedError: domain.ErrInvalidInput,
+			validateResult: func(t *testing.T, manifest *domain.SHLManifest, err error) {
+				assert.Nil(t, manifest)
+				assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			obsRepo := ports.NewMockObservationRepository(ctrl)
+			docRepo := ports.NewMockDocumentRepository(ctrl)
+			shlRepo := ports.NewMockSHLRepository(ctrl)
+			client := ports.NewMockTmpAccessClient(ctrl)
+
+			tt.setupMocks(shlRepo)
+
+			service := NewShareService(LinkOptions{}, obsRepo, docRepo, nil, shlRepo, client, ports.NewMockFileProvider(ctrl), NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl)), permitAllConsents(ctrl), discardOutbox(ctrl))
+
+			result, err := service.GetSHLManifest(context.Background(), testSHLID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.validateResult != nil {
+				tt.validateResult(t, result, err)
+			}
+		})
+	}
+}
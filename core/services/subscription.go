@@ -0,0 +1,474 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+// SubscriptionService manages topic-based subscriptions and, as the
+// EventHandler of the outbox relay, queues a notification for every
+// subscription a resource event matches.
+type SubscriptionService struct {
+	repo          ports.SubscriptionRepository
+	topics        ports.SubscriptionTopicRepository
+	notifications ports.SubscriptionNotificationRepository
+	authz         ports.Authorizer
+	consent       ports.ConsentEvaluator
+	validator     *validator.SubscriptionValidator
+}
+
+func NewSubscriptionService(
+	repo ports.SubscriptionRepository,
+	topics ports.SubscriptionTopicRepository,
+	notifications ports.SubscriptionNotificationRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+	v *validator.SubscriptionValidator,
+) *SubscriptionService {
+	return &SubscriptionService{
+		repo:          repo,
+		topics:        topics,
+		notifications: notifications,
+		authz:         authz,
+		consent:       consent,
+		validator:     v,
+	}
+}
+
+// Create subscribes the user to an active topic. The subscription only ever
+// notifies of resources the user may read, and is activated right away with
+// a handshake sent to its endpoint.
+func (s *SubscriptionService) Create(ctx context.Context, sub *models.Subscription) (*models.Subscription, error) {
+	if err := s.validator.Validate(sub); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "Subscription"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if user.UserID == "" {
+		return nil, domain.ErrUserIDRequired
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "Subscription", OwnerID: user.UserID}); err != nil {
+		return nil, err
+	}
+
+	if sub.Id != nil && *sub.Id != "" {
+		return nil, fmt.Errorf("%w: subscription ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	if err := s.validateTopic(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	sub.Id = &id
+	if sub.Status == domain.SubscriptionStatusRequested {
+		sub.Status = domain.SubscriptionStatusActive
+	}
+	if sub.Status != domain.SubscriptionStatusActive && sub.Status != domain.SubscriptionStatusOff {
+		return nil, fmt.Errorf("%w: a new subscription must be requested, active or off", domain.ErrInvalidInput)
+	}
+
+	record := &domain.SubscriptionRecord{
+		Subscription: *sub,
+		Owner:        user.Principal(),
+		LastSentAt:   time.Now().Unix(),
+	}
+	if err := s.repo.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	if sub.Status == domain.SubscriptionStatusActive {
+		if err := s.handshake(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *SubscriptionService) Get(ctx context.Context, id string) (*models.Subscription, error) {
+	record, err := s.get(ctx, domain.ActionRead, id)
+	if err != nil {
+		return nil, err
+	}
+	return &record.Subscription, nil
+}
+
+// Update changes a subscription. Setting it to requested or active again
+// resumes delivery, after an error too, with a new handshake; setting it off
+// drops the notifications still queued.
+func (s *SubscriptionService) Update(ctx context.Context, sub *models.Subscription) (*models.Subscription, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "Subscription"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if sub.Id == nil || *sub.Id == "" {
+		return nil, domain.ErrSubscriptionIDRequired
+	}
+
+	existing, err := s.get(ctx, domain.ActionUpdate, *sub.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(sub); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	if err := s.validateTopic(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	if sub.Status == domain.SubscriptionStatusRequested {
+		sub.Status = domain.SubscriptionStatusActive
+	}
+	if sub.Status == domain.SubscriptionStatusError && existing.Subscription.Status != domain.SubscriptionStatusError {
+		return nil, fmt.Errorf("%w: only the server sets a subscription to error", domain.ErrInvalidInput)
+	}
+
+	if err := s.repo.Update(ctx, sub); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	if sub.Status != domain.SubscriptionStatusActive {
+		if err := s.notifications.DeleteBySubscription(ctx, *sub.Id); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+		}
+	} else if existing.Subscription.Status != domain.SubscriptionStatusActive {
+		if err := s.handshake(ctx, *sub.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *SubscriptionService) Delete(ctx context.Context, id string) error {
+	if _, err := s.get(ctx, domain.ActionDelete, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	if err := s.notifications.DeleteBySubscription(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+// List returns the subscriptions of the user.
+func (s *SubscriptionService) List(ctx context.Context, search domain.SubscriptionSearch, limit, offset int) (*domain.ListResponse[models.Subscription], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "Subscription"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	search.OwnerID = user.UserID
+	if search.OwnerID == "" {
+		return nil, domain.ErrUserIDRequired
+	}
+
+	items, total, err := s.repo.Search(ctx, search, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.Subscription]{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+// Status returns the $status of a subscription.
+func (s *SubscriptionService) Status(ctx context.Context, id string) (*models.SubscriptionStatus, error) {
+	record, err := s.get(ctx, domain.ActionRead, id)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionStatus(record, "query-status", nil), nil
+}
+
+// get loads a subscription and checks the user may perform action on it.
+func (s *SubscriptionService) get(ctx context.Context, action domain.Action, id string) (*domain.SubscriptionRecord, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, action, "Subscription"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if id == "" {
+		return nil, domain.ErrSubscriptionIDRequired
+	}
+
+	record, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if record == nil {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, action, subscriptionRef(record)); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// validateTopic checks that the subscribed topic is active and allows every
+// filter of the subscription.
+func (s *SubscriptionService) validateTopic(ctx context.Context, sub *models.Subscription) error {
+	topic, err := s.topics.GetByURL(ctx, sub.Topic)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if topic == nil || topic.Status != "active" {
+		return domain.ErrTopicRefNotFound
+	}
+
+	for _, filter := range sub.FilterBy {
+		if !topicAllowsFilter(topic, filter) {
+			return fmt.Errorf("%w: topic %s cannot filter by %q", domain.ErrInvalidInput, topic.Url, filter.FilterParameter)
+		}
+	}
+
+	return nil
+}
+
+func topicAllowsFilter(topic *models.SubscriptionTopic, filter models.SubscriptionFilterBy) bool {
+	for _, trigger := range topic.Trigger {
+		if filter.Resource != nil && domain.TopicResourceType(*filter.Resource) != domain.TopicResourceType(trigger.Resource) {
+			continue
+		}
+		for _, allowed := range trigger.CanFilterBy {
+			if allowed.FilterParameter == filter.FilterParameter {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *SubscriptionService) handshake(ctx context.Context, id string) error {
+	err := s.notifications.Enqueue(ctx, &domain.SubscriptionNotification{
+		ID:             uuid.New().String(),
+		SubscriptionID: id,
+		Type:           domain.SubscriptionNotificationHandshake,
+		NextAttemptAt:  time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	return nil
+}
+
+// HandleEvent queues a notification of a resource write for every active
+// subscription whose topic it triggers, whose filters it passes and whose
+// owner may read the resource.
+func (s *SubscriptionService) HandleEvent(ctx context.Context, event domain.DomainEvent) error {
+	resource, ok, err := resourceEvent(event)
+	if err != nil || !ok {
+		return err
+	}
+	return s.notify(ctx, resource)
+}
+
+func (s *SubscriptionService) notify(ctx context.Context, event domain.ResourceEvent) error {
+	topics, err := s.topics.ListActive(ctx, event.Ref.Type)
+	if err != nil {
+		return fmt.Errorf("listing topics: %w", err)
+	}
+
+	var urls []string
+	for i := range topics {
+		if topicTriggeredBy(&topics[i], event) {
+			urls = append(urls, topics[i].Url)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	subscriptions, err := s.repo.ListActive(ctx, urls)
+	if err != nil {
+		return fmt.Errorf("listing subscriptions: %w", err)
+	}
+
+	params := eventFilterParams(event)
+	for i := range subscriptions {
+		record := &subscriptions[i]
+		id := *record.Subscription.Id
+
+		if subscriptionEnded(&record.Subscription, event.OccurredAt) {
+			if err := s.repo.SetStatus(ctx, id, domain.SubscriptionStatusOff); err != nil {
+				return fmt.Errorf("ending subscription %s: %w", id, err)
+			}
+			continue
+		}
+
+		if !filtersMatch(record.Subscription.FilterBy, event.Ref.Type, params) {
+			continue
+		}
+
+		allowed, err := mayRead(ctx, s.authz, s.consent, record.Owner, event.Ref)
+		if err != nil {
+			return fmt.Errorf("authorizing subscription %s: %w", id, err)
+		}
+		if !allowed {
+			continue
+		}
+
+		number, err := s.repo.NextEventNumber(ctx, id)
+		if err != nil {
+			return fmt.Errorf("numbering event of subscription %s: %w", id, err)
+		}
+
+		err = s.notifications.Enqueue(ctx, &domain.SubscriptionNotification{
+			ID:             uuid.New().String(),
+			SubscriptionID: id,
+			Type:           domain.SubscriptionNotificationEvent,
+			EventNumber:    number,
+			Event:          &event,
+			NextAttemptAt:  event.OccurredAt.Unix(),
+		})
+		if err != nil {
+			return fmt.Errorf("queueing notification of subscription %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// mayRead reports whether the owner of a subscription may read the resource
+// of an event under the grants and consents in force now, not the scopes of
+// the token the subscription was created with.
+func mayRead(ctx context.Context, authz ports.Authorizer, consent ports.ConsentEvaluator, owner domain.Principal, ref domain.ResourceRef) (bool, error) {
+	decision, err := authz.AuthorizeStanding(ctx, owner, domain.ActionRead, ref)
+	if err != nil {
+		return false, err
+	}
+	if !decision.Allowed {
+		return false, nil
+	}
+
+	denials, err := consent.Withheld(ctx, owner.Identity(), domain.ActionRead, []domain.ResourceRef{ref})
+	if err != nil {
+		return false, err
+	}
+	return len(denials) == 0, nil
+}
+
+func topicTriggeredBy(topic *models.SubscriptionTopic, event domain.ResourceEvent) bool {
+	for _, trigger := range topic.Trigger {
+		if domain.TopicResourceType(trigger.Resource) == event.Ref.Type &&
+			slices.Contains(trigger.SupportedInteraction, string(event.Interaction)) {
+			return true
+		}
+	}
+	return false
+}
+
+func subscriptionEnded(sub *models.Subscription, now time.Time) bool {
+	if sub.End == nil {
+		return false
+	}
+	end, err := time.Parse(time.RFC3339, *sub.End)
+	return err == nil && !end.After(now)
+}
+
+// eventFilterParams returns the values of the resource of an event that
+// subscription filters match against: its token search parameters, status
+// and patient.
+func eventFilterParams(event domain.ResourceEvent) url.Values {
+	params := url.Values{}
+	for name, values := range event.Ref.SearchParams {
+		params[name] = values
+	}
+	if event.Status != "" {
+		params["status"] = []string{event.Status}
+	}
+	if event.Ref.PatientID != "" {
+		params["patient"] = []string{event.Ref.PatientID, "Patient/" + event.Ref.PatientID}
+	}
+	return params
+}
+
+// filtersMatch reports whether the resource passes every filter that applies
+// to its type. A comma-separated value matches any of its values; the not
+// modifier inverts the match.
+func filtersMatch(filters []models.SubscriptionFilterBy, resourceType string, params url.Values) bool {
+	for _, filter := range filters {
+		if filter.Resource != nil && domain.TopicResourceType(*filter.Resource) != resourceType {
+			continue
+		}
+
+		matched := false
+		for _, value := range strings.Split(filter.Value, ",") {
+			if slices.Contains(params[filter.FilterParameter], strings.TrimSpace(value)) {
+				matched = true
+				break
+			}
+		}
+
+		if filter.Modifier != nil && *filter.Modifier == "not" {
+			matched = !matched
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// subscriptionStatus describes the state of a subscription and the events of
+// a notification.
+func subscriptionStatus(record *domain.SubscriptionRecord, statusType string, events []models.SubscriptionStatusNotificationEvent) *models.SubscriptionStatus {
+	ref := "Subscription/" + *record.Subscription.Id
+	status := record.Subscription.Status
+	count := record.EventCount
+	return &models.SubscriptionStatus{
+		ResourceType:                 "SubscriptionStatus",
+		Status:                       &status,
+		Type:                         statusType,
+		EventsSinceSubscriptionStart: &count,
+		NotificationEvent:            events,
+		Subscription:                 &models.Reference{Reference: &ref},
+		Topic:                        &record.Subscription.Topic,
+	}
+}
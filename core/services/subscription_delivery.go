@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	deliveryLease               = "subscription-delivery"
+	defaultDeliveryInterval     = 5 * time.Second
+	defaultDeliveryLeaseTTL     = time.Minute
+	minDeliveryLeaseTTL         = 3 * domain.SubscriptionMaxTimeout * time.Second
+	defaultDeliveryMaxAttempts  = 8
+	defaultDeliveryRetryBackoff = 10 * time.Second
+	maxDeliveryRetryBackoff     = time.Hour
+	deliveryBatchSize           = 100
+)
+
+// DeliveryOptions configure the SubscriptionDispatcher; zero values take the
+// defaults.
+type DeliveryOptions struct {
+	// Interval is how often queued notifications and heartbeats are
+	// delivered; LeaseTTL is as for ReminderOptions.
+	Interval time.Duration
+	LeaseTTL time.Duration
+	// MaxAttempts is how many times a notification is tried before its
+	// subscription is set to error. RetryBackoff is the wait after the first
+	// failure, doubled after each further one.
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// SubscriptionDispatcher delivers queued subscription notifications and
+// heartbeats. Like the ReminderScheduler it runs on every replica and a lease
+// lets one of them deliver at a time. Notifications of a subscription are
+// delivered in event order: when one fails, it and the later ones wait for
+// the retry, with a backoff doubling after each failure, and the
+// subscription is set to error once the attempts run out. The lease is
+// renewed while a tick delivers, so the slowest endpoints cannot make it
+// expire mid-tick and let another replica deliver the same notifications.
+// The owner's access is checked again before an event is delivered, so
+// grants and consents withdrawn while it was queued are respected.
+type SubscriptionDispatcher struct {
+	subscriptions ports.SubscriptionRepository
+	notifications ports.SubscriptionNotificationRepository
+	channel       ports.NotificationChannel
+	leases        ports.LeaseRepository
+	authz         ports.Authorizer
+	consent       ports.ConsentEvaluator
+	publicURL     string
+	interval      time.Duration
+	leaseTTL      time.Duration
+	maxAttempts   int
+	retryBackoff  time.Duration
+	holder        string
+	leasedAt      time.Time
+	now           func() time.Time
+}
+
+func NewSubscriptionDispatcher(
+	opts DeliveryOptions,
+	links LinkOptions,
+	subscriptions ports.SubscriptionRepository,
+	notifications ports.SubscriptionNotificationRepository,
+	channel ports.NotificationChannel,
+	leases ports.LeaseRepository,
+	authz ports.Authorizer,
+	consent ports.ConsentEvaluator,
+) *SubscriptionDispatcher {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultDeliveryInterval
+	}
+	leaseTTL := opts.LeaseTTL
+	if leaseTTL <= interval {
+		leaseTTL = max(defaultDeliveryLeaseTTL, 2*interval)
+	}
+	leaseTTL = max(leaseTTL, minDeliveryLeaseTTL)
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultDeliveryMaxAttempts
+	}
+	retryBackoff := opts.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultDeliveryRetryBackoff
+	}
+
+	hostname, _ := os.Hostname()
+	return &SubscriptionDispatcher{
+		subscriptions: subscriptions,
+		notifications: notifications,
+		channel:       channel,
+		leases:        leases,
+		authz:         authz,
+		consent:       consent,
+		publicURL:     links.baseURL(),
+		interval:      interval,
+		leaseTTL:      leaseTTL,
+		maxAttempts:   maxAttempts,
+		retryBackoff:  retryBackoff,
+		holder:        hostname + "-" + uuid.New().String(),
+		now:           time.Now,
+	}
+}
+
+// Run delivers notifications every interval until ctx is done.
+func (d *SubscriptionDispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.tick(ctx); err != nil {
+			log.Printf("Subscription delivery: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			d.releaseLease()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *SubscriptionDispatcher) releaseLease() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.leases.Release(ctx, deliveryLease, d.holder); err != nil {
+		log.Printf("Subscription delivery: releasing lease: %v", err)
+	}
+}
+
+// tick delivers the notifications due now and the heartbeats that are
+// overdue if this replica holds the lease.
+func (d *SubscriptionDispatcher) tick(ctx context.Context) error {
+	acquired, err := d.leases.Acquire(ctx, deliveryLease, d.holder, d.leaseTTL)
+	if err != nil {
+		return fmt.Errorf("acquiring lease: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	d.leasedAt = d.now()
+
+	now := d.now()
+	due, err := d.notifications.ListDue(ctx, now, deliveryBatchSize)
+	if err != nil {
+		return fmt.Errorf("listing notifications: %w", err)
+	}
+
+	records := make(map[string]*domain.SubscriptionRecord)
+	held := make(map[string]bool)
+	for _, n := range due {
+		if held[n.SubscriptionID] {
+			continue
+		}
+
+		record, ok := records[n.SubscriptionID]
+		if !ok {
+			if record, err = d.subscriptions.GetByID(ctx, n.SubscriptionID); err != nil {
+				return fmt.Errorf("loading subscription %s: %w", n.SubscriptionID, err)
+			}
+			records[n.SubscriptionID] = record
+		}
+		if record == nil || record.Subscription.Status != domain.SubscriptionStatusActive {
+			if err := d.notifications.Delete(ctx, n.ID); err != nil {
+				return fmt.Errorf("dropping notification %s: %w", n.ID, err)
+			}
+			continue
+		}
+
+		if n.Event != nil {
+			allowed, err := mayRead(ctx, d.authz, d.consent, record.Owner, n.Event.Ref)
+			if err != nil {
+				return fmt.Errorf("authorizing notification %s: %w", n.ID, err)
+			}
+			if !allowed {
+				if err := d.notifications.Delete(ctx, n.ID); err != nil {
+					return fmt.Errorf("dropping notification %s: %w", n.ID, err)
+				}
+				continue
+			}
+		}
+
+		if kept, err := d.keepLease(ctx); !kept {
+			return err
+		}
+		if err := d.send(ctx, record, n.Type, &n, now); err != nil {
+			log.Printf("Subscription delivery: %s of subscription %s: %v", n.Type, n.SubscriptionID, err)
+			held[n.SubscriptionID] = true
+			if err := d.retry(ctx, record, n, now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := d.notifications.Delete(ctx, n.ID); err != nil {
+			return fmt.Errorf("removing delivered notification %s: %w", n.ID, err)
+		}
+	}
+
+	return d.heartbeats(ctx, now, held)
+}
+
+// heartbeats sends a heartbeat to every subscription that has been sent
+// nothing for its heartbeat period. A failed heartbeat is not retried; the
+// next one is due at the following tick.
+func (d *SubscriptionDispatcher) heartbeats(ctx context.Context, now time.Time, held map[string]bool) error {
+	subscriptions, err := d.subscriptions.ListHeartbeat(ctx)
+	if err != nil {
+		return fmt.Errorf("listing heartbeat subscriptions: %w", err)
+	}
+
+	for i := range subscriptions {
+		record := &subscriptions[i]
+		sub := &record.Subscription
+		if sub.HeartbeatPeriod == nil || held[*sub.Id] {
+			continue
+		}
+		if now.Unix()-record.LastSentAt < int64(*sub.HeartbeatPeriod) {
+			continue
+		}
+
+		if kept, err := d.keepLease(ctx); !kept {
+			return err
+		}
+		if err := d.send(ctx, record, domain.SubscriptionNotificationHeartbeat, nil, now); err != nil {
+			log.Printf("Subscription delivery: heartbeat of subscription %s: %v", *sub.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// keepLease renews the lease once a third of its TTL has passed since it was
+// taken. As endpoints get at most SubscriptionMaxTimeout to answer, which the
+// TTL is at least three times, a send started with the lease held ends
+// before it expires. It reports false when the lease is lost, which ends the
+// tick.
+func (d *SubscriptionDispatcher) keepLease(ctx context.Context) (bool, error) {
+	if d.now().Sub(d.leasedAt) < d.leaseTTL/3 {
+		return true, nil
+	}
+
+	acquired, err := d.leases.Acquire(ctx, deliveryLease, d.holder, d.leaseTTL)
+	if err != nil {
+		return false, fmt.Errorf("renewing lease: %w", err)
+	}
+	if acquired {
+		d.leasedAt = d.now()
+	}
+	return acquired, nil
+}
+
+// send delivers a notification bundle and records when the subscription was
+// last sent to.
+func (d *SubscriptionDispatcher) send(ctx context.Context, record *domain.SubscriptionRecord, notificationType string, n *domain.SubscriptionNotification, now time.Time) error {
+	bundle, err := d.bundle(record, notificationType, n, now)
+	if err != nil {
+		return err
+	}
+
+	if err := d.channel.Send(ctx, &record.Subscription, bundle); err != nil {
+		return err
+	}
+
+	record.LastSentAt = now.Unix()
+	if err := d.subscriptions.MarkSent(ctx, *record.Subscription.Id, now); err != nil {
+		log.Printf("Subscription delivery: recording delivery to subscription %s: %v", *record.Subscription.Id, err)
+	}
+	return nil
+}
+
+// retry schedules another attempt of a failed notification or, once the
+// attempts are used up, sets its subscription to error and drops its queue.
+func (d *SubscriptionDispatcher) retry(ctx context.Context, record *domain.SubscriptionRecord, n domain.SubscriptionNotification, now time.Time) error {
+	id := *record.Subscription.Id
+	if n.Attempts+1 >= d.maxAttempts {
+		if err := d.subscriptions.SetStatus(ctx, id, domain.SubscriptionStatusError); err != nil {
+			return fmt.Errorf("setting subscription %s to error: %w", id, err)
+		}
+		if err := d.notifications.DeleteBySubscription(ctx, id); err != nil {
+			return fmt.Errorf("dropping notifications of subscription %s: %w", id, err)
+		}
+		return nil
+	}
+
+	if err := d.notifications.Retry(ctx, n, now.Add(d.backoff(n.Attempts+1))); err != nil {
+		return fmt.Errorf("rescheduling notification %s: %w", n.ID, err)
+	}
+	return nil
+}
+
+// backoff returns the wait before the next attempt after the given number of
+// failed ones.
+func (d *SubscriptionDispatcher) backoff(failures int) time.Duration {
+	wait := d.retryBackoff
+	for i := 1; i < failures && wait < maxDeliveryRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxDeliveryRetryBackoff)
+}
+
+// bundle builds the subscription-notification bundle of a notification: a
+// SubscriptionStatus followed, for full-resource subscriptions, by the
+// resource the event is about.
+func (d *SubscriptionDispatcher) bundle(record *domain.SubscriptionRecord, notificationType string, n *domain.SubscriptionNotification, now time.Time) (*models.Bundle, error) {
+	content := domain.SubscriptionContentIDOnly
+	if record.Subscription.Content != nil {
+		content = *record.Subscription.Content
+	}
+
+	var events []models.SubscriptionStatusNotificationEvent
+	var entries []models.BundleEntry
+	if n != nil && n.Event != nil {
+		timestamp := n.Event.OccurredAt.UTC().Format(time.RFC3339)
+		event := models.SubscriptionStatusNotificationEvent{
+			EventNumber: n.EventNumber,
+			Timestamp:   &timestamp,
+		}
+		if content != domain.SubscriptionContentEmpty {
+			focus := n.Event.Focus()
+			event.Focus = &models.Reference{Reference: &focus}
+
+			fullURL := d.publicURL + "/api/v1/" + focus
+			entry := models.BundleEntry{FullUrl: &fullURL}
+			if n.Event.Interaction == domain.InteractionDelete {
+				entry.Request = &models.BundleEntryRequest{Method: "DELETE", Url: focus}
+			} else if content == domain.SubscriptionContentFull {
+				entry.Resource = n.Event.Resource
+			}
+			entries = append(entries, entry)
+		}
+		events = append(events, event)
+	}
+
+	status, err := json.Marshal(subscriptionStatus(record, notificationType, events))
+	if err != nil {
+		return nil, fmt.Errorf("encoding subscription status: %w", err)
+	}
+
+	timestamp := now.UTC().Format(time.RFC3339)
+	bundle := &models.Bundle{
+		ResourceType: "Bundle",
+		Type:         "subscription-notification",
+		Timestamp:    &timestamp,
+		Entry:        append([]models.BundleEntry{{FullUrl: ptr.To("urn:uuid:" + uuid.New().String()), Resource: status}}, entries...),
+	}
+	return bundle, nil
+}
@@ -0,0 +1,541 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/netguard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	testSubscriptionID = "sub-123"
+	testTopicURL       = "https://codex.gruzdev.dev/fhir/SubscriptionTopic/observation-changes"
+	testEndpoint       = "https://analytics.example.com/hook"
+)
+
+// publicEndpoints lets subscriptions notify public endpoints only.
+var publicEndpoints, _ = netguard.New(nil)
+
+func createTestTopic() *models.SubscriptionTopic {
+	return &models.SubscriptionTopic{
+		ResourceType: "SubscriptionTopic",
+		Id:           strPtr("topic-1"),
+		Url:          testTopicURL,
+		Status:       "active",
+		Trigger: []models.SubscriptionTopicTrigger{{
+			Resource:             "Observation",
+			SupportedInteraction: []string{"create", "update"},
+			CanFilterBy: []models.SubscriptionTopicTriggerCanFilterBy{
+				{FilterParameter: "code"},
+				{FilterParameter: "patient"},
+			},
+		}},
+	}
+}
+
+func createTestSubscription(id string, filters ...models.SubscriptionFilterBy) *models.Subscription {
+	sub := &models.Subscription{
+		ResourceType: "Subscription",
+		Status:       "requested",
+		Topic:        testTopicURL,
+		ChannelType:  &models.Coding{System: strPtr(domain.SubscriptionChannelSystem), Code: strPtr("rest-hook")},
+		Endpoint:     strPtr(testEndpoint),
+		Content:      strPtr("full-resource"),
+		FilterBy:     filters,
+	}
+	if id != "" {
+		sub.Id = strPtr(id)
+	}
+	return sub
+}
+
+func TestSubscriptionService_Create(t *testing.T) {
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Subscription.c"})
+
+	tests := []struct {
+		name          string
+		sub           *models.Subscription
+		topic         *models.SubscriptionTopic
+		expectedError error
+	}{
+		{
+			name:  "success path - activated with a handshake",
+			sub:   createTestSubscription("", models.SubscriptionFilterBy{FilterParameter: "code", Value: "8867-4"}),
+			topic: createTestTopic(),
+		},
+		{
+			name:          "error - topic not found",
+			sub:           createTestSubscription(""),
+			expectedError: domain.ErrTopicRefNotFound,
+		},
+		{
+			name: "error - topic retired",
+			sub:  createTestSubscription(""),
+			topic: func() *models.SubscriptionTopic {
+				topic := createTestTopic()
+				topic.Status = "retired"
+				return topic
+			}(),
+			expectedError: domain.ErrTopicRefNotFound,
+		},
+		{
+			name:          "error - filter the topic does not allow",
+			sub:           createTestSubscription("", models.SubscriptionFilterBy{FilterParameter: "category", Value: "vital-signs"}),
+			topic:         createTestTopic(),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - loopback endpoint",
+			sub: func() *models.Subscription {
+				sub := createTestSubscription("")
+				sub.Endpoint = strPtr("http://127.0.0.1:8080/admin")
+				return sub
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - localhost endpoint",
+			sub: func() *models.Subscription {
+				sub := createTestSubscription("")
+				sub.Endpoint = strPtr("http://localhost/hook")
+				return sub
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - link-local metadata endpoint",
+			sub: func() *models.Subscription {
+				sub := createTestSubscription("")
+				sub.Endpoint = strPtr("http://169.254.169.254/latest/meta-data")
+				return sub
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - timeout longer than the delivery lease allows",
+			sub: func() *models.Subscription {
+				sub := createTestSubscription("")
+				timeout := 300
+				sub.Timeout = &timeout
+				return sub
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "error - websocket channel",
+			sub: func() *models.Subscription {
+				sub := createTestSubscription("")
+				sub.ChannelType.Code = strPtr("websocket")
+				return sub
+			}(),
+			expectedError: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockSubscriptionRepository(ctrl)
+			topics := ports.NewMockSubscriptionTopicRepository(ctrl)
+			notifications := ports.NewMockSubscriptionNotificationRepository(ctrl)
+
+			topics.EXPECT().GetByURL(gomock.Any(), testTopicURL).Return(tt.topic, nil).MaxTimes(1)
+			if tt.expectedError == nil {
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, record *domain.SubscriptionRecord) error {
+						assert.Equal(t, testUserID, record.Owner.UserID)
+						assert.Equal(t, domain.SubscriptionStatusActive, record.Subscription.Status)
+						return nil
+					})
+				notifications.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, n *domain.SubscriptionNotification) error {
+						assert.Equal(t, domain.SubscriptionNotificationHandshake, n.Type)
+						return nil
+					})
+			}
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewSubscriptionService(repo, topics, notifications, authz, permitAllConsents(ctrl), validator.NewSubscriptionValidator(publicEndpoints))
+			result, err := service.Create(identity.WithCtx(context.Background(), patient), tt.sub)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, *result.Id)
+			assert.Equal(t, domain.SubscriptionStatusActive, result.Status)
+		})
+	}
+}
+
+func TestSubscriptionService_Get_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := ports.NewMockSubscriptionRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), testSubscriptionID).Return(&domain.SubscriptionRecord{
+		Subscription: *createTestSubscription(testSubscriptionID),
+		Owner:        domain.Principal{UserID: "other-user"},
+	}, nil)
+
+	authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), ports.NewMockDelegationRepository(ctrl), ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+	service := NewSubscriptionService(repo, ports.NewMockSubscriptionTopicRepository(ctrl), ports.NewMockSubscriptionNotificationRepository(ctrl), authz, permitAllConsents(ctrl), validator.NewSubscriptionValidator(publicEndpoints))
+
+	patient := createTestIdentity(testPatientID, testUserID, []string{"patient/Subscription.r"})
+	result, err := service.Get(identity.WithCtx(context.Background(), patient), testSubscriptionID)
+
+	assert.ErrorIs(t, err, domain.ErrAccessDenied)
+	assert.Nil(t, result)
+}
+
+func TestSubscriptionService_HandleEvent(t *testing.T) {
+	owner := domain.Principal{UserID: testUserID, PatientID: testPatientID}
+	heartRate := coding("http://loinc.org", "8867-4")
+
+	tests := []struct {
+		name          string
+		eventType     domain.EventType
+		patientID     string
+		filters       []models.SubscriptionFilterBy
+		expectedQueue bool
+	}{
+		{
+			name:          "matching create is queued",
+			eventType:     domain.EventResourceCreated,
+			patientID:     testPatientID,
+			filters:       []models.SubscriptionFilterBy{{FilterParameter: "code", Value: "http://loinc.org|8867-4"}},
+			expectedQueue: true,
+		},
+		{
+			name:        "filter mismatch",
+			eventType:   domain.EventResourceCreated,
+			patientID:   testPatientID,
+			filters:     []models.SubscriptionFilterBy{{FilterParameter: "code", Value: "2339-0"}},
+		},
+		{
+			name:          "not modifier inverts the filter",
+			eventType:     domain.EventResourceUpdated,
+			patientID:     testPatientID,
+			filters:       []models.SubscriptionFilterBy{{FilterParameter: "code", Value: "2339-0", Modifier: strPtr("not")}},
+			expectedQueue: true,
+		},
+		{
+			name:        "interaction not supported by the topic",
+			eventType:   domain.EventResourceDeleted,
+			patientID:   testPatientID,
+		},
+		{
+			name:      "share is not a resource write",
+			eventType: domain.EventResourceShared,
+			patientID: testPatientID,
+		},
+		{
+			name:        "owner may not read the resource",
+			eventType:   domain.EventResourceCreated,
+			patientID:   "other-patient",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := ports.NewMockSubscriptionRepository(ctrl)
+			topics := ports.NewMockSubscriptionTopicRepository(ctrl)
+			notifications := ports.NewMockSubscriptionNotificationRepository(ctrl)
+
+			if tt.eventType != domain.EventResourceShared {
+				topics.EXPECT().ListActive(gomock.Any(), "Observation").Return([]models.SubscriptionTopic{*createTestTopic()}, nil)
+			}
+			if tt.eventType == domain.EventResourceCreated || tt.eventType == domain.EventResourceUpdated {
+				repo.EXPECT().ListActive(gomock.Any(), []string{testTopicURL}).Return([]domain.SubscriptionRecord{{
+					Subscription: *createTestSubscription(testSubscriptionID, tt.filters...),
+					Owner:        owner,
+				}}, nil)
+			}
+			if tt.expectedQueue {
+				repo.EXPECT().NextEventNumber(gomock.Any(), testSubscriptionID).Return(int64(7), nil)
+				notifications.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, n *domain.SubscriptionNotification) error {
+						assert.Equal(t, domain.SubscriptionNotificationEvent, n.Type)
+						assert.Equal(t, int64(7), n.EventNumber)
+						assert.Equal(t, "Observation/"+testObsID, n.Event.Focus())
+						assert.Equal(t, "final", n.Event.Status)
+						return nil
+					})
+			}
+
+			delegations := ports.NewMockDelegationRepository(ctrl)
+			delegations.EXPECT().Find(gomock.Any(), tt.patientID, testUserID).Return(nil, nil).AnyTimes()
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), delegations, ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			service := NewSubscriptionService(repo, topics, notifications, authz, permitAllConsents(ctrl), validator.NewSubscriptionValidator(publicEndpoints))
+
+			obs := createTestObservation(testObsID, tt.patientID)
+			obs.Code = &models.CodeableConcept{Coding: []models.Coding{heartRate}}
+			err := service.HandleEvent(context.Background(), domainEvent(tt.eventType, observationRef(obs), obs))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSubscriptionDispatcher_Tick(t *testing.T) {
+	now := time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)
+	obs := createTestObservation(testObsID, testPatientID)
+	resource, _ := json.Marshal(obs)
+	event := domain.ResourceEvent{
+		Interaction: domain.InteractionCreate,
+		Ref:         observationRef(obs),
+		Status:      obs.Status,
+		Resource:    resource,
+		OccurredAt:  now,
+	}
+
+	tests := []struct {
+		name           string
+		attempts       int
+		sendErr        error
+		expectedRetry  time.Duration
+		expectedStatus string
+		withdrawn      bool
+	}{
+		{
+			name: "delivered",
+		},
+		{
+			name:      "access withdrawn while queued - notification dropped",
+			withdrawn: true,
+		},
+		{
+			name:          "first failure retried after the base backoff",
+			sendErr:       errors.New("connection refused"),
+			expectedRetry: 10 * time.Second,
+		},
+		{
+			name:          "backoff doubles with each failure",
+			attempts:      3,
+			sendErr:       errors.New("503 Service Unavailable"),
+			expectedRetry: 80 * time.Second,
+		},
+		{
+			name:           "attempts used up - subscription set to error",
+			attempts:       4,
+			sendErr:        errors.New("503 Service Unavailable"),
+			expectedStatus: domain.SubscriptionStatusError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subscriptions := ports.NewMockSubscriptionRepository(ctrl)
+			notifications := ports.NewMockSubscriptionNotificationRepository(ctrl)
+			channel := ports.NewMockNotificationChannel(ctrl)
+			leases := ports.NewMockLeaseRepository(ctrl)
+
+			delegations := ports.NewMockDelegationRepository(ctrl)
+
+			authz := NewPolicyAuthorizer(ports.NewMockCareRelationshipRepository(ctrl), delegations, ports.NewMockBreakGlassRepository(ctrl), ports.NewMockBreakGlassAuditor(ctrl))
+			dispatcher := NewSubscriptionDispatcher(DeliveryOptions{MaxAttempts: 5}, LinkOptions{PublicURL: "https://codex.example.com"}, subscriptions, notifications, channel, leases, authz, permitAllConsents(ctrl))
+			dispatcher.now = func() time.Time { return now }
+
+			n := domain.SubscriptionNotification{
+				ID:             "n-1",
+				SubscriptionID: testSubscriptionID,
+				Type:           domain.SubscriptionNotificationEvent,
+				EventNumber:    3,
+				Event:          &event,
+				Attempts:       tt.attempts,
+			}
+			record := &domain.SubscriptionRecord{
+				Subscription: *createTestSubscription(testSubscriptionID),
+				Owner:        domain.Principal{UserID: testUserID, PatientID: testPatientID},
+				EventCount:   3,
+			}
+			record.Subscription.Status = domain.SubscriptionStatusActive
+			if tt.withdrawn {
+				record.Owner = domain.Principal{UserID: testUserID}
+				delegations.EXPECT().Find(gomock.Any(), testPatientID, testUserID).Return(nil, nil)
+			}
+
+			leases.EXPECT().Acquire(gomock.Any(), deliveryLease, dispatcher.holder, dispatcher.leaseTTL).Return(true, nil)
+			notifications.EXPECT().ListDue(gomock.Any(), now, deliveryBatchSize).Return([]domain.SubscriptionNotification{n}, nil)
+			subscriptions.EXPECT().GetByID(gomock.Any(), testSubscriptionID).Return(record, nil)
+			subscriptions.EXPECT().ListHeartbeat(gomock.Any()).Return(nil, nil)
+			if tt.withdrawn {
+				notifications.EXPECT().Delete(gomock.Any(), "n-1").Return(nil)
+				require.NoError(t, dispatcher.tick(context.Background()))
+				return
+			}
+			channel.EXPECT().
+				Send(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, sub *models.Subscription, bundle *models.Bundle) error {
+					assert.Equal(t, "subscription-notification", bundle.Type)
+					require.Len(t, bundle.Entry, 2)
+
+					var status models.SubscriptionStatus
+					require.NoError(t, json.Unmarshal(bundle.Entry[0].Resource, &status))
+					assert.Equal(t, domain.SubscriptionNotificationEvent, status.Type)
+					assert.Equal(t, int64(3), *status.EventsSinceSubscriptionStart)
+					require.Len(t, status.NotificationEvent, 1)
+					assert.Equal(t, int64(3), status.NotificationEvent[0].EventNumber)
+					assert.Equal(t, "Observation/"+testObsID, *status.NotificationEvent[0].Focus.Reference)
+
+					assert.Equal(t, "https://codex.example.com/api/v1/Observation/"+testObsID, *bundle.Entry[1].FullUrl)
+					assert.JSONEq(t, string(event.Resource), string(bundle.Entry[1].Resource))
+					return tt.sendErr
+				})
+
+			switch {
+			case tt.sendErr == nil:
+				subscriptions.EXPECT().MarkSent(gomock.Any(), testSubscriptionID, now).Return(nil)
+				notifications.EXPECT().Delete(gomock.Any(), "n-1").Return(nil)
+			case tt.expectedStatus != "":
+				subscriptions.EXPECT().SetStatus(gomock.Any(), testSubscriptionID, tt.expectedStatus).Return(nil)
+				notifications.EXPECT().DeleteBySubscription(gomock.Any(), testSubscriptionID).Return(nil)
+			default:
+				notifications.EXPECT().Retry(gomock.Any(), n, now.Add(tt.expectedRetry)).Return(nil)
+			}
+
+			require.NoError(t, dispatcher.tick(context.Background()))
+		})
+	}
+}
+
+func TestSubscriptionDispatcher_Tick_RenewsLease(t *testing.T) {
+	start := time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		renewed       bool
+		expectedSends []string
+	}{
+		{name: "lease renewed - delivery goes on", renewed: true, expectedSends: []string{"sub-1", "sub-2"}},
+		{name: "lease lost - delivery stops", expectedSends: []string{"sub-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subscriptions := ports.NewMockSubscriptionRepository(ctrl)
+			notifications := ports.NewMockSubscriptionNotificationRepository(ctrl)
+			channel := ports.NewMockNotificationChannel(ctrl)
+			leases := ports.NewMockLeaseRepository(ctrl)
+
+			dispatcher := NewSubscriptionDispatcher(DeliveryOptions{}, LinkOptions{}, subscriptions, notifications, channel, leases, ports.NewMockAuthorizer(ctrl), ports.NewMockConsentEvaluator(ctrl))
+			clock := start
+			dispatcher.now = func() time.Time { return clock }
+
+			var due []domain.SubscriptionNotification
+			for _, id := range []string{"sub-1", "sub-2"} {
+				due = append(due, domain.SubscriptionNotification{ID: "n-" + id, SubscriptionID: id, Type: domain.SubscriptionNotificationHandshake})
+				record := &domain.SubscriptionRecord{Subscription: *createTestSubscription(id)}
+				record.Subscription.Status = domain.SubscriptionStatusActive
+				subscriptions.EXPECT().GetByID(gomock.Any(), id).Return(record, nil).MaxTimes(1)
+			}
+
+			gomock.InOrder(
+				leases.EXPECT().Acquire(gomock.Any(), deliveryLease, dispatcher.holder, dispatcher.leaseTTL).Return(true, nil),
+				leases.EXPECT().Acquire(gomock.Any(), deliveryLease, dispatcher.holder, dispatcher.leaseTTL).Return(tt.renewed, nil),
+			)
+			notifications.EXPECT().ListDue(gomock.Any(), start, deliveryBatchSize).Return(due, nil)
+
+			var sent []string
+			channel.EXPECT().
+				Send(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, sub *models.Subscription, bundle *models.Bundle) error {
+					sent = append(sent, *sub.Id)
+					// A slow endpoint uses up a good part of the lease.
+					clock = clock.Add(domain.SubscriptionMaxTimeout * time.Second)
+					return nil
+				}).
+				Times(len(tt.expectedSends))
+			subscriptions.EXPECT().MarkSent(gomock.Any(), gomock.Any(), start).Return(nil).Times(len(tt.expectedSends))
+			notifications.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(len(tt.expectedSends))
+			if tt.renewed {
+				subscriptions.EXPECT().ListHeartbeat(gomock.Any()).Return(nil, nil)
+			}
+
+			require.NoError(t, dispatcher.tick(context.Background()))
+			assert.Equal(t, tt.expectedSends, sent)
+		})
+	}
+}
+
+func TestSubscriptionDispatcher_Heartbeat(t *testing.T) {
+	now := time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		lastSent      time.Time
+		expectedBeats int
+	}{
+		{name: "period elapsed", lastSent: now.Add(-2 * time.Minute), expectedBeats: 1},
+		{name: "sent recently", lastSent: now.Add(-30 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			subscriptions := ports.NewMockSubscriptionRepository(ctrl)
+			notifications := ports.NewMockSubscriptionNotificationRepository(ctrl)
+			channel := ports.NewMockNotificationChannel(ctrl)
+			leases := ports.NewMockLeaseRepository(ctrl)
+
+			dispatcher := NewSubscriptionDispatcher(DeliveryOptions{}, LinkOptions{}, subscriptions, notifications, channel, leases, ports.NewMockAuthorizer(ctrl), ports.NewMockConsentEvaluator(ctrl))
+			dispatcher.now = func() time.Time { return now }
+
+			record := domain.SubscriptionRecord{
+				Subscription: *createTestSubscription(testSubscriptionID),
+				LastSentAt:   tt.lastSent.Unix(),
+			}
+			period := 60
+			record.Subscription.Status = domain.SubscriptionStatusActive
+			record.Subscription.HeartbeatPeriod = &period
+
+			leases.EXPECT().Acquire(gomock.Any(), deliveryLease, gomock.Any(), gomock.Any()).Return(true, nil)
+			notifications.EXPECT().ListDue(gomock.Any(), now, deliveryBatchSize).Return(nil, nil)
+			subscriptions.EXPECT().ListHeartbeat(gomock.Any()).Return([]domain.SubscriptionRecord{record}, nil)
+			channel.EXPECT().
+				Send(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, sub *models.Subscription, bundle *models.Bundle) error {
+					require.Len(t, bundle.Entry, 1)
+					var status models.SubscriptionStatus
+					require.NoError(t, json.Unmarshal(bundle.Entry[0].Resource, &status))
+					assert.Equal(t, domain.SubscriptionNotificationHeartbeat, status.Type)
+					assert.Empty(t, status.NotificationEvent)
+					return nil
+				}).
+				Times(tt.expectedBeats)
+			subscriptions.EXPECT().MarkSent(gomock.Any(), testSubscriptionID, now).Return(nil).Times(tt.expectedBeats)
+
+			require.NoError(t, dispatcher.tick(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/core/validator"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type SubscriptionTopicService struct {
+	repo      ports.SubscriptionTopicRepository
+	authz     ports.Authorizer
+	validator *validator.SubscriptionTopicValidator
+}
+
+func NewSubscriptionTopicService(repo ports.SubscriptionTopicRepository, authz ports.Authorizer, v *validator.SubscriptionTopicValidator) *SubscriptionTopicService {
+	return &SubscriptionTopicService{
+		repo:      repo,
+		authz:     authz,
+		validator: v,
+	}
+}
+
+// Create publishes a subscription topic. Only administrators may publish.
+func (s *SubscriptionTopicService) Create(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	if err := s.validator.Validate(topic); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionCreate, "SubscriptionTopic"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionCreate, domain.ResourceRef{Type: "SubscriptionTopic"}); err != nil {
+		return nil, err
+	}
+
+	if topic.Id != nil && *topic.Id != "" {
+		return nil, fmt.Errorf("%w: subscription topic ID must not be provided during creation", domain.ErrInvalidInput)
+	}
+
+	id := uuid.New().String()
+	topic.Id = &id
+
+	created, err := s.repo.Create(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return created, nil
+}
+
+func (s *SubscriptionTopicService) Get(ctx context.Context, id string) (*models.SubscriptionTopic, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if id == "" {
+		return nil, domain.ErrSubscriptionTopicIDRequired
+	}
+
+	topic, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if topic == nil {
+		return nil, domain.ErrSubscriptionTopicNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionRead, subscriptionTopicRef(topic)); err != nil {
+		return nil, err
+	}
+
+	return topic, nil
+}
+
+func (s *SubscriptionTopicService) Update(ctx context.Context, topic *models.SubscriptionTopic) (*models.SubscriptionTopic, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionUpdate, "SubscriptionTopic"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	if topic.Id == nil || *topic.Id == "" {
+		return nil, domain.ErrSubscriptionTopicIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, *topic.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return nil, domain.ErrSubscriptionTopicNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionUpdate, subscriptionTopicRef(existing)); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(topic); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	updated, err := s.repo.Update(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return updated, nil
+}
+
+func (s *SubscriptionTopicService) Delete(ctx context.Context, id string) error {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionDelete, "SubscriptionTopic"); !decision.Allowed {
+		return decision.Err
+	}
+
+	if id == "" {
+		return domain.ErrSubscriptionTopicIDRequired
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+	if existing == nil {
+		return domain.ErrSubscriptionTopicNotFound
+	}
+
+	if err := authorize(ctx, s.authz, user, domain.ActionDelete, subscriptionTopicRef(existing)); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return nil
+}
+
+func (s *SubscriptionTopicService) List(ctx context.Context, search domain.SubscriptionTopicSearch, limit, offset int) (*domain.ListResponse[models.SubscriptionTopic], error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	if decision := s.authz.AuthorizeType(user, domain.ActionSearch, "SubscriptionTopic"); !decision.Allowed {
+		return nil, decision.Err
+	}
+
+	topics, total, err := s.repo.Search(ctx, search, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInternal, err)
+	}
+
+	return &domain.ListResponse[models.SubscriptionTopic]{
+		Items: topics,
+		Total: total,
+	}, nil
+}
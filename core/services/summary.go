@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	"github.com/gruzdev-dev/codex-documents/pkg/ptr"
+
+	"github.com/google/uuid"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	loincSystem           = "http://loinc.org"
+	listEmptyReasonSystem = "http://terminology.hl7.org/CodeSystem/list-empty-reason"
+
+	// summaryListLimit caps the resources of each type a summary is built
+	// from.
+	summaryListLimit = 200
+)
+
+// summaryEntry is a resource listed in a section of a patient summary.
+type summaryEntry struct {
+	ref      domain.ResourceRef
+	resource any
+	display  string
+}
+
+// summarySection is a section of the International Patient Summary and how
+// its entries are collected. Required sections are present even when empty.
+type summarySection struct {
+	title    string
+	code     string
+	display  string
+	required bool
+	collect  func(ctx context.Context, patientID string) (entries []summaryEntry, withheld bool, err error)
+}
+
+// summarySectionOf describes a section whose entries are the resources of a
+// type that pick selects from the patient's records.
+func summarySectionOf[T any](
+	title, code, display string,
+	required bool,
+	list func(ctx context.Context, patientID string) (*domain.ListResponse[T], error),
+	ref func(*T) domain.ResourceRef,
+	pick func([]T) []T,
+	describe func(*T) string,
+) summarySection {
+	return summarySection{
+		title:    title,
+		code:     code,
+		display:  display,
+		required: required,
+		collect: func(ctx context.Context, patientID string) ([]summaryEntry, bool, error) {
+			res, err := list(ctx, patientID)
+			if err != nil {
+				return nil, false, err
+			}
+			items := pick(res.Items)
+			entries := make([]summaryEntry, 0, len(items))
+			for i := range items {
+				item := &items[i]
+				entries = append(entries, summaryEntry{ref: ref(item), resource: *item, display: describe(item)})
+			}
+			return entries, len(res.Withheld) > 0, nil
+		},
+	}
+}
+
+type SummaryService struct {
+	patients  ports.PatientService
+	documents ports.DocumentService
+	sections  []summarySection
+	publicURL string
+}
+
+func NewSummaryService(
+	links LinkOptions,
+	patients ports.PatientService,
+	documents ports.DocumentService,
+	conditions ports.ConditionService,
+	allergies ports.AllergyIntoleranceService,
+	statements ports.MedicationStatementService,
+	immunizations ports.ImmunizationService,
+	observations ports.ObservationService,
+) *SummaryService {
+	// Only the latest observations make it into the summary, so they are
+	// fetched newest first in case a patient has more than fit in a page.
+	listObservations := func(ctx context.Context, patientID string) (*domain.ListResponse[models.Observation], error) {
+		return observations.List(ctx, domain.ObservationSearch{PatientID: patientID, Newest: true}, summaryListLimit, 0)
+	}
+
+	return &SummaryService{
+		patients:  patients,
+		documents: documents,
+		sections: []summarySection{
+			summarySectionOf("Problems", "11450-4", "Problem list - Reported", true,
+				func(ctx context.Context, patientID string) (*domain.ListResponse[models.Condition], error) {
+					return conditions.List(ctx, domain.ConditionSearch{PatientID: patientID}, summaryListLimit, 0)
+				}, conditionRef, activeProblems,
+				func(cond *models.Condition) string { return conceptDisplay(cond.Code) }),
+			summarySectionOf("Allergies and Intolerances", "48765-2", "Allergies and adverse reactions Document", true,
+				func(ctx context.Context, patientID string) (*domain.ListResponse[models.AllergyIntolerance], error) {
+					return allergies.List(ctx, domain.AllergySearch{PatientID: patientID}, summaryListLimit, 0)
+				}, allergyIntoleranceRef, recordedAllergies,
+				func(ai *models.AllergyIntolerance) string { return conceptDisplay(ai.Code) }),
+			summarySectionOf("Medication Summary", "10160-0", "History of Medication use Narrative", true,
+				func(ctx context.Context, patientID string) (*domain.ListResponse[models.MedicationStatement], error) {
+					return statements.List(ctx, domain.MedicationSearch{PatientID: patientID}, summaryListLimit, 0)
+				}, medicationStatementRef, recordedMedications,
+				func(ms *models.MedicationStatement) string {
+					if ms.Medication == nil {
+						return ""
+					}
+					return conceptDisplay(ms.Medication.Concept)
+				}),
+			summarySectionOf("Immunizations", "11369-6", "History of Immunization Narrative", false,
+				func(ctx context.Context, patientID string) (*domain.ListResponse[models.Immunization], error) {
+					return immunizations.List(ctx, domain.ImmunizationSearch{PatientID: patientID}, summaryListLimit, 0)
+				}, immunizationRef, completedImmunizations,
+				func(imm *models.Immunization) string { return conceptDisplay(imm.VaccineCode) }),
+			summarySectionOf("Results", "30954-2", "Relevant diagnostic tests/laboratory data Narrative", false,
+				listObservations, observationRef, recentObservations("laboratory"), observationDisplay),
+			summarySectionOf("Vital Signs", "8716-3", "Vital signs", false,
+				listObservations, observationRef, recentObservations("vital-signs"), observationDisplay),
+		},
+		publicURL: links.baseURL(),
+	}
+}
+
+// Summary generates an International Patient Summary of a patient as a FHIR
+// Document. Sections are built from the records the user may search; a type
+// the user has no scope for, or whose records are all withheld by consent,
+// leaves its section empty with the reason "withheld". With persist set the
+// summary is also stored as a DocumentReference of the patient, which can
+// then be shared like any other document.
+func (s *SummaryService) Summary(ctx context.Context, patientID string, persist bool) (*domain.GeneratedDocument, error) {
+	user, ok := identity.FromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrAccessDenied
+	}
+
+	patient, err := s.patients.Get(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	compID := uuid.New().String()
+	comp := &models.Composition{
+		ResourceType: "Composition",
+		Id:           &compID,
+		Status:       "final",
+		Type: &models.CodeableConcept{Coding: []models.Coding{{
+			System:  ptr.To(loincSystem),
+			Code:    ptr.To("60591-5"),
+			Display: ptr.To("Patient summary Document"),
+		}}},
+		Subject: []models.Reference{{Reference: ptr.To("Patient/" + patientID)}},
+		Date:    time.Now().UTC().Format(time.RFC3339),
+		Author:  []models.Reference{summaryAuthor(user, patientID)},
+		Title:   ptr.To("International Patient Summary"),
+	}
+
+	var entries []summaryEntry
+	for _, section := range s.sections {
+		collected, withheld, err := section.collect(ctx, patientID)
+		if errors.Is(err, domain.ErrAccessDenied) {
+			collected, withheld = nil, true
+		} else if err != nil {
+			return nil, err
+		}
+		if len(collected) == 0 && !section.required {
+			continue
+		}
+		comp.Section = append(comp.Section, summaryCompositionSection(section, collected, withheld))
+		entries = append(entries, collected...)
+	}
+
+	bundle := newDocumentBundle()
+	if err := appendDocumentEntry(bundle, "urn:uuid:"+compID, comp); err != nil {
+		return nil, err
+	}
+	if err := appendDocumentEntry(bundle, resourceURL(s.publicURL, "Patient", patientID), patient); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if err := appendDocumentEntry(bundle, resourceURL(s.publicURL, entry.ref.Type, entry.ref.ID), entry.resource); err != nil {
+			return nil, err
+		}
+	}
+
+	generated := &domain.GeneratedDocument{Bundle: bundle}
+	if !persist {
+		return generated, nil
+	}
+
+	doc, err := documentFromBundle(comp, bundle)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.documents.CreateDocument(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	generated.DocumentReference = result.Document
+
+	return generated, nil
+}
+
+// summaryCompositionSection renders a section with its entries and a
+// narrative listing them.
+func summaryCompositionSection(section summarySection, entries []summaryEntry, withheld bool) models.CompositionSection {
+	out := models.CompositionSection{
+		Title: ptr.To(section.title),
+		Code: &models.CodeableConcept{Coding: []models.Coding{{
+			System:  ptr.To(loincSystem),
+			Code:    ptr.To(section.code),
+			Display: ptr.To(section.display),
+		}}},
+	}
+
+	if len(entries) == 0 {
+		reason, text := "unavailable", "No information available"
+		if withheld {
+			reason, text = "withheld", "Information withheld"
+		}
+		out.EmptyReason = &models.CodeableConcept{Coding: []models.Coding{{
+			System: ptr.To(listEmptyReasonSystem),
+			Code:   ptr.To(reason),
+		}}}
+		out.Text = &models.Narrative{Status: "generated", Div: narrativeDiv(html.EscapeString(text))}
+		return out
+	}
+
+	var items strings.Builder
+	items.WriteString("<ul>")
+	for _, entry := range entries {
+		out.Entry = append(out.Entry, models.Reference{Reference: ptr.To(entry.ref.Type + "/" + entry.ref.ID)})
+		display := entry.display
+		if display == "" {
+			display = entry.ref.Type
+		}
+		items.WriteString("<li>" + html.EscapeString(display) + "</li>")
+	}
+	items.WriteString("</ul>")
+	out.Text = &models.Narrative{Status: "generated", Div: narrativeDiv(items.String())}
+
+	return out
+}
+
+func narrativeDiv(content string) string {
+	return `<div xmlns="http://www.w3.org/1999/xhtml">` + content + `</div>`
+}
+
+// summaryAuthor attributes a generated summary to the practitioner requesting
+// it, or else to the patient.
+func summaryAuthor(user domain.Identity, patientID string) models.Reference {
+	if user.IsPractitioner() {
+		return models.Reference{Reference: ptr.To("Practitioner/" + user.PractitionerID)}
+	}
+	return models.Reference{Reference: ptr.To("Patient/" + patientID)}
+}
+
+// activeProblems keeps the conditions that are current problems of the
+// patient.
+func activeProblems(conditions []models.Condition) []models.Condition {
+	var active []models.Condition
+	for _, cond := range conditions {
+		if cond.VerificationStatus != nil && codingsContainCode(cond.VerificationStatus.Coding, "entered-in-error") {
+			continue
+		}
+		if cond.ClinicalStatus == nil {
+			continue
+		}
+		for _, status := range []string{"active", "recurrence", "relapse"} {
+			if codingsContainCode(cond.ClinicalStatus.Coding, status) {
+				active = append(active, cond)
+				break
+			}
+		}
+	}
+	return active
+}
+
+func recordedAllergies(allergies []models.AllergyIntolerance) []models.AllergyIntolerance {
+	var recorded []models.AllergyIntolerance
+	for _, ai := range allergies {
+		if ai.VerificationStatus != nil && codingsContainCode(ai.VerificationStatus.Coding, "entered-in-error") {
+			continue
+		}
+		recorded = append(recorded, ai)
+	}
+	return recorded
+}
+
+func recordedMedications(statements []models.MedicationStatement) []models.MedicationStatement {
+	var recorded []models.MedicationStatement
+	for _, ms := range statements {
+		if ms.Status != "entered-in-error" && ms.Status != "draft" {
+			recorded = append(recorded, ms)
+		}
+	}
+	return recorded
+}
+
+func completedImmunizations(immunizations []models.Immunization) []models.Immunization {
+	var completed []models.Immunization
+	for _, imm := range immunizations {
+		if imm.Status == "completed" {
+			completed = append(completed, imm)
+		}
+	}
+	return completed
+}
+
+// recentObservations returns a selector of the most recent observation of
+// each code in an observation category.
+func recentObservations(category string) func([]models.Observation) []models.Observation {
+	return func(observations []models.Observation) []models.Observation {
+		var inCategory []models.Observation
+		for _, obs := range observations {
+			if obs.Status == "entered-in-error" || obs.Status == "cancelled" {
+				continue
+			}
+			if conceptsContainCode(obs.Category, category) {
+				inCategory = append(inCategory, obs)
+			}
+		}
+
+		slices.SortStableFunc(inCategory, func(a, b models.Observation) int {
+			return strings.Compare(observationEffective(&b), observationEffective(&a))
+		})
+
+		seen := make(map[string]bool)
+		var recent []models.Observation
+		for _, obs := range inCategory {
+			key := observationCodeKey(&obs)
+			if key != "" && seen[key] {
+				continue
+			}
+			seen[key] = true
+			recent = append(recent, obs)
+		}
+		return recent
+	}
+}
+
+// observationEffective returns the start of the clinically relevant time of
+// an observation. FHIR dateTimes of the same precision order as strings.
+func observationEffective(obs *models.Observation) string {
+	switch {
+	case obs.EffectiveDateTime != nil:
+		return *obs.EffectiveDateTime
+	case obs.EffectiveInstant != nil:
+		return *obs.EffectiveInstant
+	case obs.EffectivePeriod != nil && obs.EffectivePeriod.Start != nil:
+		return *obs.EffectivePeriod.Start
+	}
+	return ""
+}
+
+func observationCodeKey(obs *models.Observation) string {
+	if values := tokenValues(obs.Code); len(values) > 0 {
+		return values[len(values)-1]
+	}
+	if obs.Code != nil && obs.Code.Text != nil {
+		return *obs.Code.Text
+	}
+	return ""
+}
+
+func observationDisplay(obs *models.Observation) string {
+	display := conceptDisplay(obs.Code)
+	if effective := observationEffective(obs); effective != "" {
+		display = fmt.Sprintf("%s (%s)", display, effective)
+	}
+	return display
+}
+
+// conceptDisplay returns the human-readable text of a concept.
+func conceptDisplay(concept *models.CodeableConcept) string {
+	if concept == nil {
+		return ""
+	}
+	if concept.Text != nil {
+		return *concept.Text
+	}
+	for _, coding := range concept.Coding {
+		if coding.Display != nil {
+			return *coding.Display
+		}
+	}
+	for _, coding := range concept.Coding {
+		if coding.Code != nil {
+			return *coding.Code
+		}
+	}
+	return ""
+}
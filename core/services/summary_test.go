@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/core/ports"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+func createTestCategorizedObservation(id, category, code, effective string) models.Observation {
+	obs := createTestObservation(id, testPatientID)
+	obs.Category = []models.CodeableConcept{{Coding: []models.Coding{
+		coding("http://terminology.hl7.org/CodeSystem/observation-category", category),
+	}}}
+	obs.Code = &models.CodeableConcept{Coding: []models.Coding{coding("http://loinc.org", code)}}
+	obs.EffectiveDateTime = strPtr(effective)
+	return *obs
+}
+
+func TestSummaryService_Summary(t *testing.T) {
+	tests := []struct {
+		name          string
+		persist       bool
+		patientErr    error
+		expectedError error
+	}{
+		{
+			name: "success path",
+		},
+		{
+			name:    "success path - persisted as a document",
+			persist: true,
+		},
+		{
+			name:          "error - patient not accessible",
+			patientErr:    domain.ErrAccessDenied,
+			expectedError: domain.ErrAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patients := ports.NewMockPatientService(ctrl)
+			documents := ports.NewMockDocumentService(ctrl)
+			conditions := ports.NewMockConditionService(ctrl)
+			allergies := ports.NewMockAllergyIntoleranceService(ctrl)
+			statements := ports.NewMockMedicationStatementService(ctrl)
+			immunizations := ports.NewMockImmunizationService(ctrl)
+			observations := ports.NewMockObservationService(ctrl)
+
+			if tt.patientErr != nil {
+				patients.EXPECT().Get(gomock.Any(), testPatientID).Return(nil, tt.patientErr)
+			} else {
+				resolved := createTestCondition("resolved-cond", testPatientID)
+				resolved.ClinicalStatus = &models.CodeableConcept{Coding: []models.Coding{
+					coding("http://terminology.hl7.org/CodeSystem/condition-clinical", "resolved"),
+				}}
+
+				patients.EXPECT().Get(gomock.Any(), testPatientID).Return(&models.Patient{ResourceType: "Patient", Id: strPtr(testPatientID)}, nil)
+				conditions.EXPECT().
+					List(gomock.Any(), domain.ConditionSearch{PatientID: testPatientID}, summaryListLimit, 0).
+					Return(&domain.ListResponse[models.Condition]{Items: []models.Condition{*createTestCondition(testCondID, testPatientID), *resolved}, Total: 2}, nil)
+				allergies.EXPECT().
+					List(gomock.Any(), domain.AllergySearch{PatientID: testPatientID}, summaryListLimit, 0).
+					Return(&domain.ListResponse[models.AllergyIntolerance]{}, nil)
+				statements.EXPECT().
+					List(gomock.Any(), domain.MedicationSearch{PatientID: testPatientID}, summaryListLimit, 0).
+					Return(nil, domain.ErrAccessDenied)
+				immunizations.EXPECT().
+					List(gomock.Any(), domain.ImmunizationSearch{PatientID: testPatientID}, summaryListLimit, 0).
+					Return(&domain.ListResponse[models.Immunization]{}, nil)
+				observations.EXPECT().
+					List(gomock.Any(), domain.ObservationSearch{PatientID: testPatientID, Newest: true}, summaryListLimit, 0).
+					Return(&domain.ListResponse[models.Observation]{Items: []models.Observation{
+						createTestCategorizedObservation("bp-old", "vital-signs", "85354-9", "2024-01-10"),
+						createTestCategorizedObservation("bp-new", "vital-signs", "85354-9", "2024-05-10"),
+						createTestCategorizedObservation("hb", "laboratory", "718-7", "2024-03-01"),
+					}, Total: 3}, nil).
+					Times(2)
+			}
+			if tt.persist {
+				documents.EXPECT().
+					CreateDocument(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, doc *models.DocumentReference) (*domain.CreateDocumentResult, error) {
+						assert.Equal(t, "60591-5", *doc.Type.Coding[0].Code)
+						assert.Equal(t, "Patient/"+testPatientID, *doc.Subject.Reference)
+						doc.Id = strPtr("summary-doc")
+						return &domain.CreateDocumentResult{Document: doc}, nil
+					})
+			}
+
+			service := NewSummaryService(LinkOptions{PublicURL: testPublicURL}, patients, documents, conditions, allergies, statements, immunizations, observations)
+
+			patient := createTestIdentity(testPatientID, testUserID, []string{"patient/*.rs"})
+			result, err := service.Summary(identity.WithCtx(context.Background(), patient), testPatientID, tt.persist)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+
+			bundle := result.Bundle
+			assert.Equal(t, "document", bundle.Type)
+			require.Len(t, bundle.Entry, 5)
+
+			var comp models.Composition
+			require.NoError(t, json.Unmarshal(bundle.Entry[0].Resource, &comp))
+			assert.Equal(t, "60591-5", *comp.Type.Coding[0].Code)
+
+			sections := make(map[string]models.CompositionSection)
+			for _, section := range comp.Section {
+				sections[*section.Code.Coding[0].Code] = section
+			}
+			assert.Len(t, sections, 5, "the empty immunization section is left out")
+
+			problems := sections["11450-4"]
+			require.Len(t, problems.Entry, 1, "resolved conditions are not current problems")
+			assert.Equal(t, "Condition/"+testCondID, *problems.Entry[0].Reference)
+
+			assert.Equal(t, "unavailable", *sections["48765-2"].EmptyReason.Coding[0].Code)
+			assert.Equal(t, "withheld", *sections["10160-0"].EmptyReason.Coding[0].Code)
+
+			vitals := sections["8716-3"]
+			require.Len(t, vitals.Entry, 1, "only the most recent value of each code is kept")
+			assert.Equal(t, "Observation/bp-new", *vitals.Entry[0].Reference)
+
+			results := sections["30954-2"]
+			require.Len(t, results.Entry, 1)
+			assert.Equal(t, "Observation/hb", *results.Entry[0].Reference)
+
+			if tt.persist {
+				require.NotNil(t, result.DocumentReference)
+				assert.Equal(t, "summary-doc", *result.DocumentReference.Id)
+			}
+		})
+	}
+}
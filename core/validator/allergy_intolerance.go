@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	allergyClinicalSystem     = "http://terminology.hl7.org/CodeSystem/allergyintolerance-clinical"
+	allergyVerificationSystem = "http://terminology.hl7.org/CodeSystem/allergyintolerance-verification"
+)
+
+var allergyClinicalStatuses = map[string]bool{
+	"active":   true,
+	"inactive": true,
+	"resolved": true,
+}
+
+var allergyVerificationStatuses = map[string]bool{
+	"unconfirmed":      true,
+	"presumed":         true,
+	"confirmed":        true,
+	"refuted":          true,
+	"entered-in-error": true,
+}
+
+var allergyCategories = map[string]bool{
+	"food":        true,
+	"medication":  true,
+	"environment": true,
+	"biologic":    true,
+}
+
+var allergyCriticalities = map[string]bool{
+	"low":              true,
+	"high":             true,
+	"unable-to-assess": true,
+}
+
+type AllergyIntoleranceValidator struct{}
+
+func NewAllergyIntoleranceValidator() *AllergyIntoleranceValidator {
+	return &AllergyIntoleranceValidator{}
+}
+
+func (v *AllergyIntoleranceValidator) Validate(ai *models.AllergyIntolerance) error {
+	if ai == nil {
+		return errors.New("allergy intolerance resource is nil")
+	}
+
+	if ai.ResourceType != "AllergyIntolerance" {
+		return fmt.Errorf("invalid resourceType: expected 'AllergyIntolerance', got '%s'", ai.ResourceType)
+	}
+
+	if ai.Patient == nil || ai.Patient.Reference == nil {
+		return errors.New("patient is required")
+	}
+
+	if ai.Code == nil || (len(ai.Code.Coding) == 0 && ai.Code.Text == nil) {
+		return errors.New("code is required")
+	}
+
+	if ai.ClinicalStatus != nil {
+		if err := validateStatusConcept("clinicalStatus", ai.ClinicalStatus, allergyClinicalSystem, allergyClinicalStatuses); err != nil {
+			return err
+		}
+	}
+	if ai.VerificationStatus != nil {
+		if err := validateStatusConcept("verificationStatus", ai.VerificationStatus, allergyVerificationSystem, allergyVerificationStatuses); err != nil {
+			return err
+		}
+	}
+
+	for _, category := range ai.Category {
+		if !allergyCategories[category] {
+			return fmt.Errorf("invalid category %q", category)
+		}
+	}
+	if ai.Criticality != nil && !allergyCriticalities[*ai.Criticality] {
+		return fmt.Errorf("invalid criticality %q", *ai.Criticality)
+	}
+
+	if ai.OnsetDateTime != nil && !isFHIRDateTime(*ai.OnsetDateTime) {
+		return fmt.Errorf("invalid onsetDateTime %q", *ai.OnsetDateTime)
+	}
+	if ai.RecordedDate != nil && !isFHIRDateTime(*ai.RecordedDate) {
+		return fmt.Errorf("invalid recordedDate %q", *ai.RecordedDate)
+	}
+
+	return nil
+}
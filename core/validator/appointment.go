@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var appointmentStatuses = map[string]bool{
+	"proposed":         true,
+	"pending":          true,
+	"booked":           true,
+	"arrived":          true,
+	"fulfilled":        true,
+	"cancelled":        true,
+	"noshow":           true,
+	"entered-in-error": true,
+	"checked-in":       true,
+	"waitlist":         true,
+}
+
+// appointmentUnscheduledStatuses are the statuses in which an appointment
+// may lack a start and end time.
+var appointmentUnscheduledStatuses = map[string]bool{
+	"proposed":  true,
+	"cancelled": true,
+	"waitlist":  true,
+}
+
+var participationStatuses = map[string]bool{
+	"accepted":     true,
+	"declined":     true,
+	"tentative":    true,
+	"needs-action": true,
+}
+
+type AppointmentValidator struct{}
+
+func NewAppointmentValidator() *AppointmentValidator {
+	return &AppointmentValidator{}
+}
+
+func (v *AppointmentValidator) Validate(appt *models.Appointment) error {
+	if appt == nil {
+		return errors.New("appointment resource is nil")
+	}
+
+	if appt.ResourceType != "Appointment" {
+		return fmt.Errorf("invalid resourceType: expected 'Appointment', got '%s'", appt.ResourceType)
+	}
+
+	if !appointmentStatuses[appt.Status] {
+		return fmt.Errorf("invalid status %q", appt.Status)
+	}
+
+	if appt.Subject == nil || appt.Subject.Reference == nil {
+		return errors.New("subject is required")
+	}
+
+	if len(appt.Participant) == 0 {
+		return errors.New("at least one participant is required")
+	}
+	for i, p := range appt.Participant {
+		if !participationStatuses[p.Status] {
+			return fmt.Errorf("participant[%d]: invalid status %q", i, p.Status)
+		}
+	}
+
+	var start, end time.Time
+	var err error
+	if appt.Start != nil {
+		if start, err = time.Parse(time.RFC3339, *appt.Start); err != nil {
+			return fmt.Errorf("invalid start %q: must be an instant with a time zone", *appt.Start)
+		}
+	}
+	if appt.End != nil {
+		if end, err = time.Parse(time.RFC3339, *appt.End); err != nil {
+			return fmt.Errorf("invalid end %q: must be an instant with a time zone", *appt.End)
+		}
+	}
+	if (appt.Start == nil) != (appt.End == nil) {
+		return errors.New("start and end must be given together")
+	}
+	if appt.Start == nil && !appointmentUnscheduledStatuses[appt.Status] {
+		return fmt.Errorf("start and end are required for %s appointments", appt.Status)
+	}
+	if appt.Start != nil && end.Before(start) {
+		return errors.New("end must not be before start")
+	}
+
+	if appt.MinutesDuration != nil && *appt.MinutesDuration <= 0 {
+		return errors.New("minutesDuration must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var carePlanStatuses = map[string]bool{
+	"draft":            true,
+	"active":           true,
+	"on-hold":          true,
+	"revoked":          true,
+	"completed":        true,
+	"entered-in-error": true,
+	"unknown":          true,
+}
+
+var carePlanIntents = map[string]bool{
+	"proposal":  true,
+	"plan":      true,
+	"order":     true,
+	"option":    true,
+	"directive": true,
+}
+
+type CarePlanValidator struct{}
+
+func NewCarePlanValidator() *CarePlanValidator {
+	return &CarePlanValidator{}
+}
+
+func (v *CarePlanValidator) Validate(plan *models.CarePlan) error {
+	if plan == nil {
+		return errors.New("care plan resource is nil")
+	}
+
+	if plan.ResourceType != "CarePlan" {
+		return fmt.Errorf("invalid resourceType: expected 'CarePlan', got '%s'", plan.ResourceType)
+	}
+
+	if !carePlanStatuses[plan.Status] {
+		return fmt.Errorf("invalid status %q", plan.Status)
+	}
+
+	if !carePlanIntents[plan.Intent] {
+		return fmt.Errorf("invalid intent %q", plan.Intent)
+	}
+
+	if err := validatePeriod("period", plan.Period); err != nil {
+		return err
+	}
+	if plan.Created != nil && !isFHIRDateTime(*plan.Created) {
+		return fmt.Errorf("invalid created %q", *plan.Created)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var compositionStatuses = map[string]bool{
+	"registered":       true,
+	"partial":          true,
+	"preliminary":      true,
+	"final":            true,
+	"amended":          true,
+	"corrected":        true,
+	"appended":         true,
+	"cancelled":        true,
+	"entered-in-error": true,
+	"deprecated":       true,
+	"unknown":          true,
+}
+
+type CompositionValidator struct{}
+
+func NewCompositionValidator() *CompositionValidator {
+	return &CompositionValidator{}
+}
+
+func (v *CompositionValidator) Validate(comp *models.Composition) error {
+	if comp == nil {
+		return errors.New("composition resource is nil")
+	}
+
+	if comp.ResourceType != "Composition" {
+		return fmt.Errorf("invalid resourceType: expected 'Composition', got '%s'", comp.ResourceType)
+	}
+
+	if !compositionStatuses[comp.Status] {
+		return fmt.Errorf("invalid status %q", comp.Status)
+	}
+
+	if comp.Type == nil || (len(comp.Type.Coding) == 0 && comp.Type.Text == nil) {
+		return errors.New("type is required")
+	}
+
+	if comp.Date == "" || !isFHIRDateTime(comp.Date) {
+		return fmt.Errorf("invalid date %q", comp.Date)
+	}
+
+	if comp.Title == nil || *comp.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if len(comp.Author) == 0 {
+		return errors.New("at least one author is required")
+	}
+
+	if len(comp.Subject) > 1 {
+		return errors.New("composition must have a single subject")
+	}
+
+	return validateSections(comp.Section)
+}
+
+// validateSections checks that every section, at any depth, has a title and
+// carries text, entries, subsections or a reason for being empty.
+func validateSections(sections []models.CompositionSection) error {
+	for i, section := range sections {
+		if section.Title == nil || *section.Title == "" {
+			return fmt.Errorf("section[%d]: title is required", i)
+		}
+		if section.Text == nil && len(section.Entry) == 0 && len(section.Section) == 0 && section.EmptyReason == nil {
+			return fmt.Errorf("section[%d]: text, entry, section or emptyReason is required", i)
+		}
+		if err := validateSections(section.Section); err != nil {
+			return fmt.Errorf("section[%d].%w", i, err)
+		}
+	}
+	return nil
+}
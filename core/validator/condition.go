@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const (
+	conditionClinicalSystem     = "http://terminology.hl7.org/CodeSystem/condition-clinical"
+	conditionVerificationSystem = "http://terminology.hl7.org/CodeSystem/condition-ver-status"
+)
+
+var conditionClinicalStatuses = map[string]bool{
+	"active":     true,
+	"recurrence": true,
+	"relapse":    true,
+	"inactive":   true,
+	"remission":  true,
+	"resolved":   true,
+	"unknown":    true,
+}
+
+var conditionVerificationStatuses = map[string]bool{
+	"unconfirmed":      true,
+	"provisional":      true,
+	"differential":     true,
+	"confirmed":        true,
+	"refuted":          true,
+	"entered-in-error": true,
+}
+
+type ConditionValidator struct{}
+
+func NewConditionValidator() *ConditionValidator {
+	return &ConditionValidator{}
+}
+
+func (v *ConditionValidator) Validate(cond *models.Condition) error {
+	if cond == nil {
+		return errors.New("condition resource is nil")
+	}
+
+	if cond.ResourceType != "Condition" {
+		return fmt.Errorf("invalid resourceType: expected 'Condition', got '%s'", cond.ResourceType)
+	}
+
+	if cond.ClinicalStatus == nil {
+		return errors.New("clinicalStatus is required")
+	}
+	if err := validateStatusConcept("clinicalStatus", cond.ClinicalStatus, conditionClinicalSystem, conditionClinicalStatuses); err != nil {
+		return err
+	}
+
+	if cond.VerificationStatus != nil {
+		if err := validateStatusConcept("verificationStatus", cond.VerificationStatus, conditionVerificationSystem, conditionVerificationStatuses); err != nil {
+			return err
+		}
+	}
+
+	if cond.OnsetDateTime != nil && !isFHIRDateTime(*cond.OnsetDateTime) {
+		return fmt.Errorf("invalid onsetDateTime %q", *cond.OnsetDateTime)
+	}
+	if cond.AbatementDateTime != nil && !isFHIRDateTime(*cond.AbatementDateTime) {
+		return fmt.Errorf("invalid abatementDateTime %q", *cond.AbatementDateTime)
+	}
+
+	return nil
+}
+
+// validateStatusConcept checks that a status concept carries a code from its
+// value set. Codings from other systems are allowed alongside it.
+func validateStatusConcept(field string, concept *models.CodeableConcept, system string, codes map[string]bool) error {
+	for _, coding := range concept.Coding {
+		if coding.System != nil && *coding.System != system {
+			continue
+		}
+		if coding.Code != nil && codes[*coding.Code] {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must have a code from %s", field, system)
+}
+
+func isFHIRDateTime(value string) bool {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02", "2006-01", "2006"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type ConsentValidator struct{}
+
+func NewConsentValidator() *ConsentValidator {
+	return &ConsentValidator{}
+}
+
+func (v *ConsentValidator) Validate(c *models.Consent) error {
+	if c == nil {
+		return errors.New("consent resource is nil")
+	}
+
+	if c.ResourceType != "Consent" {
+		return fmt.Errorf("invalid resourceType: expected 'Consent', got '%s'", c.ResourceType)
+	}
+
+	switch models.ConsentState(c.Status) {
+	case models.ConsentStateDraft, models.ConsentStateActive, models.ConsentStateInactive,
+		models.ConsentStateNotDone, models.ConsentStateEnteredInError, models.ConsentStateUnknown:
+	default:
+		return fmt.Errorf("invalid consent status %q", c.Status)
+	}
+
+	if c.Subject == nil || c.Subject.Reference == nil || !strings.HasPrefix(*c.Subject.Reference, "Patient/") {
+		return errors.New("consent subject must reference a Patient")
+	}
+
+	if c.Decision != nil {
+		switch models.ConsentProvisionType(*c.Decision) {
+		case models.ConsentProvisionTypeDeny, models.ConsentProvisionTypePermit:
+		default:
+			return fmt.Errorf("invalid consent decision %q", *c.Decision)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var diagnosticReportStatuses = map[string]bool{
+	"registered":       true,
+	"partial":          true,
+	"preliminary":      true,
+	"modified":         true,
+	"final":            true,
+	"amended":          true,
+	"corrected":        true,
+	"appended":         true,
+	"cancelled":        true,
+	"entered-in-error": true,
+	"unknown":          true,
+}
+
+type DiagnosticReportValidator struct{}
+
+func NewDiagnosticReportValidator() *DiagnosticReportValidator {
+	return &DiagnosticReportValidator{}
+}
+
+func (v *DiagnosticReportValidator) Validate(report *models.DiagnosticReport) error {
+	if report == nil {
+		return errors.New("diagnostic report resource is nil")
+	}
+
+	if report.ResourceType != "DiagnosticReport" {
+		return fmt.Errorf("invalid resourceType: expected 'DiagnosticReport', got '%s'", report.ResourceType)
+	}
+
+	if !diagnosticReportStatuses[report.Status] {
+		return fmt.Errorf("invalid status %q", report.Status)
+	}
+
+	if report.Code == nil || (len(report.Code.Coding) == 0 && report.Code.Text == nil) {
+		return errors.New("code is required")
+	}
+
+	if report.EffectiveDateTime != nil && !isFHIRDateTime(*report.EffectiveDateTime) {
+		return fmt.Errorf("invalid effectiveDateTime %q", *report.EffectiveDateTime)
+	}
+	if err := validatePeriod("effectivePeriod", report.EffectivePeriod); err != nil {
+		return err
+	}
+	if report.Issued != nil && !isFHIRDateTime(*report.Issued) {
+		return fmt.Errorf("invalid issued %q", *report.Issued)
+	}
+
+	return nil
+}
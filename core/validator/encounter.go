@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var encounterStatuses = map[string]bool{
+	"planned":          true,
+	"in-progress":      true,
+	"on-hold":          true,
+	"discharged":       true,
+	"completed":        true,
+	"cancelled":        true,
+	"discontinued":     true,
+	"entered-in-error": true,
+	"unknown":          true,
+}
+
+type EncounterValidator struct{}
+
+func NewEncounterValidator() *EncounterValidator {
+	return &EncounterValidator{}
+}
+
+func (v *EncounterValidator) Validate(enc *models.Encounter) error {
+	if enc == nil {
+		return errors.New("encounter resource is nil")
+	}
+
+	if enc.ResourceType != "Encounter" {
+		return fmt.Errorf("invalid resourceType: expected 'Encounter', got '%s'", enc.ResourceType)
+	}
+
+	if !encounterStatuses[enc.Status] {
+		return fmt.Errorf("invalid status %q", enc.Status)
+	}
+
+	if err := validatePeriod("actualPeriod", enc.ActualPeriod); err != nil {
+		return err
+	}
+	if enc.ActualPeriod != nil && enc.ActualPeriod.Start != nil && enc.ActualPeriod.End != nil && *enc.ActualPeriod.End < *enc.ActualPeriod.Start {
+		return errors.New("actualPeriod.end must not be before actualPeriod.start")
+	}
+	if enc.PlannedStartDate != nil && !isFHIRDateTime(*enc.PlannedStartDate) {
+		return fmt.Errorf("invalid plannedStartDate %q", *enc.PlannedStartDate)
+	}
+	if enc.PlannedEndDate != nil && !isFHIRDateTime(*enc.PlannedEndDate) {
+		return fmt.Errorf("invalid plannedEndDate %q", *enc.PlannedEndDate)
+	}
+
+	if enc.ServiceProvider != nil && enc.ServiceProvider.Reference == nil && enc.ServiceProvider.Display == nil {
+		return errors.New("serviceProvider must have a reference or display")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var familyMemberHistoryStatuses = map[string]bool{
+	"partial":          true,
+	"completed":        true,
+	"entered-in-error": true,
+	"health-unknown":   true,
+}
+
+type FamilyMemberHistoryValidator struct{}
+
+func NewFamilyMemberHistoryValidator() *FamilyMemberHistoryValidator {
+	return &FamilyMemberHistoryValidator{}
+}
+
+func (v *FamilyMemberHistoryValidator) Validate(fmh *models.FamilyMemberHistory) error {
+	if fmh == nil {
+		return errors.New("family member history resource is nil")
+	}
+
+	if fmh.ResourceType != "FamilyMemberHistory" {
+		return fmt.Errorf("invalid resourceType: expected 'FamilyMemberHistory', got '%s'", fmh.ResourceType)
+	}
+
+	if !familyMemberHistoryStatuses[fmh.Status] {
+		return fmt.Errorf("invalid status %q", fmh.Status)
+	}
+
+	if fmh.Patient == nil || fmh.Patient.Reference == nil {
+		return errors.New("patient is required")
+	}
+
+	if fmh.Relationship == nil || (len(fmh.Relationship.Coding) == 0 && fmh.Relationship.Text == nil) {
+		return errors.New("relationship is required")
+	}
+
+	if fmh.Date != nil && !isFHIRDateTime(*fmh.Date) {
+		return fmt.Errorf("invalid date %q", *fmh.Date)
+	}
+
+	hasAge := fmh.AgeAge != nil || fmh.AgeRange != nil || fmh.AgeString != nil
+	hasBorn := fmh.BornPeriod != nil || fmh.BornDate != nil || fmh.BornString != nil
+	if hasAge && hasBorn {
+		return errors.New("only one of age[x] and born[x] may be given")
+	}
+	if fmh.EstimatedAge != nil && !hasAge {
+		return errors.New("estimatedAge requires age[x]")
+	}
+
+	for i, condition := range fmh.Condition {
+		if condition.Code == nil || (len(condition.Code.Coding) == 0 && condition.Code.Text == nil) {
+			return fmt.Errorf("condition[%d].code is required", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+const goalAchievementSystem = "http://terminology.hl7.org/CodeSystem/goal-achievement"
+
+var goalLifecycleStatuses = map[string]bool{
+	"proposed":         true,
+	"planned":          true,
+	"accepted":         true,
+	"active":           true,
+	"on-hold":          true,
+	"completed":        true,
+	"cancelled":        true,
+	"entered-in-error": true,
+	"rejected":         true,
+}
+
+var goalAchievementStatuses = map[string]bool{
+	"in-progress":    true,
+	"improving":      true,
+	"worsening":      true,
+	"no-change":      true,
+	"achieved":       true,
+	"sustaining":     true,
+	"not-achieved":   true,
+	"no-progress":    true,
+	"not-attainable": true,
+}
+
+type GoalValidator struct{}
+
+func NewGoalValidator() *GoalValidator {
+	return &GoalValidator{}
+}
+
+func (v *GoalValidator) Validate(goal *models.Goal) error {
+	if goal == nil {
+		return errors.New("goal resource is nil")
+	}
+
+	if goal.ResourceType != "Goal" {
+		return fmt.Errorf("invalid resourceType: expected 'Goal', got '%s'", goal.ResourceType)
+	}
+
+	if !goalLifecycleStatuses[goal.LifecycleStatus] {
+		return fmt.Errorf("invalid lifecycleStatus %q", goal.LifecycleStatus)
+	}
+
+	if goal.AchievementStatus != nil {
+		if err := validateStatusConcept("achievementStatus", goal.AchievementStatus, goalAchievementSystem, goalAchievementStatuses); err != nil {
+			return err
+		}
+	}
+
+	if goal.Description == nil || (len(goal.Description.Coding) == 0 && goal.Description.Text == nil) {
+		return errors.New("description is required")
+	}
+
+	if goal.StartDate != nil && !isFHIRDateTime(*goal.StartDate) {
+		return fmt.Errorf("invalid startDate %q", *goal.StartDate)
+	}
+
+	for i, target := range goal.Target {
+		if hasTargetDetail(target) && !hasSystemCode(target.Measure) {
+			return fmt.Errorf("target[%d]: measure with a coding of system and code is required with a detail", i)
+		}
+		if target.DueDate != nil && !isFHIRDateTime(*target.DueDate) {
+			return fmt.Errorf("target[%d]: invalid dueDate %q", i, *target.DueDate)
+		}
+		if target.DetailRange != nil && target.DetailRange.Low == nil && target.DetailRange.High == nil {
+			return fmt.Errorf("target[%d]: detailRange needs a low or high limit", i)
+		}
+	}
+
+	return nil
+}
+
+func hasTargetDetail(target models.GoalTarget) bool {
+	return target.DetailQuantity != nil || target.DetailRange != nil || target.DetailCodeableConcept != nil ||
+		target.DetailString != nil || target.DetailBoolean != nil || target.DetailInteger != nil || target.DetailRatio != nil
+}
+
+// hasSystemCode reports whether a concept has a coding naming both its system
+// and code, which is what observations are matched by.
+func hasSystemCode(concept *models.CodeableConcept) bool {
+	if concept == nil {
+		return false
+	}
+	for _, c := range concept.Coding {
+		if c.System != nil && *c.System != "" && c.Code != nil && *c.Code != "" {
+			return true
+		}
+	}
+	return false
+}
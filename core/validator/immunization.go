@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var immunizationStatuses = map[string]bool{
+	"completed":        true,
+	"entered-in-error": true,
+	"not-done":         true,
+}
+
+type ImmunizationValidator struct{}
+
+func NewImmunizationValidator() *ImmunizationValidator {
+	return &ImmunizationValidator{}
+}
+
+func (v *ImmunizationValidator) Validate(imm *models.Immunization) error {
+	if imm == nil {
+		return errors.New("immunization resource is nil")
+	}
+
+	if imm.ResourceType != "Immunization" {
+		return fmt.Errorf("invalid resourceType: expected 'Immunization', got '%s'", imm.ResourceType)
+	}
+
+	if !immunizationStatuses[imm.Status] {
+		return fmt.Errorf("invalid status %q", imm.Status)
+	}
+
+	if imm.Patient == nil || imm.Patient.Reference == nil {
+		return errors.New("patient is required")
+	}
+
+	if imm.VaccineCode == nil || (len(imm.VaccineCode.Coding) == 0 && imm.VaccineCode.Text == nil) {
+		return errors.New("vaccineCode is required")
+	}
+
+	switch {
+	case imm.OccurrenceDateTime != nil && imm.OccurrenceString != nil:
+		return errors.New("only one of occurrenceDateTime and occurrenceString may be given")
+	case imm.OccurrenceDateTime != nil:
+		if !isFHIRDateTime(*imm.OccurrenceDateTime) {
+			return fmt.Errorf("invalid occurrenceDateTime %q", *imm.OccurrenceDateTime)
+		}
+	case imm.OccurrenceString != nil:
+		if *imm.OccurrenceString == "" {
+			return errors.New("occurrenceString must not be empty")
+		}
+	default:
+		return errors.New("occurrence[x] is required")
+	}
+
+	if imm.ExpirationDate != nil && !isFHIRDateTime(*imm.ExpirationDate) {
+		return fmt.Errorf("invalid expirationDate %q", *imm.ExpirationDate)
+	}
+
+	return nil
+}
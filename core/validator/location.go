@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var locationStatuses = map[string]bool{
+	"active":    true,
+	"suspended": true,
+	"inactive":  true,
+}
+
+var locationModes = map[string]bool{
+	"instance": true,
+	"kind":     true,
+}
+
+type LocationValidator struct{}
+
+func NewLocationValidator() *LocationValidator {
+	return &LocationValidator{}
+}
+
+func (v *LocationValidator) Validate(loc *models.Location) error {
+	if loc == nil {
+		return errors.New("location resource is nil")
+	}
+
+	if loc.ResourceType != "Location" {
+		return fmt.Errorf("invalid resourceType: expected 'Location', got '%s'", loc.ResourceType)
+	}
+
+	if loc.Name == nil || strings.TrimSpace(*loc.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if loc.Status != nil && !locationStatuses[*loc.Status] {
+		return fmt.Errorf("invalid status %q", *loc.Status)
+	}
+	if loc.Mode != nil && !locationModes[*loc.Mode] {
+		return fmt.Errorf("invalid mode %q", *loc.Mode)
+	}
+
+	if loc.ManagingOrganization != nil && (loc.ManagingOrganization.Reference == nil || !strings.HasPrefix(*loc.ManagingOrganization.Reference, "Organization/")) {
+		return errors.New("managingOrganization must reference an Organization")
+	}
+
+	return nil
+}
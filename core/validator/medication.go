@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var medicationStatementStatuses = map[string]bool{
+	"recorded":         true,
+	"entered-in-error": true,
+	"draft":            true,
+}
+
+var medicationRequestStatuses = map[string]bool{
+	"active":           true,
+	"on-hold":          true,
+	"ended":            true,
+	"stopped":          true,
+	"completed":        true,
+	"cancelled":        true,
+	"entered-in-error": true,
+	"draft":            true,
+	"unknown":          true,
+}
+
+var medicationRequestIntents = map[string]bool{
+	"proposal":       true,
+	"plan":           true,
+	"order":          true,
+	"original-order": true,
+	"reflex-order":   true,
+	"filler-order":   true,
+	"instance-order": true,
+	"option":         true,
+}
+
+type MedicationStatementValidator struct{}
+
+func NewMedicationStatementValidator() *MedicationStatementValidator {
+	return &MedicationStatementValidator{}
+}
+
+func (v *MedicationStatementValidator) Validate(ms *models.MedicationStatement) error {
+	if ms == nil {
+		return errors.New("medication statement resource is nil")
+	}
+
+	if ms.ResourceType != "MedicationStatement" {
+		return fmt.Errorf("invalid resourceType: expected 'MedicationStatement', got '%s'", ms.ResourceType)
+	}
+
+	if !medicationStatementStatuses[ms.Status] {
+		return fmt.Errorf("invalid status %q", ms.Status)
+	}
+
+	if err := validateMedication(ms.Medication); err != nil {
+		return err
+	}
+
+	if ms.EffectiveDateTime != nil && !isFHIRDateTime(*ms.EffectiveDateTime) {
+		return fmt.Errorf("invalid effectiveDateTime %q", *ms.EffectiveDateTime)
+	}
+	if err := validatePeriod("effectivePeriod", ms.EffectivePeriod); err != nil {
+		return err
+	}
+	if ms.DateAsserted != nil && !isFHIRDateTime(*ms.DateAsserted) {
+		return fmt.Errorf("invalid dateAsserted %q", *ms.DateAsserted)
+	}
+
+	return nil
+}
+
+type MedicationRequestValidator struct{}
+
+func NewMedicationRequestValidator() *MedicationRequestValidator {
+	return &MedicationRequestValidator{}
+}
+
+func (v *MedicationRequestValidator) Validate(mr *models.MedicationRequest) error {
+	if mr == nil {
+		return errors.New("medication request resource is nil")
+	}
+
+	if mr.ResourceType != "MedicationRequest" {
+		return fmt.Errorf("invalid resourceType: expected 'MedicationRequest', got '%s'", mr.ResourceType)
+	}
+
+	if !medicationRequestStatuses[mr.Status] {
+		return fmt.Errorf("invalid status %q", mr.Status)
+	}
+	if !medicationRequestIntents[mr.Intent] {
+		return fmt.Errorf("invalid intent %q", mr.Intent)
+	}
+
+	if err := validateMedication(mr.Medication); err != nil {
+		return err
+	}
+
+	if mr.AuthoredOn != nil && !isFHIRDateTime(*mr.AuthoredOn) {
+		return fmt.Errorf("invalid authoredOn %q", *mr.AuthoredOn)
+	}
+	if err := validatePeriod("effectiveTimingPeriod", mr.EffectiveTimingPeriod); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMedication checks that a medication is given either as a coded
+// concept or as a reference.
+func validateMedication(medication *models.CodeableReference) error {
+	if medication == nil || (medication.Concept == nil && medication.Reference == nil) {
+		return errors.New("medication is required")
+	}
+	if medication.Concept != nil && len(medication.Concept.Coding) == 0 && medication.Concept.Text == nil {
+		return errors.New("medication concept must have a coding or text")
+	}
+	return nil
+}
+
+func validatePeriod(field string, period *models.Period) error {
+	if period == nil {
+		return nil
+	}
+	if period.Start != nil && !isFHIRDateTime(*period.Start) {
+		return fmt.Errorf("invalid %s.start %q", field, *period.Start)
+	}
+	if period.End != nil && !isFHIRDateTime(*period.End) {
+		return fmt.Errorf("invalid %s.end %q", field, *period.End)
+	}
+	return nil
+}
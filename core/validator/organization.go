@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type OrganizationValidator struct{}
+
+func NewOrganizationValidator() *OrganizationValidator {
+	return &OrganizationValidator{}
+}
+
+func (v *OrganizationValidator) Validate(org *models.Organization) error {
+	if org == nil {
+		return errors.New("organization resource is nil")
+	}
+
+	if org.ResourceType != "Organization" {
+		return fmt.Errorf("invalid resourceType: expected 'Organization', got '%s'", org.ResourceType)
+	}
+
+	if org.Name == nil || strings.TrimSpace(*org.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if org.PartOf != nil && (org.PartOf.Reference == nil || !strings.HasPrefix(*org.PartOf.Reference, "Organization/")) {
+		return errors.New("partOf must reference an Organization")
+	}
+
+	return nil
+}
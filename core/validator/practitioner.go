@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"errors"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type PractitionerValidator struct{}
+
+func NewPractitionerValidator() *PractitionerValidator {
+	return &PractitionerValidator{}
+}
+
+func (v *PractitionerValidator) Validate(p *models.Practitioner) error {
+	if p == nil {
+		return errors.New("practitioner resource is nil")
+	}
+
+	if len(p.Name) == 0 {
+		return errors.New("practitioner must have at least one name")
+	}
+
+	return nil
+}
+
+type PractitionerRoleValidator struct{}
+
+func NewPractitionerRoleValidator() *PractitionerRoleValidator {
+	return &PractitionerRoleValidator{}
+}
+
+func (v *PractitionerRoleValidator) Validate(role *models.PractitionerRole) error {
+	if role == nil {
+		return errors.New("practitioner role resource is nil")
+	}
+
+	if len(role.Code) == 0 && len(role.Specialty) == 0 {
+		return errors.New("practitioner role must have a code or specialty")
+	}
+
+	return nil
+}
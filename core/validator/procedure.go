@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var procedureStatuses = map[string]bool{
+	"preparation":      true,
+	"in-progress":      true,
+	"not-done":         true,
+	"on-hold":          true,
+	"stopped":          true,
+	"completed":        true,
+	"entered-in-error": true,
+	"unknown":          true,
+}
+
+type ProcedureValidator struct{}
+
+func NewProcedureValidator() *ProcedureValidator {
+	return &ProcedureValidator{}
+}
+
+func (v *ProcedureValidator) Validate(proc *models.Procedure) error {
+	if proc == nil {
+		return errors.New("procedure resource is nil")
+	}
+
+	if proc.ResourceType != "Procedure" {
+		return fmt.Errorf("invalid resourceType: expected 'Procedure', got '%s'", proc.ResourceType)
+	}
+
+	if !procedureStatuses[proc.Status] {
+		return fmt.Errorf("invalid status %q", proc.Status)
+	}
+
+	if proc.Subject == nil || proc.Subject.Reference == nil {
+		return errors.New("subject is required")
+	}
+
+	if proc.Code == nil || (len(proc.Code.Coding) == 0 && proc.Code.Text == nil) {
+		return errors.New("code is required")
+	}
+
+	if proc.OccurrenceDateTime != nil && proc.OccurrencePeriod != nil {
+		return errors.New("only one of occurrenceDateTime and occurrencePeriod may be given")
+	}
+	if proc.OccurrenceDateTime != nil && !isFHIRDateTime(*proc.OccurrenceDateTime) {
+		return fmt.Errorf("invalid occurrenceDateTime %q", *proc.OccurrenceDateTime)
+	}
+	if period := proc.OccurrencePeriod; period != nil {
+		if period.Start != nil && !isFHIRDateTime(*period.Start) {
+			return fmt.Errorf("invalid occurrencePeriod.start %q", *period.Start)
+		}
+		if period.End != nil && !isFHIRDateTime(*period.End) {
+			return fmt.Errorf("invalid occurrencePeriod.end %q", *period.End)
+		}
+	}
+
+	if proc.Recorded != nil && !isFHIRDateTime(*proc.Recorded) {
+		return fmt.Errorf("invalid recorded %q", *proc.Recorded)
+	}
+
+	return nil
+}
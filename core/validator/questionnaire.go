@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var questionnaireStatuses = map[string]bool{
+	"draft":   true,
+	"active":  true,
+	"retired": true,
+	"unknown": true,
+}
+
+var questionnaireItemTypes = map[string]bool{
+	"group":      true,
+	"display":    true,
+	"boolean":    true,
+	"decimal":    true,
+	"integer":    true,
+	"date":       true,
+	"dateTime":   true,
+	"time":       true,
+	"string":     true,
+	"text":       true,
+	"url":        true,
+	"coding":     true,
+	"attachment": true,
+	"reference":  true,
+	"quantity":   true,
+}
+
+type QuestionnaireValidator struct{}
+
+func NewQuestionnaireValidator() *QuestionnaireValidator {
+	return &QuestionnaireValidator{}
+}
+
+func (v *QuestionnaireValidator) Validate(q *models.Questionnaire) error {
+	if q == nil {
+		return errors.New("questionnaire resource is nil")
+	}
+
+	if q.ResourceType != "Questionnaire" {
+		return fmt.Errorf("invalid resourceType: expected 'Questionnaire', got '%s'", q.ResourceType)
+	}
+
+	if !questionnaireStatuses[q.Status] {
+		return fmt.Errorf("invalid status %q", q.Status)
+	}
+
+	if q.Url == nil || strings.TrimSpace(*q.Url) == "" {
+		return errors.New("url is required")
+	}
+
+	if len(q.Item) == 0 {
+		return errors.New("at least one item is required")
+	}
+
+	return validateQuestionnaireItems(q.Item, make(map[string]bool))
+}
+
+// validateQuestionnaireItems checks the items at any depth; linkIds must be
+// unique across the whole questionnaire.
+func validateQuestionnaireItems(items []models.QuestionnaireItem, linkIDs map[string]bool) error {
+	for _, item := range items {
+		if item.LinkId == "" {
+			return errors.New("item linkId is required")
+		}
+		if linkIDs[item.LinkId] {
+			return fmt.Errorf("item %q: duplicate linkId", item.LinkId)
+		}
+		linkIDs[item.LinkId] = true
+
+		if !questionnaireItemTypes[item.Type] {
+			return fmt.Errorf("item %q: invalid type %q", item.LinkId, item.Type)
+		}
+		if item.Type == "group" && len(item.Item) == 0 {
+			return fmt.Errorf("item %q: group must have items", item.LinkId)
+		}
+		if item.Type == "display" && (len(item.Item) > 0 || (item.Required != nil && *item.Required)) {
+			return fmt.Errorf("item %q: display items cannot have items or be required", item.LinkId)
+		}
+
+		if err := validateQuestionnaireItems(item.Item, linkIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var questionnaireResponseStatuses = map[string]bool{
+	"in-progress":      true,
+	"completed":        true,
+	"amended":          true,
+	"entered-in-error": true,
+	"stopped":          true,
+}
+
+type QuestionnaireResponseValidator struct{}
+
+func NewQuestionnaireResponseValidator() *QuestionnaireResponseValidator {
+	return &QuestionnaireResponseValidator{}
+}
+
+// Validate checks the structure of a response. Whether the answers fit the
+// questionnaire is checked against the questionnaire itself.
+func (v *QuestionnaireResponseValidator) Validate(resp *models.QuestionnaireResponse) error {
+	if resp == nil {
+		return errors.New("questionnaire response resource is nil")
+	}
+
+	if resp.ResourceType != "QuestionnaireResponse" {
+		return fmt.Errorf("invalid resourceType: expected 'QuestionnaireResponse', got '%s'", resp.ResourceType)
+	}
+
+	if !questionnaireResponseStatuses[resp.Status] {
+		return fmt.Errorf("invalid status %q", resp.Status)
+	}
+
+	if strings.TrimSpace(resp.Questionnaire) == "" {
+		return errors.New("questionnaire is required")
+	}
+
+	if resp.Authored != nil && !isFHIRDateTime(*resp.Authored) {
+		return fmt.Errorf("invalid authored %q", *resp.Authored)
+	}
+
+	return validateResponseItems(resp.Item)
+}
+
+func validateResponseItems(items []models.QuestionnaireResponseItem) error {
+	for _, item := range items {
+		if item.LinkId == "" {
+			return errors.New("item linkId is required")
+		}
+		if err := validateResponseItems(item.Item); err != nil {
+			return err
+		}
+		for _, answer := range item.Answer {
+			if err := validateResponseItems(answer.Item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
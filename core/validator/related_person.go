@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+type RelatedPersonValidator struct{}
+
+func NewRelatedPersonValidator() *RelatedPersonValidator {
+	return &RelatedPersonValidator{}
+}
+
+func (v *RelatedPersonValidator) Validate(p *models.RelatedPerson) error {
+	if p == nil {
+		return errors.New("related person resource is nil")
+	}
+
+	if p.Patient == nil || p.Patient.Reference == nil || !strings.HasPrefix(*p.Patient.Reference, "Patient/") {
+		return errors.New("related person must reference a Patient")
+	}
+
+	if len(p.Name) == 0 && len(p.Relationship) == 0 {
+		return errors.New("related person must have a name or relationship")
+	}
+
+	return nil
+}
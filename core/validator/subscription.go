@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gruzdev-dev/codex-documents/core/domain"
+	"github.com/gruzdev-dev/codex-documents/pkg/netguard"
+
+	models "github.com/gruzdev-dev/fhir/r5"
+)
+
+var subscriptionTopicStatuses = map[string]bool{
+	"draft":   true,
+	"active":  true,
+	"retired": true,
+	"unknown": true,
+}
+
+var topicInteractions = map[string]bool{
+	string(domain.InteractionCreate): true,
+	string(domain.InteractionUpdate): true,
+	string(domain.InteractionDelete): true,
+}
+
+// topicFilterParameters are the resources topics may be triggered by and the
+// parameters their subscriptions may filter on.
+var topicFilterParameters = map[string]map[string]bool{
+	"Observation":       {"patient": true, "status": true, "code": true, "category": true},
+	"DocumentReference": {"patient": true, "status": true, "type": true, "category": true},
+}
+
+var subscriptionStatuses = map[string]bool{
+	domain.SubscriptionStatusRequested: true,
+	domain.SubscriptionStatusActive:    true,
+	domain.SubscriptionStatusError:     true,
+	domain.SubscriptionStatusOff:       true,
+	"entered-in-error":                 true,
+}
+
+var subscriptionContents = map[string]bool{
+	domain.SubscriptionContentEmpty:  true,
+	domain.SubscriptionContentIDOnly: true,
+	domain.SubscriptionContentFull:   true,
+}
+
+var subscriptionContentTypes = map[string]bool{
+	"application/fhir+json": true,
+	"application/json":      true,
+}
+
+type SubscriptionTopicValidator struct{}
+
+func NewSubscriptionTopicValidator() *SubscriptionTopicValidator {
+	return &SubscriptionTopicValidator{}
+}
+
+func (v *SubscriptionTopicValidator) Validate(topic *models.SubscriptionTopic) error {
+	if topic == nil {
+		return errors.New("subscription topic resource is nil")
+	}
+
+	if topic.ResourceType != "SubscriptionTopic" {
+		return fmt.Errorf("invalid resourceType: expected 'SubscriptionTopic', got '%s'", topic.ResourceType)
+	}
+
+	if !subscriptionTopicStatuses[topic.Status] {
+		return fmt.Errorf("invalid status %q", topic.Status)
+	}
+
+	if strings.TrimSpace(topic.Url) == "" {
+		return errors.New("url is required")
+	}
+
+	if len(topic.Trigger) == 0 {
+		return errors.New("at least one trigger is required")
+	}
+
+	for i, trigger := range topic.Trigger {
+		params, ok := topicFilterParameters[domain.TopicResourceType(trigger.Resource)]
+		if !ok {
+			return fmt.Errorf("trigger[%d]: resource %q cannot trigger notifications", i, trigger.Resource)
+		}
+
+		if len(trigger.SupportedInteraction) == 0 {
+			return fmt.Errorf("trigger[%d]: at least one supportedInteraction is required", i)
+		}
+		for _, interaction := range trigger.SupportedInteraction {
+			if !topicInteractions[interaction] {
+				return fmt.Errorf("trigger[%d]: invalid supportedInteraction %q", i, interaction)
+			}
+		}
+
+		if trigger.QueryCriteria != nil || trigger.FhirPathCriteria != nil {
+			return fmt.Errorf("trigger[%d]: queryCriteria and fhirPathCriteria are not supported", i)
+		}
+
+		for _, filter := range trigger.CanFilterBy {
+			if filter.Resource != nil && domain.TopicResourceType(*filter.Resource) != domain.TopicResourceType(trigger.Resource) {
+				return fmt.Errorf("trigger[%d]: canFilterBy resource %q does not match the trigger", i, *filter.Resource)
+			}
+			if !params[filter.FilterParameter] {
+				return fmt.Errorf("trigger[%d]: cannot filter by %q", i, filter.FilterParameter)
+			}
+		}
+	}
+
+	return nil
+}
+
+type SubscriptionValidator struct {
+	endpoints *netguard.Guard
+}
+
+// NewSubscriptionValidator returns a validator that refuses endpoints on
+// hosts the guard does not let through.
+func NewSubscriptionValidator(endpoints *netguard.Guard) *SubscriptionValidator {
+	return &SubscriptionValidator{endpoints: endpoints}
+}
+
+func (v *SubscriptionValidator) Validate(sub *models.Subscription) error {
+	if sub == nil {
+		return errors.New("subscription resource is nil")
+	}
+
+	if sub.ResourceType != "Subscription" {
+		return fmt.Errorf("invalid resourceType: expected 'Subscription', got '%s'", sub.ResourceType)
+	}
+
+	if !subscriptionStatuses[sub.Status] {
+		return fmt.Errorf("invalid status %q", sub.Status)
+	}
+
+	if strings.TrimSpace(sub.Topic) == "" {
+		return errors.New("topic is required")
+	}
+
+	if sub.ChannelType == nil || sub.ChannelType.Code == nil || *sub.ChannelType.Code != domain.SubscriptionChannelRestHook {
+		return errors.New("channelType must be rest-hook")
+	}
+	if sub.ChannelType.System != nil && *sub.ChannelType.System != domain.SubscriptionChannelSystem {
+		return fmt.Errorf("unknown channelType system %q", *sub.ChannelType.System)
+	}
+
+	if sub.Endpoint == nil {
+		return errors.New("endpoint is required")
+	}
+	endpoint, err := url.Parse(*sub.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("endpoint %q must be an absolute http or https URL", *sub.Endpoint)
+	}
+	if err := v.endpoints.CheckHost(endpoint.Hostname()); err != nil {
+		return fmt.Errorf("endpoint %q must be a public address: %w", *sub.Endpoint, err)
+	}
+
+	if sub.Content != nil && !subscriptionContents[*sub.Content] {
+		return fmt.Errorf("invalid content %q", *sub.Content)
+	}
+
+	if sub.ContentType != nil && !subscriptionContentTypes[*sub.ContentType] {
+		return fmt.Errorf("unsupported contentType %q", *sub.ContentType)
+	}
+
+	if sub.HeartbeatPeriod != nil && *sub.HeartbeatPeriod <= 0 {
+		return errors.New("heartbeatPeriod must be positive")
+	}
+	if sub.Timeout != nil && (*sub.Timeout <= 0 || *sub.Timeout > domain.SubscriptionMaxTimeout) {
+		return fmt.Errorf("timeout must be between 1 and %d seconds", domain.SubscriptionMaxTimeout)
+	}
+	if sub.MaxCount != nil && *sub.MaxCount <= 0 {
+		return errors.New("maxCount must be positive")
+	}
+
+	if sub.End != nil {
+		if _, err := time.Parse(time.RFC3339, *sub.End); err != nil {
+			return fmt.Errorf("invalid end %q: must be an instant with a time zone", *sub.End)
+		}
+	}
+
+	for i, filter := range sub.FilterBy {
+		if filter.FilterParameter == "" {
+			return fmt.Errorf("filterBy[%d]: filterParameter is required", i)
+		}
+		if strings.TrimSpace(filter.Value) == "" {
+			return fmt.Errorf("filterBy[%d]: value is required", i)
+		}
+		if filter.Comparator != nil && *filter.Comparator != "eq" {
+			return fmt.Errorf("filterBy[%d]: unsupported comparator %q", i, *filter.Comparator)
+		}
+		if filter.Modifier != nil && *filter.Modifier != "not" {
+			return fmt.Errorf("filterBy[%d]: unsupported modifier %q", i, *filter.Modifier)
+		}
+	}
+
+	for i, param := range sub.Parameter {
+		if param.Name == "" {
+			return fmt.Errorf("parameter[%d]: name is required", i)
+		}
+	}
+
+	return nil
+}
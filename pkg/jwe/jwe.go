@@ -0,0 +1,124 @@
+package jwe
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	algDirect  = "dir"
+	encA256GCM = "A256GCM"
+	keySize    = 32
+)
+
+var (
+	ErrInvalidKey   = errors.New("jwe: key must be 32 bytes")
+	ErrInvalidToken = errors.New("jwe: malformed compact serialization")
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Enc string `json:"enc"`
+	Cty string `json:"cty,omitempty"`
+}
+
+var encoding = base64.RawURLEncoding
+
+// NewKey returns a random key suitable for direct A256GCM encryption.
+func NewKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Encrypt seals plaintext into a compact JWE using direct key agreement and A256GCM.
+func Encrypt(key, plaintext []byte, contentType string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawHeader, err := json.Marshal(header{Alg: algDirect, Enc: encA256GCM, Cty: contentType})
+	if err != nil {
+		return "", err
+	}
+	protected := encoding.EncodeToString(rawHeader)
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nil, iv, plaintext, []byte(protected))
+	tagStart := len(sealed) - gcm.Overhead()
+
+	return strings.Join([]string{
+		protected,
+		"",
+		encoding.EncodeToString(iv),
+		encoding.EncodeToString(sealed[:tagStart]),
+		encoding.EncodeToString(sealed[tagStart:]),
+	}, "."), nil
+}
+
+// Decrypt opens a compact JWE produced by Encrypt.
+func Decrypt(key []byte, token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 || parts[1] != "" {
+		return nil, ErrInvalidToken
+	}
+
+	rawHeader, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var h header
+	if err := json.Unmarshal(rawHeader, &h); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if h.Alg != algDirect || h.Enc != encA256GCM {
+		return nil, fmt.Errorf("jwe: unsupported algorithm %s/%s", h.Alg, h.Enc)
+	}
+
+	iv, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	ciphertext, err := encoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	tag, err := encoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != gcm.NonceSize() {
+		return nil, ErrInvalidToken
+	}
+
+	return gcm.Open(nil, iv, append(ciphertext, tag...), []byte(parts[0]))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
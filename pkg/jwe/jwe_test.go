@@ -0,0 +1,88 @@
+package jwe
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	token, err := Encrypt(key, []byte(`{"resourceType":"Bundle"}`), "application/fhir+json")
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 5)
+	assert.Empty(t, parts[1], "direct encryption carries no encrypted key")
+
+	plaintext, err := Decrypt(key, token)
+	require.NoError(t, err)
+	assert.Equal(t, `{"resourceType":"Bundle"}`, string(plaintext))
+
+	again, err := Encrypt(key, []byte(`{"resourceType":"Bundle"}`), "application/fhir+json")
+	require.NoError(t, err)
+	assert.NotEqual(t, token, again, "every token gets a fresh IV")
+}
+
+func TestDecrypt(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	token, err := Encrypt(key, []byte("secret"), "")
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	otherKey, err := NewKey()
+	require.NoError(t, err)
+
+	tamper := func(index int) string {
+		tampered := append([]string(nil), parts...)
+		raw, err := encoding.DecodeString(tampered[index])
+		require.NoError(t, err)
+		raw[0] ^= 0xff
+		tampered[index] = encoding.EncodeToString(raw)
+		return strings.Join(tampered, ".")
+	}
+	withHeader := func(header string) string {
+		tampered := append([]string(nil), parts...)
+		tampered[0] = encoding.EncodeToString([]byte(header))
+		return strings.Join(tampered, ".")
+	}
+
+	tests := []struct {
+		name          string
+		key           []byte
+		token         string
+		expectedError error
+	}{
+		{name: "wrong key", key: otherKey, token: token},
+		{name: "tampered ciphertext", key: key, token: tamper(3)},
+		{name: "tampered tag", key: key, token: tamper(4)},
+		{name: "tampered header", key: key, token: withHeader(`{"alg":"dir","enc":"A256GCM","cty":"x"}`)},
+		{name: "unsupported algorithm", key: key, token: withHeader(`{"alg":"RSA-OAEP","enc":"A256GCM"}`)},
+		{name: "too few parts", key: key, token: strings.Join(parts[:4], "."), expectedError: ErrInvalidToken},
+		{name: "encrypted key present", key: key, token: strings.Join([]string{parts[0], "AAAA", parts[2], parts[3], parts[4]}, "."), expectedError: ErrInvalidToken},
+		{name: "malformed header", key: key, token: "!!!" + token, expectedError: ErrInvalidToken},
+		{name: "short key", key: key[:16], token: token, expectedError: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, err := Decrypt(tt.key, tt.token)
+			require.Error(t, err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			}
+			assert.Nil(t, plaintext)
+		})
+	}
+}
+
+func TestEncrypt_InvalidKey(t *testing.T) {
+	_, err := Encrypt(bytes.Repeat([]byte{1}, 16), []byte("secret"), "")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,341 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultCacheTTL        = 10 * time.Minute
+	defaultRefreshInterval = 5 * time.Second
+	defaultMaxStale        = time.Hour
+	fetchTimeout           = 10 * time.Second
+	maxDocumentSize        = 1 << 20
+)
+
+var (
+	ErrKeyNotFound    = errors.New("jwks: key not found")
+	ErrUnsupportedKey = errors.New("jwks: unsupported key")
+)
+
+// Source returns the raw JSON Web Key Set document.
+type Source interface {
+	Fetch(ctx context.Context) ([]byte, error)
+}
+
+type fileSource struct {
+	path string
+}
+
+// NewFileSource reads the key set from a local file on every fetch, so a
+// rotated file is picked up on the next refresh.
+func NewFileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+func (s *fileSource) Fetch(context.Context) ([]byte, error) {
+	return os.ReadFile(s.path)
+}
+
+type httpSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSource fetches the key set from a jwks_uri endpoint.
+func NewHTTPSource(url string, client *http.Client) Source {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &httpSource{url: url, client: client}
+}
+
+func (s *httpSource) Fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: unexpected status %d from %s", resp.StatusCode, s.url)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize))
+}
+
+// Key is a public verification key from the set.
+type Key struct {
+	ID        string
+	Algorithm string
+	Public    crypto.PublicKey
+}
+
+// KeySet caches the keys of a Source. The cache is refreshed when it is older
+// than the TTL, or when a key ID is requested that the cache does not know,
+// which is how newly rotated keys become available. Concurrent callers share
+// one fetch, and no fetch blocks readers of the cache. When a refresh fails
+// the expired keys are served for up to the max staleness, so an outage of
+// the source does not lock every user out at once.
+type KeySet struct {
+	source          Source
+	cacheTTL        time.Duration
+	refreshInterval time.Duration
+	maxStale        time.Duration
+	now             func() time.Time
+	flight          singleflight.Group
+
+	mu          sync.Mutex
+	cache       keyCache
+	attemptedAt time.Time
+	lastErr     error
+}
+
+type keyCache struct {
+	keys      []Key
+	fetchedAt time.Time
+}
+
+type Option func(*KeySet)
+
+// WithCacheTTL sets how long fetched keys are used before a refresh.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(s *KeySet) {
+		if ttl > 0 {
+			s.cacheTTL = ttl
+		}
+	}
+}
+
+// WithRefreshInterval sets the minimum time between two fetches triggered by
+// unknown key IDs or failed refreshes, so forged kids and a failing source
+// cannot hammer it.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(s *KeySet) {
+		if interval > 0 {
+			s.refreshInterval = interval
+		}
+	}
+}
+
+// WithMaxStale sets how long past the TTL the cached keys are still served
+// while refreshes fail.
+func WithMaxStale(maxStale time.Duration) Option {
+	return func(s *KeySet) {
+		if maxStale > 0 {
+			s.maxStale = maxStale
+		}
+	}
+}
+
+func NewKeySet(source Source, opts ...Option) *KeySet {
+	s := &KeySet{
+		source:          source,
+		cacheTTL:        defaultCacheTTL,
+		refreshInterval: defaultRefreshInterval,
+		maxStale:        defaultMaxStale,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Keys returns the keys matching kid. An empty kid matches every key in the
+// set, which lets tokens without a kid be checked against all active keys.
+func (s *KeySet) Keys(ctx context.Context, kid string) ([]Key, error) {
+	s.mu.Lock()
+	cache := s.cache
+	s.mu.Unlock()
+
+	now := s.now()
+	if cache.fetchedAt.IsZero() || now.Sub(cache.fetchedAt) > s.cacheTTL {
+		fresh, err := s.refresh(ctx)
+		switch {
+		case err == nil:
+			cache = fresh
+		case cache.fetchedAt.IsZero() || now.Sub(cache.fetchedAt) > s.cacheTTL+s.maxStale:
+			return nil, err
+		}
+	}
+
+	if found := cache.lookup(kid); len(found) > 0 {
+		return found, nil
+	}
+
+	fresh, err := s.refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if found := fresh.lookup(kid); len(found) > 0 {
+		return found, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (c keyCache) lookup(kid string) []Key {
+	if kid == "" {
+		return c.keys
+	}
+	var found []Key
+	for _, k := range c.keys {
+		if k.ID == kid {
+			found = append(found, k)
+		}
+	}
+	return found
+}
+
+// refresh fetches the key set, sharing the fetch with concurrent callers. An
+// attempt within the refresh interval of the previous one returns the cache
+// and the outcome of that attempt instead. The fetch is not cancelled with
+// the context of the caller that started it, as the others wait for it too,
+// but it is bounded by its own timeout.
+func (s *KeySet) refresh(ctx context.Context) (keyCache, error) {
+	result, err, _ := s.flight.Do("refresh", func() (any, error) {
+		s.mu.Lock()
+		now := s.now()
+		if !s.attemptedAt.IsZero() && now.Sub(s.attemptedAt) < s.refreshInterval {
+			cache, err := s.cache, s.lastErr
+			s.mu.Unlock()
+			return cache, err
+		}
+		s.attemptedAt = now
+		s.mu.Unlock()
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+		keys, err := s.fetch(fetchCtx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.lastErr = err
+		if err == nil {
+			s.cache = keyCache{keys: keys, fetchedAt: now}
+		}
+		return s.cache, err
+	})
+	return result.(keyCache), err
+}
+
+func (s *KeySet) fetch(ctx context.Context) ([]Key, error) {
+	raw, err := s.source.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: fetch: %w", err)
+	}
+	return Parse(raw)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Parse decodes a JWK Set document. Keys that are not signature keys or use
+// an unsupported type or curve are skipped.
+func Parse(raw []byte) ([]Key, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("jwks: decode: %w", err)
+	}
+
+	keys := make([]Key, 0, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys = append(keys, Key{ID: jwk.Kid, Algorithm: jwk.Alg, Public: pub})
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, ErrUnsupportedKey
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, ErrUnsupportedKey
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, ErrUnsupportedKey
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedKey
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, ErrUnsupportedKey
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,274 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var b64 = base64.RawURLEncoding
+
+func rsaJWK(t *testing.T, kid string) map[string]string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"alg": "RS256",
+		"n":   b64.EncodeToString(key.N.Bytes()),
+		"e":   b64.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(t *testing.T, kid string) map[string]string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   b64.EncodeToString(key.X.Bytes()),
+		"y":   b64.EncodeToString(key.Y.Bytes()),
+	}
+}
+
+func keySetDoc(t *testing.T, keys ...map[string]string) []byte {
+	raw, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return raw
+}
+
+func TestParse(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		jwk           map[string]string
+		expectedKey   any
+		expectedID    string
+		expectedError bool
+	}{
+		{name: "RSA key", jwk: rsaJWK(t, "rsa-1"), expectedKey: &rsa.PublicKey{}, expectedID: "rsa-1"},
+		{name: "EC P-256 key", jwk: ecJWK(t, "ec-1"), expectedKey: &ecdsa.PublicKey{}, expectedID: "ec-1"},
+		{
+			name:        "Ed25519 key",
+			jwk:         map[string]string{"kty": "OKP", "crv": "Ed25519", "kid": "ed-1", "x": b64.EncodeToString(pub)},
+			expectedKey: ed25519.PublicKey{},
+			expectedID:  "ed-1",
+		},
+		{name: "key without kid", jwk: func() map[string]string { k := ecJWK(t, ""); delete(k, "kid"); return k }(), expectedKey: &ecdsa.PublicKey{}},
+		{name: "unknown key type skipped", jwk: map[string]string{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}},
+		{name: "unsupported curve skipped", jwk: func() map[string]string { k := ecJWK(t, "ec-2"); k["crv"] = "P-384"; return k }()},
+		{name: "encryption key skipped", jwk: func() map[string]string { k := rsaJWK(t, "enc"); k["use"] = "enc"; return k }()},
+		{name: "RSA key without modulus skipped", jwk: func() map[string]string { k := rsaJWK(t, "rsa-2"); delete(k, "n"); return k }()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := Parse(keySetDoc(t, tt.jwk))
+			require.NoError(t, err)
+
+			if tt.expectedKey == nil {
+				assert.Empty(t, keys)
+				return
+			}
+			require.Len(t, keys, 1)
+			assert.IsType(t, tt.expectedKey, keys[0].Public)
+			assert.Equal(t, tt.expectedID, keys[0].ID)
+		})
+	}
+
+	_, err = Parse([]byte("not json"))
+	assert.Error(t, err)
+}
+
+// stubSource serves a key set document, or an error, and counts fetches.
+type stubSource struct {
+	mu      sync.Mutex
+	doc     []byte
+	err     error
+	fetches atomic.Int32
+	release chan struct{}
+}
+
+func (s *stubSource) set(doc []byte, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.doc, s.err = doc, err
+}
+
+func (s *stubSource) Fetch(context.Context) ([]byte, error) {
+	s.fetches.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.doc, s.err
+}
+
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestKeySet(source Source, c *clock) *KeySet {
+	s := NewKeySet(source, WithCacheTTL(time.Minute), WithRefreshInterval(5*time.Second), WithMaxStale(10*time.Minute))
+	s.now = c.Now
+	return s
+}
+
+func TestKeySet_Keys(t *testing.T) {
+	first, second := ecJWK(t, "key-1"), ecJWK(t, "key-2")
+	c := &clock{now: time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)}
+	source := &stubSource{doc: keySetDoc(t, first)}
+	set := newTestKeySet(source, c)
+	ctx := context.Background()
+
+	keys, err := set.Keys(ctx, "key-1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, int32(1), source.fetches.Load())
+
+	// Cached within the TTL.
+	c.Advance(30 * time.Second)
+	_, err = set.Keys(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), source.fetches.Load())
+
+	// An unknown kid triggers a refresh, which picks up a rotated key.
+	source.set(keySetDoc(t, first, second), nil)
+	keys, err = set.Keys(ctx, "key-2")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "key-2", keys[0].ID)
+	assert.Equal(t, int32(2), source.fetches.Load())
+
+	// Unknown kids within the refresh interval do not fetch again.
+	_, err = set.Keys(ctx, "forged")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, int32(2), source.fetches.Load())
+
+	// An empty kid matches every key.
+	keys, err = set.Keys(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	// The cache expires after the TTL.
+	c.Advance(2 * time.Minute)
+	source.set(keySetDoc(t, second), nil)
+	_, err = set.Keys(ctx, "key-2")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), source.fetches.Load())
+	_, err = set.Keys(ctx, "key-1")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestKeySet_Keys_RefreshFailure(t *testing.T) {
+	outage := errors.New("connection refused")
+
+	tests := []struct {
+		name          string
+		elapsed       time.Duration
+		expectedError error
+	}{
+		{name: "stale keys served after the TTL", elapsed: 2 * time.Minute},
+		{name: "stale keys served up to the max staleness", elapsed: 11*time.Minute - time.Second},
+		{name: "keys beyond the max staleness dropped", elapsed: 11*time.Minute + time.Second, expectedError: outage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &clock{now: time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)}
+			source := &stubSource{doc: keySetDoc(t, ecJWK(t, "key-1"))}
+			set := newTestKeySet(source, c)
+			ctx := context.Background()
+
+			_, err := set.Keys(ctx, "key-1")
+			require.NoError(t, err)
+
+			c.Advance(tt.elapsed)
+			source.set(nil, outage)
+			keys, err := set.Keys(ctx, "key-1")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, keys, 1)
+
+			// A failing source is not retried within the refresh interval.
+			_, err = set.Keys(ctx, "key-1")
+			require.NoError(t, err)
+			assert.Equal(t, int32(2), source.fetches.Load())
+		})
+	}
+}
+
+func TestKeySet_Keys_FirstFetchFails(t *testing.T) {
+	c := &clock{now: time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)}
+	source := &stubSource{err: errors.New("connection refused")}
+	set := newTestKeySet(source, c)
+
+	_, err := set.Keys(context.Background(), "key-1")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestKeySet_Keys_SingleFlight(t *testing.T) {
+	c := &clock{now: time.Date(2030, 5, 1, 8, 0, 0, 0, time.UTC)}
+	source := &stubSource{doc: keySetDoc(t, ecJWK(t, "key-1")), release: make(chan struct{})}
+	set := newTestKeySet(source, c)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := set.Keys(context.Background(), "key-1")
+			errs <- err
+		}()
+	}
+
+	require.Eventually(t, func() bool { return source.fetches.Load() == 1 }, time.Second, time.Millisecond)
+	// Readers of an empty cache wait for the fetch in flight instead of
+	// starting their own.
+	time.Sleep(20 * time.Millisecond)
+	close(source.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), source.fetches.Load())
+}
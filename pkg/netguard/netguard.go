@@ -0,0 +1,141 @@
+// Package netguard keeps requests to URLs that users supply, such as the
+// endpoints of subscriptions, away from the network the service runs in.
+// Loopback, private, link-local and other addresses that are not publicly
+// routable are refused unless they are allowlisted.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenAddress = errors.New("netguard: address not allowed")
+
+// reserved are the ranges IsGlobalUnicast and IsPrivate let through that are
+// not publicly routable either.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+type Guard struct {
+	hosts    map[string]bool
+	prefixes []netip.Prefix
+}
+
+// New returns a guard that lets through public addresses and the host names
+// and CIDR ranges of allow.
+func New(allow []string) (*Guard, error) {
+	g := &Guard{hosts: make(map[string]bool)}
+	for _, entry := range allow {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("netguard: invalid CIDR %q: %w", entry, err)
+			}
+			g.prefixes = append(g.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			g.prefixes = append(g.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		g.hosts[normalizeHost(entry)] = true
+	}
+	return g, nil
+}
+
+// CheckHost refuses localhost and IP literals that CheckAddr refuses. Other
+// names are only resolved when dialled, so DialContext checks them again.
+func (g *Guard) CheckHost(host string) error {
+	host = normalizeHost(host)
+	if g.hosts[host] {
+		return nil
+	}
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %q", ErrForbiddenAddress, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return g.CheckAddr(addr)
+	}
+	return nil
+}
+
+// CheckAddr refuses an address that is not publicly routable unless it is
+// in an allowlisted range.
+func (g *Guard) CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	for _, prefix := range g.prefixes {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	if !public(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	return nil
+}
+
+// DialContext returns a dial function for an http.Transport that dials with
+// dialer and checks every address a host resolves to right before it is
+// connected to, so neither a name resolving to an internal address nor one
+// rebound to it after validation is reached. Allowlisted host names are
+// dialled without a check.
+func (g *Guard) DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	checked := *dialer
+	checked.Control = g.control
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if g.hosts[normalizeHost(host)] {
+			return dialer.DialContext(ctx, network, address)
+		}
+		if err := g.CheckHost(host); err != nil {
+			return nil, err
+		}
+		return checked.DialContext(ctx, network, address)
+	}
+}
+
+// control checks the resolved address a dialer is about to connect to.
+func (g *Guard) control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrForbiddenAddress, address)
+	}
+	return g.CheckAddr(addrPort.Addr())
+}
+
+func public(addr netip.Addr) bool {
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,108 @@
+package netguard
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard_CheckHost(t *testing.T) {
+	tests := []struct {
+		name      string
+		allow     []string
+		host      string
+		forbidden bool
+	}{
+		{name: "public name", host: "hooks.example.com"},
+		{name: "public IPv4", host: "93.184.216.34"},
+		{name: "public IPv6", host: "[2606:2800:220:1:248:1893:25c8:1946]"},
+		{name: "localhost", host: "localhost", forbidden: true},
+		{name: "localhost subdomain", host: "api.localhost.", forbidden: true},
+		{name: "loopback", host: "127.0.0.1", forbidden: true},
+		{name: "loopback IPv6", host: "[::1]", forbidden: true},
+		{name: "IPv4-mapped loopback", host: "::ffff:127.0.0.1", forbidden: true},
+		{name: "private", host: "10.1.2.3", forbidden: true},
+		{name: "private IPv6", host: "fd00::1", forbidden: true},
+		{name: "link-local metadata", host: "169.254.169.254", forbidden: true},
+		{name: "unspecified", host: "0.0.0.0", forbidden: true},
+		{name: "carrier-grade NAT", host: "100.64.0.1", forbidden: true},
+		{name: "allowlisted name", allow: []string{"Receiver.Internal"}, host: "receiver.internal"},
+		{name: "allowlisted localhost", allow: []string{"localhost"}, host: "localhost"},
+		{name: "allowlisted range", allow: []string{"10.0.0.0/8"}, host: "10.1.2.3"},
+		{name: "outside allowlisted range", allow: []string{"10.0.0.0/24"}, host: "10.1.2.3", forbidden: true},
+		{name: "allowlisted address", allow: []string{"192.168.1.10"}, host: "192.168.1.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard, err := New(tt.allow)
+			require.NoError(t, err)
+
+			err = guard.CheckHost(tt.host)
+			if tt.forbidden {
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNew_InvalidCIDR(t *testing.T) {
+	_, err := New([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestGuard_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	addr := netip.MustParseAddrPort(server.Listener.Addr().String())
+
+	tests := []struct {
+		name      string
+		allow     []string
+		address   string
+		forbidden bool
+	}{
+		{name: "loopback address", address: addr.String(), forbidden: true},
+		{name: "localhost", address: net.JoinHostPort("localhost.", "1"), forbidden: true},
+		{name: "allowlisted range", allow: []string{"127.0.0.0/8"}, address: addr.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard, err := New(tt.allow)
+			require.NoError(t, err)
+
+			dial := guard.DialContext(&net.Dialer{Timeout: time.Second})
+			conn, err := dial(context.Background(), "tcp", tt.address)
+			if tt.forbidden {
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+				return
+			}
+			require.NoError(t, err)
+			_ = conn.Close()
+		})
+	}
+}
+
+func TestGuard_Control(t *testing.T) {
+	guard, err := New([]string{"receiver.internal"})
+	require.NoError(t, err)
+
+	// A name is checked again by the address it resolved to, allowlisted
+	// names aside, which are dialled without the control.
+	assert.ErrorIs(t, guard.control("tcp4", "127.0.0.1:443", nil), ErrForbiddenAddress)
+	assert.ErrorIs(t, guard.control("tcp6", "[fe80::1%eth0]:443", nil), ErrForbiddenAddress)
+	assert.ErrorIs(t, guard.control("tcp6", "[::ffff:10.0.0.1]:443", nil), ErrForbiddenAddress)
+	assert.NoError(t, guard.control("tcp4", "93.184.216.34:443", nil))
+}
@@ -0,0 +1,326 @@
+//go:build integration
+
+package tests
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	httpadapter "github.com/gruzdev-dev/codex-documents/adapters/http"
+	"github.com/gruzdev-dev/codex-documents/configs"
+	"github.com/gruzdev-dev/codex-documents/pkg/identity"
+	models "github.com/gruzdev-dev/fhir/r5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signingKey struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+// jwksStandIn serves a mutable JSON Web Key Set, like an identity provider
+// rotating its keys.
+type jwksStandIn struct {
+	mu   sync.Mutex
+	keys []signingKey
+}
+
+func (s *jwksStandIn) setKeys(keys ...signingKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksStandIn) ServeHTTP(w nethttp.ResponseWriter, _ *nethttp.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jwks := make([]map[string]string, 0, len(s.keys))
+	for _, k := range s.keys {
+		jwks = append(jwks, publicJWK(k))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": jwks})
+}
+
+func publicJWK(k signingKey) map[string]string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwk := map[string]string{"kid": k.kid, "use": "sig", "alg": k.method.Alg()}
+	switch pub := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		jwk["kty"] = "RSA"
+		jwk["n"] = b64(pub.N.Bytes())
+		jwk["e"] = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		jwk["kty"] = "EC"
+		jwk["crv"] = "P-256"
+		jwk["x"] = b64(pub.X.FillBytes(make([]byte, 32)))
+		jwk["y"] = b64(pub.Y.FillBytes(make([]byte, 32)))
+	case ed25519.PublicKey:
+		jwk["kty"] = "OKP"
+		jwk["crv"] = "Ed25519"
+		jwk["x"] = b64(pub)
+	}
+	return jwk
+}
+
+func newRSAKey(t *testing.T, kid string) signingKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return signingKey{kid: kid, method: jwt.SigningMethodRS256, key: key}
+}
+
+func newECKey(t *testing.T, kid string) signingKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return signingKey{kid: kid, method: jwt.SigningMethodES256, key: key}
+}
+
+func newEdKey(t *testing.T, kid string) signingKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return signingKey{kid: kid, method: jwt.SigningMethodEdDSA, key: key}
+}
+
+func signToken(t *testing.T, k signingKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.kid
+	signed, err := token.SignedString(k.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func patientClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":        "test-user",
+		"patient_id": "patient-1",
+		"scope":      "patient/*.rs",
+		"iss":        "https://idp.example",
+		"aud":        "codex-documents",
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func newAuthTestServer(t *testing.T, cfg *configs.Config) *httptest.Server {
+	protected := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		id, ok := identity.FromCtx(r.Context())
+		if !ok {
+			w.WriteHeader(nethttp.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(id.PatientID))
+	})
+	ts := httptest.NewServer(httpadapter.NewAuthMiddleware(cfg).Handler(protected))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func callWithToken(t *testing.T, url, token string) int {
+	req, err := nethttp.NewRequest(nethttp.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := nethttp.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAuthJWKSIntegration(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	edKey := newEdKey(t, "ed-1")
+
+	standIn := &jwksStandIn{}
+	standIn.setKeys(rsaKey, ecKey, edKey)
+	jwksServer := httptest.NewServer(standIn)
+	defer jwksServer.Close()
+
+	cfg := &configs.Config{}
+	cfg.Auth.JWTSecret = "secret-key"
+	cfg.Auth.JWKSURL = jwksServer.URL
+	cfg.Auth.JWKSRefreshInterval = time.Millisecond
+	cfg.Auth.Issuer = "https://idp.example"
+	cfg.Auth.Audience = []string{"codex-documents"}
+	cfg.Auth.RequireExp = true
+	ts := newAuthTestServer(t, cfg)
+
+	t.Run("RS256, ES256 and EdDSA tokens are accepted", func(t *testing.T) {
+		for _, k := range []signingKey{rsaKey, ecKey, edKey} {
+			assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, signToken(t, k, patientClaims())), k.method.Alg())
+		}
+	})
+
+	t.Run("HS256 tokens still use the shared secret", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, patientClaims())
+		signed, err := token.SignedString([]byte("secret-key"))
+		require.NoError(t, err)
+		assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, signed))
+	})
+
+	t.Run("unknown key is rejected", func(t *testing.T) {
+		stranger := newRSAKey(t, "stranger")
+		assert.Equal(t, nethttp.StatusUnauthorized, callWithToken(t, ts.URL, signToken(t, stranger, patientClaims())))
+	})
+
+	t.Run("key with a known kid but wrong material is rejected", func(t *testing.T) {
+		forged := newRSAKey(t, rsaKey.kid)
+		assert.Equal(t, nethttp.StatusUnauthorized, callWithToken(t, ts.URL, signToken(t, forged, patientClaims())))
+	})
+
+	t.Run("rotated key is fetched on kid miss", func(t *testing.T) {
+		rotated := newECKey(t, "ec-2")
+		standIn.setKeys(rsaKey, ecKey, rotated)
+		time.Sleep(5 * time.Millisecond)
+
+		assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, signToken(t, rotated, patientClaims())))
+		assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, signToken(t, ecKey, patientClaims())))
+	})
+
+	t.Run("retired key is rejected after refresh", func(t *testing.T) {
+		standIn.setKeys(rsaKey)
+		time.Sleep(5 * time.Millisecond)
+
+		// The first call refreshes the set on the unknown kid.
+		rotated := newECKey(t, "ec-3")
+		assert.Equal(t, nethttp.StatusUnauthorized, callWithToken(t, ts.URL, signToken(t, rotated, patientClaims())))
+		assert.Equal(t, nethttp.StatusUnauthorized, callWithToken(t, ts.URL, signToken(t, ecKey, patientClaims())))
+		assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, signToken(t, rsaKey, patientClaims())))
+	})
+
+	t.Run("registered claims are validated", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			mutate func(jwt.MapClaims)
+		}{
+			{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }},
+			{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "other-service" }},
+			{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+			{name: "not yet valid", mutate: func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Hour).Unix() }},
+			{name: "missing exp", mutate: func(c jwt.MapClaims) { delete(c, "exp") }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				claims := patientClaims()
+				tt.mutate(claims)
+				assert.Equal(t, nethttp.StatusUnauthorized, callWithToken(t, ts.URL, signToken(t, rsaKey, claims)))
+			})
+		}
+	})
+}
+
+func TestAuthJWKSFileIntegration(t *testing.T) {
+	edKey := newEdKey(t, "ed-file")
+
+	raw, err := json.Marshal(map[string]any{"keys": []map[string]string{publicJWK(edKey)}})
+	require.NoError(t, err)
+	path := t.TempDir() + "/jwks.json"
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	cfg := &configs.Config{}
+	cfg.Auth.JWKSFile = path
+	cfg.Auth.Leeway = time.Minute
+	ts := newAuthTestServer(t, cfg)
+
+	claims := patientClaims()
+	claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+	assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, signToken(t, edKey, claims)), "leeway covers clock skew")
+
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, patientClaims())
+	signed, err := hs.SignedString([]byte("any-secret"))
+	require.NoError(t, err)
+	assert.Equal(t, nethttp.StatusUnauthorized, callWithToken(t, ts.URL, signed), "HS256 is disabled without a secret")
+}
+
+func TestAuthChallengeIntegration(t *testing.T) {
+	cfg := &configs.Config{}
+	cfg.Auth.JWTSecret = "secret-key"
+	ts := newAuthTestServer(t, cfg)
+
+	hs256 := func(claims jwt.MapClaims, secret string) string {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return signed
+	}
+	expired := patientClaims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name              string
+		authorization     string
+		expectedChallenge string
+		expectedIssue     string
+	}{
+		{
+			name:              "missing credentials",
+			expectedChallenge: `Bearer realm="codex-documents"`,
+			expectedIssue:     "login",
+		},
+		{
+			name:              "other scheme",
+			authorization:     "Basic dXNlcjpwYXNz",
+			expectedChallenge: `Bearer realm="codex-documents"`,
+			expectedIssue:     "login",
+		},
+		{
+			name:              "bad signature",
+			authorization:     "Bearer " + hs256(patientClaims(), "wrong-secret"),
+			expectedChallenge: `Bearer realm="codex-documents", error="invalid_token", error_description="the access token signature could not be verified"`,
+			expectedIssue:     "login",
+		},
+		{
+			name:              "expired token",
+			authorization:     "Bearer " + hs256(expired, "secret-key"),
+			expectedChallenge: `Bearer realm="codex-documents", error="invalid_token", error_description="the access token expired"`,
+			expectedIssue:     "expired",
+		},
+		{
+			name:              "malformed token",
+			authorization:     "Bearer not-a-jwt",
+			expectedChallenge: `Bearer realm="codex-documents", error="invalid_token", error_description="the access token is malformed"`,
+			expectedIssue:     "login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := nethttp.NewRequest(nethttp.MethodGet, ts.URL, nil)
+			require.NoError(t, err)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			resp, err := nethttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, nethttp.StatusUnauthorized, resp.StatusCode)
+			assert.Equal(t, tt.expectedChallenge, resp.Header.Get("WWW-Authenticate"))
+
+			var outcome models.OperationOutcome
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&outcome))
+			assert.Equal(t, "OperationOutcome", outcome.ResourceType)
+			require.Len(t, outcome.Issue, 1)
+			assert.Equal(t, tt.expectedIssue, outcome.Issue[0].Code)
+		})
+	}
+
+	t.Run("valid token passes through", func(t *testing.T) {
+		assert.Equal(t, nethttp.StatusOK, callWithToken(t, ts.URL, hs256(patientClaims(), "secret-key")))
+	})
+}
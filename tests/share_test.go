@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	nethttp "net/http"
+	"strings"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -30,6 +31,8 @@ func TestShareIntegration(t *testing.T) {
 		UploadUrl:   "http://test/upload",
 		DownloadUrl: "http://test/download",
 	}, nil).AnyTimes()
+	env.MockFileProvider.EXPECT().GetDownloadUrl(gomock.Any(), "test-file-id", gomock.Any()).
+		Return("http://test/download?signature=shared", nil).AnyTimes()
 
 	client := &nethttp.Client{}
 
@@ -335,6 +338,58 @@ func TestShareIntegration(t *testing.T) {
 		assert.Contains(t, sharedResp.DocumentReferences, fmt.Sprintf("/api/v1/DocumentReference/%s", doc1ID))
 	})
 
+	t.Run("Step 7a: Get shared resources as a Bundle", func(t *testing.T) {
+		require.NotEmpty(t, tmpToken, "Tmp token should be set")
+
+		req, err := nethttp.NewRequest("GET", env.ServerURL+"/api/v1/shared/$bundle?_type=Observation,DocumentReference", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tmpToken)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var bundle models.Bundle
+		err = json.Unmarshal(body, &bundle)
+		require.NoError(t, err)
+		assert.Equal(t, "searchset", bundle.Type)
+		require.NotNil(t, bundle.Total)
+		assert.Equal(t, 2, *bundle.Total)
+		assert.Len(t, bundle.Entry, 2)
+	})
+
+	t.Run("Step 7b: Get shared Bundle filtered by _type", func(t *testing.T) {
+		require.NotEmpty(t, tmpToken, "Tmp token should be set")
+
+		req, err := nethttp.NewRequest("GET", env.ServerURL+"/api/v1/shared/$bundle?_type=DocumentReference", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+tmpToken)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var bundle models.Bundle
+		err = json.Unmarshal(body, &bundle)
+		require.NoError(t, err)
+		require.Len(t, bundle.Entry, 1)
+
+		var doc models.DocumentReference
+		err = json.Unmarshal(bundle.Entry[0].Resource, &doc)
+		require.NoError(t, err)
+		assert.Equal(t, doc1ID, *doc.Id)
+	})
+
 	t.Run("Step 8: Get Observation 1 with tmp token", func(t *testing.T) {
 		require.NotEmpty(t, tmpToken, "Tmp token should be set")
 
@@ -439,4 +494,84 @@ func TestShareIntegration(t *testing.T) {
 
 		assert.Equal(t, nethttp.StatusForbidden, resp.StatusCode)
 	})
+
+	var shlID string
+
+	t.Run("Step 13: Create SMART Health Link with passcode", func(t *testing.T) {
+		shlReq := map[string]any{
+			"resource_ids": []string{"Observation/" + obs1ID},
+			"ttl_seconds":  3600,
+			"passcode":     "1234",
+			"label":        "Hemoglobin",
+		}
+
+		reqJSON, err := json.Marshal(shlReq)
+		require.NoError(t, err)
+
+		req, err := nethttp.NewRequest("POST", env.ServerURL+"/api/v1/share/shl", bytes.NewBuffer(reqJSON))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, nethttp.StatusCreated, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var shlResp domain.SHLResponse
+		err = json.Unmarshal(body, &shlResp)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(shlResp.Link, "shlink:/"))
+		require.NotEmpty(t, shlResp.ID)
+		shlID = shlResp.ID
+	})
+
+	t.Run("Step 14: Fetch SMART Health Link manifest with wrong passcode", func(t *testing.T) {
+		require.NotEmpty(t, shlID, "SHL ID should be set")
+
+		body := bytes.NewBufferString(`{"recipient":"Dr. Smith","passcode":"0000"}`)
+		req, err := nethttp.NewRequest("POST", env.ServerURL+"/api/v1/shl/"+shlID, body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, nethttp.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Step 15: Fetch SMART Health Link manifest", func(t *testing.T) {
+		require.NotEmpty(t, shlID, "SHL ID should be set")
+
+		body := bytes.NewBufferString(`{"recipient":"Dr. Smith","passcode":"1234"}`)
+		req, err := nethttp.NewRequest("POST", env.ServerURL+"/api/v1/shl/"+shlID, body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, nethttp.StatusOK, resp.StatusCode)
+
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var manifest struct {
+			Files []struct {
+				ContentType string `json:"contentType"`
+				Embedded    string `json:"embedded"`
+			} `json:"files"`
+		}
+		err = json.Unmarshal(respBody, &manifest)
+		require.NoError(t, err)
+		require.Len(t, manifest.Files, 1)
+		assert.Equal(t, "application/fhir+json", manifest.Files[0].ContentType)
+		assert.NotEmpty(t, manifest.Files[0].Embedded)
+	})
 }